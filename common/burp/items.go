@@ -0,0 +1,114 @@
+package burp
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/yaklang/yaklang/common/utils"
+)
+
+// TimeLayout Burp 导出的 XML 中 <time> 的格式，例如 Mon Jan 01 08:00:00 CST 2024
+const TimeLayout = "Mon Jan 02 15:04:05 MST 2006"
+
+// Item Burp Suite 中 "Save items" 导出的 XML 中的一条记录
+type Item struct {
+	Time           string `xml:"time"`
+	URL            string `xml:"url"`
+	Host           Host   `xml:"host"`
+	Port           int    `xml:"port"`
+	Protocol       string `xml:"protocol"`
+	Method         string `xml:"method"`
+	Path           string `xml:"path"`
+	Extension      string `xml:"extension"`
+	Request        Data   `xml:"request"`
+	Status         int    `xml:"status"`
+	ResponseLength int    `xml:"responselength"`
+	MimeType       string `xml:"mimetype"`
+	Response       Data   `xml:"response"`
+	Comment        string `xml:"comment"`
+	Highlight      string `xml:"highlight"`
+}
+
+type Host struct {
+	IP   string `xml:"ip,attr"`
+	Name string `xml:",chardata"`
+}
+
+// Data 请求或响应报文，勾选 "Base64-encode requests and responses" 时 base64 属性为 true
+type Data struct {
+	Base64 bool   `xml:"base64,attr"`
+	Value  string `xml:",chardata"`
+}
+
+// Bytes 返回原始报文
+func (d Data) Bytes() ([]byte, error) {
+	if !d.Base64 {
+		return []byte(d.Value), nil
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(d.Value))
+	if err != nil {
+		return nil, utils.Errorf("decode base64 packet failed: %v", err)
+	}
+	return raw, nil
+}
+
+// IsHTTPS 是否为 https 请求
+func (i *Item) IsHTTPS() bool {
+	if i.Protocol != "" {
+		return strings.EqualFold(i.Protocol, "https")
+	}
+	return strings.HasPrefix(strings.ToLower(i.URL), "https://")
+}
+
+// StartedTime 解析 <time>，无法解析时返回零值
+func (i *Item) StartedTime() time.Time {
+	t, err := time.Parse(TimeLayout, strings.TrimSpace(i.Time))
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// ReadItems 流式读取 Burp 导出的 XML，每读取到一个 <item> 调用一次 handler，handler 返回错误时停止读取
+func ReadItems(r io.Reader, handler func(item *Item) error) error {
+	decoder := xml.NewDecoder(r)
+	decoder.Strict = false
+
+	index, foundRoot := 0, false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return utils.Errorf("read burp xml failed: %v", err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "items":
+			foundRoot = true
+		case "item":
+			if !foundRoot {
+				return utils.Error("invalid burp xml: <item> outside of <items>")
+			}
+			var item Item
+			if err := decoder.DecodeElement(&item, &start); err != nil {
+				return utils.Errorf("decode item[%d] failed: %v", index, err)
+			}
+			if err := handler(&item); err != nil {
+				return err
+			}
+			index++
+		}
+	}
+	if !foundRoot {
+		return utils.Error("invalid burp xml: <items> not found")
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package burp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 与 Burp Suite 中 "Save items" 导出的 XML 结构一致
+const savedItems = `<?xml version="1.0"?>
+<!DOCTYPE items [
+<!ELEMENT items (item*)>
+<!ATTLIST items burpVersion CDATA "">
+<!ATTLIST items exportTime CDATA "">
+<!ELEMENT item (time, url, host, port, protocol, method, path, extension, request, status, responselength, mimetype, response, comment)>
+<!ELEMENT time (#PCDATA)>
+<!ELEMENT url (#PCDATA)>
+<!ELEMENT host (#PCDATA)>
+<!ATTLIST host ip CDATA "">
+<!ELEMENT request (#PCDATA)>
+<!ATTLIST request base64 (true|false) "false">
+<!ELEMENT response (#PCDATA)>
+<!ATTLIST response base64 (true|false) "false">
+]>
+<items burpVersion="2023.10.3.4" exportTime="Mon Jan 01 08:00:00 UTC 2024">
+  <item>
+    <time>Mon Jan 01 08:00:00 UTC 2024</time>
+    <url><![CDATA[https://example.com/login?a=1]]></url>
+    <host ip="93.184.216.34">example.com</host>
+    <port>443</port>
+    <protocol>https</protocol>
+    <method><![CDATA[POST]]></method>
+    <path><![CDATA[/login?a=1]]></path>
+    <extension>null</extension>
+    <request base64="true"><![CDATA[UE9TVCAvbG9naW4/YT0xIEhUVFAvMS4xDQpIb3N0OiBleGFtcGxlLmNvbQ0KQ29udGVudC1MZW5ndGg6IDEwDQoNCnVzZXI9YWRtaW4=]]></request>
+    <status>200</status>
+    <responselength>21</responselength>
+    <mimetype>HTML</mimetype>
+    <response base64="true"><![CDATA[SFRUUC8xLjEgMjAwIE9LDQpDb250ZW50LUxlbmd0aDogMg0KDQpvaw==]]></response>
+    <comment>sqli?</comment>
+    <highlight>red</highlight>
+  </item>
+  <item>
+    <time>Mon Jan 01 08:00:01 UTC 2024</time>
+    <url><![CDATA[http://example.com/]]></url>
+    <host ip="">example.com</host>
+    <port>80</port>
+    <protocol>http</protocol>
+    <method><![CDATA[GET]]></method>
+    <path><![CDATA[/]]></path>
+    <extension>null</extension>
+    <request base64="false"><![CDATA[GET / HTTP/1.1
+Host: example.com
+
+]]></request>
+    <status></status>
+    <responselength></responselength>
+    <mimetype></mimetype>
+    <response base64="false"></response>
+    <comment></comment>
+  </item>
+</items>`
+
+func TestReadItems(t *testing.T) {
+	var items []*Item
+	err := ReadItems(strings.NewReader(savedItems), func(item *Item) error {
+		items = append(items, item)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	item := items[0]
+	require.True(t, item.IsHTTPS())
+	require.Equal(t, "https://example.com/login?a=1", item.URL)
+	require.Equal(t, Host{IP: "93.184.216.34", Name: "example.com"}, item.Host)
+	require.Equal(t, 443, item.Port)
+	require.Equal(t, 200, item.Status)
+	require.Equal(t, "sqli?", item.Comment)
+	require.Equal(t, "red", item.Highlight)
+	require.Equal(t, time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC), item.StartedTime().UTC())
+	request, err := item.Request.Bytes()
+	require.NoError(t, err)
+	require.Equal(t, "POST /login?a=1 HTTP/1.1\r\nHost: example.com\r\nContent-Length: 10\r\n\r\nuser=admin", string(request))
+	response, err := item.Response.Bytes()
+	require.NoError(t, err)
+	require.Equal(t, "HTTP/1.1 200 OK\r\nContent-Length: 2\r\n\r\nok", string(response))
+
+	// 没有勾选 base64 编码，也没有响应
+	item = items[1]
+	require.False(t, item.IsHTTPS())
+	require.Equal(t, 0, item.Status)
+	request, err = item.Request.Bytes()
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(request), "GET / HTTP/1.1\nHost: example.com"))
+	response, err = item.Response.Bytes()
+	require.NoError(t, err)
+	require.Empty(t, response)
+
+	err = ReadItems(strings.NewReader(`<items><item><port>abc</port></item></items>`), func(item *Item) error {
+		return nil
+	})
+	require.ErrorContains(t, err, "item[0]")
+	err = ReadItems(strings.NewReader(`<log></log>`), func(item *Item) error {
+		return nil
+	})
+	require.Error(t, err)
+}
@@ -0,0 +1,181 @@
+package har
+
+// HAR 1.2 格式，见 http://www.softwareishard.com/blog/har-12-spec/
+// 以 _ 开头的字段为扩展字段，与 Chrome DevTools 导出的 HAR 保持一致
+
+const Version = "1.2"
+
+type HTTPArchive struct {
+	Log *Log `json:"log"`
+}
+
+type Log struct {
+	Version string   `json:"version"`
+	Creator *Creator `json:"creator"`
+	Browser *Creator `json:"browser,omitempty"`
+	Pages   []*Page  `json:"pages,omitempty"`
+	Entries []*Entry `json:"entries"`
+	Comment string   `json:"comment,omitempty"`
+}
+
+type Creator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Comment string `json:"comment,omitempty"`
+}
+
+type Page struct {
+	StartedDateTime string       `json:"startedDateTime"`
+	ID              string       `json:"id"`
+	Title           string       `json:"title"`
+	PageTimings     *PageTimings `json:"pageTimings"`
+	Comment         string       `json:"comment,omitempty"`
+}
+
+type PageTimings struct {
+	OnContentLoad float64 `json:"onContentLoad,omitempty"`
+	OnLoad        float64 `json:"onLoad,omitempty"`
+	Comment       string  `json:"comment,omitempty"`
+}
+
+type Entry struct {
+	Pageref         string `json:"pageref,omitempty"`
+	StartedDateTime string `json:"startedDateTime"`
+	// 总耗时(ms)，为 timings 中除 ssl 外所有非 -1 耗时之和
+	Time            float64   `json:"time"`
+	Request         *Request  `json:"request"`
+	Response        *Response `json:"response"`
+	Cache           *Cache    `json:"cache"`
+	Timings         *Timings  `json:"timings"`
+	ServerIPAddress string    `json:"serverIPAddress,omitempty"`
+	Connection      string    `json:"connection,omitempty"`
+	Comment         string    `json:"comment,omitempty"`
+
+	// 扩展字段
+	ResourceType      string              `json:"_resourceType,omitempty"`
+	TLS               *TLS                `json:"_tls,omitempty"`
+	WebSocketMessages []*WebSocketMessage `json:"_webSocketMessages,omitempty"`
+}
+
+type Request struct {
+	Method      string           `json:"method"`
+	URL         string           `json:"url"`
+	HTTPVersion string           `json:"httpVersion"`
+	Cookies     []*Cookie        `json:"cookies"`
+	Headers     []*NameValuePair `json:"headers"`
+	QueryString []*NameValuePair `json:"queryString"`
+	PostData    *PostData        `json:"postData,omitempty"`
+	HeadersSize int64            `json:"headersSize"`
+	BodySize    int64            `json:"bodySize"`
+	Comment     string           `json:"comment,omitempty"`
+}
+
+type Response struct {
+	Status      int              `json:"status"`
+	StatusText  string           `json:"statusText"`
+	HTTPVersion string           `json:"httpVersion"`
+	Cookies     []*Cookie        `json:"cookies"`
+	Headers     []*NameValuePair `json:"headers"`
+	Content     *Content         `json:"content"`
+	RedirectURL string           `json:"redirectURL"`
+	HeadersSize int64            `json:"headersSize"`
+	BodySize    int64            `json:"bodySize"`
+	Comment     string           `json:"comment,omitempty"`
+}
+
+type Cookie struct {
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Path     string `json:"path,omitempty"`
+	Domain   string `json:"domain,omitempty"`
+	Expires  string `json:"expires,omitempty"`
+	HTTPOnly bool   `json:"httpOnly,omitempty"`
+	Secure   bool   `json:"secure,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+type NameValuePair struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	Comment string `json:"comment,omitempty"`
+}
+
+type PostData struct {
+	MimeType string       `json:"mimeType"`
+	Params   []*PostParam `json:"params,omitempty"`
+	Text     string       `json:"text"`
+	Comment  string       `json:"comment,omitempty"`
+	// 扩展字段，为 base64 时 Text 为 base64 编码的请求体
+	Encoding string `json:"_encoding,omitempty"`
+}
+
+type PostParam struct {
+	Name        string `json:"name"`
+	Value       string `json:"value,omitempty"`
+	FileName    string `json:"fileName,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+	Comment     string `json:"comment,omitempty"`
+}
+
+type Content struct {
+	Size        int64  `json:"size"`
+	Compression int64  `json:"compression,omitempty"`
+	MimeType    string `json:"mimeType"`
+	Text        string `json:"text,omitempty"`
+	// 为 base64 时 Text 为 base64 编码的 body
+	Encoding string `json:"encoding,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+type Cache struct {
+	Comment string `json:"comment,omitempty"`
+}
+
+// Timings 请求各阶段耗时(ms)，-1 表示不适用或者未知
+type Timings struct {
+	Blocked float64 `json:"blocked,omitempty"`
+	DNS     float64 `json:"dns,omitempty"`
+	Connect float64 `json:"connect,omitempty"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+	SSL     float64 `json:"ssl,omitempty"`
+	Comment string  `json:"comment,omitempty"`
+}
+
+// Total 返回各阶段耗时之和，ssl 已经包含在 connect 中
+func (t *Timings) Total() float64 {
+	if t == nil {
+		return 0
+	}
+	var total float64
+	for _, i := range []float64{t.Blocked, t.DNS, t.Connect, t.Send, t.Wait, t.Receive} {
+		if i > 0 {
+			total += i
+		}
+	}
+	return total
+}
+
+// TLS 扩展字段，记录 https 连接的信息
+type TLS struct {
+	ServerName string `json:"serverName,omitempty"`
+	Version    string `json:"version,omitempty"`
+}
+
+const (
+	WebSocketMessageSend    = "send"
+	WebSocketMessageReceive = "receive"
+
+	WebSocketOpcodeText   = 1
+	WebSocketOpcodeBinary = 2
+)
+
+// WebSocketMessage 与 Chrome DevTools 导出的 _webSocketMessages 格式相同，
+// time 为 unix 时间戳(秒)，二进制消息的 data 为 base64 编码
+type WebSocketMessage struct {
+	Type   string  `json:"type"`
+	Time   float64 `json:"time"`
+	Opcode int     `json:"opcode"`
+	Data   string  `json:"data"`
+}
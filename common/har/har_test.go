@@ -0,0 +1,160 @@
+package har
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yaklang/yaklang/common/utils/lowhttp"
+)
+
+// 与 Chrome DevTools 导出的 HAR 结构一致
+const devtoolsHAR = `{
+  "log": {
+    "version": "1.2",
+    "creator": {"name": "WebInspector", "version": "537.36"},
+    "pages": [{"startedDateTime": "2024-01-01T00:00:00.000Z", "id": "page_1", "title": "https://example.com/", "pageTimings": {"onContentLoad": 10, "onLoad": 20}}],
+    "entries": [
+      {
+        "_resourceType": "document",
+        "startedDateTime": "2024-01-01T00:00:00.123Z",
+        "time": 35.5,
+        "request": {
+          "method": "POST",
+          "url": "https://example.com/login?a=1&b=%E4%BD%A0",
+          "httpVersion": "h2",
+          "headers": [
+            {"name": ":authority", "value": "example.com"},
+            {"name": ":method", "value": "POST"},
+            {"name": "content-type", "value": "application/x-www-form-urlencoded"},
+            {"name": "content-length", "value": "100"}
+          ],
+          "queryString": [{"name": "a", "value": "1"}],
+          "cookies": [{"name": "session", "value": "abc"}],
+          "headersSize": -1,
+          "bodySize": 11,
+          "postData": {"mimeType": "application/x-www-form-urlencoded", "text": "user=admin1"}
+        },
+        "response": {
+          "status": 200,
+          "statusText": "",
+          "httpVersion": "h2",
+          "headers": [
+            {"name": "content-type", "value": "text/html"},
+            {"name": "content-encoding", "value": "gzip"},
+            {"name": "content-length", "value": "3"}
+          ],
+          "cookies": [],
+          "content": {"size": 5, "mimeType": "text/html", "text": "aGVsbG8=", "encoding": "base64"},
+          "redirectURL": "",
+          "headersSize": -1,
+          "bodySize": -1
+        },
+        "cache": {},
+        "timings": {"blocked": 1.5, "dns": -1, "ssl": -1, "connect": -1, "send": 0.5, "wait": 30, "receive": 3.5},
+        "serverIPAddress": "[::1]",
+        "connection": "443"
+      },
+      {
+        "startedDateTime": "2024-01-01T00:00:01.000Z",
+        "time": 0,
+        "request": {"method": "GET", "url": "data:image/png;base64,AAAA", "httpVersion": "", "headers": [], "queryString": [], "cookies": [], "headersSize": -1, "bodySize": 0},
+        "response": {"status": 200, "statusText": "OK", "httpVersion": "", "headers": [], "cookies": [], "content": {"size": 0, "mimeType": "image/png"}, "redirectURL": "", "headersSize": -1, "bodySize": 0},
+        "cache": {},
+        "timings": {"send": 0, "wait": 0, "receive": 0}
+      }
+    ]
+  },
+  "extra": {"ignored": [1, 2, 3]}
+}`
+
+func TestReadEntries(t *testing.T) {
+	var entries []*Entry
+	err := ReadEntries(strings.NewReader(devtoolsHAR), func(entry *Entry) error {
+		entries = append(entries, entry)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	entry := entries[0]
+	require.True(t, entry.IsHTTPS())
+	require.Equal(t, "document", entry.ResourceType)
+	require.Equal(t, 35.5, entry.Timings.Total())
+	require.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 123000000, time.UTC), entry.StartedTime().UTC())
+
+	request, err := entry.Request.Packet()
+	require.NoError(t, err)
+	require.Equal(t, "POST /login?a=1&b=%E4%BD%A0 HTTP/2.0\r\n"+
+		"Host: example.com\r\n"+
+		"content-type: application/x-www-form-urlencoded\r\n"+
+		"Cookie: session=abc\r\n"+
+		"Content-Length: 11\r\n\r\nuser=admin1", string(request))
+
+	// HAR 中的响应体已经解码，Content-Encoding 需要移除
+	response, err := entry.Response.Packet()
+	require.NoError(t, err)
+	require.Equal(t, "HTTP/2.0 200 OK\r\ncontent-type: text/html\r\nContent-Length: 5\r\n\r\nhello", string(response))
+
+	require.False(t, entries[1].IsHTTPS())
+
+	err = ReadEntries(strings.NewReader(`{"log": {"entries": [{"request": 1}]}}`), func(entry *Entry) error {
+		return nil
+	})
+	require.ErrorContains(t, err, "entry[0]")
+	err = ReadEntries(strings.NewReader(`{"version": "1.2"}`), func(entry *Entry) error {
+		return nil
+	})
+	require.Error(t, err)
+}
+
+func TestWriterRoundTrip(t *testing.T) {
+	request := []byte("GET /ws?x=1 HTTP/1.1\r\nHost: example.com\r\nCookie: a=1; b=2\r\nUpgrade: websocket\r\n\r\n")
+	response := []byte("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nSet-Cookie: c=3; Path=/; HttpOnly\r\n\r\n")
+	entry := NewEntry("wss://example.com/ws?x=1", request, response, time.Unix(1700000000, 0))
+	entry.WebSocketMessages = []*WebSocketMessage{
+		{Type: WebSocketMessageSend, Time: 1700000000.5, Opcode: WebSocketOpcodeText, Data: "hello"},
+		{Type: WebSocketMessageReceive, Time: 1700000001, Opcode: WebSocketOpcodeBinary, Data: "AAE="},
+	}
+	binary := NewEntry("http://example.com/upload", []byte("POST /upload HTTP/1.1\r\nHost: example.com\r\n\r\n\x00\xff"), []byte("HTTP/1.1 200 OK\r\n\r\n\x89PNG"), time.Unix(1700000002, 0))
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, &Creator{Name: "yaklang", Version: "dev"})
+	require.NoError(t, err)
+	require.NoError(t, w.Write(entry))
+	require.NoError(t, w.Write(binary))
+	require.NoError(t, w.Close())
+	require.Equal(t, 2, w.Count())
+	require.Error(t, w.Write(entry))
+
+	var entries []*Entry
+	require.NoError(t, ReadEntries(&buf, func(entry *Entry) error {
+		entries = append(entries, entry)
+		return nil
+	}))
+	require.Len(t, entries, 2)
+
+	got := entries[0]
+	require.Equal(t, "example.com", got.TLS.ServerName)
+	require.Equal(t, []*NameValuePair{{Name: "x", Value: "1"}}, got.Request.QueryString)
+	require.Equal(t, []*Cookie{{Name: "a", Value: "1"}, {Name: "b", Value: "2"}}, got.Request.Cookies)
+	require.Equal(t, "c", got.Response.Cookies[0].Name)
+	require.True(t, got.Response.Cookies[0].HTTPOnly)
+	require.Equal(t, 101, got.Response.Status)
+	require.Equal(t, entry.WebSocketMessages, got.WebSocketMessages)
+
+	// 非 utf8 的请求体与响应体使用 base64 编码，还原后保持不变
+	got = entries[1]
+	require.Equal(t, encodingBase64, got.Request.PostData.Encoding)
+	require.Equal(t, encodingBase64, got.Response.Content.Encoding)
+	packet, err := got.Request.Packet()
+	require.NoError(t, err)
+	_, body := lowhttp.SplitHTTPPacketFast(packet)
+	require.Equal(t, []byte("\x00\xff"), body)
+	packet, err = got.Response.Packet()
+	require.NoError(t, err)
+	_, body = lowhttp.SplitHTTPPacketFast(packet)
+	require.Equal(t, []byte("\x89PNG"), body)
+}
@@ -0,0 +1,283 @@
+package har
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/utils/lowhttp"
+	"github.com/yaklang/yaklang/common/yak/yaklib/codec"
+)
+
+const encodingBase64 = "base64"
+
+// IsHTTPS 根据 url 判断请求是否为 https / wss
+func (e *Entry) IsHTTPS() bool {
+	if e.Request == nil {
+		return false
+	}
+	u, err := url.Parse(e.Request.URL)
+	if err != nil {
+		return false
+	}
+	return u.Scheme == "https" || u.Scheme == "wss"
+}
+
+// StartedTime 返回请求开始时间，无法解析时返回零值
+func (e *Entry) StartedTime() time.Time {
+	t, err := time.Parse(time.RFC3339Nano, e.StartedDateTime)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// Packet 将 HAR 中的请求还原为原始请求报文，HTTP/2 的伪首部会被转换为 Host 与请求行
+func (r *Request) Packet() ([]byte, error) {
+	u, err := url.Parse(r.URL)
+	if err != nil {
+		return nil, utils.Errorf("parse url %#v failed: %v", r.URL, err)
+	}
+	method := r.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	headers := []string{method + " " + u.RequestURI() + " " + packetVersion(r.HTTPVersion)}
+	hasHost, hasCookie, hasContentType := false, false, false
+	for _, header := range r.Headers {
+		if strings.HasPrefix(header.Name, ":") {
+			continue
+		}
+		switch strings.ToLower(header.Name) {
+		case "host":
+			hasHost = true
+		case "cookie":
+			hasCookie = true
+		case "content-type":
+			hasContentType = true
+		case "content-length", "transfer-encoding":
+			continue
+		}
+		headers = append(headers, header.Name+": "+header.Value)
+	}
+	if !hasHost {
+		headers = append([]string{headers[0], "Host: " + u.Host}, headers[1:]...)
+	}
+	if !hasCookie && len(r.Cookies) > 0 {
+		cookies := make([]string, 0, len(r.Cookies))
+		for _, cookie := range r.Cookies {
+			cookies = append(cookies, cookie.Name+"="+cookie.Value)
+		}
+		headers = append(headers, "Cookie: "+strings.Join(cookies, "; "))
+	}
+
+	var body []byte
+	if r.PostData != nil {
+		body, err = r.PostData.Body()
+		if err != nil {
+			return nil, err
+		}
+		if !hasContentType && len(body) > 0 && r.PostData.MimeType != "" {
+			headers = append(headers, "Content-Type: "+r.PostData.MimeType)
+		}
+	}
+	return lowhttp.ReplaceHTTPPacketBody([]byte(strings.Join(headers, lowhttp.CRLF)+lowhttp.CRLF+lowhttp.CRLF), body, false), nil
+}
+
+// Body 返回请求体，text 为空时使用 params 构造 urlencoded 的请求体
+func (p *PostData) Body() ([]byte, error) {
+	if p.Text != "" {
+		if p.Encoding == encodingBase64 {
+			return codec.DecodeBase64(p.Text)
+		}
+		return []byte(p.Text), nil
+	}
+	var params []string
+	for _, param := range p.Params {
+		if param.FileName != "" {
+			continue
+		}
+		params = append(params, url.QueryEscape(param.Name)+"="+url.QueryEscape(param.Value))
+	}
+	return []byte(strings.Join(params, "&")), nil
+}
+
+// Packet 将 HAR 中的响应还原为原始响应报文，HAR 中的响应体已经解码，
+// 所以会移除 Content-Encoding 与 Transfer-Encoding，status 为 0 (请求未完成) 时返回 nil
+func (r *Response) Packet() ([]byte, error) {
+	if r.Status <= 0 {
+		return nil, nil
+	}
+	statusText := r.StatusText
+	if statusText == "" {
+		statusText = http.StatusText(r.Status)
+	}
+
+	headers := []string{packetVersion(r.HTTPVersion) + " " + strconv.Itoa(r.Status) + " " + statusText}
+	for _, header := range r.Headers {
+		if strings.HasPrefix(header.Name, ":") {
+			continue
+		}
+		switch strings.ToLower(header.Name) {
+		case "content-length", "content-encoding", "transfer-encoding":
+			continue
+		}
+		headers = append(headers, header.Name+": "+header.Value)
+	}
+
+	var body []byte
+	if r.Content != nil {
+		var err error
+		body, err = r.Content.Body()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return lowhttp.ReplaceHTTPPacketBody([]byte(strings.Join(headers, lowhttp.CRLF)+lowhttp.CRLF+lowhttp.CRLF), body, false), nil
+}
+
+func (c *Content) Body() ([]byte, error) {
+	if c.Encoding == encodingBase64 {
+		return codec.DecodeBase64(c.Text)
+	}
+	return []byte(c.Text), nil
+}
+
+// packetVersion 将 HAR 中的 httpVersion 转换为报文中的协议版本，例如 h2 => HTTP/2.0
+func packetVersion(version string) string {
+	switch v := strings.ToUpper(strings.TrimSpace(version)); {
+	case v == "HTTP/1.0":
+		return "HTTP/1.0"
+	case v == "H2", strings.HasPrefix(v, "HTTP/2"):
+		return "HTTP/2.0"
+	default:
+		return "HTTP/1.1"
+	}
+}
+
+// NewRequest 解析原始请求报文，url 为请求的完整 url
+func NewRequest(urlStr string, packet []byte) *Request {
+	req := &Request{
+		URL:         urlStr,
+		Cookies:     []*Cookie{},
+		Headers:     []*NameValuePair{},
+		QueryString: []*NameValuePair{},
+	}
+	var contentType string
+	header, body := lowhttp.SplitHTTPPacket(packet, func(method string, requestUri string, proto string) error {
+		req.Method, req.HTTPVersion = method, proto
+		return nil
+	}, nil, func(line string) string {
+		k, v := lowhttp.SplitHTTPHeader(line)
+		req.Headers = append(req.Headers, &NameValuePair{Name: k, Value: v})
+		switch strings.ToLower(k) {
+		case "cookie":
+			for _, cookie := range lowhttp.ParseCookie(k, v) {
+				req.Cookies = append(req.Cookies, &Cookie{Name: cookie.Name, Value: cookie.Value})
+			}
+		case "content-type":
+			contentType = v
+		}
+		return line
+	})
+	req.HeadersSize, req.BodySize = int64(len(header)), int64(len(body))
+
+	if u, err := url.Parse(urlStr); err == nil && u.RawQuery != "" {
+		for _, pair := range strings.Split(u.RawQuery, "&") {
+			if pair == "" {
+				continue
+			}
+			k, v, _ := strings.Cut(pair, "=")
+			req.QueryString = append(req.QueryString, &NameValuePair{Name: unescapeQuery(k), Value: unescapeQuery(v)})
+		}
+	}
+	if len(body) > 0 {
+		req.PostData = &PostData{MimeType: contentType}
+		req.PostData.Text, req.PostData.Encoding = encodeText(body)
+	}
+	return req
+}
+
+// NewResponse 解析原始响应报文，报文中的响应体应该已经解码(去除 chunked 与压缩)
+func NewResponse(packet []byte) *Response {
+	rsp := &Response{
+		Cookies: []*Cookie{},
+		Headers: []*NameValuePair{},
+		Content: &Content{},
+	}
+	if len(packet) <= 0 {
+		return rsp
+	}
+	header, body := lowhttp.SplitHTTPPacket(packet, nil, func(proto string, code int, codeMsg string) error {
+		rsp.HTTPVersion, rsp.Status, rsp.StatusText = proto, code, codeMsg
+		return nil
+	}, func(line string) string {
+		k, v := lowhttp.SplitHTTPHeader(line)
+		rsp.Headers = append(rsp.Headers, &NameValuePair{Name: k, Value: v})
+		switch strings.ToLower(k) {
+		case "set-cookie":
+			for _, cookie := range lowhttp.ParseCookie(k, v) {
+				c := &Cookie{
+					Name:     cookie.Name,
+					Value:    cookie.Value,
+					Path:     cookie.Path,
+					Domain:   cookie.Domain,
+					HTTPOnly: cookie.HttpOnly,
+					Secure:   cookie.Secure,
+				}
+				if !cookie.Expires.IsZero() {
+					c.Expires = cookie.Expires.Format(time.RFC3339)
+				}
+				rsp.Cookies = append(rsp.Cookies, c)
+			}
+		case "content-type":
+			rsp.Content.MimeType = v
+		case "location":
+			rsp.RedirectURL = v
+		}
+		return line
+	})
+	rsp.HeadersSize, rsp.BodySize = int64(len(header)), int64(len(body))
+	rsp.Content.Size = int64(len(body))
+	rsp.Content.Text, rsp.Content.Encoding = encodeText(body)
+	return rsp
+}
+
+// NewEntry 根据原始请求与响应报文构建 entry，timings 为空时各阶段耗时未知
+func NewEntry(urlStr string, request, response []byte, started time.Time) *Entry {
+	entry := &Entry{
+		StartedDateTime: started.Format(time.RFC3339Nano),
+		Request:         NewRequest(urlStr, request),
+		Response:        NewResponse(response),
+		Cache:           &Cache{},
+		Timings:         &Timings{Blocked: -1, DNS: -1, Connect: -1, SSL: -1},
+	}
+	if entry.IsHTTPS() {
+		if u, err := url.Parse(urlStr); err == nil {
+			entry.TLS = &TLS{ServerName: u.Hostname()}
+		}
+	}
+	return entry
+}
+
+// encodeText 返回文本形式的数据，不是合法的 utf8 时使用 base64 编码
+func encodeText(raw []byte) (string, string) {
+	if utf8.Valid(raw) && !bytes.ContainsRune(raw, 0) {
+		return string(raw), ""
+	}
+	return codec.EncodeBase64(raw), encodingBase64
+}
+
+func unescapeQuery(s string) string {
+	ret, err := url.QueryUnescape(s)
+	if err != nil {
+		return s
+	}
+	return ret
+}
@@ -0,0 +1,157 @@
+package har
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/yaklang/yaklang/common/utils"
+)
+
+// ReadEntries 流式读取 HAR 文件中的 entries，每读取一个 entry 调用一次 handler，
+// 不会一次性将整个文件读入内存，handler 返回错误时停止读取
+func ReadEntries(r io.Reader, handler func(entry *Entry) error) error {
+	decoder := json.NewDecoder(r)
+	if err := expectDelim(decoder, '{'); err != nil {
+		return err
+	}
+	foundLog := false
+	for decoder.More() {
+		key, err := readKey(decoder)
+		if err != nil {
+			return err
+		}
+		if key != "log" {
+			if err := skipValue(decoder); err != nil {
+				return err
+			}
+			continue
+		}
+		foundLog = true
+		if err := readLog(decoder, handler); err != nil {
+			return err
+		}
+	}
+	if !foundLog {
+		return utils.Error("invalid har: log not found")
+	}
+	return nil
+}
+
+func readLog(decoder *json.Decoder, handler func(entry *Entry) error) error {
+	if err := expectDelim(decoder, '{'); err != nil {
+		return err
+	}
+	for decoder.More() {
+		key, err := readKey(decoder)
+		if err != nil {
+			return err
+		}
+		if key != "entries" {
+			if err := skipValue(decoder); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := expectDelim(decoder, '['); err != nil {
+			return err
+		}
+		for index := 0; decoder.More(); index++ {
+			var entry Entry
+			if err := decoder.Decode(&entry); err != nil {
+				return utils.Errorf("decode har entry[%d] failed: %v", index, err)
+			}
+			if err := handler(&entry); err != nil {
+				return err
+			}
+		}
+		if err := expectDelim(decoder, ']'); err != nil {
+			return err
+		}
+	}
+	return expectDelim(decoder, '}')
+}
+
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return utils.Errorf("invalid har: %v", err)
+	}
+	if d, ok := token.(json.Delim); !ok || d != delim {
+		return utils.Errorf("invalid har: expect %v but got %v", delim, token)
+	}
+	return nil
+}
+
+func readKey(decoder *json.Decoder) (string, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return "", utils.Errorf("invalid har: %v", err)
+	}
+	key, ok := token.(string)
+	if !ok {
+		return "", utils.Errorf("invalid har: expect key but got %v", token)
+	}
+	return key, nil
+}
+
+func skipValue(decoder *json.Decoder) error {
+	var raw json.RawMessage
+	if err := decoder.Decode(&raw); err != nil {
+		return utils.Errorf("invalid har: %v", err)
+	}
+	return nil
+}
+
+// Writer 流式写入 HAR 文件，Close 之后才是完整的 HAR
+type Writer struct {
+	w       io.Writer
+	encoder *json.Encoder
+	count   int
+	closed  bool
+}
+
+func NewWriter(w io.Writer, creator *Creator) (*Writer, error) {
+	if creator == nil {
+		creator = &Creator{Name: "yaklang"}
+	}
+	raw, err := json.Marshal(creator)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(w, `{"log":{"version":"`+Version+`","creator":`+string(raw)+`,"entries":[`+"\n"); err != nil {
+		return nil, err
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return &Writer{w: w, encoder: encoder}, nil
+}
+
+func (w *Writer) Write(entry *Entry) error {
+	if w.closed {
+		return utils.Error("har writer is closed")
+	}
+	if w.count > 0 {
+		if _, err := io.WriteString(w.w, ","); err != nil {
+			return err
+		}
+	}
+	if err := w.encoder.Encode(entry); err != nil {
+		return err
+	}
+	w.count++
+	return nil
+}
+
+// Count 返回已经写入的 entry 数量
+func (w *Writer) Count() int {
+	return w.count
+}
+
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	_, err := io.WriteString(w.w, "]}}\n")
+	return err
+}
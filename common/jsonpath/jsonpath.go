@@ -97,6 +97,22 @@ func Replace(origin any, path string, replaceValue interface{}) (any, error) {
 
 // ReplaceEx replace the value of the path in origin
 func ReplaceEx(origin any, path string, replaceValue interface{}) (any, error) {
+	return replaceWithParser(origin, path, func(path string) *parser {
+		return newScannerWithReplaceValue(path, replaceValue)
+	})
+}
+
+// ReplaceFunc 与 ReplaceEx 类似，但每个匹配到的值都会调用 replaceFunc 计算新值，不会新增原本不存在的 key
+func ReplaceFunc(origin any, path string, replaceFunc func(old interface{}) interface{}) (any, error) {
+	if replaceFunc == nil {
+		return nil, utils.Error("replace func is nil")
+	}
+	return replaceWithParser(origin, path, func(path string) *parser {
+		return newScannerWithReplaceFunc(path, replaceFunc)
+	})
+}
+
+func replaceWithParser(origin any, path string, newParser func(path string) *parser) (any, error) {
 	var data any
 	var originMap, originObj, err = ToMapInterface(origin)
 	var isMap bool
@@ -129,7 +145,7 @@ func ReplaceEx(origin any, path string, replaceValue interface{}) (any, error) {
 		_, after, _ := strings.Cut(path, ".")
 		path = "$" + after
 	}
-	p := newScannerWithReplaceValue(path, replaceValue)
+	p := newParser(path)
 	if err := p.parse(); err != nil {
 		return nil, err
 	}
@@ -198,6 +214,7 @@ type parser struct {
 	path         string
 	actions      actions
 	replaceValue interface{}
+	replaceFunc  func(old interface{}) interface{}
 	mode         int
 }
 
@@ -222,6 +239,22 @@ func newScannerWithReplaceValue(path string, replaceValue interface{}) *parser {
 	return &parser{path: path, replaceValue: replaceValue, mode: replace}
 }
 
+func newScannerWithReplaceFunc(path string, replaceFunc func(old interface{}) interface{}) *parser {
+	return &parser{path: path, replaceFunc: replaceFunc, mode: replace}
+}
+
+func (p *parser) hasReplaceValue() bool {
+	return p.replaceValue != nil || p.replaceFunc != nil
+}
+
+// newValue 返回用于替换 old 的值，设置了 replaceFunc 时根据原值计算
+func (p *parser) newValue(old interface{}) interface{} {
+	if p.replaceFunc != nil {
+		return p.replaceFunc(old)
+	}
+	return p.replaceValue
+}
+
 func (p *parser) scan() rune {
 	return p.scanner.Scan()
 }
@@ -291,7 +324,10 @@ func (p *parser) parseObjAccess() error {
 
 		if p.mode == replace {
 			if len(a) == 1 {
-				obj[ident] = p.replaceValue
+				// replaceFunc 只修改已经存在的值
+				if _, ok := obj[ident]; ok || p.replaceFunc == nil {
+					obj[ident] = p.newValue(obj[ident])
+				}
 			}
 			return a.next(r, obj[ident])
 		}
@@ -317,9 +353,9 @@ func (p *parser) prepareWildcard() error {
 				values = values.append(v)
 
 			}
-			if p.replaceValue != nil && len(a) == 1 {
+			if p.hasReplaceValue() && len(a) == 1 {
 				for k := range obj {
-					obj[k] = p.replaceValue
+					obj[k] = p.newValue(obj[k])
 				}
 			}
 		} else if array, ok := c.([]interface{}); ok {
@@ -329,8 +365,8 @@ func (p *parser) prepareWildcard() error {
 					continue
 				}
 				values = values.append(v)
-				if p.replaceValue != nil && len(a) == 1 {
-					array[k] = p.replaceValue
+				if p.hasReplaceValue() && len(a) == 1 {
+					array[k] = p.newValue(array[k])
 				}
 			}
 		}
@@ -503,17 +539,17 @@ func (p *parser) recSearchChildren(r, c interface{}, a actions, acc searchResult
 		for _, c := range valuesSortedByKey(obj) {
 			acc = p.recSearchParent(r, c, a, acc)
 		}
-		if p.replaceValue != nil && len(allowReplace) > 0 {
+		if p.hasReplaceValue() && len(allowReplace) > 0 {
 			for key := range obj {
-				obj[key] = p.replaceValue
+				obj[key] = p.newValue(obj[key])
 			}
 		}
 	} else if array, ok := c.([]interface{}); ok {
 		for index, c := range array {
 			_ = index
 			acc = p.recSearchParent(r, c, a, acc)
-			if p.replaceValue != nil && len(allowReplace) > 0 {
-				array[index] = p.replaceValue
+			if p.hasReplaceValue() && len(allowReplace) > 0 {
+				array[index] = p.newValue(array[index])
 			}
 		}
 	}
@@ -530,8 +566,8 @@ func (p *parser) prepareIndex(index interface{}, column int) actionFunc {
 			if c, ok = obj[key]; !ok {
 				return nil, fmt.Errorf("no key '%s' for object at %d", key, column)
 			}
-			if p.replaceValue != nil && len(a) == 1 {
-				obj[key] = p.replaceValue
+			if p.hasReplaceValue() && len(a) == 1 {
+				obj[key] = p.newValue(obj[key])
 			}
 
 			return a.next(r, c)
@@ -543,8 +579,8 @@ func (p *parser) prepareIndex(index interface{}, column int) actionFunc {
 			if index < 0 || index >= len(array) {
 				return nil, fmt.Errorf("out of bound array access at %d", column)
 			}
-			if p.replaceValue != nil && len(a) == 1 {
-				array[index] = p.replaceValue
+			if p.hasReplaceValue() && len(a) == 1 {
+				array[index] = p.newValue(array[index])
 			}
 			return a.next(r, array[index])
 		}
@@ -592,8 +628,8 @@ func (p *parser) prepareSlice(indexes []interface{}, column int) actionFunc {
 					continue
 				}
 				values = values.append(v)
-				if p.replaceValue != nil && len(a) == 1 {
-					array[i] = p.replaceValue
+				if p.hasReplaceValue() && len(a) == 1 {
+					array[i] = p.newValue(array[i])
 				}
 			}
 		} else { // reverse order on negative step
@@ -603,8 +639,8 @@ func (p *parser) prepareSlice(indexes []interface{}, column int) actionFunc {
 					continue
 				}
 				values = values.append(v)
-				if p.replaceValue != nil && len(a) == 1 {
-					array[i] = p.replaceValue
+				if p.hasReplaceValue() && len(a) == 1 {
+					array[i] = p.newValue(array[i])
 				}
 			}
 		}
@@ -628,8 +664,8 @@ func (p *parser) prepareUnion(indexes []interface{}, column int) actionFunc {
 					return nil, err
 				}
 				values = values.append(c)
-				if p.replaceValue != nil && len(a) == 1 {
-					obj[key] = p.replaceValue
+				if p.hasReplaceValue() && len(a) == 1 {
+					obj[key] = p.newValue(obj[key])
 				}
 			}
 			return values, nil
@@ -647,8 +683,8 @@ func (p *parser) prepareUnion(indexes []interface{}, column int) actionFunc {
 					return nil, err
 				}
 				values = values.append(c)
-				if p.replaceValue != nil && len(a) == 1 {
-					array[index] = p.replaceValue
+				if p.hasReplaceValue() && len(a) == 1 {
+					array[index] = p.newValue(array[index])
 				}
 			}
 			return values, nil
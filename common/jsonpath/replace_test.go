@@ -174,3 +174,30 @@ func TestReplace3List(t *testing.T) {
 		spew.Dump("h1", hash1, "h2", hash2)
 	}
 }
+
+func TestReplaceFunc(t *testing.T) {
+	upper := func(old interface{}) interface{} {
+		if s, ok := old.(string); ok {
+			return strings.ToUpper(s)
+		}
+		return old
+	}
+	for _, c := range []struct {
+		Raw      string
+		Path     string
+		Expected string
+	}{
+		{Raw: `{"a":"x","b":{"c":"y"}}`, Path: `$.b.c`, Expected: `{"a":"x","b":{"c":"Y"}}`},
+		{Raw: `{"a":"x","b":{"c":"y"}}`, Path: `$.b.d`, Expected: `{"a":"x","b":{"c":"y"}}`},
+		{Raw: `{"list":[{"n":"a"},{"n":"b"},{"n":1}]}`, Path: `$.list[*].n`, Expected: `{"list":[{"n":"A"},{"n":"B"},{"n":1}]}`},
+		{Raw: `{"list":["a","b"]}`, Path: `$.list[1]`, Expected: `{"list":["a","B"]}`},
+	} {
+		result, err := ReplaceFunc(c.Raw, c.Path, upper)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(utils.Jsonify(result)); got != c.Expected {
+			t.Fatalf("replace %v failed: expect %v, got %v", c.Path, c.Expected, got)
+		}
+	}
+}
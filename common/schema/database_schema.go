@@ -33,6 +33,7 @@ var ProfileTables = []interface{}{
 	&WebFuzzerLabel{},
 	&PluginGroup{},
 	&CodecFlow{},
+	&SyntaxFlowRule{},
 }
 
 var databaseSchemas = map[uint8][]any{
@@ -56,6 +56,13 @@ type HTTPFlow struct {
 	IsTooLargeResponse         bool
 	TooLargeResponseHeaderFile string
 	TooLargeResponseBodyFile   string
+
+	// 请求各阶段耗时(ms)，来自 lowhttp 的 TraceInfo 或者导入的 HAR，0 表示未记录
+	DNSDurationMs   int64
+	ConnDurationMs  int64
+	DurationMs      int64
+	TotalDurationMs int64
+
 	// 同步到企业端
 	UploadOnline bool `json:"upload_online"`
 }
@@ -0,0 +1,73 @@
+package schema
+
+import (
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/yakgrpc/ypb"
+)
+
+// SyntaxFlowRule is the SyntaxFlow rule saved in rule library,
+// the meta info is extracted from `desc(...)` of rule content
+type SyntaxFlowRule struct {
+	gorm.Model
+
+	// RuleName is the identity of rule, usually the file name without `.sf`
+	RuleName    string `json:"rule_name" gorm:"unique_index"`
+	Title       string `json:"title"`
+	Language    string `json:"language" gorm:"index"`
+	Type        string `json:"type"`
+	Severity    string `json:"severity"`
+	CWE         string `json:"cwe"`
+	Tags        string `json:"tags"`
+	Description string `json:"description"`
+	Content     string `json:"content"`
+	Version     string `json:"version"`
+
+	// Groups is joined by comma, the rule can be in many groups
+	Groups   string `json:"groups"`
+	Disabled bool   `json:"disabled"`
+
+	Hash string `json:"hash"`
+}
+
+func (s *SyntaxFlowRule) CalcHash() string {
+	return utils.CalcSha1(s.RuleName, s.Content)
+}
+
+func (s *SyntaxFlowRule) GetGroups() []string {
+	return utils.StringArrayFilterEmpty(utils.PrettifyListFromStringSplitEx(s.Groups, ","))
+}
+
+func (s *SyntaxFlowRule) GetTags() []string {
+	return utils.StringArrayFilterEmpty(utils.PrettifyListFromStringSplitEx(s.Tags, ",", "|"))
+}
+
+func (s *SyntaxFlowRule) BeforeSave() error {
+	s.Language = strings.ToLower(strings.TrimSpace(s.Language))
+	s.Groups = strings.Join(utils.RemoveRepeatStringSlice(s.GetGroups()), ",")
+	s.Hash = s.CalcHash()
+	return nil
+}
+
+func (s *SyntaxFlowRule) ToGRPCModel() *ypb.SyntaxFlowRule {
+	return &ypb.SyntaxFlowRule{
+		Id:          int64(s.ID),
+		CreatedAt:   s.CreatedAt.Unix(),
+		UpdatedAt:   s.UpdatedAt.Unix(),
+		RuleName:    s.RuleName,
+		Title:       s.Title,
+		Language:    s.Language,
+		Type:        s.Type,
+		Severity:    s.Severity,
+		CWE:         s.CWE,
+		Tags:        s.GetTags(),
+		Description: s.Description,
+		Content:     s.Content,
+		Version:     s.Version,
+		Groups:      s.GetGroups(),
+		Disabled:    s.Disabled,
+		Hash:        s.Hash,
+	}
+}
@@ -37,6 +37,8 @@ type YakScript struct {
 	ForceInteractive bool `json:"force_interactive"`
 
 	FromStore bool `json:"from_store"`
+	// SandboxPolicy 为执行插件时使用的沙箱策略，可以是内置策略名（restricted / isolated）或 JSON 格式的策略，为空时不限制
+	SandboxPolicy string `json:"sandbox_policy"`
 
 	IsGeneralModule      bool   `json:"is_general_module"`
 	GeneralModuleVerbose string `json:"general_module_verbose"`
@@ -196,6 +198,7 @@ func (s *YakScript) ToGRPCModel() *ypb.YakScript {
 		RiskAnnotation:       s.RiskAnnotation,
 		RiskInfo:             riskDetail,
 		IsCorePlugin:         s.IsCorePlugin,
+		SandboxPolicy:        s.SandboxPolicy,
 	}
 	/*if s.Type == "mitm" {
 		script.Params = mitmPluginDefaultPlugins
@@ -79,6 +79,22 @@ func (s *SFFrame) GetSymbolTable() *omap.OrderedMap[string, ValueOperator] {
 	return s.result.SymbolTable
 }
 
+// GetDescription collect the `desc(...)` of rule without executing it
+func (s *SFFrame) GetDescription() *omap.OrderedMap[string, string] {
+	desc := omap.NewEmptyOrderedMap[string, string]()
+	for _, code := range s.Codes {
+		if code.OpCode != OpAddDescription || code.UnaryStr == "" {
+			continue
+		}
+		value := code.ValueByIndex(1)
+		if value == "" {
+			value = code.ValueByIndex(0)
+		}
+		desc.Set(code.UnaryStr, value)
+	}
+	return desc
+}
+
 func (s *SFFrame) ToLeft() bool {
 	return s.toLeft
 }
@@ -747,22 +763,8 @@ func (s *SFFrame) execStatement(i *SFI) error {
 			elseStr = "$" + i.UnaryStr + " is not found"
 		}
 
-		haveResult := false
-
-		results, ok := s.GetSymbolTable().Get(i.UnaryStr)
-		if !ok {
-			haveResult = false
-		} else if results == nil {
-			haveResult = false
-		} else {
-			_ = results.Recursive(func(operator ValueOperator) error {
-				if _, ok := operator.(ssa.GetIdIF); ok {
-					haveResult = true
-					return utils.Error("abort")
-				}
-				return nil
-			})
-		}
+		results, _ := s.GetSymbolTable().Get(i.UnaryStr)
+		haveResult := haveSSAValue(results)
 
 		if !haveResult {
 			s.debugSubLog("-   error: " + elseStr)
@@ -925,6 +927,8 @@ func (s *SFFrame) execStatement(i *SFI) error {
 		}
 		ok, ret, err := call(value, s)
 		if err != nil || !ok {
+			// keep the stack balanced for the rest of filter expr
+			s.stack.Push(NewEmptyValues())
 			return err
 		}
 		s.stack.Push(ret)
@@ -24,6 +24,14 @@ type SFFrameResult struct {
 	SymbolTable      *omap.OrderedMap[string, ValueOperator]
 	AlertSymbolTable map[string]ValueOperator
 	AlertMsgTable    map[string]string
+	// the data flow path stopped by barrier
+	PrunedPaths []*PrunedPath
+}
+
+// PrunedPath record the value which the data flow analysis stopped at, because it matched the barrier
+type PrunedPath struct {
+	Barrier ValueOperator
+	Reason  string
 }
 
 func NewSFResult(rule string) *SFFrameResult {
@@ -55,6 +63,13 @@ func (s *SFFrameResult) String() string {
 		return buf.String()
 	}
 
+	if len(s.PrunedPaths) > 0 {
+		buf.WriteString("Pruned Paths: \n")
+		for idx, p := range s.PrunedPaths {
+			buf.WriteString(fmt.Sprintf("  %v: %v\n", idx+1, p.Reason))
+		}
+	}
+
 	count := 0
 	if s.SymbolTable.Len() > 0 {
 		buf.WriteString("Result Vars: \n")
@@ -120,5 +135,6 @@ func (s *SFFrameResult) Copy() *SFFrameResult {
 	ret.Errors = append([]string{}, s.Errors...)
 	ret.SymbolTable = s.SymbolTable.Copy()
 	ret.AlertSymbolTable = s.AlertSymbolTable
+	ret.PrunedPaths = append([]*PrunedPath{}, s.PrunedPaths...)
 	return ret
 }
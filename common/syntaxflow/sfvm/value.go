@@ -1,8 +1,11 @@
 package sfvm
 
 import (
-	"github.com/yaklang/yaklang/common/log"
 	"reflect"
+
+	"github.com/yaklang/yaklang/common/log"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/yak/ssa"
 )
 
 func AutoValue(i any) ValueOperator {
@@ -37,3 +40,19 @@ func valuesLen(i ValueOperator) int {
 
 	return 1
 }
+
+// haveSSAValue check the value operator contains any ssa value, the empty list or nil is false
+func haveSSAValue(i ValueOperator) bool {
+	if utils.IsNil(i) {
+		return false
+	}
+	haveResult := false
+	_ = i.Recursive(func(operator ValueOperator) error {
+		if _, ok := operator.(ssa.GetIdIF); ok {
+			haveResult = true
+			return utils.Error("abort")
+		}
+		return nil
+	})
+	return haveResult
+}
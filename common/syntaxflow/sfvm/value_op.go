@@ -18,6 +18,7 @@ const (
 	RecursiveConfig_Exclude                     = "exclude"
 	RecursiveConfig_Until                       = "until"
 	RecursiveConfig_Hook                        = "hook"
+	RecursiveConfig_Barrier                     = "barrier"
 )
 
 func FormatRecursiveConfigKey(i string) RecursiveConfigKey {
@@ -34,6 +35,8 @@ func FormatRecursiveConfigKey(i string) RecursiveConfigKey {
 		return RecursiveConfig_Until
 	case "hook":
 		return RecursiveConfig_Hook
+	case "barrier", "sanitizer", "sanitize":
+		return RecursiveConfig_Barrier
 	default:
 		log.Warnf("unknown recursive config key: %s", i)
 	}
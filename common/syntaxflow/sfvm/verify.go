@@ -0,0 +1,255 @@
+package sfvm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yaklang/yaklang/common/utils"
+)
+
+// the description keys of the test embedded in rule, like:
+//
+//	desc(
+//		title: "rce",
+//		lang: java,
+//		expect: "$sink",
+//		'positive://A.java': "class A { void f(String c) { Runtime.getRuntime().exec(c); } }",
+//		'negative://B.java': "class B { void f() { } }",
+//	)
+//
+// the positive sample should make every expected variable matched, the negative sample should not.
+// the variable with `!` prefix, like `expect: "$sink, !$high"`, should not be matched in positive sample.
+// the expectation of one sample can be set by `expect://<sample name>`, like:
+//
+//	'positive://C.java': "...",
+//	'expect://C.java': "$sink, !$high",
+const (
+	RuleTestLanguageKey    = "lang"
+	RuleTestExpectKey      = "expect"
+	RuleTestPositivePrefix = "positive"
+	RuleTestNegativePrefix = "negative"
+)
+
+// RuleTestNotFoundError is returned when rule has no embedded test sample
+var RuleTestNotFoundError = utils.Error("no embedded test sample")
+
+// RuleTestExpect is the expectation of one variable, the variable should be matched or not
+type RuleTestExpect struct {
+	Variable string
+	Match    bool
+}
+
+func (e *RuleTestExpect) String() string {
+	if e.Match {
+		return "$" + e.Variable
+	}
+	return "!$" + e.Variable
+}
+
+// RuleTestSample is the code sample embedded in rule,
+// the Name is from the key, like `positive://A.java`, the file extension can decide the language.
+// the Expect is set by `expect://<Name>`, if it's empty, the expectation of rule is used.
+type RuleTestSample struct {
+	Name     string
+	Language string
+	Code     string
+	Positive bool
+	Expect   []*RuleTestExpect
+}
+
+func (s *RuleTestSample) String() string {
+	kind := RuleTestNegativePrefix
+	if s.Positive {
+		kind = RuleTestPositivePrefix
+	}
+	return fmt.Sprintf("%v://%v", kind, s.Name)
+}
+
+// RuleTest is the embedded test of one rule
+type RuleTest struct {
+	Rule    string
+	Title   string
+	Expect  []*RuleTestExpect
+	Samples []*RuleTestSample
+}
+
+// RuleTestResult is the result of one sample
+type RuleTestResult struct {
+	Sample *RuleTestSample
+	Pass   bool
+	Reason string
+}
+
+// RuleTestCompiler compile the sample to the value operator which the rule feed on
+type RuleTestCompiler func(sample *RuleTestSample) (ValueOperator, error)
+
+// GetRuleTest collect the embedded test from description of rule, it's not needed to execute the rule.
+// if no `expect` is set, the variables in `alert` and `check` statement are expected.
+func (f *SFFrame) GetRuleTest() *RuleTest {
+	test := &RuleTest{Rule: f.Text}
+	var language string
+	var expect []*RuleTestExpect
+	var fallback []string
+	var samples []*RuleTestSample
+	sampleExpect := make(map[string][]*RuleTestExpect)
+
+	for _, code := range f.Codes {
+		switch code.OpCode {
+		case OpAlert, OpCheckParams:
+			fallback = append(fallback, code.UnaryStr)
+			continue
+		case OpAddDescription:
+		default:
+			continue
+		}
+
+		key, value := code.UnaryStr, code.ValueByIndex(1)
+		switch {
+		case key == "title":
+			test.Title = value
+		case key == RuleTestLanguageKey:
+			language = value
+		case key == RuleTestExpectKey:
+			expect = append(expect, parseRuleTestExpect(value)...)
+		default:
+			kind, name, ok := splitRuleTestKey(key)
+			if !ok {
+				continue
+			}
+			switch kind {
+			case RuleTestExpectKey:
+				if name != "" {
+					sampleExpect[name] = append(sampleExpect[name], parseRuleTestExpect(value)...)
+				}
+			case RuleTestPositivePrefix, RuleTestNegativePrefix:
+				if name == "" {
+					name = fmt.Sprintf("sample-%d", len(samples)+1)
+				}
+				samples = append(samples, &RuleTestSample{
+					Name:     name,
+					Code:     value,
+					Positive: kind == RuleTestPositivePrefix,
+				})
+			}
+		}
+	}
+
+	for _, sample := range samples {
+		sample.Language = language
+		sample.Expect = sampleExpect[sample.Name]
+	}
+	test.Samples = samples
+	if len(expect) > 0 {
+		test.Expect = expect
+	} else {
+		for _, name := range utils.RemoveRepeatStringSlice(fallback) {
+			test.Expect = append(test.Expect, &RuleTestExpect{Variable: name, Match: true})
+		}
+	}
+	return test
+}
+
+// splitRuleTestKey split the key like `positive://A.java` to kind and name,
+// the kind should be `positive`, `negative` or `expect` exactly
+func splitRuleTestKey(key string) (kind string, name string, ok bool) {
+	kind, name, found := strings.Cut(key, "://")
+	switch kind {
+	case RuleTestPositivePrefix, RuleTestNegativePrefix:
+		return kind, name, true
+	case RuleTestExpectKey:
+		return kind, name, found
+	}
+	return "", "", false
+}
+
+// parseRuleTestExpect parse the variables like `$sink, !$high`
+func parseRuleTestExpect(s string) []*RuleTestExpect {
+	var ret []*RuleTestExpect
+	for _, item := range strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '|'
+	}) {
+		match := !strings.HasPrefix(item, "!")
+		if item = strings.TrimLeft(item, "!$"); item != "" {
+			ret = append(ret, &RuleTestExpect{Variable: item, Match: match})
+		}
+	}
+	return ret
+}
+
+// Run compile every sample in memory and execute the rule on it
+func (t *RuleTest) Run(compile RuleTestCompiler, opts ...Option) ([]*RuleTestResult, error) {
+	if len(t.Samples) == 0 {
+		return nil, utils.Wrapf(RuleTestNotFoundError, "rule %#v", t.Title)
+	}
+	for _, sample := range t.Samples {
+		if len(sample.Expect) == 0 && len(t.Expect) == 0 {
+			return nil, utils.Errorf("rule %#v has no variable to check, set `%v` in desc", t.Title, RuleTestExpectKey)
+		}
+	}
+
+	results := make([]*RuleTestResult, 0, len(t.Samples))
+	for _, sample := range t.Samples {
+		result := &RuleTestResult{Sample: sample}
+		if err := t.runSample(sample, compile, opts...); err != nil {
+			result.Reason = err.Error()
+		} else {
+			result.Pass = true
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (t *RuleTest) runSample(sample *RuleTestSample, compile RuleTestCompiler, opts ...Option) error {
+	value, err := compile(sample)
+	if err != nil {
+		return utils.Wrapf(err, "compile sample failed")
+	}
+	frame, err := NewSyntaxFlowVirtualMachine(opts...).Compile(t.Rule)
+	if err != nil {
+		return err
+	}
+	result, err := frame.Feed(value)
+	if err != nil && (sample.Positive || result == nil) {
+		return utils.Wrapf(err, "execute rule failed")
+	}
+	// the rule may stop with error when nothing matched in negative sample, the variables are still checked
+
+	for _, expect := range t.getSampleExpect(sample) {
+		value, _ := result.SymbolTable.Get(expect.Variable)
+		matched := haveSSAValue(value)
+		if expect.Match && !matched {
+			return utils.Errorf("$%v should be matched but not", expect.Variable)
+		}
+		if !expect.Match && matched {
+			return utils.Errorf("$%v should not be matched but matched", expect.Variable)
+		}
+	}
+	return nil
+}
+
+// getSampleExpect get the expectation of sample, nothing expected should be matched in negative sample
+func (t *RuleTest) getSampleExpect(sample *RuleTestSample) []*RuleTestExpect {
+	if len(sample.Expect) > 0 {
+		return sample.Expect
+	}
+	if sample.Positive {
+		return t.Expect
+	}
+	ret := make([]*RuleTestExpect, 0, len(t.Expect))
+	for _, expect := range t.Expect {
+		ret = append(ret, &RuleTestExpect{Variable: expect.Variable, Match: false})
+	}
+	return ret
+}
+
+// RunRuleTest compile the rule and run its embedded test
+func RunRuleTest(rule string, compile RuleTestCompiler, opts ...Option) (*RuleTest, []*RuleTestResult, error) {
+	frame, err := NewSyntaxFlowVirtualMachine(opts...).Compile(rule)
+	if err != nil {
+		return nil, nil, err
+	}
+	test := frame.GetRuleTest()
+	results, err := test.Run(compile, opts...)
+	return test, results, err
+}
@@ -26,7 +26,7 @@ func (v *SyntaxFlowVisitor) VisitRecursiveConfig(i *sf.ConfigContext) []*Recursi
 			configItem.SyntaxFlowRule = true
 			configItem.Value = rule.GetText()
 		} else {
-			configItem.Value = value.GetText()
+			configItem.Value = mustUnquoteSyntaxFlowString(value.GetText())
 		}
 		res = append(res, configItem)
 	}
@@ -26,6 +26,7 @@ type LowhttpExecConfig struct {
 	Https                            bool
 	ResponseCallback                 func(response *LowhttpResponse)
 	Http2                            bool
+	Http3                            bool
 	GmTLS                            bool
 	OverrideEnableSystemProxyFromEnv bool
 	EnableSystemProxyFromEnv         bool
@@ -87,6 +88,7 @@ type LowhttpResponse struct {
 	Proxy                  string
 	Https                  bool
 	Http2                  bool
+	Http3                  bool // 通过 QUIC 协商到了 h3
 	RawRequest             []byte
 	Source                 string // 请求源
 	RuntimeId              string
@@ -340,6 +342,13 @@ func WithHttp2(Http2 bool) LowhttpOpt {
 	}
 }
 
+// WithHttp3 使用 HTTP/3 (QUIC) 发送请求，请求头使用 QPACK 编码，代理仅支持 socks5 (UDP ASSOCIATE)
+func WithHttp3(Http3 bool) LowhttpOpt {
+	return func(o *LowhttpExecConfig) {
+		o.Http3 = Http3
+	}
+}
+
 func WithTimeout(timeout time.Duration) LowhttpOpt {
 	return func(o *LowhttpExecConfig) {
 		o.Timeout = timeout
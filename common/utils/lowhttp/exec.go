@@ -268,7 +268,7 @@ func HTTPWithoutRedirect(opts ...LowhttpOpt) (*LowhttpResponse, error) {
 		proxy = ordinaryProxy
 	}
 
-	if gmTLS {
+	if gmTLS || option.Http3 {
 		https = true
 	}
 
@@ -448,6 +448,11 @@ func HTTPWithoutRedirect(opts ...LowhttpOpt) (*LowhttpResponse, error) {
 		traceInfo.TotalTime = time.Since(totalTimeStart)
 	}()
 
+	// h3
+	if option.Http3 {
+		return doHTTP3(option, ctx, reqIns, host, port, proxy, requestPacket, response)
+	}
+
 	// h2
 	var nextProto []string
 	reqSchema := H1
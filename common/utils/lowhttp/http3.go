@@ -0,0 +1,406 @@
+package lowhttp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/quic-go/qpack"
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/quicvarint"
+	"github.com/yaklang/yaklang/common/log"
+	"github.com/yaklang/yaklang/common/netx"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/utils/lowhttp/httpctx"
+)
+
+const H3 = "h3"
+
+// http3 frame / stream type, RFC 9114
+const (
+	http3FrameData     = 0x00
+	http3FrameHeaders  = 0x01
+	http3FrameSettings = 0x04
+
+	http3StreamControl = 0x00
+
+	// H3_NO_ERROR
+	http3ErrNoError = 0x100
+)
+
+// http3MaxHeaderFrameSize 限制响应头帧的大小，防止异常服务器耗尽内存
+const http3MaxHeaderFrameSize = 1 << 20
+
+type http3Request struct {
+	ctx            context.Context
+	host           string
+	port           int
+	sni            string
+	proxy          []string
+	verify         bool
+	connectTimeout time.Duration
+	timeout        time.Duration
+	dnsOpts        []netx.DNSOption
+}
+
+// resolveAddr 解析目标的 udp 地址，返回 dns 耗时
+func (r *http3Request) resolveAddr() (*net.UDPAddr, time.Duration, error) {
+	start := time.Now()
+	ip := r.host
+	if net.ParseIP(ip) == nil {
+		ip = netx.LookupFirst(r.host, r.dnsOpts...)
+		if ip == "" {
+			return nil, time.Since(start), utils.Errorf("cannot resolve host: %v", r.host)
+		}
+	}
+	addr, err := net.ResolveUDPAddr("udp", utils.HostPort(ip, r.port))
+	return addr, time.Since(start), err
+}
+
+// listenPacket 创建 QUIC 使用的 udp 连接，配置了代理时仅支持 socks5 代理的 UDP ASSOCIATE
+func (r *http3Request) listenPacket() (net.PacketConn, string, error) {
+	if len(r.proxy) <= 0 {
+		conn, err := net.ListenUDP("udp", nil)
+		return conn, "", err
+	}
+	var errs []string
+	for _, proxy := range r.proxy {
+		u, err := url.Parse(proxy)
+		if err != nil {
+			continue
+		}
+		if u.Scheme != "socks5" {
+			errs = append(errs, utils.Errorf("proxy %v: http3 only support socks5 proxy", proxy).Error())
+			continue
+		}
+		conn, err := netx.DialSocks5UDP(r.ctx, proxy, r.connectTimeout)
+		if err != nil {
+			errs = append(errs, utils.Errorf("proxy %v: %v", proxy, err).Error())
+			continue
+		}
+		return conn, proxy, nil
+	}
+	return nil, "", utils.Errorf("no proxy available for http3: %v", strings.Join(errs, "; "))
+}
+
+func (r *http3Request) dial() (quic.Connection, net.PacketConn, string, time.Duration, error) {
+	remote, dnsTime, err := r.resolveAddr()
+	if err != nil {
+		return nil, nil, "", dnsTime, err
+	}
+	packetConn, proxy, err := r.listenPacket()
+	if err != nil {
+		return nil, nil, "", dnsTime, err
+	}
+	serverName := r.sni
+	if serverName == "" {
+		serverName = r.host
+	}
+	ctx, cancel := context.WithTimeout(r.ctx, r.connectTimeout)
+	defer cancel()
+	conn, err := quic.Dial(ctx, packetConn, remote, &tls.Config{
+		NextProtos:         []string{H3},
+		ServerName:         serverName,
+		InsecureSkipVerify: !r.verify,
+		MinVersion:         tls.VersionTLS13,
+	}, &quic.Config{
+		HandshakeIdleTimeout: r.connectTimeout,
+		MaxIdleTimeout:       r.timeout,
+	})
+	if err != nil {
+		packetConn.Close()
+		return nil, nil, proxy, dnsTime, utils.Errorf("quic dial %v failed: %v", remote, err)
+	}
+	return conn, packetConn, proxy, dnsTime, nil
+}
+
+func appendHTTP3Frame(b []byte, typ uint64, payload []byte) []byte {
+	b = quicvarint.Append(b, typ)
+	b = quicvarint.Append(b, uint64(len(payload)))
+	return append(b, payload...)
+}
+
+// encodeHTTP3Request 将原始请求报文转换为 HEADERS 帧与 DATA 帧，请求头保持报文中的顺序
+func encodeHTTP3Request(packet []byte) ([]byte, error) {
+	var (
+		authority string
+		headers   []qpack.HeaderField
+	)
+	addHeader := func(k, v string) {
+		headers = append(headers, qpack.HeaderField{Name: k, Value: v})
+	}
+	var pseudo []qpack.HeaderField
+	_, body := SplitHTTPHeadersAndBodyFromPacketEx(packet, func(method string, requestUri string, proto string) error {
+		if method == "" {
+			method = http.MethodGet
+		}
+		pseudo = append(pseudo, qpack.HeaderField{Name: ":method", Value: method})
+		if !utils.AsciiEqualFold(method, "CONNECT") {
+			if u, err := url.Parse(requestUri); err == nil && u.Scheme != "" {
+				// 绝对路径的请求，使用其中的 host 与 path
+				authority = u.Host
+				requestUri = u.RequestURI()
+			}
+			if requestUri == "" {
+				requestUri = "/"
+			}
+			pseudo = append(pseudo, qpack.HeaderField{Name: ":scheme", Value: "https"})
+			pseudo = append(pseudo, qpack.HeaderField{Name: ":path", Value: requestUri})
+		}
+		return nil
+	}, func(line string) {
+		key, value := SplitHTTPHeader(line)
+		key = strings.ToLower(strings.TrimSpace(key))
+		switch key {
+		case "host":
+			if authority == "" {
+				authority = strings.TrimSpace(value)
+			}
+		case "content-length", "connection", "proxy-connection",
+			"transfer-encoding", "upgrade", "keep-alive": // h3 中不应该出现的头
+		default:
+			addHeader(key, strings.TrimLeft(value, " "))
+		}
+	})
+	if authority != "" {
+		pseudo = append(pseudo, qpack.HeaderField{Name: ":authority", Value: authority})
+	}
+
+	var headerBlock bytes.Buffer
+	enc := qpack.NewEncoder(&headerBlock)
+	for _, h := range append(pseudo, headers...) {
+		if err := enc.WriteField(h); err != nil {
+			return nil, utils.Errorf("qpack encode header %v failed: %v", h.Name, err)
+		}
+	}
+	raw := appendHTTP3Frame(nil, http3FrameHeaders, headerBlock.Bytes())
+	if len(body) > 0 {
+		raw = appendHTTP3Frame(raw, http3FrameData, body)
+	}
+	return raw, nil
+}
+
+// readHTTP3Response 读取响应的 HEADERS 帧与 DATA 帧，还原为 HTTP/3.0 的响应报文
+func readHTTP3Response(stream io.Reader, firstByte func()) ([]byte, error) {
+	reader := bufio.NewReader(stream)
+	decoder := qpack.NewDecoder(nil)
+
+	var (
+		header   bytes.Buffer
+		body     bytes.Buffer
+		gotFinal bool
+		first    = true
+	)
+	for {
+		typ, err := quicvarint.Read(reader)
+		if first && err == nil {
+			first = false
+			firstByte()
+		}
+		if err != nil {
+			if err == io.EOF && gotFinal {
+				break
+			}
+			return nil, utils.Errorf("read http3 frame failed: %v", err)
+		}
+		length, err := quicvarint.Read(reader)
+		if err != nil {
+			return nil, utils.Errorf("read http3 frame length failed: %v", err)
+		}
+
+		switch typ {
+		case http3FrameHeaders:
+			if length > http3MaxHeaderFrameSize {
+				return nil, utils.Errorf("http3 header frame too large: %v", length)
+			}
+			block := make([]byte, length)
+			if _, err := io.ReadFull(reader, block); err != nil {
+				return nil, utils.Errorf("read http3 header frame failed: %v", err)
+			}
+			if gotFinal {
+				// trailers
+				continue
+			}
+			fields, err := decoder.DecodeFull(block)
+			if err != nil {
+				return nil, utils.Errorf("qpack decode header failed: %v", err)
+			}
+			status := 0
+			header.Reset()
+			for _, h := range fields {
+				if h.IsPseudo() {
+					if h.Name == ":status" {
+						status, _ = strconv.Atoi(h.Value)
+					}
+					continue
+				}
+				header.WriteString(h.Name + ": " + h.Value + "\r\n")
+			}
+			if status <= 0 {
+				return nil, utils.Error("http3 response without :status")
+			}
+			// 1xx 为临时响应，继续读取最终响应
+			if status >= 100 && status < 200 {
+				continue
+			}
+			statusLine := "HTTP/3.0 " + strconv.Itoa(status) + " " + http.StatusText(status) + "\r\n"
+			headerLines := header.String()
+			header.Reset()
+			header.WriteString(statusLine)
+			header.WriteString(headerLines)
+			gotFinal = true
+		case http3FrameData:
+			if !gotFinal {
+				return nil, utils.Error("http3 received DATA before HEADERS")
+			}
+			if _, err := io.CopyN(&body, reader, int64(length)); err != nil {
+				return nil, utils.Errorf("read http3 data frame failed: %v", err)
+			}
+		default:
+			// 忽略未知的帧(例如 reserved/grease 帧)
+			if _, err := io.CopyN(io.Discard, reader, int64(length)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	header.WriteString("\r\n")
+	header.Write(body.Bytes())
+	return header.Bytes(), nil
+}
+
+// sendHTTP3Request 通过 QUIC 发送原始请求报文，每个请求使用一个新的 QUIC 连接
+func sendHTTP3Request(r *http3Request, requestPacket []byte, response *LowhttpResponse) ([]byte, error) {
+	traceInfo := response.TraceInfo
+	connStart := time.Now()
+	conn, packetConn, proxy, dnsTime, err := r.dial()
+	traceInfo.DNSTime = dnsTime
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		conn.CloseWithError(http3ErrNoError, "")
+		packetConn.Close()
+	}()
+	traceInfo.ConnTime = time.Since(connStart) - dnsTime
+	response.Proxy = proxy
+	response.RemoteAddr = conn.RemoteAddr().String()
+	response.PortIsOpen = true
+	response.Http3 = conn.ConnectionState().TLS.NegotiatedProtocol == H3
+	if !response.Http3 {
+		return nil, utils.Errorf("server does not support h3, negotiated protocol: %#v", conn.ConnectionState().TLS.NegotiatedProtocol)
+	}
+
+	// 控制流，只发送空的 SETTINGS
+	control, err := conn.OpenUniStream()
+	if err != nil {
+		return nil, utils.Errorf("open http3 control stream failed: %v", err)
+	}
+	if _, err := control.Write(appendHTTP3Frame(quicvarint.Append(nil, http3StreamControl), http3FrameSettings, nil)); err != nil {
+		return nil, utils.Errorf("write http3 settings failed: %v", err)
+	}
+
+	raw, err := encodeHTTP3Request(requestPacket)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(r.ctx, r.timeout)
+	defer cancel()
+	stream, err := conn.OpenStreamSync(ctx)
+	if err != nil {
+		return nil, utils.Errorf("open http3 request stream failed: %v", err)
+	}
+	_ = stream.SetDeadline(time.Now().Add(r.timeout))
+	if _, err := stream.Write(raw); err != nil {
+		return nil, utils.Errorf("write http3 request failed: %v", err)
+	}
+	// 关闭写方向，表示请求结束
+	if err := stream.Close(); err != nil {
+		log.Debugf("close http3 request stream failed: %v", err)
+	}
+
+	serverTimeStart := time.Now()
+	return readHTTP3Response(stream, func() {
+		traceInfo.ServerTime = time.Since(serverTimeStart)
+	})
+}
+
+func newHTTP3Request(option *LowhttpExecConfig, ctx context.Context, host string, port int, proxy []string) *http3Request {
+	connectTimeout := option.ConnectTimeout
+	if connectTimeout <= 0 {
+		connectTimeout = 15 * time.Second
+	}
+	timeout := option.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &http3Request{
+		ctx:            ctx,
+		host:           host,
+		port:           port,
+		sni:            option.SNI,
+		proxy:          proxy,
+		verify:         option.VerifyCertificate,
+		connectTimeout: connectTimeout,
+		timeout:        timeout,
+		dnsOpts: []netx.DNSOption{
+			netx.WithDNSServers(option.DNSServers...),
+			netx.WithTemporaryHosts(option.EtcHosts),
+		},
+	}
+}
+
+// doHTTP3 HTTPWithoutRedirect 中 HTTP/3 的分支
+func doHTTP3(option *LowhttpExecConfig, ctx context.Context, reqIns *http.Request, host string, port int, proxy []string, requestPacket []byte, response *LowhttpResponse) (*LowhttpResponse, error) {
+	if option.BeforeDoRequest != nil {
+		requestPacket = option.BeforeDoRequest(requestPacket)
+	}
+	if reqIns != nil {
+		httpctx.SetRequestHTTPS(reqIns, true)
+		httpctx.SetBareRequestBytes(reqIns, requestPacket)
+	}
+	response.Https = true
+	response.Http2 = false
+
+	rawBytes, err := sendHTTP3Request(newHTTP3Request(option, ctx, host, port, proxy), requestPacket, response)
+	if err != nil {
+		return response, err
+	}
+	if reqIns != nil {
+		httpctx.SetRemoteAddr(reqIns, response.RemoteAddr)
+		httpctx.SetBareResponseBytes(reqIns, rawBytes)
+	}
+	if session := option.Session; session != nil {
+		if rsp, err := ParseBytesToHTTPResponse(rawBytes); err == nil {
+			if u, err := url.Parse(response.Url); err == nil {
+				GetCookiejar(session).SetCookies(u, rsp.Cookies())
+			}
+		}
+	}
+	if option.EnableMaxContentLength && option.MaxContentLength > 0 {
+		if body := GetHTTPPacketBody(rawBytes); len(body) > option.MaxContentLength {
+			rawBytes = ReplaceHTTPPacketBodyFast(rawBytes, body[:option.MaxContentLength])
+		}
+	}
+	response.BareResponse = rawBytes
+	if option.NoFixContentLength {
+		response.RawPacket = rawBytes
+		return response, nil
+	}
+	rspRaw, _, err := FixHTTPResponse(rawBytes)
+	if err != nil {
+		log.Errorf("fix http3 response failed: %s", err)
+		response.RawPacket = rawBytes
+		return response, nil
+	}
+	response.RawPacket = rspRaw
+	return response, nil
+}
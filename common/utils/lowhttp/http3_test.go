@@ -0,0 +1,170 @@
+package lowhttp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/quic-go/quic-go/http3"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklang/yaklang/common/utils/tlsutils"
+)
+
+func startHTTP3Server(t *testing.T, handler http.HandlerFunc) int {
+	ca, key, err := tlsutils.GenerateSelfSignedCertKey("127.0.0.1", nil, nil)
+	require.NoError(t, err)
+	serverCert, serverKey, err := tlsutils.SignServerCrtNKeyWithParams(ca, key, "127.0.0.1", time.Now().Add(time.Hour), false)
+	require.NoError(t, err)
+	tlsConfig, err := tlsutils.GetX509ServerTlsConfig(ca, serverCert, serverKey)
+	require.NoError(t, err)
+
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	server := &http3.Server{Handler: handler, TLSConfig: http3.ConfigureTLSConfig(tlsConfig)}
+	go server.Serve(conn)
+	t.Cleanup(func() {
+		server.Close()
+		conn.Close()
+	})
+	return conn.LocalAddr().(*net.UDPAddr).Port
+}
+
+func http3EchoHandler(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	w.Header().Set("X-Proto", r.Proto)
+	w.Header().Set("X-Method", r.Method)
+	w.Header().Set("X-Host", r.Host)
+	w.Header().Set("X-Token", r.Header.Get("X-Token"))
+	w.WriteHeader(http.StatusCreated)
+	fmt.Fprintf(w, "path=%s body=%s", r.URL.RequestURI(), body)
+}
+
+func TestHTTP3(t *testing.T) {
+	port := startHTTP3Server(t, http3EchoHandler)
+	host := "127.0.0.1:" + strconv.Itoa(port)
+
+	rsp, err := HTTP(WithHttp3(true), WithTimeout(5*time.Second), WithPacketBytes([]byte("POST /echo?a=1 HTTP/1.1\r\n"+
+		"Host: "+host+"\r\n"+
+		"X-Token: abc\r\n"+
+		"Connection: keep-alive\r\n"+
+		"Content-Length: 5\r\n\r\nhello")))
+	require.NoError(t, err)
+	require.True(t, rsp.Http3)
+	require.False(t, rsp.Http2)
+	require.True(t, rsp.Https)
+	require.Equal(t, "https://"+host+"/echo?a=1", rsp.Url)
+	require.Equal(t, host, rsp.RemoteAddr)
+
+	packet := string(rsp.RawPacket)
+	require.True(t, strings.HasPrefix(packet, "HTTP/3.0 201 Created\r\n"), packet)
+	require.Equal(t, 201, rsp.GetStatusCode())
+	require.Equal(t, "HTTP/3.0", GetHTTPPacketHeader(rsp.RawPacket, "X-Proto"))
+	require.Equal(t, "POST", GetHTTPPacketHeader(rsp.RawPacket, "X-Method"))
+	require.Equal(t, host, GetHTTPPacketHeader(rsp.RawPacket, "X-Host"))
+	require.Equal(t, "abc", GetHTTPPacketHeader(rsp.RawPacket, "X-Token"))
+	require.Equal(t, "path=/echo?a=1 body=hello", string(rsp.GetBody()))
+
+	// 请求行中的 HTTP/2 不影响 HTTP/3
+	rsp, err = HTTP(WithHttp3(true), WithTimeout(5*time.Second), WithPacketBytes([]byte("GET /h2 HTTP/2\r\nHost: "+host+"\r\n\r\n")))
+	require.NoError(t, err)
+	require.True(t, rsp.Http3)
+	require.Equal(t, "path=/h2 body=", string(rsp.GetBody()))
+}
+
+func TestHTTP3_NotSupported(t *testing.T) {
+	// 没有 HTTP/3 服务的端口
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	port := conn.LocalAddr().(*net.UDPAddr).Port
+	conn.Close()
+
+	_, err = HTTP(WithHttp3(true), WithConnectTimeout(time.Second), WithPacketBytes([]byte("GET / HTTP/1.1\r\nHost: 127.0.0.1:"+strconv.Itoa(port)+"\r\n\r\n")))
+	require.Error(t, err)
+
+	// http 代理无法转发 udp
+	_, err = HTTP(WithHttp3(true), WithProxy("http://127.0.0.1:1"), WithPacketBytes([]byte("GET / HTTP/1.1\r\nHost: 127.0.0.1:"+strconv.Itoa(port)+"\r\n\r\n")))
+	require.ErrorContains(t, err, "only support socks5")
+}
+
+// startSocks5UDPProxy 只支持无认证的 UDP ASSOCIATE 的 socks5 代理
+func startSocks5UDPProxy(t *testing.T, relayed *int64) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { lis.Close() })
+	go func() {
+		for {
+			ctrl, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer ctrl.Close()
+				buf := make([]byte, 262)
+				if _, err := io.ReadFull(ctrl, buf[:3]); err != nil {
+					return
+				}
+				ctrl.Write([]byte{5, 0})
+				if _, err := io.ReadFull(ctrl, buf[:10]); err != nil || buf[1] != 3 {
+					return
+				}
+				relay, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+				if err != nil {
+					return
+				}
+				defer relay.Close()
+				reply := []byte{5, 0, 0, 1, 0, 0, 0, 0, 0, 0}
+				binary.BigEndian.PutUint16(reply[8:], uint16(relay.LocalAddr().(*net.UDPAddr).Port))
+				ctrl.Write(reply)
+
+				go func() {
+					var client *net.UDPAddr
+					packet := make([]byte, 65535)
+					for {
+						n, from, err := relay.ReadFromUDP(packet)
+						if err != nil {
+							return
+						}
+						if client == nil || from.String() == client.String() {
+							// client -> target
+							client = from
+							if n < 10 || packet[3] != 1 {
+								continue
+							}
+							target := &net.UDPAddr{IP: net.IP(packet[4:8]), Port: int(binary.BigEndian.Uint16(packet[8:10]))}
+							atomic.AddInt64(relayed, 1)
+							relay.WriteToUDP(packet[10:n], target)
+							continue
+						}
+						// target -> client
+						header := []byte{0, 0, 0, 1}
+						header = append(header, from.IP.To4()...)
+						header = binary.BigEndian.AppendUint16(header, uint16(from.Port))
+						relay.WriteToUDP(append(header, packet[:n]...), client)
+					}
+				}()
+				io.Copy(io.Discard, ctrl)
+			}()
+		}
+	}()
+	return "socks5://" + lis.Addr().String()
+}
+
+func TestHTTP3_Socks5Proxy(t *testing.T) {
+	port := startHTTP3Server(t, http3EchoHandler)
+	var relayed int64
+	proxy := startSocks5UDPProxy(t, &relayed)
+
+	rsp, err := HTTP(WithHttp3(true), WithProxy(proxy), WithTimeout(5*time.Second), WithPacketBytes([]byte("GET /proxy HTTP/1.1\r\nHost: 127.0.0.1:"+strconv.Itoa(port)+"\r\n\r\n")))
+	require.NoError(t, err)
+	require.True(t, rsp.Http3)
+	require.Equal(t, proxy, rsp.Proxy)
+	require.Equal(t, "path=/proxy body=", string(rsp.GetBody()))
+	require.Greater(t, atomic.LoadInt64(&relayed), int64(0))
+}
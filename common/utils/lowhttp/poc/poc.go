@@ -36,6 +36,7 @@ type PocConfig struct {
 	Port                 int
 	ForceHttps           *bool
 	ForceHttp2           *bool
+	ForceHttp3           *bool
 	SNI                  *string
 	Timeout              *time.Duration
 	ConnectTimeout       *time.Duration
@@ -109,6 +110,9 @@ func (c *PocConfig) ToLowhttpOptions() []lowhttp.LowhttpOpt {
 	if c.ForceHttp2 != nil {
 		opts = append(opts, lowhttp.WithHttp2(*c.ForceHttp2))
 	}
+	if c.ForceHttp3 != nil {
+		opts = append(opts, lowhttp.WithHttp3(*c.ForceHttp3))
+	}
 	if c.SNI != nil {
 		opts = append(opts, lowhttp.WithSNI(*c.SNI))
 	}
@@ -378,6 +382,17 @@ func WithForceHTTP2(isHttp2 bool) PocConfigOption {
 	}
 }
 
+// http3 是一个请求选项参数，用于指定是否使用 http3(QUIC) 协议，默认为 false，使用 http3 时代理只支持 socks5
+// Example:
+// ```
+// rsp, req = poc.Get("https://www.example.com", poc.http3(true))~ // 向 www.example.com 发起请求，使用 http3 协议
+// ```
+func WithForceHTTP3(isHttp3 bool) PocConfigOption {
+	return func(c *PocConfig) {
+		c.ForceHttp3 = &isHttp3
+	}
+}
+
 // sni 是一个请求选项参数，用于指定使用 tls(https) 协议时的 服务器名称指示(SNI)
 // Example:
 // ```
@@ -1317,6 +1332,24 @@ func handleRawPacketAndConfig(i interface{}, opts ...PocConfigOption) ([]byte, *
 	return packet, config, nil
 }
 
+// RequestTarget 返回 HTTP / HTTPEx / Websocket 发送原始请求 i 时实际连接的主机与端口，不会发送请求
+func RequestTarget(i interface{}, opts ...PocConfigOption) (string, int, error) {
+	_, config, err := handleRawPacketAndConfig(i, opts...)
+	if err != nil {
+		return "", 0, err
+	}
+	return config.Host, config.Port, nil
+}
+
+// URLTarget 返回 Do / Get / Post 等函数请求 urlStr 时实际连接的主机与端口，不会发送请求
+func URLTarget(urlStr string, opts ...PocConfigOption) (string, int, error) {
+	config, err := handleUrlAndConfig(urlStr, opts...)
+	if err != nil {
+		return "", 0, err
+	}
+	return config.Host, config.Port, nil
+}
+
 func pochttp(packet []byte, config *PocConfig) (*lowhttp.LowhttpResponse, error) {
 	if config.Websocket {
 		if config.Timeout == nil || *config.Timeout <= 0 {
@@ -1638,6 +1671,7 @@ var PoCExports = map[string]interface{}{
 	"redirectHandler":      WithRedirectHandler,
 	"https":                WithForceHTTPS,
 	"http2":                WithForceHTTP2,
+	"http3":                WithForceHTTP3,
 	"sni":                  WithSNI,
 	"params":               WithParams,
 	"proxy":                WithProxy,
@@ -0,0 +1,43 @@
+package yakgit
+
+import (
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/utils/filesys"
+)
+
+// FileSystemFromRevision load the files of git revision (hash, tag, branch or expression like HEAD~1)
+// into a virtual file system, the binary files are skipped
+func FileSystemFromRevision(repo *git.Repository, rev string) (*filesys.VirtualFS, error) {
+	hash, err := repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return nil, utils.Wrapf(err, "resolve revision %v failed", rev)
+	}
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, utils.Wrapf(err, "get commit %v failed", hash)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, utils.Wrapf(err, "get tree of commit %v failed", hash)
+	}
+
+	vfs := filesys.NewVirtualFs()
+	err = tree.Files().ForEach(func(file *object.File) error {
+		if isBinary, _ := file.IsBinary(); isBinary {
+			return nil
+		}
+		content, err := file.Contents()
+		if err != nil {
+			return utils.Wrapf(err, "read %v failed", file.Name)
+		}
+		vfs.AddFile(file.Name, content)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return vfs, nil
+}
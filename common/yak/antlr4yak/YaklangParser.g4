@@ -18,6 +18,9 @@ statement
     // 基本语句
     : lineCommentStmt eos
 
+    // import "module" 与 import(...) 函数调用共用标识符，需要在表达式之前匹配
+    | importStmt eos
+
     // 声明变量的优先级比表达式高，这个规则匹配应该是 var a,d,b,c 只能支持 Var，特殊语法
     | declareVariableExpressionStmt eos
 
@@ -51,6 +54,7 @@ assignExpressionStmt: assignExpression;
 lineCommentStmt: (LINE_COMMENT | COMMENT);
 
 includeStmt: 'include' StringLiteral;
+importStmt: { this.isImportStmt() }? Identifier StringLiteral;
 deferStmt: 'defer' expression;
 goStmt: 'go' ((expression functionCall) | instanceCode);
 assertStmt: 'assert' expression (',' expression)*;
@@ -102,11 +106,11 @@ assignExpression
     | leftExpression inplaceAssignOperator expression
     ;
 
-// 变量声明语句，和赋值不一样
+// 变量声明语句，和赋值不一样，可以带有类型标注：var a int = 1
 declareVariableExpressionStmt: declareVariableExpression;
 declareVariableExpression: declareVariableOnly | declareAndAssignExpression;
-declareVariableOnly: Var Identifier (',' Identifier) *;
-declareAndAssignExpression: Var leftExpressionList ('=' | ':=') expressionList;
+declareVariableOnly: Var Identifier (',' Identifier) * typeLiteral?;
+declareAndAssignExpression: Var leftExpressionList typeLiteral? ('=' | ':=') expressionList;
 
 leftExpressionList
     : leftExpression (',' leftExpression) *
@@ -218,14 +222,17 @@ instanceCode: Func block;
 fn(p1,p2,p3){}
 fn abc(p1,p2,p3){}
 fn abc(p1,p2,p3...){}
+
+可选的类型标注，只用于静态检查，不影响执行
+fn abc(p1 int, p2 []string, p3 ...any) bool {}
 */
 anonymousFunctionDecl
-    : Func functionNameDecl? '(' functionParamDecl? ')'  block
+    : Func functionNameDecl? '(' functionParamDecl? ')' typeLiteral? block
     | ('('  functionParamDecl? ')' | Identifier ) '=>' (block | expression)
     ;
 
 functionNameDecl: Identifier;
-functionParamDecl: ws* Identifier (ws* ',' ws* Identifier)* '...'? ws* ','? ws*;
+functionParamDecl: ws* Identifier typeLiteral? (ws* ',' ws* Identifier typeLiteral?)* ('...' typeLiteral?)? ws* ','? ws*;
 
 functionCall: '(' ordinaryArguments? ')' '~'?;
 ordinaryArguments: ws* expression (ws* ',' ws* expression)* '...'? ws* ','? ws*;
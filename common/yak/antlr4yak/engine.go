@@ -124,7 +124,7 @@ func (n *Engine) EnableStrictMode() {
 }
 
 func (n *Engine) GetSymNames() []string {
-	return nil
+	return n.rootSymbol.GetLocalSymbolNames()
 }
 
 func (n *Engine) CopyVars() map[string]interface{} {
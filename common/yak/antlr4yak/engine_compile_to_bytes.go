@@ -177,6 +177,19 @@ func (n *Engine) Marshal(code string, key []byte) ([]byte, error) {
 	return n._marshal(cl.GetRootSymbolTable(), cl.GetOpcodes(), key)
 }
 
+// MarshalWithOpcodes 与 Marshal 相同，同时返回编译得到的 opcodes（保留源码位置信息）
+func (n *Engine) MarshalWithOpcodes(code string, key []byte) ([]byte, []*yakvm.Code, error) {
+	cl, err := n._compile(code)
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err := n._marshal(cl.GetRootSymbolTable(), cl.GetOpcodes(), key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b, cl.GetOpcodes(), nil
+}
+
 func (n *Engine) UnMarshal(b []byte, key []byte, code string) (*yakvm.SymbolTable, []*yakvm.Code, error) {
 	var err error
 	hasKey, isCrypto := len(key) > 0, IsCryptoYakc(b)
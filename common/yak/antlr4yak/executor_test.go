@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -18,6 +19,7 @@ import (
 	"github.com/yaklang/yaklang/common/yak/antlr4yak/yakast"
 	"github.com/yaklang/yaklang/common/yak/antlr4yak/yakvm"
 	"github.com/yaklang/yaklang/common/yak/yaklib/codec"
+	"github.com/yaklang/yaklang/common/yak/yakmod"
 
 	"github.com/antlr/antlr4/runtime/Go/antlr/v4"
 	"github.com/davecgh/go-spew/spew"
@@ -387,6 +389,38 @@ a.risk = () => {
 	_formattest(code)
 }
 
+func TestNewExecutor_TypeAnnotation(t *testing.T) {
+	code := `
+var a int = 1
+var b, c string
+var d []string
+var e map[string]int = {"a": 1}
+func sum(n int, args ...int) int {
+	for i in args {
+		n += i
+	}
+	return n
+}
+f = func(m map[string]var, ch chan int) []string {
+	return [string(len(m))]
+}
+assert a == 1 && b == undefined && c == undefined && d == undefined
+assert e.a == 1
+assert sum(1, 2, 3) == 6 && sum(1) == 1
+assert f({}, nil)[0] == "0"
+a = "a"
+assert a == "a"
+`
+	_marshallerTest(code)
+	_formattest(code)
+	code2, _ := _formatCodeTest(code)
+	for _, s := range []string{"var a int = 1", "func sum(n int, args ...int) int {", "func(m map[string]var, ch chan int) []string {"} {
+		if !strings.Contains(code2, s) {
+			t.Fatalf("type annotation %#v is lost in formatted code:\n%s", s, code2)
+		}
+	}
+}
+
 func TestNewExecutor(t *testing.T) {
 	code := `
 a = func{
@@ -1729,6 +1763,83 @@ abc = func(){
 	}
 }
 
+func TestNewExecutor_ImportModule(t *testing.T) {
+	cacheDir := t.TempDir()
+	t.Setenv(yakmod.ModCacheEnv, cacheDir)
+	t.Setenv(yakmod.ModRegistryEnv, "")
+	moduleDir := filepath.Join(cacheDir, "github.com", "org", "lib@v1.0.0")
+	if err := os.MkdirAll(moduleDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{
+		"a.yak":      `libA = func() { return "a" + libB }`,
+		"b.yak":      `libB = "b"`,
+		"b_test.yak": `die("test file should not be imported")`,
+	} {
+		if err := os.WriteFile(filepath.Join(moduleDir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the file of module is only imported once
+	code := `
+import "github.com/org/lib@v1.0.0"
+import "github.com/org/lib@v1.0.0/b.yak"
+assert libA() == "ab"
+`
+	_formattest(code)
+
+	_, err := New().Compile(`import "github.com/org/lib@v2.0.0"`)
+	if err == nil || !strings.Contains(err.Error(), "import module[github.com/org/lib@v2.0.0] failed") {
+		t.Fatalf("expect module not found error, got: %v", err)
+	}
+
+	// import is not a keyword, import(...) is still a function call
+	if _, err := New().Compile(`a = import("a.yak", "b"); import("a.yak")`); err != nil {
+		t.Fatalf("compile import function call failed: %v", err)
+	}
+}
+
+func TestNewExecutor_IncludeRelativeToSource(t *testing.T) {
+	srcDir, workDir := t.TempDir(), t.TempDir()
+	for file, content := range map[string]string{
+		filepath.Join(srcDir, "lib.yak"):          `include "sub/b.yak"; a = "src"`,
+		filepath.Join(srcDir, "sub", "b.yak"):     `include "c.yak"`,
+		filepath.Join(srcDir, "sub", "c.yak"):     `c = "sub"`,
+		filepath.Join(workDir, "lib.yak"):         `a = "cwd"`,
+		filepath.Join(workDir, "only_in_cwd.yak"): `d = "cwd"`,
+	} {
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(workDir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	// 相对路径先从源文件所在目录查找，找不到再从工作目录查找
+	engine := New()
+	engine.SetSourceFilePath(filepath.Join(srcDir, "main.yak"))
+	code := `
+include "lib.yak"
+include "only_in_cwd.yak"
+assert a == "src"
+assert c == "sub"
+assert d == "cwd"
+`
+	if err := engine.SafeEval(context.Background(), code); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestNewExecutor_Eval(t *testing.T) {
 	code := `
 	a = 1
@@ -1,5 +1,7 @@
 package parser
 
+import "github.com/antlr/antlr4/runtime/Go/antlr/v4"
+
 // Returns true if the current Token is a closing bracket (")" or "}")
 func (p *YaklangParser) closingBracket() bool {
 	stream := p.GetTokenStream()
@@ -8,3 +10,27 @@ func (p *YaklangParser) closingBracket() bool {
 	//return prevTokenType == GoParserR_PAREN || prevTokenType == GoParserR_CURLY;
 	return prevTokenType == YaklangParserRParen || prevTokenType == YaklangParserRBrace || prevTokenType == YaklangParserEOF
 }
+
+// Returns true if the current Token is `import`, import is not a keyword, so that `import(...)` is still a function call
+func (p *YaklangParser) isImportStmt() bool {
+	return p.GetTokenStream().LT(1).GetText() == "import"
+}
+
+// ParamTypes 返回每个参数的类型标注，与 AllIdentifier 一一对应，没有标注的参数为 nil，
+// 可变参数 `args ...T` 的类型为元素的类型 T
+func (s *FunctionParamDeclContext) ParamTypes() []ITypeLiteralContext {
+	var types []ITypeLiteralContext
+	for _, child := range s.GetChildren() {
+		switch ret := child.(type) {
+		case antlr.TerminalNode:
+			if ret.GetSymbol().GetTokenType() == YaklangParserIdentifier {
+				types = append(types, nil)
+			}
+		case ITypeLiteralContext:
+			if len(types) > 0 {
+				types[len(types)-1] = ret
+			}
+		}
+	}
+	return types
+}
@@ -40,6 +40,10 @@ func (v *BaseYaklangParserVisitor) VisitIncludeStmt(ctx *IncludeStmtContext) int
 	return v.VisitChildren(ctx)
 }
 
+func (v *BaseYaklangParserVisitor) VisitImportStmt(ctx *ImportStmtContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *BaseYaklangParserVisitor) VisitDeferStmt(ctx *DeferStmtContext) interface{} {
 	return v.VisitChildren(ctx)
 }
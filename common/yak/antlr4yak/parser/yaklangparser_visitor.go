@@ -32,6 +32,9 @@ type YaklangParserVisitor interface {
 	// Visit a parse tree produced by YaklangParser#includeStmt.
 	VisitIncludeStmt(ctx *IncludeStmtContext) interface{}
 
+	// Visit a parse tree produced by YaklangParser#importStmt.
+	VisitImportStmt(ctx *ImportStmtContext) interface{}
+
 	// Visit a parse tree produced by YaklangParser#deferStmt.
 	VisitDeferStmt(ctx *DeferStmtContext) interface{}
 
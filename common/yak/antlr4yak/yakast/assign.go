@@ -306,6 +306,9 @@ func (y *YakCompiler) VisitDeclareAndAssignExpression(raw yak.IDeclareAndAssignE
 	y.VisitExpressionList(i.ExpressionList())
 	buf := recoverFormat()
 	y.VisitLeftExpressionList(true, i.LeftExpressionList())
+	if t := i.TypeLiteral(); t != nil {
+		y.writeString(" " + formatTypeAnnotation(t))
+	}
 
 	y.writeString(" = ")
 	y.writeString(buf)
@@ -345,6 +348,9 @@ func (y *YakCompiler) VisitDeclareVariableOnly(raw yak.IDeclareVariableOnlyConte
 			y.writeString(", ")
 		}
 	}
+	if t := i.TypeLiteral(); t != nil {
+		y.writeString(" " + formatTypeAnnotation(t))
+	}
 	y.pushListWithLen(count)
 	y.pushOperator(yakvm.OpAssign)
 	return nil
@@ -60,7 +60,7 @@ func (y *YakCompiler) VisitBlockWithCallback(raw yak.IBlockContext, callback fun
 	if !inline {
 		y.incIndent()
 		y.writeNewLine()
-	} else {
+	} else if lines > 0 {
 		y.writeString(" ")
 	}
 	recoverSymbolTableAndScope := y.SwitchSymbolTableInNewScope("block", uuid.New().String())
@@ -80,7 +80,7 @@ func (y *YakCompiler) VisitBlockWithCallback(raw yak.IBlockContext, callback fun
 		y.decIndent()
 		y.writeNewLine()
 		y.writeIndent()
-	} else {
+	} else if lines > 0 {
 		y.writeString(" ")
 	}
 	y.writeString("}")
@@ -24,6 +24,8 @@ const (
 	includeUnquoteError                       = "include path[%s] unquote error: %v"
 	includePathNotFoundError                  = "include path[%s] not found"
 	includeCycleError                         = "include cycle not allowed: %s"
+	importUnquoteError                        = "import path[%s] unquote error: %v"
+	importModuleError                         = "import module[%s] failed: %v"
 	readFileError                             = "read file[%s] read error: %v"
 	stringLiteralError                        = "invalid string literal: %s"
 	notImplemented                            = "[%s] not implemented"
@@ -52,6 +54,8 @@ var i18n = map[CompilerLanguage]map[constError]string{
 		includeUnquoteError:        "包含路径[%s] 解析错误: %v",
 		includePathNotFoundError:   "包含路径[%s] 不存在",
 		includeCycleError:          "不允许循环包含文件: %s",
+		importUnquoteError:         "导入路径[%s] 解析错误: %v",
+		importModuleError:          "导入模块[%s] 失败: %v",
 		readFileError:              "读取文件[%s] 错误: %v",
 		stringLiteralError:         "非法的字符串字面量: %s",
 		notImplemented:             "[%s] 未实现",
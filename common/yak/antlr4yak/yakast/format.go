@@ -0,0 +1,545 @@
+package yakast
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/antlr/antlr4/runtime/Go/antlr/v4"
+
+	"github.com/yaklang/yaklang/common/utils"
+	yak "github.com/yaklang/yaklang/common/yak/antlr4yak/parser"
+)
+
+// formatLookahead 对齐格式化前后的 token 时向后查找的最大距离
+const formatLookahead = 8
+
+// commentMoveDistance 格式化时注释可能被移动的最大 token 数量
+const commentMoveDistance = 2
+
+// formatMaxRounds 格式化的最大轮数，参数换行时的对齐依赖代码原来的列号，需要多轮格式化才能稳定
+const formatMaxRounds = 4
+
+// Format 格式化 yak 代码，保留代码中的所有注释，字符串（包括 fuzztag 与多行反引号字符串）保持原样
+// 代码存在语法错误或者格式化结果与原代码不等价时返回错误，格式化结果再次格式化时不会改变
+func Format(code string) (string, error) {
+	for round := 0; round < formatMaxRounds; round++ {
+		compiler := NewYakCompiler(WithFormatOnly())
+		compiler.Compiler(code)
+		if errs := compiler.GetErrors(); len(errs) > 0 {
+			return "", errs
+		}
+		formatted, err := compiler.GetFormattedCodeWithComments()
+		if err != nil {
+			return "", err
+		}
+		if formatted == code {
+			break
+		}
+		code = formatted
+	}
+	return code, nil
+}
+
+// WithFormatOnly 编译只用于格式化代码，include 的文件不会被读取
+func WithFormatOnly() CompilerOptionsFun {
+	return func(y *YakCompiler) {
+		y.formatOnly = true
+	}
+}
+
+// GetFormattedCodeWithComments 返回格式化后的代码，补回格式化时丢失的注释并整理空白行
+func (y *YakCompiler) GetFormattedCodeWithComments() (string, error) {
+	if y.sourceCodePointer == nil {
+		return y.GetFormattedCode(), nil
+	}
+	origin := *y.sourceCodePointer
+	formatted, err := restoreComments(origin, y.GetFormattedCode())
+	if err != nil {
+		return "", err
+	}
+	formatted = normalizeFormattedSpace(formatted)
+	if err := checkFormatted(origin, formatted); err != nil {
+		return "", err
+	}
+	return formatted, nil
+}
+
+type formatToken struct {
+	typ   int
+	text  string
+	start int
+	stop  int
+}
+
+func (t *formatToken) isComment() bool {
+	return t.typ == yak.YaklangLexerCOMMENT || t.typ == yak.YaklangLexerLINE_COMMENT
+}
+
+// isSignificant 对齐时使用的 token，格式化会增删的换行、逗号、分号与注释除外
+func (t *formatToken) isSignificant() bool {
+	switch t.typ {
+	case yak.YaklangLexerLF, yak.YaklangLexerComma, yak.YaklangLexerSemiColon, yak.YaklangLexerEOS:
+		return false
+	}
+	return !t.isComment()
+}
+
+func (t *formatToken) isCloseBracket() bool {
+	switch t.typ {
+	case yak.YaklangLexerRBrace, yak.YaklangLexerRBracket, yak.YaklangLexerRParen:
+		return true
+	}
+	return false
+}
+
+// lexFormatTokens 返回代码的 token，start / stop 为 rune 的下标
+func lexFormatTokens(code string) []*formatToken {
+	lexer := yak.NewYaklangLexer(antlr.NewInputStream(code))
+	lexer.RemoveErrorListeners()
+	var tokens []*formatToken
+	for _, token := range lexer.GetAllTokens() {
+		tokens = append(tokens, &formatToken{
+			typ:   token.GetTokenType(),
+			text:  token.GetText(),
+			start: token.GetStart(),
+			stop:  token.GetStop() + 1,
+		})
+	}
+	return tokens
+}
+
+func significantTokens(tokens []*formatToken) []*formatToken {
+	var ret []*formatToken
+	for _, token := range tokens {
+		if token.isSignificant() {
+			ret = append(ret, token)
+		}
+	}
+	return ret
+}
+
+// alignTokens 返回 a 中每个 token 在 b 中对应的下标，没有对应的为 -1
+func alignTokens(a, b []*formatToken) []int {
+	ret := make([]int, len(a))
+	for i := range ret {
+		ret[i] = -1
+	}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if a[i].text == b[j].text {
+			ret[i] = j
+			i++
+			j++
+			continue
+		}
+		// 向后查找最近的相同 token 重新对齐
+		di, dj, found := 0, 0, false
+		for d := 1; d <= formatLookahead && !found; d++ {
+			for k := 0; k <= d; k++ {
+				if i+k < len(a) && j+d-k < len(b) && a[i+k].text == b[j+d-k].text {
+					di, dj, found = k, d-k, true
+					break
+				}
+			}
+		}
+		if !found {
+			i++
+			j++
+			continue
+		}
+		i += di
+		j += dj
+	}
+	return ret
+}
+
+func normalizeComment(text string) string {
+	return strings.Join(strings.Fields(clearWsComment(text)), " ")
+}
+
+// missingComments 返回格式化结果中缺失的注释
+// 注释所在的位置以之前的对齐 token 表示，只有位置与内容都相同的注释才认为被保留
+func missingComments(origin, formatted []*formatToken, index map[*formatToken]int, aligned []int) map[*formatToken]bool {
+	// gaps 记录格式化结果中每个位置（之前的 token 数量）的注释
+	gaps := make(map[int][]*formatToken)
+	count := 0
+	for _, token := range formatted {
+		if token.isSignificant() {
+			count++
+		} else if token.isComment() {
+			gaps[count] = append(gaps[count], token)
+		}
+	}
+	used := make(map[*formatToken]bool)
+	find := func(comments []*formatToken, text string) bool {
+		for _, comment := range comments {
+			if !used[comment] && normalizeComment(comment.text) == text {
+				used[comment] = true
+				return true
+			}
+		}
+		return false
+	}
+
+	type originComment struct {
+		token *formatToken
+		gap   int
+	}
+	var comments []originComment
+	gap := 0
+	for _, token := range origin {
+		if token.isSignificant() {
+			gap = -1
+			if j := aligned[index[token]]; j >= 0 {
+				gap = j + 1
+			}
+		} else if token.isComment() {
+			comments = append(comments, originComment{token: token, gap: gap})
+		}
+	}
+
+	// 先查找相同位置的注释，再查找附近（格式化时可能移动到相邻的 token 之后）的注释
+	missing := make(map[*formatToken]bool)
+	for _, comment := range comments {
+		if comment.gap < 0 || !find(gaps[comment.gap], normalizeComment(comment.token.text)) {
+			missing[comment.token] = true
+		}
+	}
+	for _, comment := range comments {
+		if !missing[comment.token] {
+			continue
+		}
+		text := normalizeComment(comment.token.text)
+		if comment.gap < 0 {
+			// 之前的 token 没有对齐时按内容查找
+			for i := 0; i <= count && missing[comment.token]; i++ {
+				missing[comment.token] = !find(gaps[i], text)
+			}
+			continue
+		}
+		for d := 1; d <= commentMoveDistance && missing[comment.token]; d++ {
+			missing[comment.token] = !find(gaps[comment.gap+d], text) && !find(gaps[comment.gap-d], text)
+		}
+	}
+	for token, ok := range missing {
+		if !ok {
+			delete(missing, token)
+		}
+	}
+	return missing
+}
+
+type commentInsertion struct {
+	pos  int
+	text string
+}
+
+// restoreComments 把格式化时丢失的注释插入到格式化结果中
+// 与上一个 token 在同一行的注释跟在该 token 之后，单独成行的注释放在下一个 token 之前
+func restoreComments(origin, formatted string) (string, error) {
+	originTokens := lexFormatTokens(origin)
+	formattedTokens := lexFormatTokens(formatted)
+	originSig := significantTokens(originTokens)
+	formattedSig := significantTokens(formattedTokens)
+	index := make(map[*formatToken]int, len(originSig))
+	for i, token := range originSig {
+		index[token] = i
+	}
+	aligned := alignTokens(originSig, formattedSig)
+	missing := missingComments(originTokens, formattedTokens, index, aligned)
+
+	runes := []rune(formatted)
+	var insertions []commentInsertion
+	// prev 为注释之前最近的 token，sameLine 表示注释与 prev 在同一行
+	var (
+		prev     *formatToken
+		sameLine bool
+	)
+	for i, token := range originTokens {
+		switch {
+		case token.typ == yak.YaklangLexerLF:
+			sameLine = false
+			continue
+		case !token.isComment():
+			if token.isSignificant() {
+				prev = token
+			}
+			sameLine = true
+			continue
+		case !missing[token]:
+			continue
+		}
+
+		text := strings.TrimRight(token.text, " \t\r")
+		if sameLine && prev != nil {
+			if j := aligned[index[prev]]; j >= 0 {
+				insertions = append(insertions, trailingComment(runes, formattedTokens, formattedSig[j], token, text))
+				continue
+			}
+		}
+		next := nextSignificant(originTokens[i+1:])
+		if next == nil {
+			insertions = append(insertions, commentInsertion{pos: len(runes), text: "\n" + text})
+			continue
+		}
+		j := aligned[index[next]]
+		if j < 0 {
+			return "", utils.Errorf("cannot keep comment %s at line %d", utils.ShrinkString(text, 32), lineOf([]rune(origin), token.start))
+		}
+		// 注释与之后的代码之间有空行时保留空行
+		lines := 0
+		for _, t := range originTokens[i+1:] {
+			if t.typ != yak.YaklangLexerLF {
+				break
+			}
+			lines++
+		}
+		insertion := leadingComment(runes, formattedSig[j], token, text)
+		if lines > 1 && strings.HasSuffix(insertion.text, "\n") {
+			insertion.text += "\n"
+		}
+		insertions = append(insertions, insertion)
+	}
+	if len(insertions) == 0 {
+		return fixCommentSpace(formatted), nil
+	}
+
+	sort.SliceStable(insertions, func(i, j int) bool {
+		return insertions[i].pos < insertions[j].pos
+	})
+	var buf strings.Builder
+	last := 0
+	for _, insertion := range insertions {
+		buf.WriteString(string(runes[last:insertion.pos]))
+		buf.WriteString(insertion.text)
+		last = insertion.pos
+	}
+	buf.WriteString(string(runes[last:]))
+	return fixCommentSpace(buf.String()), nil
+}
+
+func nextSignificant(tokens []*formatToken) *formatToken {
+	for _, token := range tokens {
+		if token.isSignificant() {
+			return token
+		}
+	}
+	return nil
+}
+
+func lineOf(runes []rune, pos int) int {
+	line := 1
+	for i := 0; i < pos && i < len(runes); i++ {
+		if runes[i] == '\n' {
+			line++
+		}
+	}
+	return line
+}
+
+func lineIndent(runes []rune, pos int) string {
+	start := pos
+	for start > 0 && runes[start-1] != '\n' {
+		start--
+	}
+	end := start
+	for end < len(runes) && (runes[end] == ' ' || runes[end] == '\t') {
+		end++
+	}
+	return string(runes[start:end])
+}
+
+// trailingComment 把注释放在 after 之后（跳过紧跟的逗号与分号），行注释之后还有代码时换行
+func trailingComment(runes []rune, tokens []*formatToken, after, comment *formatToken, text string) commentInsertion {
+	pos := after.stop
+	for _, token := range tokens {
+		if token.start < pos {
+			continue
+		}
+		if token.typ != yak.YaklangLexerComma && token.typ != yak.YaklangLexerSemiColon {
+			break
+		}
+		if strings.TrimSpace(string(runes[pos:token.start])) != "" {
+			break
+		}
+		pos = token.stop
+	}
+
+	rest := pos
+	for rest < len(runes) && (runes[rest] == ' ' || runes[rest] == '\t') {
+		rest++
+	}
+	restOfLine := rest < len(runes) && runes[rest] != '\n'
+	if comment.typ == yak.YaklangLexerCOMMENT && !strings.Contains(text, "\n") {
+		return commentInsertion{pos: pos, text: " " + text}
+	}
+	if !restOfLine {
+		return commentInsertion{pos: pos, text: " " + text}
+	}
+	indent := lineIndent(runes, pos)
+	if runes[rest] != '}' && runes[rest] != ']' && runes[rest] != ')' {
+		indent += "    "
+	}
+	return commentInsertion{pos: rest, text: text + "\n" + indent}
+}
+
+// leadingComment 把注释单独放在 before 所在行之前，before 不在行首时换行
+func leadingComment(runes []rune, before, comment *formatToken, text string) commentInsertion {
+	indent := lineIndent(runes, before.start)
+	lineStart := before.start
+	for lineStart > 0 && (runes[lineStart-1] == ' ' || runes[lineStart-1] == '\t') {
+		lineStart--
+	}
+	atLineStart := lineStart == 0 || runes[lineStart-1] == '\n'
+
+	commentIndent := indent
+	if before.isCloseBracket() {
+		commentIndent += "    "
+	}
+	if atLineStart {
+		return commentInsertion{pos: lineStart, text: commentIndent + text + "\n"}
+	}
+	if comment.typ == yak.YaklangLexerCOMMENT && !strings.Contains(text, "\n") {
+		return commentInsertion{pos: before.start, text: text + " "}
+	}
+	if !before.isCloseBracket() {
+		indent += "    "
+	}
+	return commentInsertion{pos: before.start, text: "\n" + commentIndent + text + "\n" + indent}
+}
+
+// fixCommentSpace 保证代码与同一行之后的注释之间有一个空格
+func fixCommentSpace(code string) string {
+	runes := []rune(code)
+	var buf strings.Builder
+	last := 0
+	for _, token := range lexFormatTokens(code) {
+		if !token.isComment() || token.start == 0 {
+			continue
+		}
+		if c := runes[token.start-1]; c != ' ' && c != '\t' && c != '\n' {
+			buf.WriteString(string(runes[last:token.start]))
+			buf.WriteByte(' ')
+			last = token.start
+		}
+	}
+	buf.WriteString(string(runes[last:]))
+	return buf.String()
+}
+
+// normalizeFormattedSpace 删除行尾空白、连续的空行以及代码块开头结尾的空行，多行字符串与注释中的内容保持原样
+func normalizeFormattedSpace(code string) string {
+	runes := []rune(code)
+	protected := make(map[int]bool)
+	for _, token := range lexFormatTokens(code) {
+		if token.typ == yak.YaklangLexerLF {
+			continue
+		}
+		for i := token.start; i < token.stop && i < len(runes); i++ {
+			if runes[i] == '\n' {
+				protected[i] = true
+			}
+		}
+	}
+
+	type line struct {
+		text string
+		// raw 表示该行在多行字符串或注释中（不包括最后一行）
+		raw, blank bool
+	}
+	var lines []*line
+	start, continued := 0, false
+	for i := 0; i <= len(runes); i++ {
+		if i < len(runes) && runes[i] != '\n' {
+			continue
+		}
+		l := &line{text: string(runes[start:i]), raw: protected[i]}
+		if !l.raw {
+			l.text = strings.TrimRight(l.text, " \t\r")
+		}
+		l.blank = !continued && !l.raw && l.text == ""
+		lines = append(lines, l)
+		start, continued = i+1, protected[i]
+	}
+
+	isOpen := func(l *line) bool {
+		return strings.HasSuffix(l.text, "{") || strings.HasSuffix(l.text, "[") || strings.HasSuffix(l.text, "(")
+	}
+	isClose := func(l *line) bool {
+		text := strings.TrimLeft(l.text, " \t")
+		return strings.HasPrefix(text, "}") || strings.HasPrefix(text, "]") || strings.HasPrefix(text, ")")
+	}
+	var result []string
+	var last *line
+	for i, l := range lines {
+		if l.blank {
+			if last == nil || last.blank || isOpen(last) {
+				continue
+			}
+			var next *line
+			for _, n := range lines[i+1:] {
+				if !n.blank {
+					next = n
+					break
+				}
+			}
+			if next == nil || isClose(next) {
+				continue
+			}
+		}
+		result = append(result, l.text)
+		last = l
+	}
+	return strings.Join(result, "\n")
+}
+
+// checkFormatted 检查格式化结果没有语法错误，并且除换行、逗号、分号外的 token 与原代码相同
+func checkFormatted(origin, formatted string) error {
+	var errs []string
+	listener := NewErrorListener(func(msg string, start, end Position) {
+		errs = append(errs, fmt.Sprintf("line %d: %s", start.LineNumber, msg))
+	})
+	lexer := yak.NewYaklangLexer(antlr.NewInputStream(formatted))
+	lexer.RemoveErrorListeners()
+	lexer.AddErrorListener(listener)
+	parser := yak.NewYaklangParser(antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel))
+	parser.RemoveErrorListeners()
+	parser.AddErrorListener(listener)
+	parser.Program()
+	if len(errs) > 0 {
+		return utils.Errorf("formatted code has syntax error: %s", strings.Join(errs, "; "))
+	}
+
+	a, b := lexFormatTokens(origin), lexFormatTokens(formatted)
+	originComments, formattedComments := commentTexts(a), commentTexts(b)
+	if len(originComments) != len(formattedComments) {
+		return utils.Errorf("formatter changed the comments: %d -> %d", len(originComments), len(formattedComments))
+	}
+	for i := range originComments {
+		if originComments[i] != formattedComments[i] {
+			return utils.Errorf("formatter changed the comment %s", utils.ShrinkString(originComments[i], 32))
+		}
+	}
+
+	a, b = significantTokens(a), significantTokens(b)
+	for i := 0; i < len(a) || i < len(b); i++ {
+		if i >= len(a) || i >= len(b) || a[i].text != b[i].text {
+			if i < len(a) {
+				return utils.Errorf("formatter changed the code at line %d: %s", lineOf([]rune(origin), a[i].start), a[i].text)
+			}
+			return utils.Errorf("formatter added code: %s", b[i].text)
+		}
+	}
+	return nil
+}
+
+func commentTexts(tokens []*formatToken) []string {
+	var ret []string
+	for _, token := range tokens {
+		if token.isComment() {
+			ret = append(ret, normalizeComment(token.text))
+		}
+	}
+	return ret
+}
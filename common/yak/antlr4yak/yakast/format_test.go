@@ -0,0 +1,113 @@
+package yakast
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat_Comments(t *testing.T) {
+	code := `# comment at top
+
+a = 1// trailing
+b = [1, // one
+  2, /* two */ 3]
+m = {
+    "a": 1, // first
+        // before b
+    "b": 2,
+}
+func f(x /* x */, y) {
+// inside
+    return x + y // ret
+}
+if a > 1 { // cond
+    println(a)
+} else {
+    // nothing
+}
+switch {
+// before case
+case a > 1:
+    println(1)
+}
+func g() {
+    return 1 /* r */
+    // end of body
+}
+// end of file`
+	expected := `# comment at top
+
+a = 1 // trailing
+b = [
+    1, // one
+    2, /* two */
+    3,
+]
+m = {
+    "a": 1, // first
+    // before b
+    "b": 2,
+}
+func f(x /* x */, y) {
+    // inside
+    return x + y // ret
+}
+if a > 1 { // cond
+    println(a)
+} else {
+    // nothing
+}
+
+switch {
+// before case
+case a > 1:
+    println(1)
+}
+
+func g() {
+    return 1 /* r */
+
+    // end of body
+}
+// end of file`
+	formatted, err := Format(code)
+	require.NoError(t, err)
+	require.Equal(t, expected, formatted)
+
+	again, err := Format(formatted)
+	require.NoError(t, err)
+	require.Equal(t, formatted, again)
+}
+
+func TestFormat_KeepStrings(t *testing.T) {
+	// fuzztag 字符串与多行反引号字符串（包括其中的行尾空白）保持原样
+	packet := "x`GET /?a={{int(1-3)}} HTTP/1.1\nHost: {{params(host)}}   \n\n`"
+	code := "rsp,req=poc.HTTP(" + packet + ")~\n" +
+		"fuzz=x\"{{base64(a)}}\"\nraw=b\"\\x00\"\ns='c'\ntpl = f`hello\n  ${name}  `\n" +
+		"var ch = make(chan var)\nn = int8(1)"
+	formatted, err := Format(code)
+	require.NoError(t, err)
+	require.Equal(t, "rsp, req = poc.HTTP("+packet+")~\n"+
+		"fuzz = x\"{{base64(a)}}\"\nraw = b\"\\x00\"\ns = 'c'\ntpl = f`hello\n  ${name}  `\n"+
+		"var ch = make(chan var)\nn = int8(1)", formatted)
+}
+
+func TestFormat_Error(t *testing.T) {
+	_, err := Format("a = (1")
+	require.Error(t, err)
+
+	// include 的文件不存在时只格式化，不报错
+	formatted, err := Format(`include   "not-existed.yak"`)
+	require.NoError(t, err)
+	require.Equal(t, `include "not-existed.yak"`, formatted)
+}
+
+func TestFormat_Space(t *testing.T) {
+	code := "a = 1   \n\n\n\nb = 2\nfunc f() {\n\n    a = 1\n\n}\n"
+	formatted, err := Format(code)
+	require.NoError(t, err)
+	require.Equal(t, "a = 1\n\nb = 2\nfunc f() {\n    a = 1\n}", formatted)
+	require.False(t, strings.HasSuffix(formatted, "\n"))
+}
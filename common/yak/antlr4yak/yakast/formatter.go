@@ -29,8 +29,8 @@ func clearWsComment(text string) string {
 	return text
 }
 
-func getIdentifersSurroundComments(tokenStream antlr.TokenStream, startToken, endToken antlr.Token, lenOfIds int) []string {
-	comments := make([]string, lenOfIds)
+func getIdentifersSurroundComments(tokenStream antlr.TokenStream, startToken, endToken antlr.Token, lenOfIds int) [][]string {
+	comments := make([][]string, lenOfIds)
 	start, stop := startToken.GetTokenIndex(), endToken.GetTokenIndex()
 	for index, idIndex := start, 0; index <= stop && idIndex < lenOfIds; index++ {
 		token := tokenStream.Get(index)
@@ -40,18 +40,55 @@ func getIdentifersSurroundComments(tokenStream antlr.TokenStream, startToken, en
 			idIndex++
 		} else if tokenType == parser.YaklangParserCOMMENT || tokenType == parser.YaklangParserLINE_COMMENT {
 			text := clearWsComment(token.GetText())
-			if text == "" {
+			// 行注释会被改写为块注释，包含 */ 的注释无法改写
+			if text == "" || strings.Contains(text, "*/") {
 				continue
 			}
-			comments[idIndex] += text + "; "
+			comments[idIndex] = append(comments[idIndex], text)
 		}
 	}
+	return comments
+}
 
-	for index := range comments {
-		comments[index] = strings.TrimRight(comments[index], "; ")
+// hasLineBreak 判断列表或字典的元素之间是否有换行，list 为元素列表的 context（ExpressionListMultiline / MapPairs）
+func hasLineBreak(ws []parser.IWsContext, list antlr.Tree) bool {
+	switch ret := list.(type) {
+	case *parser.ExpressionListMultilineContext:
+		ws = append(ws, ret.AllWs()...)
+	case *parser.MapPairsContext:
+		ws = append(ws, ret.AllWs()...)
+	}
+	for _, w := range ws {
+		if w != nil && strings.Contains(w.GetText(), "\n") {
+			return true
+		}
 	}
+	return false
+}
 
-	return comments
+// writeElements 格式化列表与字典的元素，multiline 时每个元素一行并保留末尾的逗号
+func (y *YakCompiler) writeElements(multiline bool, n int, visit func(index int)) {
+	multiline = multiline && n > 0
+	if multiline {
+		y.incIndent()
+	}
+	for index := 0; index < n; index++ {
+		if multiline {
+			y.writeNewLine()
+			y.writeIndent()
+		}
+		visit(index)
+		if multiline {
+			y.writeString(",")
+		} else if index != n-1 {
+			y.writeString(", ")
+		}
+	}
+	if multiline {
+		y.decIndent()
+		y.writeNewLine()
+		y.writeIndent()
+	}
 }
 
 func (y *YakCompiler) switchIsOMap(isOmap bool) func() {
@@ -127,8 +164,8 @@ func (y *YakCompiler) writeEOS(raw yak.IEosContext) {
 
 func (y *YakCompiler) writeEosWithText(s string) {
 	trimedLeftStr := strings.TrimLeft(s, "\r\n")
-	if strings.HasPrefix(trimedLeftStr, "//") || strings.HasPrefix(trimedLeftStr, "/*") {
-		y.writeString(s)
+	if strings.HasPrefix(trimedLeftStr, "//") || strings.HasPrefix(trimedLeftStr, "#") || strings.HasPrefix(trimedLeftStr, "/*") {
+		y.writeComments(s)
 		return
 	}
 	for j := 0; j < y.TrimEos(s); j++ {
@@ -136,6 +173,38 @@ func (y *YakCompiler) writeEosWithText(s string) {
 	}
 }
 
+// writeComments 写入语句之间的注释与换行，换行之后的注释保持当前的缩进
+func (y *YakCompiler) writeComments(s string) {
+	lineStart := false
+	for len(s) > 0 {
+		end := 1
+		switch {
+		case strings.HasPrefix(s, "//") || strings.HasPrefix(s, "#"):
+			if end = strings.IndexByte(s, '\n'); end < 0 {
+				end = len(s)
+			}
+		case strings.HasPrefix(s, "/*"):
+			if end = strings.Index(s, "*/") + 2; end < 2 {
+				end = len(s)
+			}
+		case s[0] == '\n':
+			y.writeNewLine()
+			lineStart = true
+			s = s[1:]
+			continue
+		case s[0] == '\r' || s[0] == ';':
+			s = s[1:]
+			continue
+		}
+		if lineStart {
+			y.writeIndent()
+			lineStart = false
+		}
+		y.writeString(s[:end])
+		s = s[end:]
+	}
+}
+
 func (y *YakCompiler) writeIndent() {
 	y.writeWhiteSpace(4 * y.indent)
 }
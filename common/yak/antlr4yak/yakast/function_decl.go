@@ -97,6 +97,9 @@ func (y *YakCompiler) VisitAnonymousFunctionDecl(raw yak.IAnonymousFunctionDeclC
 		y.writeString("(")
 		paramsSymbol, isVariable = y.VisitFunctionParamDecl(i.FunctionParamDecl())
 		y.writeString(") ")
+		if returnType := i.TypeLiteral(); returnType != nil {
+			y.writeString(formatTypeAnnotation(returnType) + " ")
+		}
 		// visit代码块
 		y.VisitBlock(i.Block(), true)
 		y.pushOperator(yakvm.OpReturn)
@@ -180,6 +183,7 @@ func (y *YakCompiler) VisitFunctionParamDecl(raw yak.IFunctionParamDeclContext)
 
 	hadIncIndent := false
 	comments := getIdentifersSurroundComments(i.GetParser().GetTokenStream(), i.GetStart(), i.GetStop(), lenOfIds)
+	types := i.ParamTypes()
 
 	for index, id := range ids {
 		idText := id.GetText()
@@ -209,16 +213,25 @@ func (y *YakCompiler) VisitFunctionParamDecl(raw yak.IFunctionParamDeclContext)
 
 		y.writeString(idText)
 
-		if comments[index] != "" {
-			y.writeString(fmt.Sprintf(" /* %s */", comments[index]))
+		for _, comment := range comments[index] {
+			y.writeString(fmt.Sprintf(" /* %s */", comment))
 		}
 
 		// 如果是最后一个参数且有...，就要加...
-		if index == lenOfIds-1 {
-			if ellipsis != nil {
+		typeText := ""
+		if index < len(types) && types[index] != nil {
+			typeText = formatTypeAnnotation(types[index])
+		}
+		if index == lenOfIds-1 && ellipsis != nil {
+			if typeText != "" {
+				y.writeString(" ..." + typeText)
+			} else {
 				y.writeString("...")
 			}
+		} else if typeText != "" {
+			y.writeString(" " + typeText)
 		}
+		lineLength += len(typeText)
 		// 如果不是最后一个参数或者每个参数一行就要加,
 		if index != lenOfIds-1 || eachParamOneLine {
 			y.writeString(", ")
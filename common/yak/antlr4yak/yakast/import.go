@@ -0,0 +1,89 @@
+package yakast
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/antlr/antlr4/runtime/Go/antlr/v4"
+
+	"github.com/yaklang/yaklang/common/utils"
+	yak "github.com/yaklang/yaklang/common/yak/antlr4yak/parser"
+	"github.com/yaklang/yaklang/common/yak/yakmod"
+)
+
+// VisitImportStmt 编译 import "github.com/org/lib@v1.2.0" 语句，模块由 yak.mod / vendor / 缓存目录 / 本地仓库解析，
+// 模块中的文件只会被编译一次
+func (y *YakCompiler) VisitImportStmt(raw yak.IImportStmtContext) interface{} {
+	if y == nil || raw == nil {
+		return nil
+	}
+
+	i, _ := raw.(*yak.ImportStmtContext)
+	if i == nil {
+		return nil
+	}
+	recoverRange := y.SetRange(i.BaseParserRuleContext)
+	defer recoverRange()
+	y.writeString("import ")
+
+	mpath := i.StringLiteral().GetText()
+	mpath = strings.ReplaceAll(mpath, "\\", "\\\\")
+	mpath, err := strconv.Unquote(mpath)
+	if err != nil {
+		y.panicCompilerError(importUnquoteError, mpath, err)
+	}
+	y.writeString(`"` + mpath + `"`)
+	if y.formatOnly {
+		return nil
+	}
+
+	// yak.mod 从当前编译文件所在目录向上查找
+	dir := "."
+	if y.sourceCodeFilePathPointer != nil && *y.sourceCodeFilePathPointer != "" {
+		dir = filepath.Dir(*y.sourceCodeFilePathPointer)
+	}
+	resolver, err := yakmod.NewResolver(dir)
+	if err != nil {
+		y.panicCompilerError(importModuleError, mpath, err)
+	}
+	files, err := resolver.Resolve(mpath)
+	if err != nil {
+		y.panicCompilerError(importModuleError, mpath, err)
+	}
+	for _, file := range files {
+		y.importFile(file)
+	}
+	return nil
+}
+
+func (y *YakCompiler) importFile(file string) {
+	code, err := ioutil.ReadFile(file)
+	if err != nil {
+		y.panicCompilerError(readFileError, file, err)
+	}
+	codeStr := string(code)
+	fileHash := utils.CalcSha1(codeStr)
+	if _, ok := y.importCycleHash[fileHash]; ok {
+		// 已经导入过的文件
+		return
+	}
+	y.importCycleHash[fileHash] = struct{}{}
+
+	// parse
+	inputStream := antlr.NewInputStream(codeStr)
+	lex := yak.NewYaklangLexer(inputStream)
+	tokenStream := antlr.NewCommonTokenStream(lex, antlr.TokenDefaultChannel)
+	p := yak.NewYaklangParser(tokenStream)
+
+	// compile, 忽略formatter
+	recoverFormatBufferFunc := y.switchFormatBuffer()
+	recoverSource := y.switchSource(&file, &codeStr)
+	defer func() {
+		recoverFormatBufferFunc()
+		recoverSource()
+	}()
+
+	y.VisitProgramWithoutSymbolTable(p.Program().(*yak.ProgramContext))
+}
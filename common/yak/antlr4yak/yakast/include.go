@@ -2,6 +2,7 @@ package yakast
 
 import (
 	"io/ioutil"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -31,13 +32,18 @@ func (y *YakCompiler) VisitIncludeStmt(raw yak.IIncludeStmtContext) interface{}
 	if err != nil {
 		y.panicCompilerError(includeUnquoteError, fpath, err)
 	}
-	if _, err := utils.GetFirstExistedFileE(fpath); err != nil {
+	if y.formatOnly {
+		y.writeString(`"` + fpath + `"`)
+		return nil
+	}
+	file, err := y.includeFilePath(fpath)
+	if err != nil {
 		y.panicCompilerError(includePathNotFoundError, fpath)
 	}
 
-	code, err := ioutil.ReadFile(fpath)
+	code, err := ioutil.ReadFile(file)
 	if err != nil {
-		y.panicCompilerError(readFileError, fpath, err)
+		y.panicCompilerError(readFileError, file, err)
 	}
 	codeStr := string(code)
 	fileHash := utils.CalcSha1(codeStr)
@@ -57,7 +63,7 @@ func (y *YakCompiler) VisitIncludeStmt(raw yak.IIncludeStmtContext) interface{}
 
 	// compile, 忽略formatter
 	recoverFormatBufferFunc := y.switchFormatBuffer()
-	recoverSource := y.switchSource(&fpath, &codeStr)
+	recoverSource := y.switchSource(&file, &codeStr)
 	defer func() {
 		recoverFormatBufferFunc()
 		recoverSource()
@@ -67,3 +73,13 @@ func (y *YakCompiler) VisitIncludeStmt(raw yak.IIncludeStmtContext) interface{}
 
 	return nil
 }
+
+// includeFilePath 查找被包含的文件，相对路径先从当前源文件所在目录查找，再从工作目录查找
+func (y *YakCompiler) includeFilePath(fpath string) (string, error) {
+	var paths []string
+	if !filepath.IsAbs(fpath) && y.sourceCodeFilePathPointer != nil && *y.sourceCodeFilePathPointer != "" {
+		paths = append(paths, filepath.Join(filepath.Dir(*y.sourceCodeFilePathPointer), fpath))
+	}
+	paths = append(paths, fpath)
+	return utils.GetFirstExistedFileE(paths...)
+}
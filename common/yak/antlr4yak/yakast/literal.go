@@ -168,7 +168,7 @@ func (y *YakCompiler) VisitSliceLiteral(raw yak.ISliceLiteralContext) interface{
 	// [ ... ] 语法
 	if i.LBracket() != nil && i.RBracket() != nil {
 		y.writeString("[")
-		unary := y.VisitExpressionListMultiline(i.ExpressionListMultiline())
+		unary := y.VisitExpressionListMultiline(i.ExpressionListMultiline(), hasLineBreak(i.AllWs(), i.ExpressionListMultiline()))
 		y.writeString("]")
 		y.pushNewSlice(unary)
 		return nil
@@ -193,7 +193,7 @@ func (y *YakCompiler) VisitSliceTypedLiteral(raw yak.ISliceTypedLiteralContext)
 	// 先创建一个类型
 	y.VisitSliceTypeLiteral(i.SliceTypeLiteral())
 	y.writeString("{")
-	y.pushTypedSlice(y.VisitExpressionListMultiline(i.ExpressionListMultiline()))
+	y.pushTypedSlice(y.VisitExpressionListMultiline(i.ExpressionListMultiline(), hasLineBreak(i.AllWs(), i.ExpressionListMultiline())))
 	y.writeString("}")
 	return nil
 }
@@ -213,7 +213,8 @@ func (y *YakCompiler) VisitSliceTypeLiteral(raw yak.ISliceTypeLiteralContext) in
 	return nil
 }
 
-func (y *YakCompiler) VisitExpressionListMultiline(raw yak.IExpressionListMultilineContext) int {
+// VisitExpressionListMultiline multiline 为 true 时格式化为每个元素一行
+func (y *YakCompiler) VisitExpressionListMultiline(raw yak.IExpressionListMultilineContext, multiline ...bool) int {
 	if y == nil || raw == nil {
 		return 0
 	}
@@ -226,14 +227,10 @@ func (y *YakCompiler) VisitExpressionListMultiline(raw yak.IExpressionListMultil
 	defer recoverRange()
 
 	allExpression := i.AllExpression()
-	lenOfAllExpression := len(allExpression)
-	for index, e := range allExpression {
-		y.VisitExpression(e)
-		if index != lenOfAllExpression-1 {
-			y.writeString(", ")
-		}
-	}
-	return lenOfAllExpression
+	y.writeElements(len(multiline) > 0 && multiline[0], len(allExpression), func(index int) {
+		y.VisitExpression(allExpression[index])
+	})
+	return len(allExpression)
 }
 
 func (y *YakCompiler) VisitMapLiteral(raw yak.IMapLiteralContext) interface{} {
@@ -264,14 +261,11 @@ func (y *YakCompiler) VisitMapLiteral(raw yak.IMapLiteralContext) interface{} {
 
 	allPair := pairs.(*yak.MapPairsContext).AllMapPair()
 	lenOfAllPair := len(allPair)
-	for index, p := range allPair {
-		y.VisitExpression(p.(*yak.MapPairContext).Expression(0))
+	y.writeElements(hasLineBreak(i.AllWs(), pairs), lenOfAllPair, func(index int) {
+		y.VisitExpression(allPair[index].(*yak.MapPairContext).Expression(0))
 		y.writeString(": ")
-		y.VisitExpression(p.(*yak.MapPairContext).Expression(1))
-		if index < lenOfAllPair-1 {
-			y.writeString(", ")
-		}
-	}
+		y.VisitExpression(allPair[index].(*yak.MapPairContext).Expression(1))
+	})
 
 	y.pushNewMap(lenOfAllPair, y.isOMap)
 
@@ -304,14 +298,11 @@ func (y *YakCompiler) VisitMapTypedLiteral(raw yak.IMapTypedLiteralContext) inte
 
 	allPair := pairs.(*yak.MapPairsContext).AllMapPair()
 	lenOfAllPair := len(allPair)
-	for index, p := range allPair {
-		y.VisitExpression(p.(*yak.MapPairContext).Expression(0))
+	y.writeElements(hasLineBreak(i.AllWs(), pairs), lenOfAllPair, func(index int) {
+		y.VisitExpression(allPair[index].(*yak.MapPairContext).Expression(0))
 		y.writeString(": ")
-		y.VisitExpression(p.(*yak.MapPairContext).Expression(1))
-		if index < lenOfAllPair-1 {
-			y.writeString(", ")
-		}
-	}
+		y.VisitExpression(allPair[index].(*yak.MapPairContext).Expression(1))
+	})
 
 	y.pushTypedMap(lenOfAllPair)
 	return nil
@@ -88,7 +88,7 @@ func (y *YakCompiler) VisitSwitchStmt(raw yak.ISwitchStmtContext) interface{} {
 	} else {
 		//y.pushUndefined()
 		switchExprIsEmpty = true
-		y.writeString(" {")
+		y.writeString("{")
 	}
 
 	y.writeNewLine()
@@ -84,6 +84,11 @@ func (y *YakCompiler) VisitStatement(i *yak.StatementContext) (newLine bool) {
 		return true
 	}
 
+	if s := i.ImportStmt(); s != nil {
+		y.VisitImportStmt(s)
+		return true
+	}
+
 	//if s := i.FunctionDeclareStmt(); s != nil {
 	//	y.VisitFunctionDeclareStmt(s)
 	//	return nil
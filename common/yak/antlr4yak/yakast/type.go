@@ -25,19 +25,19 @@ func (y *YakCompiler) VisitTypeLiteral(raw yak.ITypeLiteralContext) interface{}
 		y.writeString(text)
 		y.pushType(text)
 	case "var", "any":
-		y.writeString("any")
+		y.writeString(text)
 		y.pushType("any")
 	case "byte", "uint8":
-		y.writeString("byte")
+		y.writeString(text)
 		y.pushType("byte")
 	case "int", "uint", "uint16", "uint32", "uint64", "int8", "int16", "int32", "int64":
-		y.writeString("int")
+		y.writeString(text)
 		y.pushType("int")
 	case "double", "float", "float32", "float64":
-		y.writeString("float")
+		y.writeString(text)
 		y.pushType("float")
 	case "omap":
-		y.writeString("omap")
+		y.writeString(text)
 		y.pushType("omap")
 	default:
 		if slice := i.SliceTypeLiteral(); slice != nil {
@@ -61,3 +61,21 @@ func (y *YakCompiler) VisitTypeLiteral(raw yak.ITypeLiteralContext) interface{}
 
 	return nil
 }
+
+// formatTypeAnnotation 返回类型标注格式化后的文本，类型标注只用于静态检查，不生成任何指令
+func formatTypeAnnotation(raw yak.ITypeLiteralContext) string {
+	i, _ := raw.(*yak.TypeLiteralContext)
+	if i == nil {
+		return ""
+	}
+	if slice, _ := i.SliceTypeLiteral().(*yak.SliceTypeLiteralContext); slice != nil {
+		return "[]" + formatTypeAnnotation(slice.TypeLiteral())
+	}
+	if mapType, _ := i.MapTypeLiteral().(*yak.MapTypeLiteralContext); mapType != nil {
+		return "map[" + formatTypeAnnotation(mapType.TypeLiteral(0)) + "]" + formatTypeAnnotation(mapType.TypeLiteral(1))
+	}
+	if i.TypeLiteral() != nil {
+		return "chan " + formatTypeAnnotation(i.TypeLiteral())
+	}
+	return i.GetText()
+}
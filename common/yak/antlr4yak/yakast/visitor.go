@@ -34,6 +34,7 @@ type YakCompiler struct {
 	// 格式化
 	formatted                                    *bytes.Buffer
 	indent                                       int
+	formatOnly                                   bool
 	sourceCodeFilePathPointer, sourceCodePointer *string
 	codes                                        []*yakvm.Code
 	FreeValues                                   []int
@@ -0,0 +1,220 @@
+package yakvm
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// CoverageRange 是 opcode 对应的源码范围，行号从 1 开始，列号从 0 开始
+type CoverageRange struct {
+	StartLine   int `json:"start_line"`
+	StartColumn int `json:"start_column"`
+	EndLine     int `json:"end_line"`
+	EndColumn   int `json:"end_column"`
+}
+
+// CoverageBlock 是同一源码范围内 opcode 的命中情况，Count 为其中执行次数最多的 opcode 的次数
+type CoverageBlock struct {
+	CoverageRange
+	Opcodes int    `json:"opcodes"`
+	Count   uint64 `json:"count"`
+}
+
+type CoverageLine struct {
+	Line  int    `json:"line"`
+	Count uint64 `json:"count"`
+}
+
+type FileCoverage struct {
+	File   string           `json:"file"`
+	Source string           `json:"-"`
+	Blocks []*CoverageBlock `json:"blocks"`
+}
+
+// Lines 返回有 opcode 的行的命中次数，行的命中次数为从该行开始的代码块的最大命中次数
+func (f *FileCoverage) Lines() []*CoverageLine {
+	lines := make(map[int]uint64)
+	for _, block := range f.Blocks {
+		if count, ok := lines[block.StartLine]; !ok || block.Count > count {
+			lines[block.StartLine] = block.Count
+		}
+	}
+	ret := make([]*CoverageLine, 0, len(lines))
+	for line, count := range lines {
+		ret = append(ret, &CoverageLine{Line: line, Count: count})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Line < ret[j].Line
+	})
+	return ret
+}
+
+// Percent 返回行覆盖率，范围为 0-100
+func (f *FileCoverage) Percent() float64 {
+	lines := f.Lines()
+	if len(lines) == 0 {
+		return 0
+	}
+	covered := 0
+	for _, line := range lines {
+		if line.Count > 0 {
+			covered++
+		}
+	}
+	return float64(covered) * 100 / float64(len(lines))
+}
+
+type coverageKey struct {
+	file string
+	CoverageRange
+	// 同一源码范围内的第几个 opcode，同一份代码多次编译（例如在不同引擎中执行）时 key 相同，计数会合并
+	ordinal int
+}
+
+type coverageFile struct {
+	source   string
+	counters map[coverageKey]*uint64
+}
+
+// Coverage 记录 yakvm 执行 opcode 的次数，按照源码文件与范围统计，可以被多个虚拟机共享
+type Coverage struct {
+	mu    sync.Mutex
+	files map[string]*coverageFile
+	// map[*Code]*uint64，没有源码位置的 opcode 计数器为 nil
+	codes sync.Map
+}
+
+func NewCoverage() *Coverage {
+	return &Coverage{files: make(map[string]*coverageFile)}
+}
+
+// Register 注册 opcode（包括函数体与 defer 中的 opcode），没有执行过的 opcode 计数为 0
+func (c *Coverage) Register(codes []*Code) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.register(codes, make(map[coverageKey]int))
+}
+
+func (c *Coverage) register(codes []*Code, ordinals map[coverageKey]int) {
+	for _, code := range codes {
+		if code == nil {
+			continue
+		}
+		if _, ok := c.codes.Load(code); ok {
+			continue
+		}
+		var counter *uint64
+		if code.SourceCodeFilePath != nil && code.StartLineNumber > 0 {
+			file := *code.SourceCodeFilePath
+			f, ok := c.files[file]
+			if !ok {
+				f = &coverageFile{counters: make(map[coverageKey]*uint64)}
+				if code.SourceCodePointer != nil {
+					f.source = *code.SourceCodePointer
+				}
+				c.files[file] = f
+			}
+			key := coverageKey{
+				file: file,
+				CoverageRange: CoverageRange{
+					StartLine:   code.StartLineNumber,
+					StartColumn: code.StartColumnNumber,
+					EndLine:     code.EndLineNumber,
+					EndColumn:   code.EndColumnNumber,
+				},
+			}
+			ordinal := ordinals[key]
+			ordinals[key]++
+			key.ordinal = ordinal
+			counter, ok = f.counters[key]
+			if !ok {
+				counter = new(uint64)
+				f.counters[key] = counter
+			}
+		}
+		c.codes.Store(code, counter)
+
+		for _, op := range []*Value{code.Op1, code.Op2} {
+			if op == nil {
+				continue
+			}
+			switch ret := op.Value.(type) {
+			case *Function:
+				c.register(ret.codes, ordinals)
+			case []*Code:
+				c.register(ret, ordinals)
+			}
+		}
+	}
+}
+
+func (c *Coverage) hit(code *Code) {
+	if counter, ok := c.codes.Load(code); ok && counter.(*uint64) != nil {
+		atomic.AddUint64(counter.(*uint64), 1)
+	}
+}
+
+// Files 返回已注册的源码文件
+func (c *Coverage) Files() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	files := make([]string, 0, len(c.files))
+	for file := range c.files {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// File 返回文件当前的覆盖情况快照
+func (c *Coverage) File(file string) (*FileCoverage, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	f, ok := c.files[file]
+	if !ok {
+		return nil, false
+	}
+	blocks := make(map[CoverageRange]*CoverageBlock)
+	for key, counter := range f.counters {
+		block, ok := blocks[key.CoverageRange]
+		if !ok {
+			block = &CoverageBlock{CoverageRange: key.CoverageRange}
+			blocks[key.CoverageRange] = block
+		}
+		block.Opcodes++
+		if count := atomic.LoadUint64(counter); count > block.Count {
+			block.Count = count
+		}
+	}
+	ret := &FileCoverage{File: file, Source: f.source, Blocks: make([]*CoverageBlock, 0, len(blocks))}
+	for _, block := range blocks {
+		ret.Blocks = append(ret.Blocks, block)
+	}
+	sort.Slice(ret.Blocks, func(i, j int) bool {
+		a, b := ret.Blocks[i], ret.Blocks[j]
+		if a.StartLine != b.StartLine {
+			return a.StartLine < b.StartLine
+		}
+		if a.StartColumn != b.StartColumn {
+			return a.StartColumn < b.StartColumn
+		}
+		if a.EndLine != b.EndLine {
+			return a.EndLine < b.EndLine
+		}
+		return a.EndColumn < b.EndColumn
+	})
+	return ret, true
+}
+
+// SetCoverage 开启覆盖率统计，nil 表示关闭
+func (v *VirtualMachine) SetCoverage(c *Coverage) {
+	v.coverage = c
+}
+
+func (v *VirtualMachine) GetCoverage() *Coverage {
+	return v.coverage
+}
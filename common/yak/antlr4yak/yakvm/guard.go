@@ -0,0 +1,19 @@
+package yakvm
+
+// Guard 限制虚拟机的执行（例如沙箱策略的 opcode 数量、内存与时间限制）
+type Guard interface {
+	// Enter 与 Leave 在从外部进入虚拟机执行（主代码、表达式或外部调用的 yak 函数）时成对调用，
+	// 执行中嵌套的函数调用不会触发，并发的执行会重叠调用
+	Enter()
+	Leave()
+	// Check 在执行每个 opcode 之前调用，返回的错误会作为 panic 终止执行
+	Check(frame *Frame, code *Code) error
+}
+
+func (v *VirtualMachine) SetGuard(g Guard) {
+	v.guard = g
+}
+
+func (v *VirtualMachine) GetGuard() Guard {
+	return v.guard
+}
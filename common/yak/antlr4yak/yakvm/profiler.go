@@ -0,0 +1,221 @@
+package yakvm
+
+import (
+	"runtime/metrics"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultProfileInterval 是默认的采样间隔
+const DefaultProfileInterval = 10 * time.Millisecond
+
+// ProfileLocation 是 yak 调用栈中的一帧：函数名与正在执行的源码位置
+type ProfileLocation struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// ProfileSample 是同一调用栈的采样合计，Stack 从叶子函数开始
+type ProfileSample struct {
+	Stack []ProfileLocation `json:"stack"`
+	Count int64             `json:"count"`
+	// CPU 为采样次数乘以采样间隔，单位纳秒
+	CPU int64 `json:"cpu"`
+	// AllocBytes / AllocObjects 为两次采样之间进程的堆分配量，归属于采样时的调用栈，是近似值
+	AllocBytes   int64 `json:"alloc_bytes"`
+	AllocObjects int64 `json:"alloc_objects"`
+}
+
+// Profiler 是 yak 函数级别的 CPU 与内存分配采样器：定时器每个间隔设置一次采样标记，
+// 虚拟机执行 opcode 时如果发现标记，就记录当前 yak 调用栈，因此只统计执行 yak 代码（包括其中的 go 函数调用）的时间，
+// 可以被多个虚拟机共享
+type Profiler struct {
+	interval time.Duration
+	pending  atomic.Bool
+
+	mu      sync.Mutex
+	samples map[string]*ProfileSample
+	metrics []metrics.Sample
+	start   time.Time
+	elapsed time.Duration
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewProfiler(interval time.Duration) *Profiler {
+	if interval <= 0 {
+		interval = DefaultProfileInterval
+	}
+	return &Profiler{
+		interval: interval,
+		samples:  make(map[string]*ProfileSample),
+		metrics: []metrics.Sample{
+			{Name: "/gc/heap/allocs:bytes"},
+			{Name: "/gc/heap/allocs:objects"},
+		},
+	}
+}
+
+// Start 开始采样，重复调用无效
+func (p *Profiler) Start() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stop != nil {
+		return
+	}
+	metrics.Read(p.metrics)
+	p.start = time.Now()
+	p.stop, p.done = make(chan struct{}), make(chan struct{})
+	go func(stop, done chan struct{}) {
+		defer close(done)
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				p.pending.Store(true)
+			}
+		}
+	}(p.stop, p.done)
+}
+
+// Stop 停止采样，已经记录的样本保留，可以再次 Start
+func (p *Profiler) Stop() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	stop, done := p.stop, p.done
+	if stop != nil {
+		p.elapsed += time.Since(p.start)
+		p.stop, p.done = nil, nil
+	}
+	p.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+	p.pending.Store(false)
+}
+
+// Interval 返回采样间隔
+func (p *Profiler) Interval() time.Duration {
+	return p.interval
+}
+
+// Duration 返回采样的总时长
+func (p *Profiler) Duration() time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stop != nil {
+		return p.elapsed + time.Since(p.start)
+	}
+	return p.elapsed
+}
+
+// Samples 返回按照采样次数降序排列的样本
+func (p *Profiler) Samples() []*ProfileSample {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ret := make([]*ProfileSample, 0, len(p.samples))
+	for _, s := range p.samples {
+		copied := *s
+		copied.Stack = append([]ProfileLocation(nil), s.Stack...)
+		ret = append(ret, &copied)
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		if ret[i].Count != ret[j].Count {
+			return ret[i].Count > ret[j].Count
+		}
+		return profileStackKey(ret[i].Stack) < profileStackKey(ret[j].Stack)
+	})
+	return ret
+}
+
+// sample 在执行 opcode 前调用，只有定时器设置了采样标记时才记录调用栈
+func (p *Profiler) sample(frame *Frame, code *Code) {
+	if !p.pending.Load() || !p.pending.CompareAndSwap(true, false) {
+		return
+	}
+	stack := frameProfileStack(frame, code)
+	key := profileStackKey(stack)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	bytes, objects := p.metrics[0].Value.Uint64(), p.metrics[1].Value.Uint64()
+	metrics.Read(p.metrics)
+	s, ok := p.samples[key]
+	if !ok {
+		s = &ProfileSample{Stack: stack}
+		p.samples[key] = s
+	}
+	s.Count++
+	s.CPU += int64(p.interval)
+	s.AllocBytes += int64(p.metrics[0].Value.Uint64() - bytes)
+	s.AllocObjects += int64(p.metrics[1].Value.Uint64() - objects)
+}
+
+// frameProfileStack 从当前帧向上收集 yak 调用栈，父帧的位置为其正在执行的 opcode（即调用处）
+func frameProfileStack(frame *Frame, code *Code) []ProfileLocation {
+	var stack []ProfileLocation
+	for f := frame; f != nil; f = f.parent {
+		c := code
+		if f != frame {
+			if f.codePointer < 0 || f.codePointer >= len(f.codes) {
+				continue
+			}
+			c = f.codes[f.codePointer]
+		}
+		loc := ProfileLocation{Function: frameProfileName(f)}
+		if c != nil {
+			loc.Line = c.StartLineNumber
+			if c.SourceCodeFilePath != nil {
+				loc.File = *c.SourceCodeFilePath
+			}
+		}
+		stack = append(stack, loc)
+	}
+	return stack
+}
+
+func frameProfileName(f *Frame) string {
+	if f.function != nil {
+		return f.function.GetActualName()
+	}
+	if f.frameVerbose != "" {
+		return f.frameVerbose
+	}
+	return "<unknown>"
+}
+
+func profileStackKey(stack []ProfileLocation) string {
+	var b strings.Builder
+	for _, loc := range stack {
+		b.WriteString(loc.Function)
+		b.WriteByte(0)
+		b.WriteString(loc.File)
+		b.WriteByte(0)
+		b.WriteString(strconv.Itoa(loc.Line))
+		b.WriteByte(0)
+	}
+	return b.String()
+}
+
+func (v *VirtualMachine) SetProfiler(p *Profiler) {
+	v.profiler = p
+}
+
+func (v *VirtualMachine) GetProfiler() *Profiler {
+	return v.profiler
+}
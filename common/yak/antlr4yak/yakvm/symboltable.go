@@ -151,6 +151,18 @@ func (s *SymbolTable) GetSymbolByVariableName(name string) (int, bool) {
 	return s.parent.GetSymbolByVariableName(name)
 }
 
+// GetLocalSymbolNames 返回当前符号表（不包括父符号表）中定义的所有变量名
+func (s *SymbolTable) GetLocalSymbolNames() []string {
+	if s == nil {
+		return nil
+	}
+	names := make([]string, 0, len(s.symbolToId))
+	for name := range s.symbolToId {
+		names = append(names, name)
+	}
+	return names
+}
+
 func (s *SymbolTable) GetLocalSymbolByVariableName(name string) (int, bool) {
 	if s == nil {
 		return 0, false
@@ -56,6 +56,13 @@ type (
 
 		// sandbox
 		sandboxMode bool
+
+		// coverage
+		coverage *Coverage
+		// profiler
+		profiler *Profiler
+		// guard
+		guard Guard
 	}
 )
 
@@ -270,6 +277,7 @@ func (v *VirtualMachine) ExecAsyncYakFunction(ctx context.Context, f *Function,
 }
 
 func (v *VirtualMachine) ExecYakCode(ctx context.Context, sourceCode string, codes []*Code, flags ...ExecFlag) error {
+	v.coverage.Register(codes)
 	return v.Exec(ctx, func(frame *Frame) {
 		frame.SetVerbose("__yak_main__")
 		frame.SetOriginCode(sourceCode)
@@ -278,6 +286,7 @@ func (v *VirtualMachine) ExecYakCode(ctx context.Context, sourceCode string, cod
 }
 
 func (v *VirtualMachine) InlineExecYakCode(ctx context.Context, codes []*Code, flags ...ExecFlag) error {
+	v.coverage.Register(codes)
 	return v.Exec(ctx, func(frame *Frame) {
 		frame.Exec(codes)
 	}, Trace|Sub)
@@ -332,6 +341,11 @@ func (v *VirtualMachine) Exec(ctx context.Context, f func(frame *Frame), flags .
 		}
 	}
 
+	if flag&Sub != Sub && v.guard != nil {
+		v.guard.Enter()
+		defer v.guard.Leave()
+	}
+
 	if flag&Asnyc == Asnyc {
 		frame.coroutine = NewCoroutine()
 	}
@@ -301,6 +301,17 @@ func (v *Frame) execCode(c *Code, debug bool) {
 		v.codePointer = len(v.codes)
 		return
 	default:
+		if v.vm.coverage != nil {
+			v.vm.coverage.hit(c)
+		}
+		if v.vm.profiler != nil {
+			v.vm.profiler.sample(v, c)
+		}
+		if v.vm.guard != nil {
+			if err := v.vm.guard.Check(v, c); err != nil {
+				panic(err)
+			}
+		}
 		v._execCode(c, debug)
 	}
 }
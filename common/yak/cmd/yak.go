@@ -40,9 +40,14 @@ import (
 	"github.com/yaklang/yaklang/common/utils/umask"
 	"github.com/yaklang/yaklang/common/yak"
 	"github.com/yaklang/yaklang/common/yak/antlr4nasl"
+	"github.com/yaklang/yaklang/common/yak/antlr4yak/yakvm"
 	debugger "github.com/yaklang/yaklang/common/yak/interactive_debugger"
+	"github.com/yaklang/yaklang/common/yak/yakbundle"
+	"github.com/yaklang/yaklang/common/yak/yakcover"
 	"github.com/yaklang/yaklang/common/yak/yaklib"
 	"github.com/yaklang/yaklang/common/yak/yaklib/codec"
+	"github.com/yaklang/yaklang/common/yak/yakpolicy"
+	"github.com/yaklang/yaklang/common/yak/yakprof"
 	"github.com/yaklang/yaklang/common/yakgrpc"
 	"github.com/yaklang/yaklang/common/yakgrpc/yakit"
 	"github.com/yaklang/yaklang/common/yakgrpc/ypb"
@@ -748,6 +753,7 @@ func main() {
 	app.Commands = append(app.Commands, cliGroup("AI", yakcmds.AICommands...)...)
 	app.Commands = append(app.Commands, cliGroup("Yak Ysoserial Util", yakcmds.YsoCommands...)...)
 	app.Commands = append(app.Commands, cliGroup("Git Utils", yakcmds.GitCommands...)...)
+	app.Commands = append(app.Commands, cliGroup("Yak Language Tools", yakcmds.LanguageToolCommands...)...)
 
 	app.Flags = []cli.Flag{
 		cli.StringFlag{
@@ -774,6 +780,35 @@ func main() {
 			Name:  "cdebug",
 			Usage: "(Not Worked on Yakc) Enter Cli Debug Mode",
 		},
+		cli.StringFlag{
+			Name:  "coverprofile",
+			Usage: "Write the coverage profile of the executed yak code to file",
+		},
+		cli.StringFlag{
+			Name:  "coverformat",
+			Usage: "Coverage profile format: go / lcov / html, guessed by the extension of profile by default",
+		},
+		cli.StringFlag{
+			Name:  "profile",
+			Usage: "Write Yak-level CPU / allocation profile of script to file",
+		},
+		cli.StringFlag{
+			Name:  "profile-format",
+			Usage: "Profile format: pprof / folded / svg (flame graph), guessed by the extension of profile by default",
+		},
+		cli.DurationFlag{
+			Name:  "profile-interval",
+			Usage: "Profile sampling interval",
+			Value: yakvm.DefaultProfileInterval,
+		},
+		cli.StringFlag{
+			Name:  "sandbox-policy",
+			Usage: "Sandbox policy for executing yak code: unrestricted / restricted / isolated or policy in JSON",
+		},
+		cli.StringSliceFlag{
+			Name:  "trusted-key",
+			Usage: "PEM encoded ed25519 public key trusted to sign yak bundles (.ybc), unsigned bundles are refused if set",
+		},
 		cli.StringFlag{
 			Name:   "netx-proxy",
 			Usage:  "Force Set Network Proxy for yak.netx",
@@ -850,6 +885,40 @@ func main() {
 				if err != nil {
 					return err
 				}
+				if profile := c.String("coverprofile"); profile != "" {
+					cov := yakvm.NewCoverage()
+					engine.SetCoverage(cov)
+					defer func() {
+						if err := yakcover.NewProfile(cov, nil).Save(profile, c.String("coverformat")); err != nil {
+							log.Errorf("write coverage profile failed: %v", err)
+						}
+					}()
+				}
+				if profile := c.String("profile"); profile != "" {
+					profiler := yakvm.NewProfiler(c.Duration("profile-interval"))
+					engine.SetProfiler(profiler)
+					profiler.Start()
+					defer func() {
+						profiler.Stop()
+						if err := yakprof.NewProfile(profiler).Save(profile, c.String("profile-format")); err != nil {
+							log.Errorf("write profile failed: %v", err)
+						}
+					}()
+				}
+				if policy := c.String("sandbox-policy"); policy != "" {
+					p, err := yakpolicy.Resolve(policy)
+					if err != nil {
+						return err
+					}
+					engine.SetPolicy(p)
+				}
+				if files := c.StringSlice("trusted-key"); len(files) > 0 {
+					keys, err := yakbundle.LoadPublicKeyFiles(files...)
+					if err != nil {
+						return err
+					}
+					engine.SetBundleTrustedKeys(keys...)
+				}
 				err = engine.ExecuteMain(string(raw), absFile)
 				if err != nil {
 					return err
@@ -0,0 +1,161 @@
+package yakcmds
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/yaklang/yaklang/common/log"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/yak/yakbundle"
+)
+
+var buildCommand = cli.Command{
+	Name:      "build",
+	Usage:     "Build yak script into versioned plugin bundle (.ybc), optionally signed with ed25519 key",
+	ArgsUsage: "<script.yak>",
+	Description: `The bundle contains opcodes, source map, required libraries and min engine version, it is
+validated before execution (yak plugin.ybc). If trusted public keys are set (--trusted-key or
+` + yakbundle.TrustedKeysEnv + `), only the bundles signed by them can be executed.
+
+    yak build keygen -o team              // team.key (private) / team.pub (public)
+    yak build -o plugin.ybc --sign-key team.key script.yak
+    yak build verify --trusted-key team.pub plugin.ybc`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "output,o",
+			Usage: "output bundle path, default is the script path with .ybc extension",
+		},
+		cli.StringFlag{
+			Name:  "sign-key",
+			Usage: "PEM encoded ed25519 private key to sign the bundle",
+		},
+		cli.StringFlag{
+			Name:  "min-version",
+			Usage: "min engine version to run the bundle, default is current engine version",
+		},
+		cli.StringFlag{
+			Name:  "name",
+			Usage: "bundle name, default is the script file name",
+		},
+		cli.BoolFlag{
+			Name:  "embed-source",
+			Usage: "embed source code into source map",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if c.NArg() < 1 {
+			return utils.Error("no source file")
+		}
+		file := c.Args().First()
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		opts := []yakbundle.BuildOption{
+			yakbundle.WithName(c.String("name")),
+			yakbundle.WithMinEngineVersion(c.String("min-version")),
+			yakbundle.WithEmbedSource(c.Bool("embed-source")),
+		}
+		if keyFile := c.String("sign-key"); keyFile != "" {
+			key, err := yakbundle.LoadPrivateKeyFile(keyFile)
+			if err != nil {
+				return err
+			}
+			opts = append(opts, yakbundle.WithSigningKey(key))
+		}
+		b, err := yakbundle.Build(string(raw), file, opts...)
+		if err != nil {
+			return err
+		}
+
+		output := c.String("output")
+		if output == "" {
+			output = strings.TrimSuffix(file, filepath.Ext(file)) + yakbundle.FileExt
+		}
+		if err := os.WriteFile(output, b.Bytes(), 0o644); err != nil {
+			return err
+		}
+		log.Infof("build %v -> %v (libraries: %v, min engine version: %v, signed: %v)",
+			file, output, b.Manifest.Libraries, b.Manifest.MinEngineVersion, b.Signer() != "")
+		return nil
+	},
+	Subcommands: []cli.Command{
+		{
+			Name:  "keygen",
+			Usage: "generate ed25519 key pair for signing bundles",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "output,o",
+					Usage: "key file prefix, write <prefix>.key and <prefix>.pub",
+					Value: "yak-bundle",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				prefix := c.String("output")
+				priv, pub, err := yakbundle.GenerateKey()
+				if err != nil {
+					return err
+				}
+				for _, path := range []string{prefix + ".key", prefix + ".pub"} {
+					if utils.IsFile(path) {
+						return utils.Errorf("%v already exists", path)
+					}
+				}
+				if err := os.WriteFile(prefix+".key", priv, 0o600); err != nil {
+					return err
+				}
+				if err := os.WriteFile(prefix+".pub", pub, 0o644); err != nil {
+					return err
+				}
+				log.Infof("generate %v.key and %v.pub", prefix, prefix)
+				return nil
+			},
+		},
+		{
+			Name:      "verify",
+			Usage:     "validate the bundle and show its manifest",
+			ArgsUsage: "<plugin.ybc>",
+			Flags: []cli.Flag{
+				cli.StringSliceFlag{
+					Name:  "trusted-key",
+					Usage: "PEM encoded ed25519 public key trusted to sign bundles",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				if c.NArg() < 1 {
+					return utils.Error("no bundle file")
+				}
+				raw, err := os.ReadFile(c.Args().First())
+				if err != nil {
+					return err
+				}
+				var opts []yakbundle.LoadOption
+				if files := c.StringSlice("trusted-key"); len(files) > 0 {
+					keys, err := yakbundle.LoadPublicKeyFiles(files...)
+					if err != nil {
+						return err
+					}
+					opts = append(opts, yakbundle.WithTrustedKeys(keys...))
+				}
+				b, err := yakbundle.Load(raw, opts...)
+				if err != nil {
+					return err
+				}
+				manifest := *b.Manifest
+				manifest.SourceMap = nil
+				out, err := json.MarshalIndent(manifest, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(out))
+				return nil
+			},
+		},
+	},
+}
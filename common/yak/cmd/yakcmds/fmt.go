@@ -0,0 +1,148 @@
+package yakcmds
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/urfave/cli"
+
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/yak/antlr4yak/yakast"
+	"github.com/yaklang/yaklang/common/yak/yakmod"
+)
+
+var fmtCommand = cli.Command{
+	Name:      "fmt",
+	Usage:     "Format yak scripts, comments and strings (fuzztag / multiline) are kept as they are",
+	ArgsUsage: "[file or dir ...]",
+	Description: `The files are rewritten in place, the *.yak files in dirs are formatted recursively (hidden
+dirs and vendor are skipped). Without arguments, the code is read from stdin and written to stdout.
+
+    yak fmt .                  // format all scripts in current dir
+    yak fmt -l plugins         // list the scripts need to be formatted
+    yak fmt --check .          // exit with non-zero code if any script is not formatted (for CI)`,
+	Flags: []cli.Flag{
+		cli.BoolFlag{Name: "version,v", Usage: "show formatter version"},
+		cli.BoolFlag{
+			Name:  "l",
+			Usage: "list the files whose formatting differs from yak fmt, do not rewrite them",
+		},
+		cli.BoolFlag{
+			Name:  "check",
+			Usage: "do not rewrite the files, print the files need to be formatted and fail if any",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if c.Bool("version") {
+			fmt.Printf("Formatter version: %v\n", yakast.FormatterVersion)
+			return nil
+		}
+		if c.NArg() == 0 {
+			raw, err := io.ReadAll(os.Stdin)
+			if err != nil {
+				return err
+			}
+			formatted, err := yakast.Format(string(raw))
+			if err != nil {
+				return err
+			}
+			fmt.Println(formatted)
+			return nil
+		}
+
+		files, err := discoverYakFiles(c.Args())
+		if err != nil {
+			return err
+		}
+		write := !c.Bool("l") && !c.Bool("check")
+		var unformatted, failed []string
+		for _, file := range files {
+			changed, err := formatFile(file, write)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", file, err)
+				failed = append(failed, file)
+				continue
+			}
+			if changed {
+				fmt.Println(file)
+				unformatted = append(unformatted, file)
+			}
+		}
+		if len(failed) > 0 {
+			return utils.Errorf("%d file(s) cannot be formatted", len(failed))
+		}
+		if c.Bool("check") && len(unformatted) > 0 {
+			return utils.Errorf("%d file(s) are not formatted, run yak fmt to format them", len(unformatted))
+		}
+		return nil
+	},
+}
+
+// formatFile 格式化文件，返回格式化结果是否与原文件不同，write 为 true 时写回文件
+func formatFile(file string, write bool) (bool, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return false, err
+	}
+	formatted, err := yakast.Format(string(raw))
+	if err != nil {
+		return false, err
+	}
+	formatted += "\n"
+	if formatted == string(raw) {
+		return false, nil
+	}
+	if write {
+		info, err := os.Stat(file)
+		if err != nil {
+			return false, err
+		}
+		if err := os.WriteFile(file, []byte(formatted), info.Mode().Perm()); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// discoverYakFiles 返回参数中的文件与目录中所有的 .yak 文件
+func discoverYakFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		path = strings.TrimSuffix(path, "/...")
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if p != path && (d.Name() == yakmod.VendorDir || strings.HasPrefix(d.Name(), ".")) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if strings.HasSuffix(d.Name(), ".yak") {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	files = lo.Uniq(files)
+	sort.Strings(files)
+	return files, nil
+}
@@ -0,0 +1,11 @@
+package yakcmds
+
+import "github.com/urfave/cli"
+
+var LanguageToolCommands = []*cli.Command{
+	&lspCommand,
+	&testCommand,
+	&modCommand,
+	&buildCommand,
+	&replCommand,
+}
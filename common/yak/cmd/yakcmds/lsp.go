@@ -0,0 +1,35 @@
+package yakcmds
+
+import (
+	"context"
+	"os"
+
+	"github.com/urfave/cli"
+	"github.com/yaklang/yaklang/common/log"
+	"github.com/yaklang/yaklang/common/yak/yaklsp"
+)
+
+var lspCommand = cli.Command{
+	Name:  "lsp",
+	Usage: "Start Yaklang Language Server (LSP over stdio) for VSCode / Neovim ...",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "type,t",
+			Usage: "script type of documents: yak / mitm / port-scan / codec ...",
+			Value: "yak",
+		},
+		cli.StringFlag{
+			Name:  "log-level",
+			Usage: "log level, the log is written to stderr",
+			Value: "warning",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		// stdout is used by protocol, so log to stderr
+		log.SetOutput(os.Stderr)
+		if level, err := log.ParseLevel(c.String("log-level")); err == nil {
+			log.SetLevel(level)
+		}
+		return yaklsp.NewServer(c.String("type")).Serve(context.Background(), os.Stdin, os.Stdout)
+	},
+}
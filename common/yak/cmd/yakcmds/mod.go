@@ -0,0 +1,64 @@
+package yakcmds
+
+import (
+	"os"
+
+	"github.com/urfave/cli"
+	"github.com/yaklang/yaklang/common/log"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/yak/yakmod"
+)
+
+var modCommand = cli.Command{
+	Name:  "mod",
+	Usage: "Yak module maintenance: init / tidy / vendor",
+	Description: `Yak module is a directory with yak.mod, other yak code can import it by module path:
+
+    import "github.com/org/lib@v1.2.0"             // all yak files in module root
+    import "github.com/org/lib/utils.yak"          // version required in yak.mod
+
+The module is resolved from replace directory, vendor, cache (` + yakmod.ModCacheEnv + `) and
+local registry directories (` + yakmod.ModRegistryEnv + `) in order.`,
+	Subcommands: []cli.Command{
+		{
+			Name:      "init",
+			Usage:     "create yak.mod in current directory",
+			ArgsUsage: "<module path>",
+			Action: func(c *cli.Context) error {
+				if c.NArg() < 1 {
+					return utils.Error("module path is required, e.g. yak mod init github.com/org/lib")
+				}
+				mod, err := yakmod.InitFile(".", c.Args().First())
+				if err != nil {
+					return err
+				}
+				log.Infof("create %v", mod.Path)
+				return nil
+			},
+		},
+		{
+			Name:  "tidy",
+			Usage: "add missing and remove unused requirements in yak.mod, download modules from registries into cache",
+			Action: func(c *cli.Context) error {
+				mod, err := yakmod.Tidy(".")
+				if err != nil {
+					return err
+				}
+				raw, err := mod.Format()
+				if err != nil {
+					return err
+				}
+				os.Stdout.Write(raw)
+				return nil
+			},
+		},
+		{
+			Name:  "vendor",
+			Usage: "copy the required modules into vendor directory",
+			Action: func(c *cli.Context) error {
+				_, err := yakmod.Vendor(".")
+				return err
+			},
+		},
+	},
+}
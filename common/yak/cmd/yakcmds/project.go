@@ -1,12 +1,17 @@
 package yakcmds
 
 import (
+	"bufio"
+	"context"
+	"os"
+	"strings"
+
 	"github.com/urfave/cli"
 	"github.com/yaklang/yaklang/common/consts"
 	"github.com/yaklang/yaklang/common/log"
+	"github.com/yaklang/yaklang/common/schema"
 	"github.com/yaklang/yaklang/common/utils"
 	"github.com/yaklang/yaklang/common/yakgrpc/yakit"
-	"strings"
 )
 
 var ProjectCommands = []*cli.Command{
@@ -38,4 +43,43 @@ var ProjectCommands = []*cli.Command{
 			cli.StringFlag{Name: "output"},
 			cli.StringFlag{Name: "type"},
 		}},
+	{
+		Name:      "import-burp",
+		Usage:     "Import HTTP History from Burp Suite XML (Save items) to Project Database",
+		ArgsUsage: "[burp.xml]",
+		Flags: []cli.Flag{
+			cli.StringFlag{Name: "file,f", Usage: "burp xml exported by \"Save items\""},
+		},
+		Action: func(c *cli.Context) error {
+			file := c.String("file")
+			if file == "" {
+				file = c.Args().First()
+			}
+			if file == "" {
+				return utils.Error("burp xml file is empty, use --file to specify")
+			}
+			fp, err := os.Open(file)
+			if err != nil {
+				return utils.Errorf("open burp xml failed: %s", err)
+			}
+			defer fp.Close()
+
+			db := consts.GetGormProjectDatabase()
+			if db == nil {
+				return utils.Error("empty project database")
+			}
+			skipped := 0
+			count, err := yakit.ImportHTTPFlowsFromBurp(context.Background(), db, bufio.NewReader(fp), func(flow *schema.HTTPFlow, err error) {
+				if err != nil {
+					skipped++
+					log.Warnf("skip burp item: %v", err)
+				}
+			})
+			if err != nil {
+				return err
+			}
+			log.Infof("imported %d httpflows from %s, %d items skipped", count, file, skipped)
+			return nil
+		},
+	},
 }
@@ -0,0 +1,45 @@
+package yakcmds
+
+import (
+	"context"
+
+	"github.com/urfave/cli"
+
+	"github.com/yaklang/yaklang/common/yak/yakrepl"
+)
+
+var replCommand = cli.Command{
+	Name:  "repl",
+	Usage: "Start interactive Yak shell with history, completion and inspection",
+	Description: `The variables and functions are kept between inputs, unclosed brackets or strings continue
+on the next line, Tab completes libraries / functions / members, the history is saved in
+` + "`" + `~/yakit-projects/yak-repl-history` + "`" + `.
+
+    :doc poc.Get       // show document of library or function
+    :type expr         // show type of expression
+    :vars              // show defined variables`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "history",
+			Usage: "history file path, default is yak-repl-history in yakit-projects",
+		},
+		cli.BoolFlag{
+			Name:  "no-history",
+			Usage: "do not save history",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		historyFile := yakrepl.DefaultHistoryFile()
+		if c.String("history") != "" {
+			historyFile = c.String("history")
+		}
+		if c.Bool("no-history") {
+			historyFile = ""
+		}
+		repl, err := yakrepl.New(yakrepl.WithHistoryFile(historyFile))
+		if err != nil {
+			return err
+		}
+		return repl.Run(context.Background())
+	},
+}
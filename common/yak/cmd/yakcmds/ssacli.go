@@ -2,17 +2,25 @@ package yakcmds
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/go-git/go-git/v5"
+	"github.com/samber/lo"
 	"github.com/segmentio/ksuid"
 	"github.com/urfave/cli"
 	"github.com/yaklang/yaklang/common/consts"
 	"github.com/yaklang/yaklang/common/log"
+	"github.com/yaklang/yaklang/common/schema"
 	"github.com/yaklang/yaklang/common/syntaxflow/sfvm"
 	"github.com/yaklang/yaklang/common/utils"
 	"github.com/yaklang/yaklang/common/utils/filesys"
+	"github.com/yaklang/yaklang/common/utils/yakgit"
 	"github.com/yaklang/yaklang/common/yak/ssa"
 	"github.com/yaklang/yaklang/common/yak/ssa/ssadb"
 	"github.com/yaklang/yaklang/common/yak/ssaapi"
+	"github.com/yaklang/yaklang/common/yakgrpc/yakit"
+	"github.com/yaklang/yaklang/common/yakgrpc/ypb"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -89,6 +97,9 @@ var SSACompilerCommands = []*cli.Command{
 			cli.BoolFlag{
 				Name: "re-compile", Usage: "re-compile existed database program",
 			},
+			cli.BoolFlag{
+				Name: "incremental,inc", Usage: "only re-compile changed file of existed database program, keep the unchanged",
+			},
 			cli.BoolFlag{
 				Name: "dot", Usage: "dot graph text for result",
 			},
@@ -119,6 +130,7 @@ var SSACompilerCommands = []*cli.Command{
 			sfDebug := c.Bool("syntaxflow-debug")
 			showDot := c.Bool("dot")
 			withCode := c.Bool("with-code")
+			incremental := c.Bool("incremental")
 			// TODO: re-compile
 			// re-compile := c.Bool("re-compile")
 
@@ -158,7 +170,10 @@ var SSACompilerCommands = []*cli.Command{
 				opt = append(opt, ssaapi.WithDatabaseProgramName(programName))
 			}
 
-			if !noOverride {
+			if incremental && !inMemory {
+				log.Infof("incremental compile, only changed file of program %v will be re-compiled", programName)
+				opt = append(opt, ssaapi.WithIncrementalCompile())
+			} else if !noOverride {
 				ssadb.DeleteProgram(ssadb.GetDB(), programName)
 			} else {
 				log.Warnf("no-override flag is set, will not delete existed program: %v", programName)
@@ -214,6 +229,11 @@ var SSACompilerCommands = []*cli.Command{
 			buf.WriteString("//     DocumentBuilderFactory.newInstance()...parse(* #-> * as $source) as $sink; // find some call chain for parse\n")
 			buf.WriteString("//     check $sink then 'find sink point' else 'No Found' // if not found sink, the rule will stop here and report error\n")
 			buf.WriteString("//     alert $source // record $source\n\n\n")
+			buf.WriteString("// embed test samples in desc, and run them by `yak syntaxflow-test`, like:\n")
+			buf.WriteString("//     lang: java, expect: '$sink',\n")
+			buf.WriteString("//     'positive://Vuln.java': \"...code should match $sink...\",\n")
+			buf.WriteString("//     'negative://Safe.java': \"...code should not match $sink...\",\n")
+			buf.WriteString("//     'expect://Vuln.java': \"$sink, !$filtered\", // expectation of one sample, `!$var` should not be matched\n\n")
 			buf.WriteString("// the template is generate by yak.ssa.syntaxflow command line\n")
 
 			filename := c.String("output")
@@ -237,6 +257,353 @@ var SSACompilerCommands = []*cli.Command{
 			return os.WriteFile(filename, buf.Bytes(), 0o666)
 		},
 	},
+	{
+		Name:    "syntaxflow-test",
+		Aliases: []string{"sf-test", "test-sf"},
+		Usage:   "run the test samples embedded in desc of SyntaxFlow rule file or directory",
+		Flags: []cli.Flag{
+			cli.StringFlag{Name: "log", Usage: "log level"},
+			cli.BoolFlag{
+				Name:  "syntaxflow-debug,sfdebug",
+				Usage: "enable syntax flow debug mode",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			if ret, err := log.ParseLevel(c.String("log")); err == nil {
+				log.SetLevel(ret)
+			}
+			var opt []sfvm.Option
+			if c.Bool("syntaxflow-debug") {
+				opt = append(opt, sfvm.WithEnableDebug())
+			}
+
+			var files []string
+			for _, name := range c.Args() {
+				if utils.GetFirstExistedFile(name) != "" {
+					files = append(files, name)
+					continue
+				}
+				err := filesys.Recursive(name, filesys.WithRecursiveDirectory(true), filesys.WithFileStat(func(s string, info fs.FileInfo) error {
+					if strings.ToLower(filepath.Ext(s)) == ".sf" {
+						files = append(files, s)
+					}
+					return nil
+				}))
+				if err != nil {
+					log.Warnf("cannot find rule as %v: %v", name, err)
+				}
+			}
+			if len(files) == 0 {
+				return utils.Error("no SyntaxFlow rule file found")
+			}
+
+			var passed, failed, skipped int
+			for _, filename := range files {
+				raw, err := os.ReadFile(filename)
+				if err != nil {
+					return utils.Wrapf(err, "read %v failed", filename)
+				}
+				_, results, err := ssaapi.RunSyntaxFlowRuleTest(string(raw), opt...)
+				if errors.Is(err, sfvm.RuleTestNotFoundError) {
+					skipped++
+					fmt.Printf("[SKIP] %v: %v\n", filename, err)
+					continue
+				}
+				if err != nil {
+					failed++
+					fmt.Printf("[FAIL] %v: %v\n", filename, err)
+					continue
+				}
+				ruleFailed := false
+				for _, result := range results {
+					if !result.Pass {
+						ruleFailed = true
+						fmt.Printf("[FAIL] %v %v: %v\n", filename, result.Sample, result.Reason)
+					}
+				}
+				if ruleFailed {
+					failed++
+				} else {
+					passed++
+					fmt.Printf("[PASS] %v (%v samples)\n", filename, len(results))
+				}
+			}
+
+			fmt.Printf("\nrules: %v, passed: %v, failed: %v, skipped: %v\n", len(files), passed, failed, skipped)
+			if failed > 0 {
+				return utils.Errorf("%v SyntaxFlow rule(s) failed", failed)
+			}
+			return nil
+		},
+	},
+	{
+		Name:    "syntaxflow-import",
+		Aliases: []string{"sf-import"},
+		Usage:   "import SyntaxFlow rule files(*.sf) in directory to rule library",
+		Flags: []cli.Flag{
+			cli.StringFlag{Name: "log", Usage: "log level"},
+		},
+		Action: func(c *cli.Context) error {
+			if ret, err := log.ParseLevel(c.String("log")); err == nil {
+				log.SetLevel(ret)
+			}
+			var errs []string
+			for _, dir := range c.Args() {
+				count, err := yakit.ImportSyntaxFlowRuleFromDir(consts.GetGormProfileDatabase(), dir)
+				log.Infof("import %v SyntaxFlow rule(s) from %v", count, dir)
+				if err != nil {
+					errs = append(errs, err.Error())
+				}
+			}
+			if len(errs) > 0 {
+				return utils.Errorf("import SyntaxFlow rule failed: \n%v", strings.Join(errs, "\n"))
+			}
+			return nil
+		},
+	},
+	{
+		Name:    "syntaxflow-export",
+		Aliases: []string{"sf-export"},
+		Usage:   "export SyntaxFlow rules in rule library to directory, as <output>/<language>/<rule>.sf",
+		Flags: []cli.Flag{
+			cli.StringFlag{Name: "output,o", Usage: "output directory"},
+			cli.StringFlag{Name: "language,l", Usage: "only export the rules of language"},
+			cli.StringFlag{Name: "group,g", Usage: "only export the rules in group, split by comma"},
+		},
+		Action: func(c *cli.Context) error {
+			output := c.String("output")
+			if output == "" {
+				return utils.Error("output directory is required")
+			}
+			filter := &ypb.SyntaxFlowRuleFilter{
+				Language: utils.PrettifyListFromStringSplitEx(c.String("language"), ","),
+				Groups:   utils.PrettifyListFromStringSplitEx(c.String("group"), ","),
+			}
+			count, err := yakit.ExportSyntaxFlowRuleToDir(consts.GetGormProfileDatabase(), filter, output)
+			if err != nil {
+				return err
+			}
+			log.Infof("export %v SyntaxFlow rule(s) to %v", count, output)
+			return nil
+		},
+	},
+	{
+		Name:  "ssa-scan",
+		Usage: "Use all enabled SyntaxFlow rules in rule library scan SSA program in database, the findings are saved as risks",
+		Flags: []cli.Flag{
+			cli.StringFlag{Name: "log", Usage: "log level"},
+			cli.StringFlag{
+				Name:  "program,p",
+				Usage: `program name in database`,
+			},
+			cli.StringFlag{
+				Name:  "database,db",
+				Usage: "database path",
+			},
+			cli.StringFlag{
+				Name:  "language,l",
+				Usage: "the language of program, guess by the source files if not set",
+			},
+			cli.StringFlag{
+				Name:  "group,g",
+				Usage: "only use the rules in group, split by comma",
+			},
+			cli.BoolFlag{
+				Name:  "syntaxflow-debug,sfdebug",
+				Usage: "enable syntax flow debug mode",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			if ret, err := log.ParseLevel(c.String("log")); err == nil {
+				log.SetLevel(ret)
+			}
+			programName := c.String("program")
+			if programName == "" {
+				return utils.Error("program name is required")
+			}
+			databaseFileRaw := c.String("database")
+			if databaseFileRaw != "" && utils.GetFirstExistedFile(databaseFileRaw) == "" {
+				return utils.Errorf("database file not found: %v", databaseFileRaw)
+			}
+			consts.SetSSADataBaseName(databaseFileRaw)
+
+			var opt []sfvm.Option
+			if c.Bool("syntaxflow-debug") {
+				opt = append(opt, sfvm.WithEnableDebug())
+			}
+			risks, err := SyntaxFlowScan(programName, c.String("language"), utils.PrettifyListFromStringSplitEx(c.String("group"), ","), opt...)
+			if err != nil {
+				return err
+			}
+			for _, risk := range risks {
+				if err := yakit.SaveRisk(risk); err != nil {
+					log.Errorf("save risk %v failed: %v", risk.Title, err)
+					continue
+				}
+				fmt.Printf("[%v] %v: %v\n", risk.Severity, risk.Title, risk.Url)
+			}
+			log.Infof("ssa-scan finished, program: %v, risks: %v", programName, len(risks))
+			return nil
+		},
+	},
+	{
+		Name:  "ssa-callgraph",
+		Usage: "Export the call graph of SSA program in database, or check the call path from entry to sink",
+		Flags: []cli.Flag{
+			cli.StringFlag{Name: "log", Usage: "log level"},
+			cli.StringFlag{
+				Name:  "program,p",
+				Usage: `program name in database`,
+			},
+			cli.StringFlag{
+				Name:  "database,db",
+				Usage: "database path",
+			},
+			cli.StringFlag{
+				Name:  "format,f",
+				Usage: "export format: dot, graphml or json",
+				Value: "dot",
+			},
+			cli.StringFlag{
+				Name:  "output,o",
+				Usage: "export file, print to stdout if not set",
+			},
+			cli.StringFlag{
+				Name:  "entry",
+				Usage: "the entry function name (or method name) for reachability query",
+			},
+			cli.StringFlag{
+				Name:  "sink",
+				Usage: "the sink function name for reachability query, such as `os.System`",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			if ret, err := log.ParseLevel(c.String("log")); err == nil {
+				log.SetLevel(ret)
+			}
+			programName := c.String("program")
+			if programName == "" {
+				return utils.Error("program name is required")
+			}
+			databaseFileRaw := c.String("database")
+			if databaseFileRaw != "" && utils.GetFirstExistedFile(databaseFileRaw) == "" {
+				return utils.Errorf("database file not found: %v", databaseFileRaw)
+			}
+			consts.SetSSADataBaseName(databaseFileRaw)
+
+			prog, err := ssaapi.FromDatabase(programName)
+			if err != nil {
+				return utils.Wrapf(err, "load program [%v] from database failed", programName)
+			}
+			graph := prog.GetCallGraph()
+
+			entry, sink := c.String("entry"), c.String("sink")
+			if entry != "" || sink != "" {
+				if entry == "" || sink == "" {
+					return utils.Error("both entry and sink are required for reachability query")
+				}
+				path := graph.FindPathByName(entry, sink)
+				if path == nil {
+					fmt.Printf("%v cannot reach %v\n", entry, sink)
+					return nil
+				}
+				names := lo.Map(path, func(node *ssaapi.CallGraphNode, _ int) string { return node.Name })
+				fmt.Printf("%v can reach %v: %v\n", entry, sink, strings.Join(names, " -> "))
+				return nil
+			}
+
+			result, err := graph.Export(ssaapi.CallGraphFormat(strings.ToLower(c.String("format"))))
+			if err != nil {
+				return err
+			}
+			if output := c.String("output"); output != "" {
+				return os.WriteFile(output, []byte(result), 0o644)
+			}
+			fmt.Println(result)
+			return nil
+		},
+	},
+	{
+		Name:  "ssa-diff-scan",
+		Usage: "Compile base and target revision of git repository, report the SyntaxFlow findings only introduced by target",
+		Flags: []cli.Flag{
+			cli.StringFlag{Name: "log", Usage: "log level"},
+			cli.StringFlag{Name: "repository,repo,r", Usage: `git repository path, default PWD`, Value: "."},
+			cli.StringFlag{Name: "base", Usage: `base revision (hash/tag/branch), such as the target branch of merge request`},
+			cli.StringFlag{Name: "target", Usage: `target revision (hash/tag/branch)`, Value: "HEAD"},
+			cli.StringFlag{
+				Name:  "language,l",
+				Usage: "the language of repository, guess by the source files if not set",
+			},
+			cli.StringFlag{
+				Name:  "group,g",
+				Usage: "only use the rules in group, split by comma",
+			},
+			cli.BoolFlag{
+				Name:  "json",
+				Usage: "output the findings as json lines, for code review bot",
+			},
+			cli.BoolFlag{
+				Name:  "save-risk",
+				Usage: "save the new findings as risks",
+			},
+			cli.BoolFlag{
+				Name:  "syntaxflow-debug,sfdebug",
+				Usage: "enable syntax flow debug mode",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			if ret, err := log.ParseLevel(c.String("log")); err == nil {
+				log.SetLevel(ret)
+			}
+			base, target := c.String("base"), c.String("target")
+			if base == "" {
+				return utils.Error("base revision is required")
+			}
+
+			var opt []sfvm.Option
+			if c.Bool("syntaxflow-debug") {
+				opt = append(opt, sfvm.WithEnableDebug())
+			}
+			findings, err := SyntaxFlowDiffScan(
+				c.String("repository"), base, target, c.String("language"),
+				utils.PrettifyListFromStringSplitEx(c.String("group"), ","), opt...,
+			)
+			if err != nil {
+				return err
+			}
+			for _, finding := range findings {
+				risk := SyntaxFlowFindingToRisk(finding)
+				if c.Bool("save-risk") {
+					if err := yakit.SaveRisk(risk); err != nil {
+						log.Errorf("save risk %v failed: %v", risk.Title, err)
+					}
+				}
+				file, start, end := finding.GetPosition()
+				if !c.Bool("json") {
+					fmt.Printf("[%v] %v: %v:%v\n", risk.Severity, risk.Title, file, start)
+					continue
+				}
+				raw, err := json.Marshal(map[string]any{
+					"rule":       finding.Rule.RuleName,
+					"title":      risk.Title,
+					"message":    risk.TitleVerbose,
+					"severity":   risk.Severity,
+					"cwe":        finding.Rule.CWE,
+					"file":       file,
+					"start_line": start,
+					"end_line":   end,
+					"hash":       finding.DataFlowHash(),
+				})
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(raw))
+			}
+			log.Infof("ssa-diff-scan finished, %v..%v, new findings: %v", base, target, len(findings))
+			return nil
+		},
+	},
 	{
 		Name:    "ssa-query",
 		Aliases: []string{"sf", "syntaxFlow"},
@@ -432,6 +799,145 @@ func SyntaxFlowQuery(
 	return execError
 }
 
+// SyntaxFlowScan run the enabled rules for the language of program, the risks found are returned without saving
+func SyntaxFlowScan(programName, language string, groups []string, opt ...sfvm.Option) ([]*schema.Risk, error) {
+	prog, err := ssaapi.FromDatabase(programName)
+	if err != nil {
+		return nil, utils.Wrapf(err, "load program [%v] from database failed", programName)
+	}
+
+	languages := []ssaapi.Language{ssaapi.Language(language)}
+	if language == "" {
+		languages = ssaapi.GetDatabaseProgramLanguages(programName)
+		if len(languages) == 0 {
+			return nil, utils.Errorf("cannot guess the language of program %v, set it by --language", programName)
+		}
+	}
+
+	rules, err := getEnabledSyntaxFlowRules(languages, groups)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		log.Warnf("no enabled SyntaxFlow rule for language: %v", languages)
+		return nil, nil
+	}
+	log.Infof("start to scan program %v(%v) with %v SyntaxFlow rule(s)", programName, languages, len(rules))
+	findings := prog.SyntaxFlowRuleFindings(rules, opt...)
+	risks := make([]*schema.Risk, 0, len(findings))
+	for _, finding := range findings {
+		risks = append(risks, SyntaxFlowFindingToRisk(finding))
+	}
+	return risks, nil
+}
+
+// SyntaxFlowFindingToRisk convert finding to risk, the same value found by the same rule has the same risk hash
+func SyntaxFlowFindingToRisk(f *ssaapi.SyntaxFlowFinding) *schema.Risk {
+	rule, value := f.Rule, f.Value
+	programName := f.Program.GetProgramName()
+	details := map[string]any{
+		"program":  programName,
+		"rule":     rule.RuleName,
+		"variable": f.Variable,
+		"ir":       value.String(),
+	}
+	file, start, end := f.GetPosition()
+	if file != "" {
+		details["file"] = file
+		details["start_line"] = start
+		details["end_line"] = end
+		details["code"] = value.GetRange().GetText()
+	}
+
+	titleVerbose := rule.Title
+	if f.Message != "" {
+		titleVerbose = f.Message
+	}
+	risk := yakit.CreateRisk("",
+		yakit.WithRiskParam_Title(rule.Title),
+		yakit.WithRiskParam_TitleVerbose(titleVerbose),
+		yakit.WithRiskParam_Description(rule.Description),
+		yakit.WithRiskParam_RiskType(rule.Type),
+		yakit.WithRiskParam_Severity(rule.Severity),
+		yakit.WithRiskParam_Parameter(f.Variable),
+		yakit.WithRiskParam_FromScript(rule.RuleName),
+		yakit.WithRiskParam_Details(details),
+	)
+	risk.Url = file
+	risk.TaskName = programName
+	risk.Tags = rule.Tags
+	// the same value found by the same rule is the same risk, re-scan will update it
+	risk.Hash = utils.CalcSha1(programName, rule.RuleName, f.Variable, file, start, end, value.String())
+	return risk
+}
+
+// SyntaxFlowDiffScan compile the base and target revision of git repository in memory,
+// return the SyntaxFlow findings only exist in target revision
+func SyntaxFlowDiffScan(repoPath, base, target, language string, groups []string, opt ...sfvm.Option) ([]*ssaapi.SyntaxFlowFinding, error) {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return nil, utils.Wrapf(err, "open git repository %v failed", repoPath)
+	}
+	baseFS, err := yakgit.FileSystemFromRevision(repo, base)
+	if err != nil {
+		return nil, err
+	}
+	targetFS, err := yakgit.FileSystemFromRevision(repo, target)
+	if err != nil {
+		return nil, err
+	}
+
+	var compileOpts []ssaapi.Option
+	languages := []ssaapi.Language{ssaapi.Language(language)}
+	if language != "" {
+		compileOpts = append(compileOpts, ssaapi.WithLanguage(ssaapi.Language(language)))
+	} else {
+		languages = nil
+		filesys.Recursive(".", filesys.WithFileSystem(targetFS), filesys.WithFileStat(func(path string, info fs.FileInfo) error {
+			if l, ok := ssaapi.GetLanguageByFile(path); ok && !lo.Contains(languages, l) {
+				languages = append(languages, l)
+			}
+			return nil
+		}))
+		if len(languages) == 0 {
+			return nil, utils.Errorf("cannot guess the language of repository %v, set it by --language", repoPath)
+		}
+		if len(languages) == 1 {
+			compileOpts = append(compileOpts, ssaapi.WithLanguage(languages[0]))
+		}
+	}
+
+	rules, err := getEnabledSyntaxFlowRules(languages, groups)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		log.Warnf("no enabled SyntaxFlow rule for language: %v", languages)
+		return nil, nil
+	}
+	log.Infof("start to diff scan %v..%v(%v) with %v SyntaxFlow rule(s)", base, target, languages, len(rules))
+	return ssaapi.DiffScanWithSyntaxFlowRule(baseFS, targetFS, rules, compileOpts, opt...)
+}
+
+func getEnabledSyntaxFlowRules(languages []ssaapi.Language, groups []string) ([]*schema.SyntaxFlowRule, error) {
+	var rules []*schema.SyntaxFlowRule
+	for _, l := range languages {
+		ret, err := yakit.GetEnabledSyntaxFlowRuleByLanguage(consts.GetGormProfileDatabase(), string(l))
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range ret {
+			if len(groups) > 0 && len(lo.Intersect(rule.GetGroups(), groups)) == 0 {
+				continue
+			}
+			if !lo.ContainsBy(rules, func(item *schema.SyntaxFlowRule) bool { return item.ID == rule.ID }) {
+				rules = append(rules, rule)
+			}
+		}
+	}
+	return rules, nil
+}
+
 func showValues(name string, vs ssaapi.Values, showDot bool) {
 	log.Infof("===================== Variable:%v =================== ", name)
 	show(vs)
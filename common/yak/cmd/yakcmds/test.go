@@ -0,0 +1,120 @@
+package yakcmds
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/yak/antlr4yak/yakvm"
+	"github.com/yaklang/yaklang/common/yak/yakcover"
+	"github.com/yaklang/yaklang/common/yak/yakunit"
+)
+
+var testCommand = cli.Command{
+	Name:      "test",
+	Usage:     "Run Yak unit tests: test* functions in *_test.yak files",
+	ArgsUsage: "[file or dir ...]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "run",
+			Usage: "only run the tests (and subtests) matching the regexp",
+		},
+		cli.DurationFlag{
+			Name:  "timeout",
+			Usage: "timeout of every test function, 0 means no timeout",
+			Value: 0,
+		},
+		cli.BoolFlag{
+			Name:  "v",
+			Usage: "verbose output: print every test",
+		},
+		cli.StringFlag{
+			Name:  "format,f",
+			Usage: "report format: text / json / junit",
+			Value: "text",
+		},
+		cli.StringFlag{
+			Name:  "output,o",
+			Usage: "write the report to file instead of stdout",
+		},
+		cli.BoolFlag{
+			Name:  "cover",
+			Usage: "collect and print the line coverage of the yak code (test files excluded)",
+		},
+		cli.StringFlag{
+			Name:  "coverprofile",
+			Usage: "write the coverage profile to file, implies --cover",
+		},
+		cli.StringFlag{
+			Name:  "coverformat",
+			Usage: "coverage profile format: go / lcov / html, guessed by the extension of profile by default",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		format := c.String("format")
+		// the progress is written to stderr when the report is written to stdout
+		progress := os.Stdout
+		if format != "text" && c.String("output") == "" {
+			progress = os.Stderr
+		}
+		opts := []yakunit.Option{
+			yakunit.WithRunPattern(c.String("run")),
+			yakunit.WithTimeout(c.Duration("timeout")),
+			yakunit.WithVerbose(c.Bool("v")),
+			yakunit.WithProgressWriter(progress),
+		}
+		var cov *yakvm.Coverage
+		if c.Bool("cover") || c.String("coverprofile") != "" {
+			cov = yakvm.NewCoverage()
+			opts = append(opts, yakunit.WithCoverage(cov))
+		}
+		report, err := yakunit.Run(context.Background(), c.Args(), opts...)
+		if err != nil {
+			return err
+		}
+
+		if cov != nil {
+			profile := yakcover.NewProfile(cov, func(file string) bool {
+				return !strings.HasSuffix(file, yakunit.TestFileSuffix)
+			})
+			fmt.Fprintf(progress, "coverage: %.1f%% of lines\n", profile.Percent())
+			if output := c.String("coverprofile"); output != "" {
+				if err := profile.Save(output, c.String("coverformat")); err != nil {
+					return utils.Errorf("write coverage profile failed: %v", err)
+				}
+			}
+		}
+
+		var raw []byte
+		switch format {
+		case "text":
+		case "json":
+			raw, err = report.JSON()
+		case "junit":
+			raw, err = report.JUnit()
+		default:
+			return utils.Errorf("unsupported report format: %v", format)
+		}
+		if err != nil {
+			return err
+		}
+		if len(raw) > 0 {
+			if output := c.String("output"); output != "" {
+				if err := os.WriteFile(output, raw, 0o644); err != nil {
+					return err
+				}
+			} else {
+				os.Stdout.Write(append(raw, '\n'))
+			}
+		}
+
+		pass, fail, skip := report.Count()
+		if report.Failed() {
+			return utils.Errorf("test failed: %d passed, %d failed, %d skipped", pass, fail, skip)
+		}
+		return nil
+	},
+}
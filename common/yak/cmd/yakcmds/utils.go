@@ -10,7 +10,6 @@ import (
 	"io/fs"
 	"io/ioutil"
 	"os"
-	"path/filepath"
 	"sort"
 	"strings"
 	"time"
@@ -29,7 +28,6 @@ import (
 	"github.com/yaklang/yaklang/common/utils/filesys"
 	"github.com/yaklang/yaklang/common/utils/omap"
 	"github.com/yaklang/yaklang/common/yak/antlr4yak/dap"
-	"github.com/yaklang/yaklang/common/yak/antlr4yak/yakast"
 	"github.com/yaklang/yaklang/common/yak/yaklib"
 	"github.com/yaklang/yaklang/common/yak/yaklib/codec"
 	"github.com/yaklang/yaklang/scannode"
@@ -179,41 +177,7 @@ var UtilsCommands = []*cli.Command{
 	},
 
 	// fmt
-	{
-		Name:  "fmt",
-		Usage: "Formatter for Yaklang Code",
-		Flags: []cli.Flag{
-			cli.BoolFlag{Name: "version,v", Usage: "show formatter version"},
-		},
-		Action: func(c *cli.Context) error {
-			if c.Bool("version") {
-				fmt.Printf("Formatter version: %v\n", yakast.FormatterVersion)
-				return nil
-			}
-			args := c.Args()
-			file := args[0]
-			if file != "" {
-				var err error
-				absFile := file
-				if !filepath.IsAbs(absFile) {
-					absFile, err = filepath.Abs(absFile)
-					if err != nil {
-						return utils.Errorf("fetch abs file path failed: %s", err)
-					}
-				}
-				raw, err := os.ReadFile(file)
-				if err != nil {
-					return err
-				}
-				vt := yakast.NewYakCompiler()
-				vt.Compiler(string(raw))
-				fmt.Printf("%s", vt.GetFormattedCode())
-			} else {
-				return utils.Errorf("empty yak file")
-			}
-			return nil
-		},
-	},
+	&fmtCommand,
 
 	{
 		Name:  "fuzz",
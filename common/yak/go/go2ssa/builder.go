@@ -0,0 +1,117 @@
+package go2ssa
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+
+	"github.com/yaklang/yaklang/common/log"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/yak/ssa"
+)
+
+// ========================================== For SSAAPI ==========================================
+
+type SSABuilder struct {
+	ssa.DummyExtraFileAnalyzer
+}
+
+var Builder = &SSABuilder{}
+
+func (*SSABuilder) Build(src string, force bool, b *ssa.FunctionBuilder) error {
+	file, fset, err := Frontend(src, force)
+	if err != nil {
+		return err
+	}
+	b.SupportClosure = true
+	b.SupportClass = true
+	build := &builder{
+		FunctionBuilder: b,
+		fset:            fset,
+		constMap:        make(map[string]ssa.Value),
+		importMap:       make(map[string]string),
+		structFields:    make(map[string][]string),
+	}
+	build.VisitFile(file)
+	return nil
+}
+
+func (*SSABuilder) FilterFile(path string) bool {
+	return filepath.Ext(path) == ".go"
+}
+
+// ========================================== Build Front End ==========================================
+
+type builder struct {
+	*ssa.FunctionBuilder
+	fset *token.FileSet
+
+	constMap map[string]ssa.Value
+	// importMap records local package name -> import path
+	importMap map[string]string
+	// structFields records the field order of struct, for `T{1, 2}`
+	structFields map[string][]string
+
+	// iota is the index of the current const spec
+	iota int
+	// funcLevel is 0 when building package level declaration
+	funcLevel int
+	// namedResults is the named return values of the function being built
+	namedResults []string
+}
+
+func Frontend(src string, force bool) (*ast.File, *token.FileSet, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments|parser.AllErrors)
+	if err == nil || (force && file != nil) {
+		return file, fset, nil
+	}
+	return nil, nil, utils.Errorf("parse AST FrontEnd error: %v", err)
+}
+
+// SetRangeFromNode set current range by go/ast node, return a function to recover the old range
+func (b *builder) SetRangeFromNode(node ast.Node) func() {
+	if utils.IsNil(node) {
+		return func() {}
+	}
+	start, end := b.fset.Position(node.Pos()), b.fset.Position(node.End())
+	if !start.IsValid() || !end.IsValid() {
+		return func() {}
+	}
+	backup := b.CurrentRange
+	b.CurrentRange = ssa.NewRange(
+		b.GetEditor(),
+		ssa.NewPosition(int64(start.Line), int64(start.Column-1)),
+		ssa.NewPosition(int64(end.Line), int64(end.Column-1)),
+	)
+	return func() {
+		b.CurrentRange = backup
+	}
+}
+
+func (b *builder) AssignConst(name string, value ssa.Value) bool {
+	if ConstValue, ok := b.constMap[name]; ok {
+		log.Warnf("const %v has been defined value is %v", name, ConstValue.String())
+		return false
+	}
+
+	b.constMap[name] = value
+	return true
+}
+
+func (b *builder) ReadConst(name string) (ssa.Value, bool) {
+	v, ok := b.constMap[name]
+	return v, ok
+}
+
+func (b *builder) AssignClassConst(className, key string, value ssa.Value) {
+	name := fmt.Sprintf("%s_%s", className, key)
+	b.AssignConst(name, value)
+}
+
+func (b *builder) ReadClassConst(className, key string) (ssa.Value, bool) {
+	name := fmt.Sprintf("%s_%s", className, key)
+	return b.ReadConst(name)
+}
@@ -0,0 +1,34 @@
+package go2ssa
+
+import (
+	"fmt"
+	"go/ast"
+
+	"github.com/yaklang/yaklang/common/yak/ssa"
+)
+
+const TAG ssa.ErrorTag = "goast"
+
+func AssignmentMismatch(left, right int) string {
+	return fmt.Sprintf("assignment mismatch: %d variables but %d values", left, right)
+}
+
+func UnsupportedStatement(stmt ast.Stmt) string {
+	return fmt.Sprintf("statement not support: %T", stmt)
+}
+
+func UnsupportedExpression(expr ast.Expr) string {
+	return fmt.Sprintf("expression not support: %T", expr)
+}
+
+func UnexpectedBranchStmt(tok string) string {
+	return fmt.Sprintf("%s statement is not in for, switch or select", tok)
+}
+
+func UnaryOperatorNotSupport(op string) string {
+	return fmt.Sprintf("unary operator not support: %s", op)
+}
+
+func BinaryOperatorNotSupport(op string) string {
+	return fmt.Sprintf("binary operator not support: %s", op)
+}
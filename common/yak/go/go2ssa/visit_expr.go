@@ -0,0 +1,457 @@
+package go2ssa
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/yaklang/yaklang/common/log"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/yak/ssa"
+)
+
+var binaryOpcode = map[token.Token]ssa.BinaryOpcode{
+	token.ADD:     ssa.OpAdd,
+	token.SUB:     ssa.OpSub,
+	token.MUL:     ssa.OpMul,
+	token.QUO:     ssa.OpDiv,
+	token.REM:     ssa.OpMod,
+	token.AND:     ssa.OpAnd,
+	token.OR:      ssa.OpOr,
+	token.XOR:     ssa.OpXor,
+	token.SHL:     ssa.OpShl,
+	token.SHR:     ssa.OpShr,
+	token.AND_NOT: ssa.OpAndNot,
+	token.EQL:     ssa.OpEq,
+	token.NEQ:     ssa.OpNotEq,
+	token.LSS:     ssa.OpLt,
+	token.LEQ:     ssa.OpLtEq,
+	token.GTR:     ssa.OpGt,
+	token.GEQ:     ssa.OpGtEq,
+}
+
+var unaryOpcode = map[token.Token]ssa.UnaryOpcode{
+	token.NOT:   ssa.OpNot,
+	token.SUB:   ssa.OpNeg,
+	token.ADD:   ssa.OpPlus,
+	token.XOR:   ssa.OpBitwiseNot,
+	token.ARROW: ssa.OpChan,
+}
+
+func (b *builder) VisitExprList(exprs []ast.Expr) []ssa.Value {
+	values := make([]ssa.Value, 0, len(exprs))
+	for _, expr := range exprs {
+		value := b.VisitExpr(expr)
+		if value == nil {
+			// keep the position of values, `a, b := panic(1), 2`
+			if undefined := b.EmitUndefined(""); undefined != nil {
+				value = undefined
+			}
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
+func (b *builder) VisitExpr(raw ast.Expr) ssa.Value {
+	if b == nil || raw == nil {
+		return nil
+	}
+	recoverRange := b.SetRangeFromNode(raw)
+	defer recoverRange()
+
+	// emit instruction in finished block will get typed nil
+	if value := b.visitExpr(raw); !utils.IsNil(value) {
+		return value
+	}
+	return nil
+}
+
+func (b *builder) visitExpr(raw ast.Expr) ssa.Value {
+	switch expr := raw.(type) {
+	case *ast.Ident:
+		return b.VisitIdentifier(expr.Name)
+	case *ast.BasicLit:
+		return b.VisitBasicLit(expr)
+	case *ast.CompositeLit:
+		return b.VisitCompositeLit(expr, expr.Type)
+	case *ast.FuncLit:
+		return b.VisitFuncLit(expr)
+	case *ast.ParenExpr:
+		return b.VisitExpr(expr.X)
+	case *ast.SelectorExpr:
+		return b.VisitSelectorExpr(expr)
+	case *ast.IndexExpr:
+		if b.isTypeExpr(expr.Index) {
+			// generic function instantiation `f[int]`
+			return b.VisitExpr(expr.X)
+		}
+		return b.ReadMemberCallVariable(b.VisitExpr(expr.X), b.VisitExpr(expr.Index))
+	case *ast.IndexListExpr:
+		return b.VisitExpr(expr.X)
+	case *ast.SliceExpr:
+		value := b.VisitExpr(expr.X)
+		if value == nil {
+			return nil
+		}
+		var low, high, max ssa.Value
+		if expr.Low != nil {
+			low = b.VisitExpr(expr.Low)
+		}
+		if expr.High != nil {
+			high = b.VisitExpr(expr.High)
+		}
+		if expr.Max != nil {
+			max = b.VisitExpr(expr.Max)
+		}
+		return b.EmitMakeSlice(value, low, high, max)
+	case *ast.TypeAssertExpr:
+		value := b.VisitExpr(expr.X)
+		if expr.Type == nil {
+			// `x.(type)` only in type switch
+			return value
+		}
+		return b.emitTypeCast(value, b.VisitType(expr.Type))
+	case *ast.StarExpr:
+		// pointer is transparent
+		return b.VisitExpr(expr.X)
+	case *ast.UnaryExpr:
+		return b.VisitUnaryExpr(expr)
+	case *ast.BinaryExpr:
+		return b.VisitBinaryExpr(expr)
+	case *ast.CallExpr:
+		return b.VisitCallExpr(expr)
+	case *ast.KeyValueExpr:
+		return b.VisitExpr(expr.Value)
+	default:
+		if b.isTypeExpr(raw) {
+			return b.emitTypeValue(b.VisitType(raw))
+		}
+		b.NewError(ssa.Warn, TAG, UnsupportedExpression(raw))
+		return nil
+	}
+}
+
+func (b *builder) VisitIdentifier(name string) ssa.Value {
+	switch name {
+	case "nil":
+		return b.EmitConstInstNil()
+	case "true":
+		return b.EmitConstInst(true)
+	case "false":
+		return b.EmitConstInst(false)
+	case "iota":
+		return b.EmitConstInst(b.iota)
+	case "_":
+		return b.EmitUndefined(name)
+	}
+
+	if value := b.PeekValueInThisFunction(name); value != nil {
+		return value
+	}
+	// package level const and function
+	if value, ok := b.ReadConst(name); ok {
+		return value
+	}
+	if value := b.ReadImportPackage(name); value != nil {
+		return value
+	}
+	return b.ReadValue(name)
+}
+
+func (b *builder) VisitBasicLit(lit *ast.BasicLit) ssa.Value {
+	switch lit.Kind {
+	case token.INT:
+		if v, err := strconv.ParseInt(lit.Value, 0, 64); err == nil {
+			return b.EmitConstInst(v)
+		}
+		if v, err := strconv.ParseUint(lit.Value, 0, 64); err == nil {
+			return b.EmitConstInst(v)
+		}
+	case token.FLOAT:
+		if v, err := strconv.ParseFloat(strings.ReplaceAll(lit.Value, "_", ""), 64); err == nil {
+			return b.EmitConstInst(v)
+		}
+	case token.IMAG:
+		// complex number is rarely used, just keep the literal
+		return b.EmitConstInst(lit.Value)
+	case token.CHAR:
+		if v, _, _, err := strconv.UnquoteChar(lit.Value[1:len(lit.Value)-1], '\''); err == nil {
+			return b.EmitConstInst(int64(v))
+		}
+	case token.STRING:
+		if v, err := strconv.Unquote(lit.Value); err == nil {
+			return b.EmitConstInst(v)
+		}
+	}
+	log.Warnf("basic literal %v parse failed", lit.Value)
+	return b.EmitConstInst(lit.Value)
+}
+
+// VisitCompositeLit build `T{...}`, typExpr is the type of the literal,
+// it is inherited from outer literal when elided, like `[]T{{...}}`
+func (b *builder) VisitCompositeLit(lit *ast.CompositeLit, typExpr ast.Expr) ssa.Value {
+	if typExpr == nil {
+		typExpr = lit.Type
+	}
+
+	var elemTypExpr ast.Expr
+	switch typ := unwrapTypeExpr(typExpr).(type) {
+	case *ast.ArrayType:
+		elemTypExpr = typ.Elt
+	case *ast.MapType:
+		elemTypExpr = typ.Value
+	}
+
+	var class *ssa.ClassBluePrint
+	if name := typeName(typExpr); name != "" {
+		class = b.GetClassBluePrint(name)
+	}
+
+	keys := make([]ssa.Value, 0, len(lit.Elts))
+	values := make([]ssa.Value, 0, len(lit.Elts))
+	visitValue := func(expr ast.Expr) ssa.Value {
+		if inner, ok := expr.(*ast.CompositeLit); ok && inner.Type == nil {
+			return b.VisitCompositeLit(inner, elemTypExpr)
+		}
+		if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+			// `[]*T{&T{}}` or elided `[]*T{{}}`
+			if inner, ok := unary.X.(*ast.CompositeLit); ok && inner.Type == nil {
+				return b.VisitCompositeLit(inner, elemTypExpr)
+			}
+		}
+		return b.VisitExpr(expr)
+	}
+
+	for index, elt := range lit.Elts {
+		var key ssa.Value
+		var value ssa.Value
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if ident, ok := kv.Key.(*ast.Ident); ok && class != nil {
+				// struct field name
+				key = b.EmitConstInst(ident.Name)
+			} else if inner, ok := kv.Key.(*ast.CompositeLit); ok && inner.Type == nil {
+				key = b.VisitCompositeLit(inner, mapKeyTypeExpr(typExpr))
+			} else {
+				key = b.VisitExpr(kv.Key)
+			}
+			value = visitValue(kv.Value)
+		} else {
+			if class != nil {
+				// positional struct field
+				if fields := b.structFields[class.Name]; index < len(fields) {
+					key = b.EmitConstInst(fields[index])
+				}
+			}
+			if key == nil {
+				key = b.EmitConstInst(index)
+			}
+			value = visitValue(elt)
+		}
+		if key == nil || value == nil {
+			continue
+		}
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+
+	obj := b.InterfaceAddFieldBuild(len(values),
+		func(i int) ssa.Value { return keys[i] },
+		func(i int) ssa.Value { return values[i] },
+	)
+	if obj == nil {
+		return nil
+	}
+	if class != nil {
+		obj.SetType(class)
+	} else if typExpr != nil {
+		obj.SetType(b.VisitType(typExpr))
+	}
+	return obj
+}
+
+func unwrapTypeExpr(expr ast.Expr) ast.Expr {
+	for {
+		switch typ := expr.(type) {
+		case *ast.ParenExpr:
+			expr = typ.X
+		case *ast.StarExpr:
+			expr = typ.X
+		default:
+			return expr
+		}
+	}
+}
+
+func mapKeyTypeExpr(expr ast.Expr) ast.Expr {
+	if typ, ok := unwrapTypeExpr(expr).(*ast.MapType); ok {
+		return typ.Key
+	}
+	return nil
+}
+
+func (b *builder) VisitSelectorExpr(expr *ast.SelectorExpr) ssa.Value {
+	key := b.EmitConstInst(expr.Sel.Name)
+	if ident, ok := expr.X.(*ast.Ident); ok && b.PeekValueInThisFunction(ident.Name) == nil {
+		if _, isConst := b.ReadConst(ident.Name); !isConst {
+			// package member, `exec.Command`
+			if pkg := b.ReadImportPackage(ident.Name); pkg != nil {
+				return b.ReadMemberCallVariable(pkg, key)
+			}
+		}
+		// method expression, `T.Method`
+		if value, ok := b.ReadClassConst(ident.Name, expr.Sel.Name); ok {
+			return value
+		}
+	}
+	return b.ReadMemberCallVariable(b.VisitExpr(expr.X), key)
+}
+
+func (b *builder) VisitUnaryExpr(expr *ast.UnaryExpr) ssa.Value {
+	if expr.Op == token.AND {
+		// pointer is transparent, `&T{}` is the same as `T{}`
+		return b.VisitExpr(expr.X)
+	}
+	op, ok := unaryOpcode[expr.Op]
+	if !ok {
+		b.NewError(ssa.Error, TAG, UnaryOperatorNotSupport(expr.Op.String()))
+		return b.VisitExpr(expr.X)
+	}
+	return b.EmitUnOp(op, b.VisitExpr(expr.X))
+}
+
+func (b *builder) VisitBinaryExpr(expr *ast.BinaryExpr) ssa.Value {
+	switch expr.Op {
+	case token.LAND:
+		// target = a && b
+		//	if a { target = b } else { target = a }
+		var left ssa.Value
+		return b.handlerJumpExpression(
+			func() ssa.Value {
+				left = b.VisitExpr(expr.X)
+				return left
+			},
+			func() ssa.Value { return b.VisitExpr(expr.Y) },
+			func() ssa.Value { return left },
+		)
+	case token.LOR:
+		// target = a || b
+		//	if a { target = a } else { target = b }
+		var left ssa.Value
+		return b.handlerJumpExpression(
+			func() ssa.Value {
+				left = b.VisitExpr(expr.X)
+				return left
+			},
+			func() ssa.Value { return left },
+			func() ssa.Value { return b.VisitExpr(expr.Y) },
+		)
+	}
+
+	op, ok := binaryOpcode[expr.Op]
+	if !ok {
+		b.NewError(ssa.Error, TAG, BinaryOperatorNotSupport(expr.Op.String()))
+		return nil
+	}
+	return b.EmitBinOp(op, b.VisitExpr(expr.X), b.VisitExpr(expr.Y))
+}
+
+// handlerJumpExpression build short-circuit expression, the right expression only build in branch,
+// all branch assign to the same variable, and read it after if-statement to generate phi
+func (b *builder) handlerJumpExpression(cond, trueExpr, falseExpr func() ssa.Value) ssa.Value {
+	id := uuid.NewString()
+	variable := b.CreateVariable(id)
+	b.AssignVariable(variable, b.EmitValueOnlyDeclare(id))
+	ifb := b.CreateIfBuilder()
+	ifb.AppendItem(
+		cond,
+		func() {
+			b.AssignVariable(b.CreateVariable(id), trueExpr())
+		},
+	)
+	ifb.SetElse(func() {
+		b.AssignVariable(b.CreateVariable(id), falseExpr())
+	})
+	ifb.Build()
+	return b.ReadValue(id)
+}
+
+func (b *builder) VisitCallExpr(call *ast.CallExpr) ssa.Value {
+	// type conversion, `string(b)`
+	if b.isTypeExpr(call.Fun) && len(call.Args) == 1 {
+		return b.emitTypeCast(b.VisitExpr(call.Args[0]), b.VisitType(call.Fun))
+	}
+
+	if ident, ok := call.Fun.(*ast.Ident); ok && b.isBuiltin(ident.Name) {
+		switch ident.Name {
+		case "panic":
+			if len(call.Args) == 1 {
+				b.EmitPanic(b.VisitExpr(call.Args[0]))
+			}
+			return nil
+		case "recover":
+			return b.EmitRecover()
+		case "make":
+			if len(call.Args) == 0 {
+				break
+			}
+			var length, capacity ssa.Value
+			if len(call.Args) > 1 {
+				length = b.VisitExpr(call.Args[1])
+			}
+			if len(call.Args) > 2 {
+				capacity = b.VisitExpr(call.Args[2])
+			}
+			return b.emitMakeWithType(b.VisitType(call.Args[0]), length, capacity)
+		case "new":
+			if len(call.Args) == 0 {
+				break
+			}
+			return b.emitMakeWithType(b.VisitType(call.Args[0]), nil, nil)
+		}
+	}
+
+	c := b.buildCall(call)
+	if c == nil {
+		return nil
+	}
+	if c = b.EmitCall(c); c == nil {
+		return nil
+	}
+	return c
+}
+
+// buildCall create call instruction but not emit it, `go` and `defer` will handle it
+func (b *builder) buildCall(call *ast.CallExpr) *ssa.Call {
+	if call == nil {
+		return nil
+	}
+	recoverRange := b.SetRangeFromNode(call)
+	defer recoverRange()
+
+	target := b.VisitExpr(call.Fun)
+	if target == nil {
+		return nil
+	}
+	args := b.VisitExprList(call.Args)
+	c := b.NewCall(target, args)
+	if call.Ellipsis.IsValid() {
+		c.IsEllipsis = true
+	}
+	return c
+}
+
+// isBuiltin check the name is builtin function and not shadowed by user
+func (b *builder) isBuiltin(name string) bool {
+	switch name {
+	case "panic", "recover", "make", "new":
+	default:
+		return false
+	}
+	if _, ok := b.ReadConst(name); ok {
+		return false
+	}
+	return b.PeekValueInThisFunction(name) == nil
+}
@@ -0,0 +1,196 @@
+package go2ssa
+
+import (
+	"go/ast"
+	"go/token"
+	"path"
+	"strconv"
+
+	"github.com/yaklang/yaklang/common/log"
+	"github.com/yaklang/yaklang/common/yak/ssa"
+)
+
+func (b *builder) VisitFile(file *ast.File) {
+	if b == nil || file == nil {
+		return
+	}
+	recoverRange := b.SetRangeFromNode(file)
+	defer recoverRange()
+
+	if file.Name != nil {
+		if builder := b.AddCurrentPackagePath([]string{file.Name.Name}); builder != nil {
+			builder.SupportClass = true
+			builder.SupportClosure = true
+			b.FunctionBuilder = builder
+		}
+	}
+
+	for _, spec := range file.Imports {
+		b.VisitImportSpec(spec)
+	}
+
+	// declare all type first, method and function can use them in any order
+	for _, decl := range file.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.TYPE {
+			b.VisitGenDecl(gen)
+		}
+	}
+
+	// declare all function, build body later
+	builders := make([]func(), 0, len(file.Decls))
+	for _, decl := range file.Decls {
+		if fun, ok := decl.(*ast.FuncDecl); ok {
+			builders = append(builders, b.VisitFuncDecl(fun))
+		}
+	}
+
+	// package level variable and const
+	for _, decl := range file.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && (gen.Tok == token.VAR || gen.Tok == token.CONST) {
+			b.VisitGenDecl(gen)
+		}
+	}
+
+	for _, build := range builders {
+		build()
+	}
+}
+
+func (b *builder) VisitImportSpec(spec *ast.ImportSpec) {
+	if b == nil || spec == nil || spec.Path == nil {
+		return
+	}
+	recoverRange := b.SetRangeFromNode(spec)
+	defer recoverRange()
+
+	importPath, err := strconv.Unquote(spec.Path.Value)
+	if err != nil {
+		log.Warnf("import path %v unquote failed: %v", spec.Path.Value, err)
+		return
+	}
+
+	name := path.Base(importPath)
+	if spec.Name != nil {
+		switch spec.Name.Name {
+		case "_":
+			// only for side effect
+			return
+		case ".":
+			log.Warnf("dot import %v is not supported yet", importPath)
+			return
+		default:
+			name = spec.Name.Name
+		}
+	}
+	b.importMap[name] = importPath
+}
+
+// ReadImportPackage read the package value in current function,
+// the value is named by the package name (not alias), so `e "os/exec"; e.Command()` is the same as `exec.Command()`
+func (b *builder) ReadImportPackage(name string) ssa.Value {
+	importPath, ok := b.importMap[name]
+	if !ok {
+		return nil
+	}
+	pkgName := path.Base(importPath)
+	if v := b.PeekValueInThisFunction(pkgName); v != nil {
+		return v
+	}
+	v := b.EmitUndefined(pkgName)
+	b.AssignVariable(b.CreateVariable(pkgName), v)
+	return v
+}
+
+func (b *builder) VisitGenDecl(decl *ast.GenDecl) {
+	if b == nil || decl == nil {
+		return
+	}
+	recoverRange := b.SetRangeFromNode(decl)
+	defer recoverRange()
+
+	switch decl.Tok {
+	case token.IMPORT:
+		for _, spec := range decl.Specs {
+			if spec, ok := spec.(*ast.ImportSpec); ok {
+				b.VisitImportSpec(spec)
+			}
+		}
+	case token.TYPE:
+		for _, spec := range decl.Specs {
+			if spec, ok := spec.(*ast.TypeSpec); ok {
+				b.VisitTypeSpec(spec)
+			}
+		}
+	case token.VAR:
+		for _, spec := range decl.Specs {
+			if spec, ok := spec.(*ast.ValueSpec); ok {
+				b.VisitValueSpec(spec, nil)
+			}
+		}
+	case token.CONST:
+		// const without value repeat the last expression list with new iota
+		var last []ast.Expr
+		for index, spec := range decl.Specs {
+			spec, ok := spec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+			if len(spec.Values) > 0 {
+				last = spec.Values
+			}
+			b.iota = index
+			b.VisitValueSpec(spec, last)
+		}
+		b.iota = 0
+	}
+}
+
+// VisitValueSpec build `var a, b = 1, 2` or `const a = iota`,
+// constValues is not nil when the spec is a const spec
+func (b *builder) VisitValueSpec(spec *ast.ValueSpec, constValues []ast.Expr) {
+	if b == nil || spec == nil {
+		return
+	}
+	recoverRange := b.SetRangeFromNode(spec)
+	defer recoverRange()
+
+	isConst := constValues != nil
+	exprs := spec.Values
+	if isConst {
+		exprs = constValues
+	}
+
+	var typ ssa.Type
+	if spec.Type != nil {
+		typ = b.VisitType(spec.Type)
+	}
+
+	variables := make([]*ssa.Variable, 0, len(spec.Names))
+	for _, name := range spec.Names {
+		recoverRange := b.SetRangeFromNode(name)
+		variables = append(variables, b.CreateLocalVariable(name.Name))
+		recoverRange()
+	}
+
+	if len(exprs) == 0 {
+		// var a int, just declare with zero value
+		for _, variable := range variables {
+			value := b.EmitValueOnlyDeclare(variable.GetName())
+			if typ != nil {
+				value.SetType(typ)
+			}
+			b.AssignVariable(variable, value)
+		}
+		return
+	}
+
+	values := b.VisitRightExprList(len(variables), exprs)
+	b.AssignList(variables, values)
+	if isConst && b.funcLevel == 0 {
+		for i, variable := range variables {
+			if i < len(values) && values[i] != nil {
+				b.AssignConst(variable.GetName(), values[i])
+			}
+		}
+	}
+}
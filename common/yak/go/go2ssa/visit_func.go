@@ -0,0 +1,148 @@
+package go2ssa
+
+import (
+	"fmt"
+	"go/ast"
+
+	"github.com/yaklang/yaklang/common/log"
+	"github.com/yaklang/yaklang/common/yak/ssa"
+)
+
+// VisitFuncDecl declare the function or method, and return the builder for function body,
+// so the function can be used before its declaration
+func (b *builder) VisitFuncDecl(decl *ast.FuncDecl) func() {
+	if b == nil || decl == nil || decl.Name == nil {
+		return func() {}
+	}
+	recoverRange := b.SetRangeFromNode(decl)
+	defer recoverRange()
+
+	name := decl.Name.Name
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		newFunction := b.NewFunc(name)
+		// init function can be declared many times and can not be referred
+		if name != "init" {
+			b.AssignConst(name, newFunction)
+		}
+		return func() {
+			recoverRange := b.SetRangeFromNode(decl)
+			defer recoverRange()
+			b.buildFunction(newFunction, nil, nil, decl.Type, decl.Body)
+		}
+	}
+
+	recv := decl.Recv.List[0]
+	className := typeName(recv.Type)
+	if className == "" {
+		log.Warnf("method %v receiver type %T is not supported", name, recv.Type)
+		return func() {}
+	}
+	class := b.GetOrCreateClassBluePrint(className)
+	funcName := fmt.Sprintf("%s_%s", class.Name, name)
+	newFunction := b.NewFunc(funcName)
+	newFunction.SetMethodName(name)
+	class.AddMethod(name, newFunction)
+	b.AssignClassConst(class.Name, name, newFunction)
+	return func() {
+		recoverRange := b.SetRangeFromNode(decl)
+		defer recoverRange()
+		b.buildFunction(newFunction, recv, class, decl.Type, decl.Body)
+	}
+}
+
+func (b *builder) VisitFuncLit(lit *ast.FuncLit) ssa.Value {
+	if b == nil || lit == nil {
+		return nil
+	}
+	recoverRange := b.SetRangeFromNode(lit)
+	defer recoverRange()
+
+	newFunction := b.NewFunc("")
+	b.buildFunction(newFunction, nil, nil, lit.Type, lit.Body)
+	return newFunction
+}
+
+// buildFunction build the function body, receiver will be the first parameter like `this` in java
+func (b *builder) buildFunction(
+	fun *ssa.Function,
+	recv *ast.Field, class *ssa.ClassBluePrint,
+	typ *ast.FuncType, body *ast.BlockStmt,
+) {
+	namedResults := b.namedResults
+	b.FunctionBuilder = b.PushFunction(fun)
+	b.funcLevel++
+	b.namedResults = nil
+
+	if recv != nil && class != nil {
+		b.MarkedThisClassBlueprint = class
+		name := "this"
+		if len(recv.Names) > 0 && recv.Names[0].Name != "_" {
+			name = recv.Names[0].Name
+		}
+		recoverRange := b.SetRangeFromNode(recv)
+		this := b.NewParam(name)
+		this.SetType(class)
+		recoverRange()
+	}
+	if typ != nil {
+		b.VisitParams(typ.Params)
+		b.VisitResults(typ.Results)
+	}
+	if body != nil {
+		b.VisitBlockStmt(body, false)
+	}
+
+	b.Finish()
+	b.funcLevel--
+	b.namedResults = namedResults
+	b.FunctionBuilder = b.PopFunction()
+}
+
+func (b *builder) VisitParams(params *ast.FieldList) {
+	if b == nil || params == nil {
+		return
+	}
+	recoverRange := b.SetRangeFromNode(params)
+	defer recoverRange()
+
+	for _, field := range params.List {
+		typ := b.VisitType(field.Type)
+		_, isVariadic := field.Type.(*ast.Ellipsis)
+
+		names := field.Names
+		if len(names) == 0 {
+			// unnamed parameter, `func(int, string)`
+			names = []*ast.Ident{{Name: "_", NamePos: field.Pos()}}
+		}
+		for _, name := range names {
+			recoverRange := b.SetRangeFromNode(field)
+			param := b.NewParam(name.Name)
+			param.SetType(typ)
+			recoverRange()
+		}
+		if isVariadic {
+			b.HandlerEllipsis()
+		}
+	}
+}
+
+// VisitResults declare the named result, `return` without value will return them
+func (b *builder) VisitResults(results *ast.FieldList) {
+	if b == nil || results == nil {
+		return
+	}
+	for _, field := range results.List {
+		if len(field.Names) == 0 {
+			continue
+		}
+		typ := b.VisitType(field.Type)
+		for _, name := range field.Names {
+			recoverRange := b.SetRangeFromNode(name)
+			value := b.EmitValueOnlyDeclare(name.Name)
+			value.SetType(typ)
+			b.AssignVariable(b.CreateLocalVariable(name.Name), value)
+			b.namedResults = append(b.namedResults, name.Name)
+			recoverRange()
+		}
+	}
+}
@@ -0,0 +1,500 @@
+package go2ssa
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/google/uuid"
+	"github.com/yaklang/yaklang/common/yak/ssa"
+)
+
+func (b *builder) VisitBlockStmt(block *ast.BlockStmt, syntaxBlock bool) {
+	if b == nil || block == nil {
+		return
+	}
+	recoverRange := b.SetRangeFromNode(block)
+	defer recoverRange()
+
+	if syntaxBlock {
+		b.BuildSyntaxBlock(func() {
+			b.VisitStmtList(block.List)
+		})
+	} else {
+		b.VisitStmtList(block.List)
+	}
+}
+
+func (b *builder) VisitStmtList(list []ast.Stmt) {
+	for _, stmt := range list {
+		if b.IsBlockFinish() {
+			// code after return/break/continue is unreachable
+			return
+		}
+		b.VisitStmt(stmt)
+	}
+}
+
+func (b *builder) VisitStmt(raw ast.Stmt) {
+	if b == nil || raw == nil {
+		return
+	}
+	recoverRange := b.SetRangeFromNode(raw)
+	defer recoverRange()
+
+	switch stmt := raw.(type) {
+	case *ast.DeclStmt:
+		if decl, ok := stmt.Decl.(*ast.GenDecl); ok {
+			b.VisitGenDecl(decl)
+		}
+	case *ast.ExprStmt:
+		b.VisitExpr(stmt.X)
+	case *ast.AssignStmt:
+		b.VisitAssignStmt(stmt)
+	case *ast.IncDecStmt:
+		op := ssa.BinaryOpcode(ssa.OpAdd)
+		if stmt.Tok == token.DEC {
+			op = ssa.OpSub
+		}
+		variable := b.VisitLeftExpr(stmt.X, false)
+		value := b.EmitBinOp(op, b.VisitExpr(stmt.X), b.EmitConstInst(1))
+		b.AssignVariable(variable, value)
+	case *ast.SendStmt:
+		b.EmitBinOp(ssa.OpSend, b.VisitExpr(stmt.Chan), b.VisitExpr(stmt.Value))
+	case *ast.ReturnStmt:
+		b.VisitReturnStmt(stmt)
+	case *ast.BlockStmt:
+		b.VisitBlockStmt(stmt, true)
+	case *ast.IfStmt:
+		b.BuildSyntaxBlock(func() {
+			b.VisitIfStmt(stmt)
+		})
+	case *ast.ForStmt:
+		b.VisitForStmt(stmt)
+	case *ast.RangeStmt:
+		b.VisitRangeStmt(stmt)
+	case *ast.SwitchStmt:
+		b.BuildSyntaxBlock(func() {
+			b.VisitSwitchStmt(stmt)
+		})
+	case *ast.TypeSwitchStmt:
+		b.BuildSyntaxBlock(func() {
+			b.VisitTypeSwitchStmt(stmt)
+		})
+	case *ast.SelectStmt:
+		b.VisitSelectStmt(stmt)
+	case *ast.GoStmt:
+		if c := b.buildCall(stmt.Call); c != nil {
+			c.Async = true
+			b.EmitCall(c)
+		}
+	case *ast.DeferStmt:
+		if c := b.buildCall(stmt.Call); c != nil {
+			b.SetInstructionPosition(c)
+			b.AddDefer(c)
+		}
+	case *ast.BranchStmt:
+		b.VisitBranchStmt(stmt)
+	case *ast.LabeledStmt:
+		// labeled break/continue is handled as the innermost one
+		b.VisitStmt(stmt.Stmt)
+	case *ast.EmptyStmt:
+	default:
+		b.NewError(ssa.Warn, TAG, UnsupportedStatement(raw))
+	}
+}
+
+func (b *builder) VisitAssignStmt(stmt *ast.AssignStmt) {
+	switch stmt.Tok {
+	case token.ASSIGN, token.DEFINE:
+		values := b.VisitRightExprList(len(stmt.Lhs), stmt.Rhs)
+		variables := make([]*ssa.Variable, 0, len(stmt.Lhs))
+		for _, lhs := range stmt.Lhs {
+			variables = append(variables, b.VisitLeftExpr(lhs, stmt.Tok == token.DEFINE))
+		}
+		b.AssignList(variables, values)
+	default:
+		// a += 1
+		op, ok := assignOpcode[stmt.Tok]
+		if !ok || len(stmt.Lhs) != 1 || len(stmt.Rhs) != 1 {
+			b.NewError(ssa.Error, TAG, UnsupportedStatement(stmt))
+			return
+		}
+		right := b.VisitExpr(stmt.Rhs[0])
+		left := b.VisitExpr(stmt.Lhs[0])
+		variable := b.VisitLeftExpr(stmt.Lhs[0], false)
+		b.AssignVariable(variable, b.EmitBinOp(op, left, right))
+	}
+}
+
+var assignOpcode = map[token.Token]ssa.BinaryOpcode{
+	token.ADD_ASSIGN:     ssa.OpAdd,
+	token.SUB_ASSIGN:     ssa.OpSub,
+	token.MUL_ASSIGN:     ssa.OpMul,
+	token.QUO_ASSIGN:     ssa.OpDiv,
+	token.REM_ASSIGN:     ssa.OpMod,
+	token.AND_ASSIGN:     ssa.OpAnd,
+	token.OR_ASSIGN:      ssa.OpOr,
+	token.XOR_ASSIGN:     ssa.OpXor,
+	token.SHL_ASSIGN:     ssa.OpShl,
+	token.SHR_ASSIGN:     ssa.OpShr,
+	token.AND_NOT_ASSIGN: ssa.OpAndNot,
+}
+
+// VisitLeftExpr get the variable for assignment, `a`, `a.b`, `a[1]` or `*a`
+func (b *builder) VisitLeftExpr(expr ast.Expr, isDefine bool) *ssa.Variable {
+	recoverRange := b.SetRangeFromNode(expr)
+	defer recoverRange()
+
+	switch left := expr.(type) {
+	case *ast.Ident:
+		if isDefine {
+			return b.CreateLocalVariable(left.Name)
+		}
+		return b.CreateVariable(left.Name)
+	case *ast.ParenExpr:
+		return b.VisitLeftExpr(left.X, isDefine)
+	case *ast.StarExpr:
+		// pointer is transparent
+		return b.VisitLeftExpr(left.X, isDefine)
+	case *ast.SelectorExpr:
+		return b.CreateMemberCallVariable(b.VisitExpr(left.X), b.EmitConstInst(left.Sel.Name))
+	case *ast.IndexExpr:
+		return b.CreateMemberCallVariable(b.VisitExpr(left.X), b.VisitExpr(left.Index))
+	default:
+		b.NewError(ssa.Error, TAG, UnsupportedExpression(expr))
+		return b.CreateVariable(uuid.NewString())
+	}
+}
+
+// AssignList assign values to variables, handle `a, b = 1, 2`, `a, b = f()` and `v, ok = m[k]`
+func (b *builder) AssignList(variables []*ssa.Variable, values []ssa.Value) {
+	leftLen, rightLen := len(variables), len(values)
+	switch {
+	case leftLen == rightLen:
+		for i := range variables {
+			if values[i] == nil {
+				continue
+			}
+			b.AssignVariable(variables[i], values[i])
+		}
+	case rightLen == 1:
+		value := values[0]
+		if c, ok := value.(*ssa.Call); ok {
+			c.SetName(uuid.NewString())
+			c.Unpack = true
+		}
+		if value == nil {
+			return
+		}
+		for i, variable := range variables {
+			b.AssignVariable(variable, b.ReadMemberCallVariable(value, b.EmitConstInst(i)))
+		}
+	default:
+		b.NewError(ssa.Error, TAG, AssignmentMismatch(leftLen, rightLen))
+	}
+}
+
+// VisitRightExprList build the right values of assignment,
+// `v, ok := m[k]`, `v, ok := x.(T)` and `v, ok := <-ch` will get two values
+func (b *builder) VisitRightExprList(leftLen int, exprs []ast.Expr) []ssa.Value {
+	if leftLen != 2 || len(exprs) != 1 || !isCommaOkExpr(exprs[0]) {
+		return b.VisitExprList(exprs)
+	}
+	value := b.VisitExpr(exprs[0])
+	if value == nil {
+		return b.VisitExprList(exprs)
+	}
+	ok := b.EmitUndefined("ok")
+	ok.SetType(ssa.GetBooleanType())
+	return []ssa.Value{value, ok}
+}
+
+func isCommaOkExpr(expr ast.Expr) bool {
+	switch expr := expr.(type) {
+	case *ast.ParenExpr:
+		return isCommaOkExpr(expr.X)
+	case *ast.IndexExpr, *ast.TypeAssertExpr:
+		return true
+	case *ast.UnaryExpr:
+		return expr.Op == token.ARROW
+	default:
+		return false
+	}
+}
+
+func (b *builder) VisitReturnStmt(stmt *ast.ReturnStmt) {
+	if len(stmt.Results) == 0 && len(b.namedResults) > 0 {
+		// return named result
+		values := make([]ssa.Value, 0, len(b.namedResults))
+		for _, name := range b.namedResults {
+			values = append(values, b.ReadValue(name))
+		}
+		b.EmitReturn(values)
+		return
+	}
+	b.EmitReturn(b.VisitExprList(stmt.Results))
+}
+
+func (b *builder) VisitIfStmt(stmt *ast.IfStmt) {
+	if stmt.Init != nil {
+		b.VisitStmt(stmt.Init)
+	}
+
+	builder := b.CreateIfBuilder()
+	var build func(stmt *ast.IfStmt) func()
+	build = func(stmt *ast.IfStmt) func() {
+		builder.AppendItem(
+			func() ssa.Value {
+				return b.VisitExpr(stmt.Cond)
+			},
+			func() {
+				b.VisitBlockStmt(stmt.Body, false)
+			},
+		)
+
+		switch elseStmt := stmt.Else.(type) {
+		case *ast.BlockStmt:
+			return func() {
+				b.VisitBlockStmt(elseStmt, false)
+			}
+		case *ast.IfStmt:
+			if elseStmt.Init == nil {
+				// "else if"
+				return build(elseStmt)
+			}
+			// `else if init; cond {}` should build init in else block
+			return func() {
+				b.VisitIfStmt(elseStmt)
+			}
+		default:
+			return nil
+		}
+	}
+	builder.SetElse(build(stmt))
+	builder.Build()
+}
+
+func (b *builder) VisitForStmt(stmt *ast.ForStmt) {
+	loop := b.CreateLoopBuilder()
+	if stmt.Init != nil {
+		loop.SetFirst(func() []ssa.Value {
+			b.VisitStmt(stmt.Init)
+			return nil
+		})
+	}
+	loop.SetCondition(func() ssa.Value {
+		var condition ssa.Value
+		if stmt.Cond != nil {
+			condition = b.VisitExpr(stmt.Cond)
+		}
+		if condition == nil {
+			condition = b.EmitConstInst(true)
+		}
+		return condition
+	})
+	if stmt.Post != nil {
+		loop.SetThird(func() []ssa.Value {
+			b.VisitStmt(stmt.Post)
+			return nil
+		})
+	}
+	loop.SetBody(func() {
+		b.VisitBlockStmt(stmt.Body, false)
+	})
+	loop.Finish()
+}
+
+func (b *builder) VisitRangeStmt(stmt *ast.RangeStmt) {
+	loop := b.CreateLoopBuilder()
+	var value ssa.Value
+	loop.SetFirst(func() []ssa.Value {
+		value = b.VisitExpr(stmt.X)
+		return []ssa.Value{value}
+	})
+	loop.SetCondition(func() ssa.Value {
+		key, field, ok := b.EmitNext(value, false)
+		if stmt.Key != nil && !isBlank(stmt.Key) {
+			b.AssignVariable(b.VisitLeftExpr(stmt.Key, stmt.Tok == token.DEFINE), key)
+		}
+		if stmt.Value != nil && !isBlank(stmt.Value) {
+			b.AssignVariable(b.VisitLeftExpr(stmt.Value, stmt.Tok == token.DEFINE), field)
+		}
+		return ok
+	})
+	loop.SetBody(func() {
+		b.VisitBlockStmt(stmt.Body, false)
+	})
+	loop.Finish()
+}
+
+func (b *builder) VisitSwitchStmt(stmt *ast.SwitchStmt) {
+	if stmt.Init != nil {
+		b.VisitStmt(stmt.Init)
+	}
+
+	clauses, defaultClause := splitCaseClause(stmt.Body)
+	switchBuilder := b.BuildSwitch()
+	switchBuilder.AutoBreak = true
+	switchBuilder.BuildCondition(func() ssa.Value {
+		if stmt.Tag == nil {
+			// `switch { case a > 1: }` is the same as `switch true {}`
+			return b.EmitConstInst(true)
+		}
+		return b.VisitExpr(stmt.Tag)
+	})
+	switchBuilder.BuildCaseSize(len(clauses))
+	switchBuilder.SetCase(func(i int) []ssa.Value {
+		return b.VisitExprList(clauses[i].List)
+	})
+	switchBuilder.BuildBody(func(i int) {
+		b.VisitStmtList(clauses[i].Body)
+	})
+	if defaultClause != nil {
+		switchBuilder.BuildDefault(func() {
+			b.VisitStmtList(defaultClause.Body)
+		})
+	}
+	switchBuilder.Finish()
+}
+
+// VisitTypeSwitchStmt build `switch v := x.(type) {}`, the case type will be type value
+func (b *builder) VisitTypeSwitchStmt(stmt *ast.TypeSwitchStmt) {
+	if stmt.Init != nil {
+		b.VisitStmt(stmt.Init)
+	}
+
+	var name string
+	var assert *ast.TypeAssertExpr
+	switch assign := stmt.Assign.(type) {
+	case *ast.AssignStmt:
+		if len(assign.Lhs) == 1 && len(assign.Rhs) == 1 {
+			if ident, ok := assign.Lhs[0].(*ast.Ident); ok {
+				name = ident.Name
+			}
+			assert, _ = assign.Rhs[0].(*ast.TypeAssertExpr)
+		}
+	case *ast.ExprStmt:
+		assert, _ = assign.X.(*ast.TypeAssertExpr)
+	}
+	if assert == nil {
+		b.NewError(ssa.Error, TAG, UnsupportedStatement(stmt))
+		return
+	}
+
+	var value ssa.Value
+	clauses, defaultClause := splitCaseClause(stmt.Body)
+	bindValue := func(clause *ast.CaseClause) {
+		if name == "" || name == "_" {
+			return
+		}
+		v := value
+		if len(clause.List) == 1 {
+			v = b.emitTypeCast(value, b.VisitType(clause.List[0]))
+		}
+		b.AssignVariable(b.CreateLocalVariable(name), v)
+	}
+
+	switchBuilder := b.BuildSwitch()
+	switchBuilder.AutoBreak = true
+	switchBuilder.BuildCondition(func() ssa.Value {
+		value = b.VisitExpr(assert.X)
+		return value
+	})
+	switchBuilder.BuildCaseSize(len(clauses))
+	switchBuilder.SetCase(func(i int) []ssa.Value {
+		values := make([]ssa.Value, 0, len(clauses[i].List))
+		for _, typ := range clauses[i].List {
+			values = append(values, b.emitTypeValue(b.VisitType(typ)))
+		}
+		return values
+	})
+	switchBuilder.BuildBody(func(i int) {
+		bindValue(clauses[i])
+		b.VisitStmtList(clauses[i].Body)
+	})
+	if defaultClause != nil {
+		switchBuilder.BuildDefault(func() {
+			bindValue(defaultClause)
+			b.VisitStmtList(defaultClause.Body)
+		})
+	}
+	switchBuilder.Finish()
+}
+
+// VisitSelectStmt build select like switch, every communication is a case
+func (b *builder) VisitSelectStmt(stmt *ast.SelectStmt) {
+	var clauses []*ast.CommClause
+	var defaultClause *ast.CommClause
+	for _, raw := range stmt.Body.List {
+		clause, ok := raw.(*ast.CommClause)
+		if !ok {
+			continue
+		}
+		if clause.Comm == nil {
+			defaultClause = clause
+		} else {
+			clauses = append(clauses, clause)
+		}
+	}
+
+	switchBuilder := b.BuildSwitch()
+	switchBuilder.AutoBreak = true
+	switchBuilder.BuildCaseSize(len(clauses))
+	switchBuilder.SetCase(func(i int) []ssa.Value {
+		return []ssa.Value{b.EmitConstInst(i)}
+	})
+	switchBuilder.BuildBody(func(i int) {
+		b.VisitStmt(clauses[i].Comm)
+		b.VisitStmtList(clauses[i].Body)
+	})
+	if defaultClause != nil {
+		switchBuilder.BuildDefault(func() {
+			b.VisitStmtList(defaultClause.Body)
+		})
+	}
+	switchBuilder.Finish()
+}
+
+func (b *builder) VisitBranchStmt(stmt *ast.BranchStmt) {
+	switch stmt.Tok {
+	case token.BREAK:
+		if !b.Break() {
+			b.NewError(ssa.Error, TAG, UnexpectedBranchStmt(stmt.Tok.String()))
+		}
+	case token.CONTINUE:
+		if !b.Continue() {
+			b.NewError(ssa.Error, TAG, UnexpectedBranchStmt(stmt.Tok.String()))
+		}
+	case token.FALLTHROUGH:
+		if !b.Fallthrough() {
+			b.NewError(ssa.Error, TAG, UnexpectedBranchStmt(stmt.Tok.String()))
+		}
+	case token.GOTO:
+		b.NewError(ssa.Warn, TAG, UnsupportedStatement(stmt))
+	}
+}
+
+func splitCaseClause(body *ast.BlockStmt) ([]*ast.CaseClause, *ast.CaseClause) {
+	var clauses []*ast.CaseClause
+	var defaultClause *ast.CaseClause
+	if body == nil {
+		return nil, nil
+	}
+	for _, raw := range body.List {
+		clause, ok := raw.(*ast.CaseClause)
+		if !ok {
+			continue
+		}
+		if clause.List == nil {
+			defaultClause = clause
+		} else {
+			clauses = append(clauses, clause)
+		}
+	}
+	return clauses, defaultClause
+}
+
+func isBlank(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == "_"
+}
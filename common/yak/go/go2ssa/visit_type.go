@@ -0,0 +1,240 @@
+package go2ssa
+
+import (
+	"go/ast"
+	"strings"
+
+	"github.com/yaklang/yaklang/common/yak/ssa"
+)
+
+var basicTypes = map[string]ssa.Type{
+	"bool":       ssa.GetBooleanType(),
+	"string":     ssa.GetStringType(),
+	"byte":       ssa.GetByteType(),
+	"rune":       ssa.GetNumberType(),
+	"int":        ssa.GetNumberType(),
+	"int8":       ssa.GetNumberType(),
+	"int16":      ssa.GetNumberType(),
+	"int32":      ssa.GetNumberType(),
+	"int64":      ssa.GetNumberType(),
+	"uint":       ssa.GetNumberType(),
+	"uint8":      ssa.GetByteType(),
+	"uint16":     ssa.GetNumberType(),
+	"uint32":     ssa.GetNumberType(),
+	"uint64":     ssa.GetNumberType(),
+	"uintptr":    ssa.GetNumberType(),
+	"float32":    ssa.GetNumberType(),
+	"float64":    ssa.GetNumberType(),
+	"complex64":  ssa.GetNumberType(),
+	"complex128": ssa.GetNumberType(),
+	"error":      ssa.BasicTypes[ssa.ErrorTypeKind],
+	"any":        ssa.GetAnyType(),
+}
+
+// VisitTypeSpec create class blue print for named type,
+// struct field will be normal member, embedded type will be parent class
+func (b *builder) VisitTypeSpec(spec *ast.TypeSpec) {
+	if b == nil || spec == nil || spec.Name == nil {
+		return
+	}
+	recoverRange := b.SetRangeFromNode(spec)
+	defer recoverRange()
+
+	class := b.GetOrCreateClassBluePrint(spec.Name.Name)
+	switch typ := spec.Type.(type) {
+	case *ast.StructType:
+		if typ.Fields == nil {
+			return
+		}
+		for _, field := range typ.Fields.List {
+			if len(field.Names) == 0 {
+				// embedded field
+				if name := typeName(field.Type); name != "" {
+					class.AddParentClass(b.GetOrCreateClassBluePrint(name))
+					// embedded field named by the type name without package
+					b.structFields[class.Name] = append(b.structFields[class.Name], embeddedFieldName(name))
+				}
+				continue
+			}
+			fieldType := b.VisitType(field.Type)
+			for _, name := range field.Names {
+				class.AddNormalMemberOnlyType(name.Name, fieldType)
+				b.structFields[class.Name] = append(b.structFields[class.Name], name.Name)
+			}
+		}
+	case *ast.InterfaceType:
+		if typ.Methods == nil {
+			return
+		}
+		for _, method := range typ.Methods.List {
+			if len(method.Names) == 0 {
+				// embedded interface
+				if name := typeName(method.Type); name != "" {
+					class.AddParentClass(b.GetOrCreateClassBluePrint(name))
+				}
+			}
+		}
+	}
+}
+
+func (b *builder) GetOrCreateClassBluePrint(name string) *ssa.ClassBluePrint {
+	if class := b.GetClassBluePrint(name); class != nil {
+		return class
+	}
+	return b.CreateClassBluePrint(name)
+}
+
+func embeddedFieldName(name string) string {
+	if index := strings.LastIndex(name, "."); index >= 0 {
+		return name[index+1:]
+	}
+	return name
+}
+
+// typeName get the name of named type, `*pkg.T[int]` => `pkg.T`
+func typeName(expr ast.Expr) string {
+	switch typ := expr.(type) {
+	case *ast.Ident:
+		return typ.Name
+	case *ast.StarExpr:
+		return typeName(typ.X)
+	case *ast.ParenExpr:
+		return typeName(typ.X)
+	case *ast.SelectorExpr:
+		if pkg := typeName(typ.X); pkg != "" {
+			return pkg + "." + typ.Sel.Name
+		}
+		return typ.Sel.Name
+	case *ast.IndexExpr:
+		return typeName(typ.X)
+	case *ast.IndexListExpr:
+		return typeName(typ.X)
+	default:
+		return ""
+	}
+}
+
+func (b *builder) VisitType(expr ast.Expr) ssa.Type {
+	if b == nil || expr == nil {
+		return ssa.GetAnyType()
+	}
+
+	switch typ := expr.(type) {
+	case *ast.Ident:
+		if t, ok := basicTypes[typ.Name]; ok {
+			return t
+		}
+		if class := b.GetClassBluePrint(typ.Name); class != nil {
+			return class
+		}
+	case *ast.StarExpr:
+		return b.VisitType(typ.X)
+	case *ast.ParenExpr:
+		return b.VisitType(typ.X)
+	case *ast.Ellipsis:
+		return ssa.NewSliceType(b.VisitType(typ.Elt))
+	case *ast.ArrayType:
+		elem := b.VisitType(typ.Elt)
+		if elem.GetTypeKind() == ssa.ByteTypeKind {
+			return ssa.GetBytesType()
+		}
+		return ssa.NewSliceType(elem)
+	case *ast.MapType:
+		return ssa.NewMapType(b.VisitType(typ.Key), b.VisitType(typ.Value))
+	case *ast.ChanType:
+		return ssa.NewChanType(b.VisitType(typ.Value))
+	case *ast.FuncType:
+		return b.VisitFuncType(typ)
+	case *ast.IndexExpr:
+		return b.VisitType(typ.X)
+	case *ast.IndexListExpr:
+		return b.VisitType(typ.X)
+	case *ast.SelectorExpr:
+		if class := b.GetClassBluePrint(typeName(typ)); class != nil {
+			return class
+		}
+	}
+	return ssa.GetAnyType()
+}
+
+func (b *builder) VisitFuncType(typ *ast.FuncType) *ssa.FunctionType {
+	var params, results []ssa.Type
+	isVariadic := false
+	if typ.Params != nil {
+		for _, field := range typ.Params.List {
+			if _, ok := field.Type.(*ast.Ellipsis); ok {
+				isVariadic = true
+			}
+			t := b.VisitType(field.Type)
+			for i := 0; i < fieldCount(field); i++ {
+				params = append(params, t)
+			}
+		}
+	}
+	if typ.Results != nil {
+		for _, field := range typ.Results.List {
+			t := b.VisitType(field.Type)
+			for i := 0; i < fieldCount(field); i++ {
+				results = append(results, t)
+			}
+		}
+	}
+	return ssa.NewFunctionTypeDefine("", params, results, isVariadic)
+}
+
+// fieldCount get the count of names in field, unnamed field count as one
+func fieldCount(field *ast.Field) int {
+	if len(field.Names) == 0 {
+		return 1
+	}
+	return len(field.Names)
+}
+
+// isTypeExpr check the expression is a type, `T(x)` is type conversion but not function call
+func (b *builder) isTypeExpr(expr ast.Expr) bool {
+	switch typ := expr.(type) {
+	case *ast.ArrayType, *ast.MapType, *ast.ChanType, *ast.FuncType, *ast.InterfaceType, *ast.StructType:
+		return true
+	case *ast.ParenExpr:
+		return b.isTypeExpr(typ.X)
+	case *ast.StarExpr:
+		return b.isTypeExpr(typ.X)
+	case *ast.Ident:
+		if _, ok := basicTypes[typ.Name]; ok {
+			return b.PeekValueInThisFunction(typ.Name) == nil
+		}
+		return b.GetClassBluePrint(typ.Name) != nil
+	default:
+		return false
+	}
+}
+
+// the class blue print type need the value has been emitted in function,
+// so emit with any type first, and set the real type later
+
+func (b *builder) emitTypeCast(value ssa.Value, typ ssa.Type) ssa.Value {
+	cast := b.EmitTypeCast(value, ssa.GetAnyType())
+	if cast == nil {
+		return nil
+	}
+	cast.SetType(typ)
+	return cast
+}
+
+func (b *builder) emitTypeValue(typ ssa.Type) ssa.Value {
+	value := b.EmitTypeValue(ssa.GetAnyType())
+	if value == nil {
+		return nil
+	}
+	value.SetType(typ)
+	return value
+}
+
+func (b *builder) emitMakeWithType(typ ssa.Type, length, capacity ssa.Value) ssa.Value {
+	obj := b.EmitMakeBuildWithType(ssa.GetAnyType(), length, capacity)
+	if obj == nil {
+		return nil
+	}
+	obj.SetType(typ)
+	return obj
+}
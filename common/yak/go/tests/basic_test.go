@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"testing"
+
+	test "github.com/yaklang/yaklang/common/yak/ssaapi/test/ssatest"
+)
+
+func TestGo_Basic(t *testing.T) {
+	t.Run("assign and binary", func(t *testing.T) {
+		test.CheckPrintlnValue(CreateGoProgram(`
+	a := 1
+	b := a + 2
+	println(b)
+	var c, d = "c", 2
+	d += b
+	println(c)
+	println(d)
+`), []string{"3", `"c"`, "5"}, t)
+	})
+
+	t.Run("package const with iota", func(t *testing.T) {
+		test.CheckPrintlnValue(`package main
+
+const (
+	A = iota
+	B
+	C = "c"
+)
+
+func main() {
+	println(A)
+	println(B)
+	println(C)
+}`, []string{"0", "1", `"c"`}, t)
+	})
+
+	t.Run("if and phi", func(t *testing.T) {
+		test.CheckPrintlnValue(CreateGoProgram(`
+	a := 1
+	if b := cond(); b {
+		a = 2
+	} else if cond() {
+		a = 3
+	}
+	println(a)
+`), []string{"phi(a)[2,3,1]"}, t)
+	})
+
+	t.Run("for loop", func(t *testing.T) {
+		test.CheckPrintlnValue(CreateGoProgram(`
+	a := 0
+	for i := 0; i < 10; i++ {
+		a = a + i
+	}
+	println(a)
+`), []string{"phi(a)[0,add(a, phi(i)[0,add(i, 1)])]"}, t)
+	})
+
+	t.Run("range loop", func(t *testing.T) {
+		test.CheckPrintlnValue(CreateGoProgram(`
+	for k, v := range m {
+		println(k)
+		println(v)
+	}
+`), []string{"Undefined-k(valid)", "Undefined-v(valid)"}, t)
+	})
+
+	t.Run("switch", func(t *testing.T) {
+		test.CheckPrintlnValue(CreateGoProgram(`
+	a := 0
+	switch x {
+	case 1:
+		a = 1
+	case 2, 3:
+		a = 2
+	default:
+		a = 3
+	}
+	println(a)
+`), []string{"phi(a)[1,2,3]"}, t)
+	})
+}
@@ -0,0 +1,108 @@
+package tests
+
+import (
+	"testing"
+
+	test "github.com/yaklang/yaklang/common/yak/ssaapi/test/ssatest"
+)
+
+func TestGo_Func(t *testing.T) {
+	t.Run("function declared after caller", func(t *testing.T) {
+		test.CheckPrintlnValue(`package main
+
+func main() {
+	println(add(1, 2))
+}
+
+func add(a, b int) int {
+	println(a)
+	return a + b
+}`, []string{"Function-add(1,2)", "Parameter-a"}, t)
+	})
+
+	t.Run("multiple return", func(t *testing.T) {
+		test.CheckPrintlnValue(`package main
+
+func f() (int, string) {
+	return 1, "a"
+}
+
+func main() {
+	a, b := f()
+	println(a)
+	println(b)
+}`, []string{"Undefined-a(valid)", "Undefined-b(valid)"}, t)
+	})
+
+	t.Run("named result", func(t *testing.T) {
+		test.CheckPrintlnValue(`package main
+
+func f() (a int, err error) {
+	a = 1
+	return
+}
+
+func main() {
+	println(f())
+}`, []string{"Function-f()"}, t)
+	})
+
+	t.Run("closure", func(t *testing.T) {
+		test.CheckPrintlnValue(CreateGoProgram(`
+	a := 1
+	f := func(b int) int {
+		println(a)
+		return a + b
+	}
+	println(f(2))
+`), []string{"FreeValue-a", "Function-f(2) binding[1]"}, t)
+	})
+
+	t.Run("goroutine and defer", func(t *testing.T) {
+		test.CheckPrintlnValue(CreateGoProgram(`
+	ch := make(chan int)
+	go func() {
+		ch <- 1
+	}()
+	defer func() {
+		if err := recover(); err != nil {
+			println(err)
+		}
+	}()
+	println(<-ch)
+`), []string{"recover", "chan(Function-make(typeValue(chan number)))"}, t)
+	})
+}
+
+func TestGo_Method(t *testing.T) {
+	t.Run("struct method", func(t *testing.T) {
+		test.CheckPrintlnValue(`package main
+
+type A struct {
+	Name string
+}
+
+func (a *A) Get() string {
+	return a.Name
+}
+
+func main() {
+	a := &A{Name: "name"}
+	println(a.Name)
+	println(a.Get())
+}`, []string{`"name"`, `Undefined-a.Get(valid)(make(ClassBluePrint: A)) member["name"]`}, t)
+	})
+
+	t.Run("positional struct literal", func(t *testing.T) {
+		test.CheckPrintlnValue(`package main
+
+type A struct {
+	X, Y int
+}
+
+func main() {
+	a := A{1, 2}
+	println(a.Y)
+}`, []string{"2"}, t)
+	})
+}
@@ -0,0 +1,29 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/yaklang/yaklang/common/yak/ssaapi"
+	test "github.com/yaklang/yaklang/common/yak/ssaapi/test/ssatest"
+)
+
+func TestGo_SyntaxFlow_CommandInjection(t *testing.T) {
+	code := `package main
+
+import (
+	"net/http"
+	e "os/exec"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	cmd := r.URL.Query().Get("cmd")
+	e.Command("sh", "-c", cmd).Run()
+}
+
+func main() {
+	http.HandleFunc("/", handler)
+}`
+	test.CheckSyntaxFlowContain(t, code, `exec.Command(* #-> as $src)`, map[string][]string{
+		"src": {`"sh"`, "Parameter-r"},
+	}, ssaapi.WithLanguage("go"))
+}
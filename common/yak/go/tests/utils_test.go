@@ -0,0 +1,21 @@
+package tests
+
+import (
+	"fmt"
+
+	"github.com/yaklang/yaklang/common/yak/go/go2ssa"
+	test "github.com/yaklang/yaklang/common/yak/ssaapi/test/ssatest"
+)
+
+func init() {
+	test.SetLanguage("go", go2ssa.Builder)
+}
+
+func CreateGoProgram(code string) string {
+	template := `package main
+
+func main() {
+	%s
+}`
+	return fmt.Sprintf(template, code)
+}
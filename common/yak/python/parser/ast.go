@@ -0,0 +1,442 @@
+package pythonparser
+
+type Node interface {
+	GetStart() Pos
+	GetEnd() Pos
+}
+
+type Stmt interface {
+	Node
+	stmtNode()
+}
+
+type Expr interface {
+	Node
+	exprNode()
+}
+
+type node struct {
+	Start Pos
+	End   Pos
+}
+
+func (n *node) GetStart() Pos { return n.Start }
+func (n *node) GetEnd() Pos   { return n.End }
+
+func (n *node) setRange(start, end Pos) {
+	n.Start = start
+	n.End = end
+}
+
+type rangeSetter interface {
+	setRange(start, end Pos)
+}
+
+type stmt struct{ node }
+
+func (*stmt) stmtNode() {}
+
+type expr struct{ node }
+
+func (*expr) exprNode() {}
+
+// ========================================== Module ==========================================
+
+type Module struct {
+	node
+	Body []Stmt
+}
+
+// ========================================== Statement ==========================================
+
+type (
+	// ExprStmt is expression used as statement, like function call
+	ExprStmt struct {
+		stmt
+		Value Expr
+	}
+
+	// Assign is `a = b = value`, Targets is [a, b]
+	Assign struct {
+		stmt
+		Targets []Expr
+		Value   Expr
+	}
+
+	// AugAssign is `a += value`, Op is "+"
+	AugAssign struct {
+		stmt
+		Target Expr
+		Op     string
+		Value  Expr
+	}
+
+	// AnnAssign is `a: int = value`, Value can be nil
+	AnnAssign struct {
+		stmt
+		Target     Expr
+		Annotation Expr
+		Value      Expr
+	}
+
+	Pass     struct{ stmt }
+	Break    struct{ stmt }
+	Continue struct{ stmt }
+
+	Return struct {
+		stmt
+		Value Expr
+	}
+
+	Raise struct {
+		stmt
+		Exc   Expr
+		Cause Expr
+	}
+
+	Delete struct {
+		stmt
+		Targets []Expr
+	}
+
+	Global struct {
+		stmt
+		Names []string
+	}
+
+	Nonlocal struct {
+		stmt
+		Names []string
+	}
+
+	Assert struct {
+		stmt
+		Test Expr
+		Msg  Expr
+	}
+
+	// Alias is `name as asname` in import statement, Name can be dotted like `os.path`
+	Alias struct {
+		node
+		Name   string
+		AsName string
+	}
+
+	Import struct {
+		stmt
+		Names []*Alias
+	}
+
+	// ImportFrom is `from ..module import a as b`, Level is the count of leading dots,
+	// Names is [*] for `from module import *`
+	ImportFrom struct {
+		stmt
+		Module string
+		Names  []*Alias
+		Level  int
+	}
+
+	If struct {
+		stmt
+		Test   Expr
+		Body   []Stmt
+		Orelse []Stmt
+	}
+
+	While struct {
+		stmt
+		Test   Expr
+		Body   []Stmt
+		Orelse []Stmt
+	}
+
+	For struct {
+		stmt
+		Target  Expr
+		Iter    Expr
+		Body    []Stmt
+		Orelse  []Stmt
+		IsAsync bool
+	}
+
+	ExceptHandler struct {
+		node
+		Type Expr
+		Name string
+		Body []Stmt
+	}
+
+	Try struct {
+		stmt
+		Body      []Stmt
+		Handlers  []*ExceptHandler
+		Orelse    []Stmt
+		Finalbody []Stmt
+	}
+
+	WithItem struct {
+		node
+		ContextExpr  Expr
+		OptionalVars Expr
+	}
+
+	With struct {
+		stmt
+		Items   []*WithItem
+		Body    []Stmt
+		IsAsync bool
+	}
+
+	// MatchCase is `case pattern if guard:`, the pattern is parsed as expression,
+	// `case x as y` is NamedExpr with target y, wildcard `_` is Name
+	MatchCase struct {
+		node
+		Pattern Expr
+		Guard   Expr
+		Body    []Stmt
+	}
+
+	Match struct {
+		stmt
+		Subject Expr
+		Cases   []*MatchCase
+	}
+
+	FunctionDef struct {
+		stmt
+		Name          string
+		Args          *Arguments
+		Body          []Stmt
+		DecoratorList []Expr
+		Returns       Expr
+		IsAsync       bool
+	}
+
+	ClassDef struct {
+		stmt
+		Name          string
+		Bases         []Expr
+		Keywords      []*Keyword
+		Body          []Stmt
+		DecoratorList []Expr
+	}
+)
+
+// Arg is a parameter of function
+type Arg struct {
+	node
+	Name       string
+	Annotation Expr
+}
+
+// Arguments is the parameter list of function or lambda,
+// Defaults is aligned to the tail of Args, KwDefaults is aligned to KwOnlyArgs and may contain nil
+type Arguments struct {
+	node
+	Args       []*Arg
+	Defaults   []Expr
+	VarArg     *Arg
+	KwOnlyArgs []*Arg
+	KwDefaults []Expr
+	KwArg      *Arg
+}
+
+// ========================================== Expression ==========================================
+
+type ConstantKind int
+
+const (
+	ConstantNone ConstantKind = iota
+	ConstantBool
+	ConstantInt
+	ConstantFloat
+	ConstantComplex
+	ConstantString
+	ConstantBytes
+	ConstantEllipsis
+)
+
+type (
+	Name struct {
+		expr
+		Id string
+	}
+
+	// Constant is literal, Value is the decoded string of string literal, or the raw text of number
+	Constant struct {
+		expr
+		Kind  ConstantKind
+		Value string
+		Bool  bool
+	}
+
+	// JoinedStr is f-string, Values contains *Constant and *FormattedValue
+	JoinedStr struct {
+		expr
+		Values []Expr
+	}
+
+	FormattedValue struct {
+		expr
+		Value      Expr
+		Conversion string
+		FormatSpec string
+	}
+
+	// BoolOp is `a and b and c`, Op is "and" or "or"
+	BoolOp struct {
+		expr
+		Op     string
+		Values []Expr
+	}
+
+	BinOp struct {
+		expr
+		Left  Expr
+		Op    string
+		Right Expr
+	}
+
+	// UnaryOp Op is one of "not", "-", "+", "~"
+	UnaryOp struct {
+		expr
+		Op      string
+		Operand Expr
+	}
+
+	// Compare is `a < b <= c`, Ops contains "<", "in", "not in", "is", "is not" ...
+	Compare struct {
+		expr
+		Left        Expr
+		Ops         []string
+		Comparators []Expr
+	}
+
+	Lambda struct {
+		expr
+		Args *Arguments
+		Body Expr
+	}
+
+	IfExp struct {
+		expr
+		Test   Expr
+		Body   Expr
+		Orelse Expr
+	}
+
+	// NamedExpr is walrus `target := value`
+	NamedExpr struct {
+		expr
+		Target Expr
+		Value  Expr
+	}
+
+	Await struct {
+		expr
+		Value Expr
+	}
+
+	Yield struct {
+		expr
+		Value Expr
+	}
+
+	YieldFrom struct {
+		expr
+		Value Expr
+	}
+
+	// Keyword is `name=value` in call, Arg is empty for `**kwargs`
+	Keyword struct {
+		node
+		Arg   string
+		Value Expr
+	}
+
+	Call struct {
+		expr
+		Func     Expr
+		Args     []Expr
+		Keywords []*Keyword
+	}
+
+	Attribute struct {
+		expr
+		Value Expr
+		Attr  string
+	}
+
+	Subscript struct {
+		expr
+		Value Expr
+		Slice Expr
+	}
+
+	Slice struct {
+		expr
+		Lower Expr
+		Upper Expr
+		Step  Expr
+	}
+
+	Starred struct {
+		expr
+		Value Expr
+	}
+
+	List struct {
+		expr
+		Elts []Expr
+	}
+
+	Tuple struct {
+		expr
+		Elts []Expr
+	}
+
+	Set struct {
+		expr
+		Elts []Expr
+	}
+
+	// Dict Keys contains nil for `**other`
+	Dict struct {
+		expr
+		Keys   []Expr
+		Values []Expr
+	}
+
+	Comprehension struct {
+		node
+		Target  Expr
+		Iter    Expr
+		Ifs     []Expr
+		IsAsync bool
+	}
+
+	ListComp struct {
+		expr
+		Elt        Expr
+		Generators []*Comprehension
+	}
+
+	SetComp struct {
+		expr
+		Elt        Expr
+		Generators []*Comprehension
+	}
+
+	GeneratorExp struct {
+		expr
+		Elt        Expr
+		Generators []*Comprehension
+	}
+
+	DictComp struct {
+		expr
+		Key        Expr
+		Value      Expr
+		Generators []*Comprehension
+	}
+)
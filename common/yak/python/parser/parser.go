@@ -0,0 +1,842 @@
+package pythonparser
+
+import (
+	"fmt"
+	"strings"
+)
+
+type SyntaxError struct {
+	Pos Pos
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("line %d:%d %s", e.Pos.Line, e.Pos.Column, e.Msg)
+}
+
+// SyntaxErrors is all errors in parsing, the parser will skip the broken statement and continue
+type SyntaxErrors []*SyntaxError
+
+func (errs SyntaxErrors) Error() string {
+	msgs := make([]string, 0, len(errs))
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+var keywords = map[string]struct{}{
+	"False": {}, "None": {}, "True": {}, "and": {}, "as": {}, "assert": {}, "async": {}, "await": {},
+	"break": {}, "class": {}, "continue": {}, "def": {}, "del": {}, "elif": {}, "else": {}, "except": {},
+	"finally": {}, "for": {}, "from": {}, "global": {}, "if": {}, "import": {}, "in": {}, "is": {},
+	"lambda": {}, "nonlocal": {}, "not": {}, "or": {}, "pass": {}, "raise": {}, "return": {}, "try": {},
+	"while": {}, "with": {}, "yield": {},
+}
+
+func IsKeyword(name string) bool {
+	_, ok := keywords[name]
+	return ok
+}
+
+// bailout is used to stop parsing current statement by panic
+type bailout struct{}
+
+type parser struct {
+	tokens []*Token
+	pos    int
+	errors SyntaxErrors
+}
+
+// Parse parse python source code into module, the module is always returned
+// even there are syntax errors, the broken statements are dropped.
+func Parse(src string) (*Module, error) {
+	tokens, lexErrs := Tokenize(src)
+	p := &parser{tokens: tokens}
+	p.errors = append(p.errors, lexErrs...)
+	module := p.parseModule()
+	if len(p.errors) > 0 {
+		return module, p.errors
+	}
+	return module, nil
+}
+
+// ========================================== token helper ==========================================
+
+func (p *parser) peek() *Token {
+	return p.peekN(0)
+}
+
+func (p *parser) peekN(n int) *Token {
+	if p.pos+n >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.pos+n]
+}
+
+func (p *parser) next() *Token {
+	tok := p.peek()
+	if p.pos < len(p.tokens)-1 {
+		p.pos++
+	}
+	return tok
+}
+
+// lastEnd is the end position of the last consumed token
+func (p *parser) lastEnd() Pos {
+	if p.pos == 0 {
+		return p.peek().Start
+	}
+	return p.tokens[p.pos-1].End
+}
+
+func (p *parser) atOp(ops ...string) bool {
+	tok := p.peek()
+	if tok.Kind != OP {
+		return false
+	}
+	for _, op := range ops {
+		if tok.Value == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) atKeyword(words ...string) bool {
+	tok := p.peek()
+	if tok.Kind != NAME {
+		return false
+	}
+	for _, word := range words {
+		if tok.Value == word {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) acceptOp(op string) bool {
+	if p.atOp(op) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) acceptKeyword(word string) bool {
+	if p.atKeyword(word) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) errorf(pos Pos, format string, args ...any) {
+	p.errors = append(p.errors, &SyntaxError{Pos: pos, Msg: fmt.Sprintf(format, args...)})
+}
+
+// fail record the error and stop parsing current statement
+func (p *parser) fail(format string, args ...any) {
+	tok := p.peek()
+	p.errorf(tok.Start, "%s, got %s", fmt.Sprintf(format, args...), describe(tok))
+	panic(bailout{})
+}
+
+func describe(tok *Token) string {
+	switch tok.Kind {
+	case NEWLINE, INDENT, DEDENT, EOF:
+		return tok.Kind.String()
+	default:
+		return fmt.Sprintf("%q", tok.Value)
+	}
+}
+
+func (p *parser) expectOp(op string) *Token {
+	if !p.atOp(op) {
+		p.fail("expect %q", op)
+	}
+	return p.next()
+}
+
+func (p *parser) expectKeyword(word string) *Token {
+	if !p.atKeyword(word) {
+		p.fail("expect %q", word)
+	}
+	return p.next()
+}
+
+func (p *parser) expectName() *Token {
+	tok := p.peek()
+	if tok.Kind != NAME || IsKeyword(tok.Value) {
+		p.fail("expect identifier")
+	}
+	return p.next()
+}
+
+func (p *parser) expectNewline() {
+	switch p.peek().Kind {
+	case NEWLINE:
+		p.next()
+	case EOF:
+	default:
+		p.fail("expect newline")
+	}
+}
+
+func finish[T rangeSetter](p *parser, n T, start Pos) T {
+	n.setRange(start, p.lastEnd())
+	return n
+}
+
+// recover skip the tokens of broken statement, include the indented block follow it
+func (p *parser) recover() {
+	depth := 0
+	for {
+		tok := p.peek()
+		switch tok.Kind {
+		case EOF:
+			return
+		case INDENT:
+			depth++
+		case DEDENT:
+			if depth == 0 {
+				return
+			}
+			depth--
+			if depth == 0 {
+				p.next()
+				if p.peek().Kind != INDENT {
+					return
+				}
+				continue
+			}
+		case NEWLINE:
+			if depth == 0 {
+				p.next()
+				if p.peek().Kind != INDENT {
+					return
+				}
+				continue
+			}
+		}
+		p.next()
+	}
+}
+
+// ========================================== statement ==========================================
+
+func (p *parser) parseModule() *Module {
+	start := p.peek().Start
+	module := &Module{}
+	module.Body = p.parseStatements(func() bool { return p.peek().Kind == EOF })
+	module.setRange(start, p.lastEnd())
+	return module
+}
+
+// parseStatements parse statements until stop return true
+func (p *parser) parseStatements(stop func() bool) []Stmt {
+	var stmts []Stmt
+	for !stop() {
+		switch p.peek().Kind {
+		case NEWLINE:
+			p.next()
+			continue
+		case INDENT:
+			p.errorf(p.peek().Start, "unexpected indent")
+			p.recover()
+			continue
+		case DEDENT:
+			// unmatched dedent in module level
+			p.next()
+			continue
+		}
+		before := p.pos
+		stmts = append(stmts, p.parseStatementSafe()...)
+		if p.pos == before {
+			// avoid dead loop
+			p.next()
+		}
+	}
+	return stmts
+}
+
+func (p *parser) parseStatementSafe() (stmts []Stmt) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(bailout); !ok {
+				panic(r)
+			}
+			stmts = nil
+			p.recover()
+		}
+	}()
+	return p.parseStatement()
+}
+
+func (p *parser) parseStatement() []Stmt {
+	tok := p.peek()
+	if tok.Kind == OP && tok.Value == "@" {
+		return []Stmt{p.parseDecorated()}
+	}
+	if tok.Kind == NAME {
+		switch tok.Value {
+		case "if":
+			return []Stmt{p.parseIf()}
+		case "while":
+			return []Stmt{p.parseWhile()}
+		case "for":
+			return []Stmt{p.parseFor(tok.Start, false)}
+		case "try":
+			return []Stmt{p.parseTry()}
+		case "with":
+			return []Stmt{p.parseWith(tok.Start, false)}
+		case "def":
+			return []Stmt{p.parseFunctionDef(tok.Start, nil, false)}
+		case "class":
+			return []Stmt{p.parseClassDef(tok.Start, nil)}
+		case "match":
+			if p.isMatchStatement() {
+				return []Stmt{p.parseMatch()}
+			}
+		case "async":
+			p.next()
+			switch {
+			case p.atKeyword("def"):
+				return []Stmt{p.parseFunctionDef(tok.Start, nil, true)}
+			case p.atKeyword("for"):
+				return []Stmt{p.parseFor(tok.Start, true)}
+			case p.atKeyword("with"):
+				return []Stmt{p.parseWith(tok.Start, true)}
+			default:
+				p.fail("expect def/for/with after async")
+			}
+		}
+	}
+	return p.parseSimpleStatements()
+}
+
+// parseSimpleStatements parse `a; b; c NEWLINE`
+func (p *parser) parseSimpleStatements() []Stmt {
+	var stmts []Stmt
+	for {
+		stmts = append(stmts, p.parseSimpleStatement())
+		if !p.acceptOp(";") {
+			break
+		}
+		if kind := p.peek().Kind; kind == NEWLINE || kind == EOF {
+			break
+		}
+	}
+	p.expectNewline()
+	return stmts
+}
+
+func (p *parser) parseSimpleStatement() Stmt {
+	tok := p.peek()
+	start := tok.Start
+	if tok.Kind == NAME {
+		switch tok.Value {
+		case "pass":
+			p.next()
+			return finish(p, &Pass{}, start)
+		case "break":
+			p.next()
+			return finish(p, &Break{}, start)
+		case "continue":
+			p.next()
+			return finish(p, &Continue{}, start)
+		case "return":
+			p.next()
+			ret := &Return{}
+			if !p.atSimpleStatementEnd() {
+				ret.Value = p.parseTestListStarExpr()
+			}
+			return finish(p, ret, start)
+		case "raise":
+			p.next()
+			raise := &Raise{}
+			if !p.atSimpleStatementEnd() {
+				raise.Exc = p.parseTest()
+				if p.acceptKeyword("from") {
+					raise.Cause = p.parseTest()
+				}
+			}
+			return finish(p, raise, start)
+		case "global", "nonlocal":
+			p.next()
+			var names []string
+			for {
+				names = append(names, p.expectName().Value)
+				if !p.acceptOp(",") {
+					break
+				}
+			}
+			if tok.Value == "global" {
+				return finish(p, &Global{Names: names}, start)
+			}
+			return finish(p, &Nonlocal{Names: names}, start)
+		case "del":
+			p.next()
+			del := &Delete{}
+			for {
+				del.Targets = append(del.Targets, p.parseExpr())
+				if !p.acceptOp(",") || p.atSimpleStatementEnd() {
+					break
+				}
+			}
+			return finish(p, del, start)
+		case "assert":
+			p.next()
+			assert := &Assert{Test: p.parseTest()}
+			if p.acceptOp(",") {
+				assert.Msg = p.parseTest()
+			}
+			return finish(p, assert, start)
+		case "import":
+			return p.parseImport()
+		case "from":
+			return p.parseImportFrom()
+		}
+	}
+	return p.parseExprStatement()
+}
+
+func (p *parser) atSimpleStatementEnd() bool {
+	tok := p.peek()
+	return tok.Kind == NEWLINE || tok.Kind == EOF || (tok.Kind == OP && tok.Value == ";")
+}
+
+var augAssignOps = map[string]string{
+	"+=": "+", "-=": "-", "*=": "*", "/=": "/", "//=": "//", "%=": "%", "@=": "@",
+	"&=": "&", "|=": "|", "^=": "^", ">>=": ">>", "<<=": "<<", "**=": "**",
+}
+
+func (p *parser) parseExprStatement() Stmt {
+	start := p.peek().Start
+	first := p.parseTestListOrYield()
+
+	tok := p.peek()
+	if tok.Kind == OP {
+		if op, ok := augAssignOps[tok.Value]; ok {
+			p.next()
+			return finish(p, &AugAssign{Target: first, Op: op, Value: p.parseTestListOrYield()}, start)
+		}
+		switch tok.Value {
+		case ":":
+			p.next()
+			ann := &AnnAssign{Target: first, Annotation: p.parseTest()}
+			if p.acceptOp("=") {
+				ann.Value = p.parseTestListOrYield()
+			}
+			return finish(p, ann, start)
+		case "=":
+			assign := &Assign{Targets: []Expr{first}}
+			for p.acceptOp("=") {
+				value := p.parseTestListOrYield()
+				if p.atOp("=") {
+					assign.Targets = append(assign.Targets, value)
+				} else {
+					assign.Value = value
+				}
+			}
+			return finish(p, assign, start)
+		}
+	}
+	return finish(p, &ExprStmt{Value: first}, start)
+}
+
+// parseDottedName parse `a.b.c`
+func (p *parser) parseDottedName() string {
+	name := p.expectName().Value
+	for p.atOp(".") {
+		p.next()
+		name += "." + p.expectName().Value
+	}
+	return name
+}
+
+func (p *parser) parseImport() Stmt {
+	start := p.expectKeyword("import").Start
+	imp := &Import{}
+	for {
+		aliasStart := p.peek().Start
+		alias := &Alias{Name: p.parseDottedName()}
+		if p.acceptKeyword("as") {
+			alias.AsName = p.expectName().Value
+		}
+		imp.Names = append(imp.Names, finish(p, alias, aliasStart))
+		if !p.acceptOp(",") {
+			break
+		}
+	}
+	return finish(p, imp, start)
+}
+
+func (p *parser) parseImportFrom() Stmt {
+	start := p.expectKeyword("from").Start
+	imp := &ImportFrom{}
+	for p.atOp(".", "...") {
+		imp.Level += len(p.next().Value)
+	}
+	if !p.atKeyword("import") {
+		imp.Module = p.parseDottedName()
+	}
+	p.expectKeyword("import")
+
+	if p.atOp("*") {
+		tok := p.next()
+		imp.Names = []*Alias{{node: node{Start: tok.Start, End: tok.End}, Name: "*"}}
+		return finish(p, imp, start)
+	}
+
+	paren := p.acceptOp("(")
+	for {
+		aliasStart := p.peek().Start
+		alias := &Alias{Name: p.expectName().Value}
+		if p.acceptKeyword("as") {
+			alias.AsName = p.expectName().Value
+		}
+		imp.Names = append(imp.Names, finish(p, alias, aliasStart))
+		if !p.acceptOp(",") {
+			break
+		}
+		if paren && p.atOp(")") {
+			break
+		}
+	}
+	if paren {
+		p.expectOp(")")
+	}
+	return finish(p, imp, start)
+}
+
+// parseSuite parse the block after `:`, it can be simple statements in same line or indented block
+func (p *parser) parseSuite() []Stmt {
+	p.expectOp(":")
+	if p.peek().Kind != NEWLINE {
+		return p.parseSimpleStatements()
+	}
+	p.next()
+	if p.peek().Kind != INDENT {
+		p.fail("expected an indented block")
+	}
+	p.next()
+	stmts := p.parseStatements(func() bool {
+		kind := p.peek().Kind
+		return kind == DEDENT || kind == EOF
+	})
+	if p.peek().Kind == DEDENT {
+		p.next()
+	}
+	return stmts
+}
+
+func (p *parser) parseIf() Stmt {
+	start := p.next().Start // if / elif
+	ifStmt := &If{Test: p.parseNamedExprTest()}
+	ifStmt.Body = p.parseSuite()
+	switch {
+	case p.atKeyword("elif"):
+		ifStmt.Orelse = []Stmt{p.parseIf()}
+	case p.acceptKeyword("else"):
+		ifStmt.Orelse = p.parseSuite()
+	}
+	return finish(p, ifStmt, start)
+}
+
+func (p *parser) parseWhile() Stmt {
+	start := p.expectKeyword("while").Start
+	while := &While{Test: p.parseNamedExprTest()}
+	while.Body = p.parseSuite()
+	if p.acceptKeyword("else") {
+		while.Orelse = p.parseSuite()
+	}
+	return finish(p, while, start)
+}
+
+func (p *parser) parseFor(start Pos, isAsync bool) Stmt {
+	p.expectKeyword("for")
+	forStmt := &For{IsAsync: isAsync}
+	forStmt.Target = p.parseExprList()
+	p.expectKeyword("in")
+	forStmt.Iter = p.parseTestList()
+	forStmt.Body = p.parseSuite()
+	if p.acceptKeyword("else") {
+		forStmt.Orelse = p.parseSuite()
+	}
+	return finish(p, forStmt, start)
+}
+
+func (p *parser) parseTry() Stmt {
+	start := p.expectKeyword("try").Start
+	try := &Try{Body: p.parseSuite()}
+	for p.atKeyword("except") {
+		handlerStart := p.next().Start
+		p.acceptOp("*") // except* for exception group
+		handler := &ExceptHandler{}
+		if !p.atOp(":") {
+			handler.Type = p.parseTest()
+			if p.acceptKeyword("as") {
+				handler.Name = p.expectName().Value
+			} else if p.acceptOp(",") {
+				// python2 style `except Exception, e`
+				handler.Name = p.expectName().Value
+			}
+		}
+		handler.Body = p.parseSuite()
+		try.Handlers = append(try.Handlers, finish(p, handler, handlerStart))
+	}
+	if p.acceptKeyword("else") {
+		try.Orelse = p.parseSuite()
+	}
+	if p.acceptKeyword("finally") {
+		try.Finalbody = p.parseSuite()
+	}
+	if len(try.Handlers) == 0 && try.Finalbody == nil {
+		p.fail("expect 'except' or 'finally' block")
+	}
+	return finish(p, try, start)
+}
+
+func (p *parser) parseWith(start Pos, isAsync bool) Stmt {
+	p.expectKeyword("with")
+	with := &With{IsAsync: isAsync}
+	parseItems := func() {
+		for {
+			itemStart := p.peek().Start
+			item := &WithItem{ContextExpr: p.parseTest()}
+			if p.acceptKeyword("as") {
+				item.OptionalVars = p.parseExpr()
+			}
+			with.Items = append(with.Items, finish(p, item, itemStart))
+			if !p.acceptOp(",") || p.atOp(")", ":") {
+				break
+			}
+		}
+	}
+	if p.atOp("(") && p.isParenthesizedWithItems() {
+		p.next()
+		parseItems()
+		p.expectOp(")")
+	} else {
+		parseItems()
+	}
+	with.Body = p.parseSuite()
+	return finish(p, with, start)
+}
+
+// isParenthesizedWithItems check `with (a as b, c as d):`, the parentheses is not a tuple
+func (p *parser) isParenthesizedWithItems() bool {
+	depth := 0
+	for i := p.pos; i < len(p.tokens); i++ {
+		tok := p.tokens[i]
+		switch {
+		case tok.Kind == OP && (tok.Value == "(" || tok.Value == "[" || tok.Value == "{"):
+			depth++
+		case tok.Kind == OP && (tok.Value == ")" || tok.Value == "]" || tok.Value == "}"):
+			depth--
+			if depth == 0 {
+				next := p.tokens[i+1]
+				return next.Kind == OP && next.Value == ":"
+			}
+		case tok.Kind == NAME && tok.Value == "as" && depth == 1:
+			return true
+		case tok.Kind == NEWLINE || tok.Kind == EOF:
+			return false
+		}
+	}
+	return false
+}
+
+// isMatchStatement check the soft keyword `match`, the logical line should end with `:` and follow an indented block
+func (p *parser) isMatchStatement() bool {
+	depth := 0
+	for i := p.pos + 1; i < len(p.tokens)-2; i++ {
+		tok := p.tokens[i]
+		switch {
+		case tok.Kind == NEWLINE || tok.Kind == EOF:
+			return false
+		case tok.Kind != OP:
+		case tok.Value == "(" || tok.Value == "[" || tok.Value == "{":
+			depth++
+		case tok.Value == ")" || tok.Value == "]" || tok.Value == "}":
+			depth--
+		case tok.Value == ":" && depth == 0:
+			return i > p.pos+1 && p.tokens[i+1].Kind == NEWLINE && p.tokens[i+2].Kind == INDENT
+		}
+	}
+	return false
+}
+
+func (p *parser) parseMatch() Stmt {
+	start := p.expectKeyword("match").Start
+	match := &Match{Subject: p.parseTestListStarExpr()}
+	p.expectOp(":")
+	p.expectNewline()
+	if p.peek().Kind != INDENT {
+		p.fail("expected an indented block")
+	}
+	p.next()
+	for p.atKeyword("case") {
+		caseStart := p.next().Start
+		matchCase := &MatchCase{Pattern: p.parseSequence(p.parsePattern, func() bool {
+			return p.atOp(":") || p.atKeyword("if")
+		})}
+		if p.acceptKeyword("if") {
+			matchCase.Guard = p.parseNamedExprTest()
+		}
+		matchCase.Body = p.parseSuite()
+		match.Cases = append(match.Cases, finish(p, matchCase, caseStart))
+	}
+	if p.peek().Kind != DEDENT {
+		p.fail("expect 'case' in match statement")
+	}
+	p.next()
+	return finish(p, match, start)
+}
+
+// parsePattern parse the pattern in case clause, `x as y` is represented as NamedExpr
+func (p *parser) parsePattern() Expr {
+	start := p.peek().Start
+	pattern := p.parseExprOrStar()
+	if p.acceptKeyword("as") {
+		name := p.expectName()
+		target := finish(p, &Name{Id: name.Value}, name.Start)
+		return finish(p, &NamedExpr{Target: target, Value: pattern}, start)
+	}
+	return pattern
+}
+
+func (p *parser) parseDecorated() Stmt {
+	start := p.peek().Start
+	var decorators []Expr
+	for p.acceptOp("@") {
+		decorators = append(decorators, p.parseNamedExprTest())
+		p.expectNewline()
+	}
+	switch {
+	case p.atKeyword("def"):
+		return p.parseFunctionDef(start, decorators, false)
+	case p.atKeyword("async"):
+		p.next()
+		return p.parseFunctionDef(start, decorators, true)
+	case p.atKeyword("class"):
+		return p.parseClassDef(start, decorators)
+	default:
+		p.fail("expect function or class definition after decorator")
+		return nil
+	}
+}
+
+func (p *parser) parseFunctionDef(start Pos, decorators []Expr, isAsync bool) Stmt {
+	p.expectKeyword("def")
+	def := &FunctionDef{
+		Name:          p.expectName().Value,
+		DecoratorList: decorators,
+		IsAsync:       isAsync,
+	}
+	// PEP 695 type parameters, `def f[T](x: T)`
+	if p.atOp("[") {
+		p.skipBracket()
+	}
+	p.expectOp("(")
+	def.Args = p.parseArguments(")", true)
+	p.expectOp(")")
+	if p.acceptOp("->") {
+		def.Returns = p.parseTest()
+	}
+	def.Body = p.parseSuite()
+	return finish(p, def, start)
+}
+
+func (p *parser) parseClassDef(start Pos, decorators []Expr) Stmt {
+	p.expectKeyword("class")
+	class := &ClassDef{
+		Name:          p.expectName().Value,
+		DecoratorList: decorators,
+	}
+	if p.atOp("[") {
+		p.skipBracket()
+	}
+	if p.acceptOp("(") {
+		class.Bases, class.Keywords = p.parseCallArguments()
+		p.expectOp(")")
+	}
+	class.Body = p.parseSuite()
+	return finish(p, class, start)
+}
+
+// skipBracket skip balanced brackets, the current token should be an open bracket
+func (p *parser) skipBracket() {
+	depth := 0
+	for {
+		tok := p.next()
+		if tok.Kind == EOF {
+			return
+		}
+		if tok.Kind != OP {
+			continue
+		}
+		switch tok.Value {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+			if depth <= 0 {
+				return
+			}
+		}
+	}
+}
+
+// parseArguments parse parameter list until the close token,
+// annotation is not allowed in lambda.
+func (p *parser) parseArguments(close string, allowAnnotation bool) *Arguments {
+	start := p.peek().Start
+	args := &Arguments{}
+	kwOnly := false
+
+	parseArg := func() *Arg {
+		tok := p.expectName()
+		arg := &Arg{Name: tok.Value}
+		if allowAnnotation && p.acceptOp(":") {
+			arg.Annotation = p.parseTest()
+		}
+		return finish(p, arg, tok.Start)
+	}
+
+	for !p.atOp(close) {
+		switch {
+		case p.acceptOp("/"):
+			// positional-only marker
+		case p.acceptOp("**"):
+			args.KwArg = parseArg()
+		case p.acceptOp("*"):
+			kwOnly = true
+			if !p.atOp(",") && !p.atOp(close) {
+				args.VarArg = parseArg()
+			}
+		default:
+			arg := parseArg()
+			var def Expr
+			if p.acceptOp("=") {
+				def = p.parseTest()
+			}
+			if kwOnly {
+				args.KwOnlyArgs = append(args.KwOnlyArgs, arg)
+				args.KwDefaults = append(args.KwDefaults, def)
+			} else {
+				args.Args = append(args.Args, arg)
+				if def != nil {
+					args.Defaults = append(args.Defaults, def)
+				} else if len(args.Defaults) > 0 {
+					p.errorf(arg.Start, "non-default argument follows default argument")
+				}
+			}
+		}
+		if !p.acceptOp(",") {
+			break
+		}
+	}
+	return finish(p, args, start)
+}
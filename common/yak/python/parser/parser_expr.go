@@ -0,0 +1,553 @@
+package pythonparser
+
+import (
+	"strings"
+)
+
+// parseTestList parse `a, b, c`, it will be tuple if there is comma
+func (p *parser) parseTestList() Expr {
+	return p.parseSequence(p.parseTest, p.atTestListEnd)
+}
+
+// parseTestListStarExpr parse `a, *b`
+func (p *parser) parseTestListStarExpr() Expr {
+	return p.parseSequence(p.parseTestOrStar, p.atTestListEnd)
+}
+
+// parseExprList parse target list of for statement and comprehension, `in` will stop it
+func (p *parser) parseExprList() Expr {
+	return p.parseSequence(p.parseExprOrStar, func() bool {
+		return p.atTestListEnd() || p.atKeyword("in")
+	})
+}
+
+func (p *parser) parseTestListOrYield() Expr {
+	if p.atKeyword("yield") {
+		return p.parseYield()
+	}
+	return p.parseTestListStarExpr()
+}
+
+func (p *parser) atTestListEnd() bool {
+	tok := p.peek()
+	switch tok.Kind {
+	case NEWLINE, EOF, INDENT, DEDENT:
+		return true
+	case OP:
+		switch tok.Value {
+		case ")", "]", "}", "=", ":", ";":
+			return true
+		}
+		_, isAug := augAssignOps[tok.Value]
+		return isAug
+	}
+	return false
+}
+
+func (p *parser) parseSequence(item func() Expr, atEnd func() bool) Expr {
+	start := p.peek().Start
+	first := item()
+	if !p.atOp(",") {
+		return first
+	}
+	elts := []Expr{first}
+	for p.acceptOp(",") {
+		if atEnd() {
+			break
+		}
+		elts = append(elts, item())
+	}
+	return finish(p, &Tuple{Elts: elts}, start)
+}
+
+func (p *parser) parseTestOrStar() Expr {
+	if p.atOp("*") {
+		return p.parseStar(p.parseExpr)
+	}
+	return p.parseTest()
+}
+
+func (p *parser) parseExprOrStar() Expr {
+	if p.atOp("*") {
+		return p.parseStar(p.parseExpr)
+	}
+	return p.parseExpr()
+}
+
+func (p *parser) parseNamedExprTestOrStar() Expr {
+	if p.atOp("*") {
+		return p.parseStar(p.parseExpr)
+	}
+	return p.parseNamedExprTest()
+}
+
+func (p *parser) parseStar(value func() Expr) Expr {
+	start := p.expectOp("*").Start
+	return finish(p, &Starred{Value: value()}, start)
+}
+
+func (p *parser) parseYield() Expr {
+	start := p.expectKeyword("yield").Start
+	if p.acceptKeyword("from") {
+		return finish(p, &YieldFrom{Value: p.parseTest()}, start)
+	}
+	yield := &Yield{}
+	if !p.atTestListEnd() {
+		yield.Value = p.parseTestListStarExpr()
+	}
+	return finish(p, yield, start)
+}
+
+// parseNamedExprTest parse `name := value` or test
+func (p *parser) parseNamedExprTest() Expr {
+	start := p.peek().Start
+	test := p.parseTest()
+	if p.atOp(":=") {
+		p.next()
+		return finish(p, &NamedExpr{Target: test, Value: p.parseTest()}, start)
+	}
+	return test
+}
+
+// parseTest parse lambda and ternary expression
+func (p *parser) parseTest() Expr {
+	if p.atKeyword("lambda") {
+		return p.parseLambda(p.parseTest)
+	}
+	start := p.peek().Start
+	body := p.parseOrTest()
+	if !p.atKeyword("if") {
+		return body
+	}
+	p.next()
+	test := p.parseOrTest()
+	p.expectKeyword("else")
+	return finish(p, &IfExp{Test: test, Body: body, Orelse: p.parseTest()}, start)
+}
+
+// parseTestNoCond parse the condition in comprehension, ternary is not allowed
+func (p *parser) parseTestNoCond() Expr {
+	if p.atKeyword("lambda") {
+		return p.parseLambda(p.parseTestNoCond)
+	}
+	return p.parseOrTest()
+}
+
+func (p *parser) parseLambda(body func() Expr) Expr {
+	start := p.expectKeyword("lambda").Start
+	lambda := &Lambda{Args: p.parseArguments(":", false)}
+	p.expectOp(":")
+	lambda.Body = body()
+	return finish(p, lambda, start)
+}
+
+func (p *parser) parseOrTest() Expr {
+	return p.parseBoolOp("or", p.parseAndTest)
+}
+
+func (p *parser) parseAndTest() Expr {
+	return p.parseBoolOp("and", p.parseNotTest)
+}
+
+func (p *parser) parseBoolOp(op string, operand func() Expr) Expr {
+	start := p.peek().Start
+	first := operand()
+	if !p.atKeyword(op) {
+		return first
+	}
+	values := []Expr{first}
+	for p.acceptKeyword(op) {
+		values = append(values, operand())
+	}
+	return finish(p, &BoolOp{Op: op, Values: values}, start)
+}
+
+func (p *parser) parseNotTest() Expr {
+	if p.atKeyword("not") {
+		start := p.next().Start
+		return finish(p, &UnaryOp{Op: "not", Operand: p.parseNotTest()}, start)
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() Expr {
+	start := p.peek().Start
+	left := p.parseExpr()
+	var ops []string
+	var comparators []Expr
+	for {
+		op := p.parseCompareOp()
+		if op == "" {
+			break
+		}
+		ops = append(ops, op)
+		comparators = append(comparators, p.parseExpr())
+	}
+	if len(ops) == 0 {
+		return left
+	}
+	return finish(p, &Compare{Left: left, Ops: ops, Comparators: comparators}, start)
+}
+
+func (p *parser) parseCompareOp() string {
+	tok := p.peek()
+	switch {
+	case tok.Kind == OP:
+		switch tok.Value {
+		case "<", ">", "==", ">=", "<=", "!=":
+			p.next()
+			return tok.Value
+		}
+	case tok.Kind == NAME:
+		switch tok.Value {
+		case "in":
+			p.next()
+			return "in"
+		case "not":
+			if next := p.peekN(1); next.Kind == NAME && next.Value == "in" {
+				p.next()
+				p.next()
+				return "not in"
+			}
+		case "is":
+			p.next()
+			if p.acceptKeyword("not") {
+				return "is not"
+			}
+			return "is"
+		}
+	}
+	return ""
+}
+
+// binary operator precedence from low to high, `**` and unary operator are handled separately
+var binaryPrecedence = [][]string{
+	{"|"},
+	{"^"},
+	{"&"},
+	{"<<", ">>"},
+	{"+", "-"},
+	{"*", "/", "//", "%", "@"},
+}
+
+// parseExpr parse bitwise or expression, it is the `expr` rule in python grammar
+func (p *parser) parseExpr() Expr {
+	return p.parseBinary(0)
+}
+
+func (p *parser) parseBinary(level int) Expr {
+	if level >= len(binaryPrecedence) {
+		return p.parseFactor()
+	}
+	start := p.peek().Start
+	left := p.parseBinary(level + 1)
+	for p.atOp(binaryPrecedence[level]...) {
+		op := p.next().Value
+		right := p.parseBinary(level + 1)
+		left = finish(p, &BinOp{Left: left, Op: op, Right: right}, start)
+	}
+	return left
+}
+
+func (p *parser) parseFactor() Expr {
+	if p.atOp("+", "-", "~") {
+		tok := p.next()
+		return finish(p, &UnaryOp{Op: tok.Value, Operand: p.parseFactor()}, tok.Start)
+	}
+	return p.parsePower()
+}
+
+func (p *parser) parsePower() Expr {
+	start := p.peek().Start
+	var base Expr
+	if p.atKeyword("await") {
+		p.next()
+		base = finish(p, &Await{Value: p.parseAtomExpr()}, start)
+	} else {
+		base = p.parseAtomExpr()
+	}
+	if p.acceptOp("**") {
+		return finish(p, &BinOp{Left: base, Op: "**", Right: p.parseFactor()}, start)
+	}
+	return base
+}
+
+// parseAtomExpr parse atom with trailers: call, subscript and attribute
+func (p *parser) parseAtomExpr() Expr {
+	start := p.peek().Start
+	value := p.parseAtom()
+	for {
+		switch {
+		case p.acceptOp("("):
+			call := &Call{Func: value}
+			call.Args, call.Keywords = p.parseCallArguments()
+			p.expectOp(")")
+			value = finish(p, call, start)
+		case p.acceptOp("["):
+			slice := p.parseSubscriptList()
+			p.expectOp("]")
+			value = finish(p, &Subscript{Value: value, Slice: slice}, start)
+		case p.acceptOp("."):
+			attr := p.expectName().Value
+			value = finish(p, &Attribute{Value: value, Attr: attr}, start)
+		default:
+			return value
+		}
+	}
+}
+
+// parseCallArguments parse arguments in call until `)`, the close token is not consumed
+func (p *parser) parseCallArguments() ([]Expr, []*Keyword) {
+	var args []Expr
+	var keywords []*Keyword
+	for !p.atOp(")") {
+		start := p.peek().Start
+		switch {
+		case p.acceptOp("**"):
+			keywords = append(keywords, finish(p, &Keyword{Value: p.parseTest()}, start))
+		case p.atOp("*"):
+			args = append(args, p.parseStar(p.parseTest))
+		default:
+			tok := p.peek()
+			if tok.Kind == NAME && !IsKeyword(tok.Value) {
+				if next := p.peekN(1); next.Kind == OP && next.Value == "=" {
+					p.next()
+					p.next()
+					keywords = append(keywords, finish(p, &Keyword{Arg: tok.Value, Value: p.parseTest()}, start))
+					break
+				}
+			}
+			arg := p.parseNamedExprTest()
+			if p.atKeyword("for", "async") {
+				// generator as the only argument, `f(x for x in y)`
+				arg = finish(p, &GeneratorExp{Elt: arg, Generators: p.parseComprehensions()}, start)
+			}
+			args = append(args, arg)
+		}
+		if !p.acceptOp(",") {
+			break
+		}
+	}
+	return args, keywords
+}
+
+func (p *parser) parseSubscriptList() Expr {
+	return p.parseSequence(p.parseSubscript, func() bool { return p.atOp("]") })
+}
+
+func (p *parser) parseSubscript() Expr {
+	start := p.peek().Start
+	if p.atOp("*") {
+		return p.parseStar(p.parseExpr)
+	}
+	var lower Expr
+	if !p.atOp(":") {
+		lower = p.parseNamedExprTest()
+		if !p.atOp(":") {
+			return lower
+		}
+	}
+	slice := &Slice{Lower: lower}
+	p.expectOp(":")
+	if !p.atOp(":", ",", "]") {
+		slice.Upper = p.parseTest()
+	}
+	if p.acceptOp(":") && !p.atOp(",", "]") {
+		slice.Step = p.parseTest()
+	}
+	return finish(p, slice, start)
+}
+
+func (p *parser) parseComprehensions() []*Comprehension {
+	var generators []*Comprehension
+	for p.atKeyword("for", "async") {
+		start := p.peek().Start
+		comp := &Comprehension{}
+		if p.acceptKeyword("async") {
+			comp.IsAsync = true
+		}
+		p.expectKeyword("for")
+		comp.Target = p.parseExprList()
+		p.expectKeyword("in")
+		comp.Iter = p.parseOrTest()
+		for p.acceptKeyword("if") {
+			comp.Ifs = append(comp.Ifs, p.parseTestNoCond())
+		}
+		generators = append(generators, finish(p, comp, start))
+	}
+	return generators
+}
+
+func (p *parser) parseAtom() Expr {
+	tok := p.peek()
+	start := tok.Start
+	switch tok.Kind {
+	case NAME:
+		switch tok.Value {
+		case "None":
+			p.next()
+			return finish(p, &Constant{Kind: ConstantNone, Value: "None"}, start)
+		case "True", "False":
+			p.next()
+			return finish(p, &Constant{Kind: ConstantBool, Value: tok.Value, Bool: tok.Value == "True"}, start)
+		}
+		if IsKeyword(tok.Value) {
+			p.fail("invalid syntax")
+		}
+		p.next()
+		return finish(p, &Name{Id: tok.Value}, start)
+	case NUMBER:
+		p.next()
+		return finish(p, &Constant{Kind: numberKind(tok.Value), Value: strings.ReplaceAll(tok.Value, "_", "")}, start)
+	case STRING:
+		return p.parseStrings()
+	case OP:
+		switch tok.Value {
+		case "...":
+			p.next()
+			return finish(p, &Constant{Kind: ConstantEllipsis, Value: "..."}, start)
+		case "(":
+			return p.parseParen()
+		case "[":
+			return p.parseListDisplay()
+		case "{":
+			return p.parseDictOrSet()
+		}
+	}
+	p.fail("invalid syntax")
+	return nil
+}
+
+func numberKind(text string) ConstantKind {
+	lower := strings.ToLower(text)
+	switch {
+	case strings.HasSuffix(lower, "j"):
+		return ConstantComplex
+	case strings.HasPrefix(lower, "0x"), strings.HasPrefix(lower, "0o"), strings.HasPrefix(lower, "0b"):
+		return ConstantInt
+	case strings.ContainsAny(lower, ".e"):
+		return ConstantFloat
+	default:
+		return ConstantInt
+	}
+}
+
+func (p *parser) parseParen() Expr {
+	start := p.expectOp("(").Start
+	if p.acceptOp(")") {
+		return finish(p, &Tuple{}, start)
+	}
+	if p.atKeyword("yield") {
+		value := p.parseYield()
+		p.expectOp(")")
+		return value
+	}
+	first := p.parseNamedExprTestOrStar()
+	if p.atKeyword("for", "async") {
+		gen := &GeneratorExp{Elt: first, Generators: p.parseComprehensions()}
+		p.expectOp(")")
+		return finish(p, gen, start)
+	}
+	if p.acceptOp(")") {
+		// parenthesized expression, keep the range of inner expression
+		return first
+	}
+	elts := []Expr{first}
+	for p.acceptOp(",") {
+		if p.atOp(")") {
+			break
+		}
+		elts = append(elts, p.parseNamedExprTestOrStar())
+	}
+	p.expectOp(")")
+	return finish(p, &Tuple{Elts: elts}, start)
+}
+
+func (p *parser) parseListDisplay() Expr {
+	start := p.expectOp("[").Start
+	if p.acceptOp("]") {
+		return finish(p, &List{}, start)
+	}
+	first := p.parseNamedExprTestOrStar()
+	if p.atKeyword("for", "async") {
+		comp := &ListComp{Elt: first, Generators: p.parseComprehensions()}
+		p.expectOp("]")
+		return finish(p, comp, start)
+	}
+	elts := []Expr{first}
+	for p.acceptOp(",") {
+		if p.atOp("]") {
+			break
+		}
+		elts = append(elts, p.parseNamedExprTestOrStar())
+	}
+	p.expectOp("]")
+	return finish(p, &List{Elts: elts}, start)
+}
+
+func (p *parser) parseDictOrSet() Expr {
+	start := p.expectOp("{").Start
+	if p.acceptOp("}") {
+		return finish(p, &Dict{}, start)
+	}
+
+	// parseDictItem return nil key for `**other`
+	parseDictItem := func() (Expr, Expr) {
+		if p.acceptOp("**") {
+			return nil, p.parseExpr()
+		}
+		key := p.parseTest()
+		p.expectOp(":")
+		return key, p.parseTest()
+	}
+
+	isDict := p.atOp("**")
+	var first Expr
+	if !isDict {
+		first = p.parseNamedExprTestOrStar()
+		isDict = p.atOp(":")
+	}
+
+	if isDict {
+		dict := &Dict{}
+		var key, value Expr
+		if first != nil {
+			p.expectOp(":")
+			key, value = first, p.parseTest()
+		} else {
+			key, value = parseDictItem()
+		}
+		if p.atKeyword("for", "async") && key != nil {
+			comp := &DictComp{Key: key, Value: value, Generators: p.parseComprehensions()}
+			p.expectOp("}")
+			return finish(p, comp, start)
+		}
+		dict.Keys = append(dict.Keys, key)
+		dict.Values = append(dict.Values, value)
+		for p.acceptOp(",") {
+			if p.atOp("}") {
+				break
+			}
+			key, value := parseDictItem()
+			dict.Keys = append(dict.Keys, key)
+			dict.Values = append(dict.Values, value)
+		}
+		p.expectOp("}")
+		return finish(p, dict, start)
+	}
+
+	if p.atKeyword("for", "async") {
+		comp := &SetComp{Elt: first, Generators: p.parseComprehensions()}
+		p.expectOp("}")
+		return finish(p, comp, start)
+	}
+	set := &Set{Elts: []Expr{first}}
+	for p.acceptOp(",") {
+		if p.atOp("}") {
+			break
+		}
+		set.Elts = append(set.Elts, p.parseNamedExprTestOrStar())
+	}
+	p.expectOp("}")
+	return finish(p, set, start)
+}
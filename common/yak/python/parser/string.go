@@ -0,0 +1,344 @@
+package pythonparser
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// parseStrings parse adjacent string literals, they are concatenated like `"a" "b"`,
+// it will be JoinedStr if any of them is f-string
+func (p *parser) parseStrings() Expr {
+	start := p.peek().Start
+	var values []Expr
+	isBytes, isJoined := false, false
+
+	var literal strings.Builder
+	literalStart := start
+	flush := func(end Pos) {
+		if literal.Len() == 0 {
+			return
+		}
+		c := &Constant{Kind: ConstantString, Value: literal.String()}
+		c.setRange(literalStart, end)
+		values = append(values, c)
+		literal.Reset()
+	}
+
+	for p.peek().Kind == STRING {
+		tok := p.next()
+		prefix, body := splitStringToken(tok.Value)
+		lowerPrefix := strings.ToLower(prefix)
+		raw := strings.Contains(lowerPrefix, "r")
+		if strings.Contains(lowerPrefix, "b") {
+			isBytes = true
+		}
+		if !strings.Contains(lowerPrefix, "f") {
+			if literal.Len() == 0 {
+				literalStart = tok.Start
+			}
+			literal.WriteString(decodeString(body, raw))
+			continue
+		}
+
+		isJoined = true
+		bodyStart := tok.Start
+		bodyStart.Column += len(tok.Value) - len(body) - quoteLen(tok.Value)
+		for _, part := range p.parseFString(body, raw, bodyStart) {
+			if c, ok := part.(*Constant); ok {
+				if literal.Len() == 0 {
+					literalStart = c.Start
+				}
+				literal.WriteString(c.Value)
+				continue
+			}
+			flush(part.GetStart())
+			values = append(values, part)
+		}
+	}
+
+	if !isJoined {
+		kind := ConstantString
+		if isBytes {
+			kind = ConstantBytes
+		}
+		return finish(p, &Constant{Kind: kind, Value: literal.String()}, start)
+	}
+	flush(p.lastEnd())
+	return finish(p, &JoinedStr{Values: values}, start)
+}
+
+// splitStringToken split the token into prefix and the content without quotes
+func splitStringToken(text string) (string, string) {
+	i := strings.IndexAny(text, `'"`)
+	if i < 0 {
+		return "", text
+	}
+	prefix, quoted := text[:i], text[i:]
+	q := quoteLen(text)
+	if len(quoted) < 2*q {
+		// unterminated string
+		return prefix, quoted[q:]
+	}
+	return prefix, quoted[q : len(quoted)-q]
+}
+
+func quoteLen(text string) int {
+	i := strings.IndexAny(text, `'"`)
+	if i < 0 {
+		return 0
+	}
+	quoted := text[i:]
+	if len(quoted) >= 6 && (strings.HasPrefix(quoted, `"""`) || strings.HasPrefix(quoted, `'''`)) {
+		return 3
+	}
+	return 1
+}
+
+// decodeString handle the escape sequence in string literal
+func decodeString(body string, raw bool) string {
+	if raw || !strings.Contains(body, `\`) {
+		return body
+	}
+	var buf strings.Builder
+	for i := 0; i < len(body); i++ {
+		ch := body[i]
+		if ch != '\\' || i+1 >= len(body) {
+			buf.WriteByte(ch)
+			continue
+		}
+		i++
+		switch c := body[i]; c {
+		case '\n':
+		case '\r':
+			if i+1 < len(body) && body[i+1] == '\n' {
+				i++
+			}
+		case '\\', '\'', '"':
+			buf.WriteByte(c)
+		case 'a':
+			buf.WriteByte('\a')
+		case 'b':
+			buf.WriteByte('\b')
+		case 'f':
+			buf.WriteByte('\f')
+		case 'n':
+			buf.WriteByte('\n')
+		case 'r':
+			buf.WriteByte('\r')
+		case 't':
+			buf.WriteByte('\t')
+		case 'v':
+			buf.WriteByte('\v')
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			j := i
+			for j < len(body) && j < i+3 && body[j] >= '0' && body[j] <= '7' {
+				j++
+			}
+			n, _ := strconv.ParseUint(body[i:j], 8, 32)
+			buf.WriteRune(rune(n))
+			i = j - 1
+		case 'x', 'u', 'U':
+			size := map[byte]int{'x': 2, 'u': 4, 'U': 8}[c]
+			if i+size < len(body) {
+				if n, err := strconv.ParseUint(body[i+1:i+1+size], 16, 32); err == nil {
+					if c == 'x' {
+						buf.WriteByte(byte(n))
+					} else {
+						buf.WriteRune(rune(n))
+					}
+					i += size
+					continue
+				}
+			}
+			buf.WriteByte('\\')
+			buf.WriteByte(c)
+		default:
+			// unknown escape sequence is kept
+			buf.WriteByte('\\')
+			buf.WriteByte(c)
+		}
+	}
+	return buf.String()
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// parseFString split the f-string content into literal and replacement fields
+func (p *parser) parseFString(body string, raw bool, start Pos) []Expr {
+	var parts []Expr
+	var literal strings.Builder
+	literalOffset := 0
+
+	posAt := func(offset int) Pos {
+		pos := start
+		for _, ch := range body[:offset] {
+			if ch == '\n' {
+				pos.Line++
+				pos.Column = 0
+			} else {
+				pos.Column += utf8.RuneLen(ch)
+			}
+		}
+		return pos
+	}
+	flush := func(end int) {
+		if literal.Len() == 0 {
+			return
+		}
+		c := &Constant{Kind: ConstantString, Value: decodeString(literal.String(), raw)}
+		c.setRange(posAt(literalOffset), posAt(end))
+		parts = append(parts, c)
+		literal.Reset()
+	}
+
+	for i := 0; i < len(body); i++ {
+		ch := body[i]
+		switch {
+		case ch == '{' && i+1 < len(body) && body[i+1] == '{':
+			if literal.Len() == 0 {
+				literalOffset = i
+			}
+			literal.WriteByte('{')
+			i++
+		case ch == '}' && i+1 < len(body) && body[i+1] == '}':
+			if literal.Len() == 0 {
+				literalOffset = i
+			}
+			literal.WriteByte('}')
+			i++
+		case ch == '{':
+			flush(i)
+			end := matchFStringField(body, i)
+			field := p.parseFStringField(body[i+1:end], posAt(i+1))
+			if field != nil {
+				field.setRange(posAt(i), posAt(minInt(end+1, len(body))))
+				parts = append(parts, field)
+			}
+			i = end
+			literalOffset = i + 1
+		default:
+			if literal.Len() == 0 {
+				literalOffset = i
+			}
+			literal.WriteByte(ch)
+		}
+	}
+	flush(len(body))
+	return parts
+}
+
+// matchFStringField find the `}` of the field start at body[open]
+func matchFStringField(body string, open int) int {
+	depth := 0
+	var quote byte
+	for i := open; i < len(body); i++ {
+		ch := body[i]
+		if quote != 0 {
+			if ch == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch ch {
+		case '\'', '"':
+			quote = ch
+		case '{', '[', '(':
+			depth++
+		case '}', ']', ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(body)
+}
+
+// parseFStringField parse `expr!r:spec` in f-string
+func (p *parser) parseFStringField(text string, start Pos) *FormattedValue {
+	exprEnd, specStart := len(text), -1
+	conversion := ""
+	depth := 0
+	var quote byte
+LOOP:
+	for i := 0; i < len(text); i++ {
+		ch := text[i]
+		if quote != 0 {
+			if ch == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch ch {
+		case '\'', '"':
+			quote = ch
+		case '{', '[', '(':
+			depth++
+		case '}', ']', ')':
+			depth--
+		case '!':
+			if depth == 0 && i+1 < len(text) && text[i+1] != '=' {
+				exprEnd = i
+				rest := text[i+1:]
+				if j := strings.IndexByte(rest, ':'); j >= 0 {
+					conversion = rest[:j]
+					specStart = i + 1 + j + 1
+				} else {
+					conversion = rest
+				}
+				break LOOP
+			}
+		case ':':
+			if depth == 0 {
+				exprEnd = i
+				specStart = i + 1
+				break LOOP
+			}
+		}
+	}
+
+	exprText := strings.TrimSpace(text[:exprEnd])
+	// self documenting expression `f"{a=}"`
+	exprText = strings.TrimSuffix(exprText, "=")
+	if exprText == "" {
+		p.errorf(start, "f-string: empty expression not allowed")
+		return nil
+	}
+
+	value := p.parseSubExpr(exprText, start)
+	if value == nil {
+		return nil
+	}
+	field := &FormattedValue{Value: value, Conversion: conversion}
+	if specStart >= 0 && specStart <= len(text) {
+		field.FormatSpec = text[specStart:]
+	}
+	return field
+}
+
+// parseSubExpr parse the expression in f-string, the errors are merged into current parser
+func (p *parser) parseSubExpr(text string, start Pos) (result Expr) {
+	tokens, lexErrs := tokenizeAt("("+text+")", Pos{Line: start.Line, Column: start.Column - 1})
+	sub := &parser{tokens: tokens}
+	sub.errors = append(sub.errors, lexErrs...)
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(bailout); !ok {
+				panic(r)
+			}
+			result = nil
+		}
+		p.errors = append(p.errors, sub.errors...)
+	}()
+	result = sub.parseTestListStarExpr()
+	if sub.peek().Kind != NEWLINE && sub.peek().Kind != EOF {
+		sub.fail("f-string: invalid syntax")
+	}
+	return result
+}
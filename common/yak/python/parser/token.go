@@ -0,0 +1,400 @@
+package pythonparser
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type TokenKind int
+
+const (
+	EOF TokenKind = iota
+	NEWLINE
+	INDENT
+	DEDENT
+	NAME
+	NUMBER
+	STRING
+	OP
+)
+
+var tokenKindNames = map[TokenKind]string{
+	EOF:     "EOF",
+	NEWLINE: "NEWLINE",
+	INDENT:  "INDENT",
+	DEDENT:  "DEDENT",
+	NAME:    "NAME",
+	NUMBER:  "NUMBER",
+	STRING:  "STRING",
+	OP:      "OP",
+}
+
+func (k TokenKind) String() string {
+	if name, ok := tokenKindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("TokenKind(%d)", int(k))
+}
+
+// Pos is the position in source code, line start from 1 and column start from 0
+type Pos struct {
+	Line   int
+	Column int
+}
+
+func (p Pos) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+type Token struct {
+	Kind  TokenKind
+	Value string
+	Start Pos
+	End   Pos
+}
+
+func (t *Token) String() string {
+	return fmt.Sprintf("%v(%q) %v-%v", t.Kind, t.Value, t.Start, t.End)
+}
+
+// operators sorted by length, the lexer use the longest match
+var operators = []string{
+	"**=", "//=", ">>=", "<<=", "...",
+	"->", ":=", "**", "//", ">>", "<<", "<=", ">=", "==", "!=",
+	"+=", "-=", "*=", "/=", "%=", "&=", "|=", "^=", "@=",
+	"+", "-", "*", "/", "%", "@", "&", "|", "^", "~", "<", ">",
+	"(", ")", "[", "]", "{", "}", ",", ":", ".", ";", "=",
+}
+
+type lexer struct {
+	src  string
+	pos  int
+	line int
+	col  int
+
+	indents     []int
+	parenDepth  int
+	atLineStart bool
+
+	tokens []*Token
+	errors []*SyntaxError
+}
+
+// Tokenize split python source code into tokens, INDENT/DEDENT/NEWLINE are generated like CPython tokenizer
+func Tokenize(src string) ([]*Token, []*SyntaxError) {
+	return tokenizeAt(src, Pos{Line: 1, Column: 0})
+}
+
+func tokenizeAt(src string, start Pos) ([]*Token, []*SyntaxError) {
+	l := &lexer{
+		src:         src,
+		line:        start.Line,
+		col:         start.Column,
+		indents:     []int{0},
+		atLineStart: true,
+	}
+	l.run()
+	return l.tokens, l.errors
+}
+
+func (l *lexer) current() Pos {
+	return Pos{Line: l.line, Column: l.col}
+}
+
+func (l *lexer) errorf(pos Pos, format string, args ...any) {
+	l.errors = append(l.errors, &SyntaxError{Pos: pos, Msg: fmt.Sprintf(format, args...)})
+}
+
+func (l *lexer) peekByte(offset int) byte {
+	if l.pos+offset >= len(l.src) {
+		return 0
+	}
+	return l.src[l.pos+offset]
+}
+
+// advance move n bytes, the source in [pos, pos+n) should not contain newline
+func (l *lexer) advance(n int) {
+	l.pos += n
+	l.col += n
+}
+
+func (l *lexer) newline() {
+	if l.peekByte(0) == '\r' && l.peekByte(1) == '\n' {
+		l.pos += 2
+	} else {
+		l.pos++
+	}
+	l.line++
+	l.col = 0
+}
+
+func (l *lexer) emit(kind TokenKind, value string, start Pos) {
+	l.tokens = append(l.tokens, &Token{Kind: kind, Value: value, Start: start, End: l.current()})
+}
+
+func (l *lexer) lastKind() TokenKind {
+	if len(l.tokens) == 0 {
+		return NEWLINE
+	}
+	return l.tokens[len(l.tokens)-1].Kind
+}
+
+func (l *lexer) run() {
+	for l.pos < len(l.src) {
+		if l.atLineStart && l.parenDepth == 0 {
+			if !l.indentation() {
+				continue
+			}
+		}
+
+		ch := l.peekByte(0)
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\f':
+			l.advance(1)
+		case ch == '#':
+			for l.pos < len(l.src) && l.peekByte(0) != '\n' && l.peekByte(0) != '\r' {
+				l.advance(1)
+			}
+		case ch == '\\' && (l.peekByte(1) == '\n' || l.peekByte(1) == '\r'):
+			// explicit line joining
+			l.advance(1)
+			l.newline()
+		case ch == '\n' || ch == '\r':
+			if l.parenDepth > 0 {
+				// implicit line joining in brackets
+				l.newline()
+				continue
+			}
+			if l.lastKind() != NEWLINE {
+				start := l.current()
+				l.newline()
+				l.tokens = append(l.tokens, &Token{Kind: NEWLINE, Value: "\n", Start: start, End: start})
+			} else {
+				l.newline()
+			}
+			l.atLineStart = true
+		case ch == '"' || ch == '\'':
+			l.scanString(l.pos, l.current())
+		case isDigit(ch) || (ch == '.' && isDigit(l.peekByte(1))):
+			l.scanNumber()
+		case isIdentifierStart(l.src[l.pos:]):
+			l.scanName()
+		default:
+			l.scanOperator()
+		}
+	}
+
+	if l.lastKind() != NEWLINE {
+		pos := l.current()
+		l.tokens = append(l.tokens, &Token{Kind: NEWLINE, Value: "", Start: pos, End: pos})
+	}
+	for len(l.indents) > 1 {
+		l.indents = l.indents[:len(l.indents)-1]
+		l.emit(DEDENT, "", l.current())
+	}
+	l.emit(EOF, "", l.current())
+}
+
+// indentation handle the indentation at line start, return false when the line is blank
+func (l *lexer) indentation() bool {
+	width := 0
+	for l.pos < len(l.src) {
+		switch l.peekByte(0) {
+		case ' ':
+			width++
+		case '\t':
+			width = (width/8 + 1) * 8
+		case '\f':
+			width = 0
+		default:
+			goto END
+		}
+		l.advance(1)
+	}
+END:
+	switch l.peekByte(0) {
+	case '#', '\n', '\r', 0:
+		// blank line or comment line, skip it
+		for l.pos < len(l.src) && l.peekByte(0) != '\n' && l.peekByte(0) != '\r' {
+			l.advance(1)
+		}
+		if l.pos < len(l.src) {
+			l.newline()
+		}
+		return false
+	case '\\':
+		if next := l.peekByte(1); next == '\n' || next == '\r' {
+			// the line is joined with next line
+			return true
+		}
+	}
+
+	l.atLineStart = false
+	pos := l.current()
+	top := l.indents[len(l.indents)-1]
+	switch {
+	case width > top:
+		l.indents = append(l.indents, width)
+		l.tokens = append(l.tokens, &Token{Kind: INDENT, Start: Pos{Line: pos.Line}, End: pos})
+	case width < top:
+		for width < l.indents[len(l.indents)-1] {
+			l.indents = l.indents[:len(l.indents)-1]
+			l.tokens = append(l.tokens, &Token{Kind: DEDENT, Start: pos, End: pos})
+		}
+		if width != l.indents[len(l.indents)-1] {
+			l.errorf(pos, "unindent does not match any outer indentation level")
+		}
+	}
+	return true
+}
+
+func (l *lexer) scanName() {
+	start, startPos := l.pos, l.current()
+	for l.pos < len(l.src) {
+		r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.In(r, unicode.Mn, unicode.Mc, unicode.Pc) {
+			break
+		}
+		l.advance(size)
+	}
+	name := l.src[start:l.pos]
+	if ch := l.peekByte(0); (ch == '"' || ch == '\'') && isStringPrefix(name) {
+		l.scanString(start, startPos)
+		return
+	}
+	l.emit(NAME, name, startPos)
+}
+
+func isStringPrefix(s string) bool {
+	switch strings.ToLower(s) {
+	case "r", "u", "b", "f", "br", "rb", "fr", "rf":
+		return true
+	default:
+		return false
+	}
+}
+
+// scanString scan string literal, the prefix has been consumed, start is the begin of prefix
+func (l *lexer) scanString(start int, startPos Pos) {
+	quote := l.peekByte(0)
+	triple := l.peekByte(1) == quote && l.peekByte(2) == quote
+	if triple {
+		l.advance(3)
+	} else {
+		l.advance(1)
+	}
+
+	for {
+		if l.pos >= len(l.src) {
+			l.errorf(startPos, "unterminated string literal")
+			break
+		}
+		ch := l.peekByte(0)
+		switch {
+		case ch == '\\':
+			l.advance(1)
+			if next := l.peekByte(0); next == '\n' || next == '\r' {
+				l.newline()
+			} else if l.pos < len(l.src) {
+				_, size := utf8.DecodeRuneInString(l.src[l.pos:])
+				l.advance(size)
+			}
+			continue
+		case ch == '\n' || ch == '\r':
+			if !triple {
+				l.errorf(startPos, "unterminated string literal")
+				l.emit(STRING, l.src[start:l.pos], startPos)
+				return
+			}
+			l.newline()
+			continue
+		case ch == quote:
+			if !triple {
+				l.advance(1)
+				l.emit(STRING, l.src[start:l.pos], startPos)
+				return
+			}
+			if l.peekByte(1) == quote && l.peekByte(2) == quote {
+				l.advance(3)
+				l.emit(STRING, l.src[start:l.pos], startPos)
+				return
+			}
+		}
+		_, size := utf8.DecodeRuneInString(l.src[l.pos:])
+		l.advance(size)
+	}
+	l.emit(STRING, l.src[start:l.pos], startPos)
+}
+
+func (l *lexer) scanNumber() {
+	start, startPos := l.pos, l.current()
+	if l.peekByte(0) == '0' {
+		switch l.peekByte(1) {
+		case 'x', 'X', 'o', 'O', 'b', 'B':
+			l.advance(2)
+			for isHexDigit(l.peekByte(0)) || l.peekByte(0) == '_' {
+				l.advance(1)
+			}
+			l.emit(NUMBER, l.src[start:l.pos], startPos)
+			return
+		}
+	}
+	digits := func() {
+		for isDigit(l.peekByte(0)) || l.peekByte(0) == '_' {
+			l.advance(1)
+		}
+	}
+	digits()
+	if l.peekByte(0) == '.' {
+		l.advance(1)
+		digits()
+	}
+	if ch := l.peekByte(0); ch == 'e' || ch == 'E' {
+		next := l.peekByte(1)
+		if isDigit(next) || ((next == '+' || next == '-') && isDigit(l.peekByte(2))) {
+			l.advance(2)
+			digits()
+		}
+	}
+	if ch := l.peekByte(0); ch == 'j' || ch == 'J' {
+		l.advance(1)
+	}
+	l.emit(NUMBER, l.src[start:l.pos], startPos)
+}
+
+func (l *lexer) scanOperator() {
+	startPos := l.current()
+	for _, op := range operators {
+		if strings.HasPrefix(l.src[l.pos:], op) {
+			l.advance(len(op))
+			switch op {
+			case "(", "[", "{":
+				l.parenDepth++
+			case ")", "]", "}":
+				if l.parenDepth > 0 {
+					l.parenDepth--
+				}
+			}
+			l.emit(OP, op, startPos)
+			return
+		}
+	}
+	r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+	l.errorf(startPos, "invalid character %q", r)
+	l.advance(size)
+}
+
+func isDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+func isHexDigit(ch byte) bool {
+	return isDigit(ch) || (ch >= 'a' && ch <= 'f') || (ch >= 'A' && ch <= 'F')
+}
+
+func isIdentifierStart(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return r == '_' || unicode.IsLetter(r)
+}
@@ -0,0 +1,122 @@
+package python2ssa
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/yaklang/yaklang/common/log"
+	"github.com/yaklang/yaklang/common/utils"
+	pythonparser "github.com/yaklang/yaklang/common/yak/python/parser"
+	"github.com/yaklang/yaklang/common/yak/ssa"
+)
+
+// ========================================== For SSAAPI ==========================================
+
+type SSABuilder struct {
+	ssa.DummyExtraFileAnalyzer
+}
+
+var Builder = &SSABuilder{}
+
+func (*SSABuilder) Build(src string, force bool, b *ssa.FunctionBuilder) error {
+	module, err := Frontend(src, force)
+	if err != nil {
+		return err
+	}
+	b.SupportClosure = true
+	b.SupportClass = true
+	build := &builder{
+		FunctionBuilder: b,
+		modules:         make(map[string]*pyModule),
+		classes:         make(map[string]*ssa.ClassBluePrint),
+		globals:         make(map[string]struct{}),
+	}
+	build.dir = build.currentDir()
+	build.VisitModule(module)
+	return nil
+}
+
+func (*SSABuilder) FilterFile(path string) bool {
+	return filepath.Ext(path) == ".py"
+}
+
+// ========================================== Build Front End ==========================================
+
+type builder struct {
+	*ssa.FunctionBuilder
+
+	// dir is the directory of current file, relative import is resolved from it
+	dir string
+	// modules records the local name of imported module which is found in project
+	modules map[string]*pyModule
+	// classes records the local name of class, include the class imported from other module
+	classes map[string]*ssa.ClassBluePrint
+
+	// globals is the names declared by `global` or `nonlocal` in current function
+	globals map[string]struct{}
+	// blockDepth is 0 when building the top level statements of current function
+	blockDepth int
+	// pending is the body of functions defined in current scope,
+	// they are built at the end of scope, so the function can use the names defined after it
+	pending []func()
+}
+
+func Frontend(src string, force bool) (*pythonparser.Module, error) {
+	module, err := pythonparser.Parse(src)
+	if err == nil || (force && module != nil) {
+		return module, nil
+	}
+	return nil, utils.Errorf("parse AST FrontEnd error: %v", err)
+}
+
+// SetRangeFromNode set current range by python ast node, return a function to recover the old range
+func (b *builder) SetRangeFromNode(node pythonparser.Node) func() {
+	if utils.IsNil(node) {
+		return func() {}
+	}
+	start, end := node.GetStart(), node.GetEnd()
+	if start.Line <= 0 || end.Line <= 0 {
+		return func() {}
+	}
+	backup := b.CurrentRange
+	b.CurrentRange = ssa.NewRange(
+		b.GetEditor(),
+		ssa.NewPosition(int64(start.Line), int64(start.Column)),
+		ssa.NewPosition(int64(end.Line), int64(end.Column)),
+	)
+	return func() {
+		b.CurrentRange = backup
+	}
+}
+
+// currentDir get the directory of current file from the editor url
+func (b *builder) currentDir() string {
+	editor := b.GetEditor()
+	if editor == nil || editor.GetUrl() == "" {
+		return ""
+	}
+	prog := b.GetProgram()
+	if prog == nil || prog.Loader == nil || prog.Loader.GetFilesysFileSystem() == nil {
+		return filepath.Dir(editor.GetUrl())
+	}
+	dir, _ := prog.Loader.GetFilesysFileSystem().PathSplit(editor.GetUrl())
+	return dir
+}
+
+func (b *builder) ReadClass(name string) *ssa.ClassBluePrint {
+	if class, ok := b.classes[name]; ok {
+		return class
+	}
+	return nil
+}
+
+func (b *builder) AssignClass(name string, class *ssa.ClassBluePrint) {
+	if old, ok := b.classes[name]; ok && old != class {
+		log.Warnf("class %v has been defined, it will be replaced", name)
+	}
+	b.classes[name] = class
+}
+
+func methodFuncName(class, method string) string {
+	return fmt.Sprintf("%s_%s", class, method)
+}
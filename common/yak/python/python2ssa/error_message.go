@@ -0,0 +1,34 @@
+package python2ssa
+
+import (
+	"fmt"
+
+	pythonparser "github.com/yaklang/yaklang/common/yak/python/parser"
+	"github.com/yaklang/yaklang/common/yak/ssa"
+)
+
+const TAG ssa.ErrorTag = "pythonast"
+
+func UnsupportedStatement(stmt pythonparser.Stmt) string {
+	return fmt.Sprintf("statement not support: %T", stmt)
+}
+
+func UnsupportedExpression(expr pythonparser.Expr) string {
+	return fmt.Sprintf("expression not support: %T", expr)
+}
+
+func UnsupportedAssignTarget(expr pythonparser.Expr) string {
+	return fmt.Sprintf("cannot assign to %T", expr)
+}
+
+func UnexpectedBranchStmt(stmt string) string {
+	return fmt.Sprintf("'%s' outside loop", stmt)
+}
+
+func BinaryOperatorNotSupport(op string) string {
+	return fmt.Sprintf("binary operator not support: %s", op)
+}
+
+func ImportModuleFailed(name string, err error) string {
+	return fmt.Sprintf("import module %s failed: %v", name, err)
+}
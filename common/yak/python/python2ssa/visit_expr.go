@@ -0,0 +1,495 @@
+package python2ssa
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/yaklang/yaklang/common/utils"
+	pythonparser "github.com/yaklang/yaklang/common/yak/python/parser"
+	"github.com/yaklang/yaklang/common/yak/ssa"
+)
+
+var binaryOpcode = map[string]ssa.BinaryOpcode{
+	"+":  ssa.OpAdd,
+	"-":  ssa.OpSub,
+	"*":  ssa.OpMul,
+	"/":  ssa.OpDiv,
+	"//": ssa.OpDiv,
+	"%":  ssa.OpMod,
+	"**": ssa.OpPow,
+	"@":  ssa.OpMul,
+	"&":  ssa.OpAnd,
+	"|":  ssa.OpOr,
+	"^":  ssa.OpXor,
+	"<<": ssa.OpShl,
+	">>": ssa.OpShr,
+}
+
+var compareOpcode = map[string]ssa.BinaryOpcode{
+	"==":     ssa.OpEq,
+	"!=":     ssa.OpNotEq,
+	"<":      ssa.OpLt,
+	"<=":     ssa.OpLtEq,
+	">":      ssa.OpGt,
+	">=":     ssa.OpGtEq,
+	"is":     ssa.OpEq,
+	"is not": ssa.OpNotEq,
+	"in":     ssa.OpIn,
+}
+
+var unaryOpcode = map[string]ssa.UnaryOpcode{
+	"not": ssa.OpNot,
+	"-":   ssa.OpNeg,
+	"+":   ssa.OpPlus,
+	"~":   ssa.OpBitwiseNot,
+}
+
+func (b *builder) VisitExprList(exprs []pythonparser.Expr) []ssa.Value {
+	values := make([]ssa.Value, 0, len(exprs))
+	for _, expr := range exprs {
+		if value := b.VisitExpr(expr); value != nil {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func (b *builder) VisitExpr(raw pythonparser.Expr) ssa.Value {
+	if b == nil || utils.IsNil(raw) {
+		return nil
+	}
+	recoverRange := b.SetRangeFromNode(raw)
+	defer recoverRange()
+
+	// emit instruction in finished block will get typed nil
+	if value := b.visitExpr(raw); !utils.IsNil(value) {
+		return value
+	}
+	return nil
+}
+
+func (b *builder) visitExpr(raw pythonparser.Expr) ssa.Value {
+	switch expr := raw.(type) {
+	case *pythonparser.Name:
+		return b.VisitName(expr.Id)
+	case *pythonparser.Constant:
+		return b.VisitConstant(expr)
+	case *pythonparser.JoinedStr:
+		return b.VisitJoinedStr(expr)
+	case *pythonparser.FormattedValue:
+		return b.VisitExpr(expr.Value)
+	case *pythonparser.BoolOp:
+		return b.VisitBoolOp(expr)
+	case *pythonparser.BinOp:
+		op, ok := binaryOpcode[expr.Op]
+		if !ok {
+			b.NewError(ssa.Error, TAG, BinaryOperatorNotSupport(expr.Op))
+			return nil
+		}
+		return b.EmitBinOp(op, b.VisitExpr(expr.Left), b.VisitExpr(expr.Right))
+	case *pythonparser.UnaryOp:
+		op, ok := unaryOpcode[expr.Op]
+		if !ok {
+			return b.VisitExpr(expr.Operand)
+		}
+		return b.EmitUnOp(op, b.VisitExpr(expr.Operand))
+	case *pythonparser.Compare:
+		return b.VisitCompare(expr)
+	case *pythonparser.Lambda:
+		return b.VisitLambda(expr)
+	case *pythonparser.IfExp:
+		return b.handlerJumpExpression(
+			func() ssa.Value { return b.VisitExpr(expr.Test) },
+			func() ssa.Value { return b.VisitExpr(expr.Body) },
+			func() ssa.Value { return b.VisitExpr(expr.Orelse) },
+		)
+	case *pythonparser.NamedExpr:
+		value := b.VisitExpr(expr.Value)
+		b.AssignTarget(expr.Target, value)
+		return value
+	case *pythonparser.Await:
+		return b.VisitExpr(expr.Value)
+	case *pythonparser.Yield:
+		// the value of yield expression is sent by caller
+		b.VisitExpr(expr.Value)
+		return b.EmitUndefined("")
+	case *pythonparser.YieldFrom:
+		b.VisitExpr(expr.Value)
+		return b.EmitUndefined("")
+	case *pythonparser.Call:
+		return b.VisitCall(expr)
+	case *pythonparser.Attribute:
+		return b.VisitAttribute(expr)
+	case *pythonparser.Subscript:
+		value := b.VisitExpr(expr.Value)
+		if value == nil {
+			return nil
+		}
+		if slice, ok := expr.Slice.(*pythonparser.Slice); ok {
+			return b.EmitMakeSlice(value, b.VisitExpr(slice.Lower), b.VisitExpr(slice.Upper), nil)
+		}
+		return b.ReadMemberCallVariable(value, b.VisitExpr(expr.Slice))
+	case *pythonparser.Slice:
+		// slice in tuple, `a[1:2, 3]`
+		b.VisitExpr(expr.Lower)
+		b.VisitExpr(expr.Upper)
+		b.VisitExpr(expr.Step)
+		return b.EmitUndefined("")
+	case *pythonparser.Starred:
+		return b.VisitExpr(expr.Value)
+	case *pythonparser.List:
+		return b.buildSequence(expr.Elts)
+	case *pythonparser.Tuple:
+		return b.buildSequence(expr.Elts)
+	case *pythonparser.Set:
+		return b.buildSequence(expr.Elts)
+	case *pythonparser.Dict:
+		return b.VisitDict(expr)
+	case *pythonparser.ListComp:
+		return b.VisitComprehension(expr.Generators, nil, expr.Elt)
+	case *pythonparser.SetComp:
+		return b.VisitComprehension(expr.Generators, nil, expr.Elt)
+	case *pythonparser.GeneratorExp:
+		return b.VisitComprehension(expr.Generators, nil, expr.Elt)
+	case *pythonparser.DictComp:
+		return b.VisitComprehension(expr.Generators, expr.Key, expr.Value)
+	default:
+		b.NewError(ssa.Warn, TAG, UnsupportedExpression(raw))
+		return nil
+	}
+}
+
+func (b *builder) VisitName(name string) ssa.Value {
+	if value := b.PeekValueInThisFunction(name); value != nil {
+		return value
+	}
+	return b.ReadValue(name)
+}
+
+func (b *builder) VisitConstant(c *pythonparser.Constant) ssa.Value {
+	switch c.Kind {
+	case pythonparser.ConstantNone:
+		return b.EmitConstInstNil()
+	case pythonparser.ConstantBool:
+		return b.EmitConstInst(c.Bool)
+	case pythonparser.ConstantInt:
+		text := strings.ReplaceAll(c.Value, "_", "")
+		if v, err := strconv.ParseInt(text, 0, 64); err == nil {
+			return b.EmitConstInst(v)
+		}
+		if v, err := strconv.ParseUint(text, 0, 64); err == nil {
+			return b.EmitConstInst(v)
+		}
+	case pythonparser.ConstantFloat:
+		if v, err := strconv.ParseFloat(strings.ReplaceAll(c.Value, "_", ""), 64); err == nil {
+			return b.EmitConstInst(v)
+		}
+	}
+	// string, bytes, complex and big integer just keep the literal
+	return b.EmitConstInst(c.Value)
+}
+
+// VisitJoinedStr build f-string as string concatenation, `f"a{b}"` is `"a" + b`
+func (b *builder) VisitJoinedStr(expr *pythonparser.JoinedStr) ssa.Value {
+	var result ssa.Value
+	for _, part := range expr.Values {
+		value := b.VisitExpr(part)
+		if value == nil {
+			continue
+		}
+		if result == nil {
+			result = value
+			continue
+		}
+		result = b.EmitBinOp(ssa.OpAdd, result, value)
+	}
+	if result == nil {
+		return b.EmitConstInst("")
+	}
+	return result
+}
+
+// VisitBoolOp build `a and b or c`, the value is one of operand like python
+func (b *builder) VisitBoolOp(expr *pythonparser.BoolOp) ssa.Value {
+	if len(expr.Values) == 0 {
+		return nil
+	}
+	result := b.VisitExpr(expr.Values[0])
+	for _, right := range expr.Values[1:] {
+		right := right
+		left := result
+		if left == nil {
+			result = b.VisitExpr(right)
+			continue
+		}
+		if expr.Op == "and" {
+			// target = a and b
+			//	if a { target = b } else { target = a }
+			result = b.handlerJumpExpression(
+				func() ssa.Value { return left },
+				func() ssa.Value { return b.VisitExpr(right) },
+				func() ssa.Value { return left },
+			)
+		} else {
+			// target = a or b
+			//	if a { target = a } else { target = b }
+			result = b.handlerJumpExpression(
+				func() ssa.Value { return left },
+				func() ssa.Value { return left },
+				func() ssa.Value { return b.VisitExpr(right) },
+			)
+		}
+	}
+	return result
+}
+
+// VisitCompare build `a < b < c` as `a < b and b < c`
+func (b *builder) VisitCompare(expr *pythonparser.Compare) ssa.Value {
+	left := b.VisitExpr(expr.Left)
+	var result ssa.Value
+	for i, opText := range expr.Ops {
+		if i >= len(expr.Comparators) {
+			break
+		}
+		right := b.VisitExpr(expr.Comparators[i])
+		var value ssa.Value
+		switch opText {
+		case "not in":
+			value = b.EmitUnOp(ssa.OpNot, b.EmitBinOp(ssa.OpIn, left, right))
+		default:
+			op, ok := compareOpcode[opText]
+			if !ok {
+				b.NewError(ssa.Error, TAG, BinaryOperatorNotSupport(opText))
+				return nil
+			}
+			value = b.EmitBinOp(op, left, right)
+		}
+		if result == nil {
+			result = value
+		} else {
+			result = b.EmitBinOp(ssa.OpLogicAnd, result, value)
+		}
+		left = right
+	}
+	return result
+}
+
+// handlerJumpExpression build short-circuit expression, the right expression only build in branch,
+// all branch assign to the same variable, and read it after if-statement to generate phi
+func (b *builder) handlerJumpExpression(cond, trueExpr, falseExpr func() ssa.Value) ssa.Value {
+	id := uuid.NewString()
+	variable := b.CreateVariable(id)
+	b.AssignVariable(variable, b.EmitValueOnlyDeclare(id))
+	ifb := b.CreateIfBuilder()
+	ifb.AppendItem(
+		cond,
+		func() {
+			b.AssignVariable(b.CreateVariable(id), trueExpr())
+		},
+	)
+	ifb.SetElse(func() {
+		b.AssignVariable(b.CreateVariable(id), falseExpr())
+	})
+	ifb.Build()
+	return b.ReadValue(id)
+}
+
+// VisitAttribute build `a.b`, the member of imported module is read from the module directly
+func (b *builder) VisitAttribute(expr *pythonparser.Attribute) ssa.Value {
+	if module := b.attributeModule(expr.Value); module != nil {
+		if value := b.ReadModuleMember(module, expr.Attr); value != nil {
+			return value
+		}
+	}
+	object := b.VisitExpr(expr.Value)
+	if object == nil {
+		return nil
+	}
+	return b.ReadMemberCallVariable(object, b.EmitConstInst(expr.Attr))
+}
+
+func (b *builder) VisitCall(call *pythonparser.Call) ssa.Value {
+	if class := b.calleeClass(call.Func); class != nil {
+		return b.newInstance(class, b.visitCallArgs(call))
+	}
+
+	target := b.VisitExpr(call.Func)
+	if target == nil {
+		return nil
+	}
+	args := b.visitCallArgs(call)
+	c := b.NewCall(target, args)
+	if n := len(call.Args); n > 0 {
+		if _, ok := call.Args[n-1].(*pythonparser.Starred); ok && len(call.Keywords) == 0 {
+			c.IsEllipsis = true
+		}
+	}
+	if c = b.EmitCall(c); c == nil {
+		return nil
+	}
+	return c
+}
+
+// visitCallArgs build the arguments of call, the keyword argument is passed after positional argument
+func (b *builder) visitCallArgs(call *pythonparser.Call) []ssa.Value {
+	args := b.VisitExprList(call.Args)
+	for _, keyword := range call.Keywords {
+		if value := b.VisitExpr(keyword.Value); value != nil {
+			args = append(args, value)
+		}
+	}
+	return args
+}
+
+// calleeClass get the class when call is instantiation, `A()` or `module.A()`
+func (b *builder) calleeClass(expr pythonparser.Expr) *ssa.ClassBluePrint {
+	switch callee := expr.(type) {
+	case *pythonparser.Name:
+		return b.ReadClass(callee.Id)
+	case *pythonparser.Attribute:
+		if module := b.attributeModule(callee.Value); module != nil {
+			return module.GetClass(callee.Attr)
+		}
+	}
+	return nil
+}
+
+// newInstance create object of class and call `__init__` with it as self
+func (b *builder) newInstance(class *ssa.ClassBluePrint, args []ssa.Value) ssa.Value {
+	obj := b.EmitUndefined(class.Name)
+	if obj == nil {
+		return nil
+	}
+	obj.SetType(class)
+	if class.Constructor != nil {
+		b.EmitCall(b.NewCall(class.Constructor, append([]ssa.Value{obj}, args...)))
+	}
+	return obj
+}
+
+// buildSequence build list, tuple and set, the element is the member indexed by position
+func (b *builder) buildSequence(elts []pythonparser.Expr) ssa.Value {
+	values := b.VisitExprList(elts)
+	return b.InterfaceAddFieldBuild(len(values),
+		func(i int) ssa.Value { return b.EmitConstInst(i) },
+		func(i int) ssa.Value { return values[i] },
+	)
+}
+
+func (b *builder) VisitDict(expr *pythonparser.Dict) ssa.Value {
+	keys := make([]ssa.Value, 0, len(expr.Keys))
+	values := make([]ssa.Value, 0, len(expr.Values))
+	for i, keyExpr := range expr.Keys {
+		if i >= len(expr.Values) {
+			break
+		}
+		value := b.VisitExpr(expr.Values[i])
+		if keyExpr == nil || value == nil {
+			// `{**other}`
+			continue
+		}
+		key := b.VisitExpr(keyExpr)
+		if key == nil {
+			continue
+		}
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	return b.InterfaceAddFieldBuild(len(values),
+		func(i int) ssa.Value { return keys[i] },
+		func(i int) ssa.Value { return values[i] },
+	)
+}
+
+// VisitComprehension build comprehension as nested loop, key is nil except dict comprehension,
+//
+//	[elt for x in iter if cond]
+//	=> for x in iter { if cond { $elt = elt } }; [$elt]
+//
+// the target of comprehension is local in the loop, and the element is merged by phi
+func (b *builder) VisitComprehension(generators []*pythonparser.Comprehension, key, elt pythonparser.Expr) ssa.Value {
+	keyID, eltID := uuid.NewString(), uuid.NewString()
+	b.AssignVariable(b.CreateVariable(eltID), b.EmitValueOnlyDeclare(eltID))
+	if key != nil {
+		b.AssignVariable(b.CreateVariable(keyID), b.EmitValueOnlyDeclare(keyID))
+	}
+
+	var build func(index int)
+	build = func(index int) {
+		if index >= len(generators) {
+			if key != nil {
+				b.AssignVariable(b.CreateVariable(keyID), b.VisitExpr(key))
+			}
+			b.AssignVariable(b.CreateVariable(eltID), b.VisitExpr(elt))
+			return
+		}
+
+		generator := generators[index]
+		var iter ssa.Value
+		loop := b.CreateLoopBuilder()
+		loop.SetFirst(func() []ssa.Value {
+			iter = b.VisitExpr(generator.Iter)
+			if iter == nil {
+				iter = b.EmitUndefined("")
+			}
+			return []ssa.Value{iter}
+		})
+		loop.SetCondition(func() ssa.Value {
+			value, field, ok := b.EmitNext(iter, true)
+			b.assignComprehensionTarget(generator.Target, value)
+			ssa.DeleteInst(field)
+			return ok
+		})
+		loop.SetBody(func() {
+			if len(generator.Ifs) == 0 {
+				build(index + 1)
+				return
+			}
+			ifb := b.CreateIfBuilder()
+			ifb.AppendItem(
+				func() ssa.Value {
+					var cond ssa.Value
+					for _, test := range generator.Ifs {
+						value := b.VisitExpr(test)
+						if cond == nil {
+							cond = value
+						} else if value != nil {
+							cond = b.EmitBinOp(ssa.OpLogicAnd, cond, value)
+						}
+					}
+					return cond
+				},
+				func() {
+					build(index + 1)
+				},
+			)
+			ifb.Build()
+		})
+		loop.Finish()
+	}
+	b.blockDepth++
+	build(0)
+	b.blockDepth--
+
+	values := []ssa.Value{b.ReadValue(eltID)}
+	keys := []ssa.Value{b.EmitConstInst(0)}
+	if key != nil {
+		keys[0] = b.ReadValue(keyID)
+	}
+	return b.InterfaceAddFieldBuild(len(values),
+		func(i int) ssa.Value { return keys[i] },
+		func(i int) ssa.Value { return values[i] },
+	)
+}
+
+// assignComprehensionTarget bind the target of comprehension, it's not visible outside the comprehension
+func (b *builder) assignComprehensionTarget(target pythonparser.Expr, value ssa.Value) {
+	if name, ok := target.(*pythonparser.Name); ok {
+		if value != nil {
+			b.AssignVariable(b.CreateLocalVariable(name.Id), value)
+		}
+		return
+	}
+	b.AssignTarget(target, value)
+}
@@ -0,0 +1,217 @@
+package python2ssa
+
+import (
+	pythonparser "github.com/yaklang/yaklang/common/yak/python/parser"
+	"github.com/yaklang/yaklang/common/yak/ssa"
+)
+
+// VisitFunctionDef declare the function, the body is built at the end of current scope
+func (b *builder) VisitFunctionDef(def *pythonparser.FunctionDef) {
+	decorators := b.VisitExprList(def.DecoratorList)
+	defaults := b.visitDefaults(def.Args)
+
+	newFunction := b.NewFunc(def.Name)
+	b.AssignName(def.Name, newFunction)
+	b.decorate(decorators, newFunction)
+	b.pending = append(b.pending, func() {
+		recoverRange := b.SetRangeFromNode(def)
+		defer recoverRange()
+		b.buildFunction(newFunction, nil, def.Args, defaults, func() {
+			b.buildScope(def.Body)
+		})
+	})
+}
+
+func (b *builder) VisitLambda(lambda *pythonparser.Lambda) ssa.Value {
+	defaults := b.visitDefaults(lambda.Args)
+	newFunction := b.NewFunc("")
+	b.buildFunction(newFunction, nil, lambda.Args, defaults, func() {
+		if value := b.VisitExpr(lambda.Body); value != nil {
+			b.EmitReturn([]ssa.Value{value})
+		}
+	})
+	return newFunction
+}
+
+// decorate call decorators from inner to outer, `@a @b def f` is `a(b(f))`,
+// the name is still bound to the origin function, most of decorator return a wrapper of it
+func (b *builder) decorate(decorators []ssa.Value, value ssa.Value) {
+	for i := len(decorators) - 1; i >= 0; i-- {
+		c := b.EmitCall(b.NewCall(decorators[i], []ssa.Value{value}))
+		if c == nil {
+			return
+		}
+		value = c
+	}
+}
+
+// visitDefaults build the default value of parameters in current scope, like python evaluate them when define function
+func (b *builder) visitDefaults(args *pythonparser.Arguments) map[string]ssa.Value {
+	defaults := make(map[string]ssa.Value)
+	if args == nil {
+		return defaults
+	}
+	offset := len(args.Args) - len(args.Defaults)
+	for i, expr := range args.Defaults {
+		if offset+i < 0 || offset+i >= len(args.Args) {
+			continue
+		}
+		if value := b.VisitExpr(expr); value != nil {
+			defaults[args.Args[offset+i].Name] = value
+		}
+	}
+	for i, expr := range args.KwDefaults {
+		if expr == nil || i >= len(args.KwOnlyArgs) {
+			continue
+		}
+		if value := b.VisitExpr(expr); value != nil {
+			defaults[args.KwOnlyArgs[i].Name] = value
+		}
+	}
+	return defaults
+}
+
+// buildFunction build the function body, class is not nil for method, its first parameter is the instance
+func (b *builder) buildFunction(
+	fun *ssa.Function, class *ssa.ClassBluePrint,
+	args *pythonparser.Arguments, defaults map[string]ssa.Value,
+	body func(),
+) {
+	globals, blockDepth := b.globals, b.blockDepth
+	b.FunctionBuilder = b.PushFunction(fun)
+	b.globals = make(map[string]struct{})
+	b.blockDepth = 0
+
+	if class != nil {
+		b.MarkedThisClassBlueprint = class
+	}
+	b.VisitArguments(args, class, defaults)
+	body()
+
+	b.Finish()
+	b.globals, b.blockDepth = globals, blockDepth
+	b.FunctionBuilder = b.PopFunction()
+}
+
+func (b *builder) VisitArguments(args *pythonparser.Arguments, class *ssa.ClassBluePrint, defaults map[string]ssa.Value) {
+	if args == nil {
+		return
+	}
+	newParam := func(arg *pythonparser.Arg) *ssa.Parameter {
+		recoverRange := b.SetRangeFromNode(arg)
+		defer recoverRange()
+		param := b.NewParam(arg.Name)
+		if value, ok := defaults[arg.Name]; ok {
+			param.SetDefault(value)
+		}
+		return param
+	}
+
+	for i, arg := range args.Args {
+		param := newParam(arg)
+		if i == 0 && class != nil {
+			// self
+			param.SetType(class)
+		}
+	}
+	if args.VarArg != nil {
+		newParam(args.VarArg)
+		if len(args.KwOnlyArgs) == 0 && args.KwArg == nil {
+			b.HandlerEllipsis()
+		}
+	}
+	for _, arg := range args.KwOnlyArgs {
+		newParam(arg)
+	}
+	if args.KwArg != nil {
+		newParam(args.KwArg)
+	}
+}
+
+// VisitClassDef build class as blueprint, the method is bound to class,
+// and the simple assignment in class body is the member of class
+func (b *builder) VisitClassDef(def *pythonparser.ClassDef) {
+	decorators := b.VisitExprList(def.DecoratorList)
+
+	class := b.CreateClassBluePrint(def.Name)
+	for _, base := range def.Bases {
+		if parent := b.calleeClass(base); parent != nil {
+			class.AddParentClass(parent)
+		} else {
+			b.VisitExpr(base)
+		}
+	}
+	for _, keyword := range def.Keywords {
+		b.VisitExpr(keyword.Value)
+	}
+	b.AssignClass(def.Name, class)
+
+	for _, raw := range def.Body {
+		recoverRange := b.SetRangeFromNode(raw)
+		switch stmt := raw.(type) {
+		case *pythonparser.FunctionDef:
+			b.VisitMethodDef(class, stmt)
+		case *pythonparser.Assign:
+			value := b.VisitExpr(stmt.Value)
+			for _, target := range stmt.Targets {
+				if name, ok := target.(*pythonparser.Name); ok && value != nil {
+					class.AddNormalMember(name.Id, value)
+				} else {
+					b.AssignTarget(target, value)
+				}
+			}
+		case *pythonparser.AnnAssign:
+			if name, ok := stmt.Target.(*pythonparser.Name); ok && stmt.Value != nil {
+				if value := b.VisitExpr(stmt.Value); value != nil {
+					class.AddNormalMember(name.Id, value)
+				}
+			}
+		default:
+			b.VisitStmt(raw)
+		}
+		recoverRange()
+	}
+
+	if len(decorators) > 0 {
+		b.decorate(decorators, b.VisitName(def.Name))
+	}
+}
+
+// VisitMethodDef declare the method of class, `__init__` is the constructor
+func (b *builder) VisitMethodDef(class *ssa.ClassBluePrint, def *pythonparser.FunctionDef) {
+	recoverRange := b.SetRangeFromNode(def)
+	defer recoverRange()
+
+	isStatic := false
+	for _, decorator := range def.DecoratorList {
+		if name, ok := decorator.(*pythonparser.Name); ok && name.Id == "staticmethod" {
+			isStatic = true
+		}
+	}
+	decorators := b.VisitExprList(def.DecoratorList)
+	defaults := b.visitDefaults(def.Args)
+
+	newFunction := b.NewFunc(methodFuncName(class.Name, def.Name))
+	newFunction.SetMethodName(def.Name)
+	if isStatic {
+		class.AddStaticMethod(def.Name, newFunction)
+	} else {
+		class.AddMethod(def.Name, newFunction)
+	}
+	if def.Name == "__init__" {
+		class.Constructor = newFunction
+	}
+	b.decorate(decorators, newFunction)
+
+	self := class
+	if isStatic {
+		self = nil
+	}
+	b.pending = append(b.pending, func() {
+		recoverRange := b.SetRangeFromNode(def)
+		defer recoverRange()
+		b.buildFunction(newFunction, self, def.Args, defaults, func() {
+			b.buildScope(def.Body)
+		})
+	})
+}
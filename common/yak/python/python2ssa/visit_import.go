@@ -0,0 +1,285 @@
+package python2ssa
+
+import (
+	"strings"
+
+	"github.com/yaklang/yaklang/common/log"
+	"github.com/yaklang/yaklang/common/utils/filesys"
+	"github.com/yaklang/yaklang/common/utils/memedit"
+	pythonparser "github.com/yaklang/yaklang/common/yak/python/parser"
+	"github.com/yaklang/yaklang/common/yak/ssa"
+)
+
+// moduleFuncName is the function of module top level code, the package of it is the file path
+const moduleFuncName = "main"
+
+// pyModule is the module or package found in project,
+// file is empty for namespace package, dir is empty for module which is not a package
+type pyModule struct {
+	name    string
+	file    string
+	dir     string
+	builder *ssa.FunctionBuilder
+
+	submodules map[string]*pyModule
+}
+
+func (m *pyModule) GetClass(name string) *ssa.ClassBluePrint {
+	if m == nil || m.builder == nil {
+		return nil
+	}
+	return m.builder.GetClassBluePrint(name)
+}
+
+func importLocalName(alias *pythonparser.Alias) string {
+	if alias.AsName != "" {
+		return alias.AsName
+	}
+	// `import a.b` bind the name `a`
+	return strings.SplitN(alias.Name, ".", 2)[0]
+}
+
+// VisitImport build `import a.b as c`, the module found in project is recorded to read its member,
+// the value of module is a placeholder, so the member of module not found in project can be searched by name
+func (b *builder) VisitImport(stmt *pythonparser.Import) {
+	for _, alias := range stmt.Names {
+		recoverRange := b.SetRangeFromNode(alias)
+		local := importLocalName(alias)
+		if alias.AsName != "" {
+			if module := b.LoadModule(alias.Name, 0); module != nil {
+				b.modules[local] = module
+			}
+			b.AssignName(local, b.importPlaceholder(alias.Name))
+		} else {
+			if module := b.LoadModule(local, 0); module != nil {
+				b.modules[local] = module
+				if local != alias.Name {
+					// build the submodule
+					b.LoadModule(alias.Name, 0)
+				}
+			}
+			b.AssignName(local, b.importPlaceholder(local))
+		}
+		recoverRange()
+	}
+}
+
+// VisitImportFrom build `from ..a import b as c`, the name is resolved in order:
+// the member of module, the submodule of package, and the member of placeholder if module not found
+func (b *builder) VisitImportFrom(stmt *pythonparser.ImportFrom) {
+	module := b.LoadModule(stmt.Module, stmt.Level)
+	for _, alias := range stmt.Names {
+		recoverRange := b.SetRangeFromNode(alias)
+		b.importFrom(stmt, module, alias)
+		recoverRange()
+	}
+}
+
+func (b *builder) importFrom(stmt *pythonparser.ImportFrom, module *pyModule, alias *pythonparser.Alias) {
+	if alias.Name == "*" {
+		log.Warnf("import * from %v is not supported yet", stmt.Module)
+		return
+	}
+
+	local := importLocalName(alias)
+	if module != nil {
+		if class := module.GetClass(alias.Name); class != nil {
+			b.AssignClass(local, class)
+		}
+		if value := b.ReadModuleMember(module, alias.Name); value != nil {
+			b.AssignName(local, value)
+			return
+		}
+		if sub := b.Submodule(module, alias.Name); sub != nil {
+			b.modules[local] = sub
+			b.AssignName(local, b.EmitUndefined(alias.Name))
+			return
+		}
+	}
+
+	if stmt.Module == "" {
+		// `from . import a` but not found
+		b.AssignName(local, b.EmitUndefined(alias.Name))
+		return
+	}
+	placeholder := b.importPlaceholder(stmt.Module)
+	if placeholder == nil {
+		return
+	}
+	b.AssignName(local, b.ReadMemberCallVariable(placeholder, b.EmitConstInst(alias.Name)))
+}
+
+// importPlaceholder create the value of module not found in project, `a.b` is the member b of undefined a
+func (b *builder) importPlaceholder(name string) ssa.Value {
+	parts := strings.Split(name, ".")
+	var value ssa.Value = b.EmitUndefined(parts[0])
+	for _, part := range parts[1:] {
+		if value == nil {
+			return nil
+		}
+		value = b.ReadMemberCallVariable(value, b.EmitConstInst(part))
+	}
+	return value
+}
+
+// attributeModule get the module referred by expression, `a` or `a.b` after `import a.b`
+func (b *builder) attributeModule(expr pythonparser.Expr) *pyModule {
+	switch e := expr.(type) {
+	case *pythonparser.Name:
+		return b.modules[e.Id]
+	case *pythonparser.Attribute:
+		if module := b.attributeModule(e.Value); module != nil {
+			return b.Submodule(module, e.Attr)
+		}
+	}
+	return nil
+}
+
+// ReadModuleMember read the top level name of module
+func (b *builder) ReadModuleMember(module *pyModule, name string) ssa.Value {
+	if module == nil || module.builder == nil {
+		return nil
+	}
+	return module.builder.PeekValueInThisFunction(name)
+}
+
+func (b *builder) getFileSystem() filesys.FileSystem {
+	prog := b.GetProgram()
+	if prog == nil || prog.Loader == nil {
+		return nil
+	}
+	return prog.Loader.GetFilesysFileSystem()
+}
+
+// LoadModule find the module in project and build it, level is the count of leading dots in relative import,
+// return nil if the module is not in project, like standard library and third party package
+func (b *builder) LoadModule(name string, level int) *pyModule {
+	fs := b.getFileSystem()
+	if fs == nil {
+		return nil
+	}
+
+	var parts []string
+	if name != "" {
+		parts = strings.Split(name, ".")
+	}
+
+	var file, dir string
+	if level > 0 {
+		base := b.dir
+		for i := 1; i < level; i++ {
+			base, _ = fs.PathSplit(base)
+		}
+		file, dir = b.lookupModule(base, parts)
+	} else {
+		file, dir = b.lookupModuleInIncludePath(parts)
+		if file == "" && dir == "" {
+			// script style import, the module is in the same directory of current file
+			file, dir = b.lookupModule(b.dir, parts)
+		}
+	}
+	if file == "" && dir == "" {
+		return nil
+	}
+	return b.newModule(name, file, dir)
+}
+
+// Submodule get the submodule of package, `a.b` when module is package `a`
+func (b *builder) Submodule(module *pyModule, name string) *pyModule {
+	if module == nil || module.dir == "" {
+		return nil
+	}
+	if sub, ok := module.submodules[name]; ok {
+		return sub
+	}
+	file, dir := b.lookupModule(module.dir, []string{name})
+	var sub *pyModule
+	if file != "" || dir != "" {
+		sub = b.newModule(module.name+"."+name, file, dir)
+	}
+	module.submodules[name] = sub
+	return sub
+}
+
+func (b *builder) newModule(name, file, dir string) *pyModule {
+	module := &pyModule{
+		name:       name,
+		file:       file,
+		dir:        dir,
+		submodules: make(map[string]*pyModule),
+	}
+	if file != "" {
+		module.builder = b.buildModuleFile(file)
+	}
+	return module
+}
+
+// lookupModule find module `a.b` in base directory, it's `a/b.py` or package `a/b/__init__.py`,
+// or namespace package `a/b/` without `__init__.py`
+func (b *builder) lookupModule(base string, parts []string) (file string, dir string) {
+	fs := b.getFileSystem()
+	if fs == nil {
+		return "", ""
+	}
+	exists := func(path string, isDir bool) bool {
+		info, err := fs.Stat(path)
+		return err == nil && info.IsDir() == isDir
+	}
+
+	target := fs.Join(append([]string{base}, parts...)...)
+	if len(parts) > 0 && exists(target+".py", false) {
+		return target + ".py", ""
+	}
+	if init := fs.Join(target, "__init__.py"); exists(init, false) {
+		return init, target
+	}
+	if len(parts) > 0 && exists(target, true) {
+		return "", target
+	}
+	return "", ""
+}
+
+// lookupModuleInIncludePath find module from the root of project by loader
+func (b *builder) lookupModuleInIncludePath(parts []string) (file string, dir string) {
+	fs := b.getFileSystem()
+	if fs == nil || len(parts) == 0 {
+		return "", ""
+	}
+	loader := b.GetProgram().Loader
+	target := fs.Join(parts...)
+	if path, err := loader.FilePath(target+".py", false); err == nil {
+		return path, ""
+	}
+	if path, err := loader.FilePath(fs.Join(target, "__init__.py"), false); err == nil {
+		dir, _ := fs.PathSplit(path)
+		return path, dir
+	}
+	if path, err := loader.DirPath(target, false); err == nil {
+		return "", path
+	}
+	return "", ""
+}
+
+// buildModuleFile build the module file once, the module is built in its own package,
+// if it's building (circular import), the partial built module is returned
+func (b *builder) buildModuleFile(file string) *ssa.FunctionBuilder {
+	prog := b.GetProgram()
+	if prog.Build == nil {
+		return nil
+	}
+	if prog.GetFunctionFast(file, moduleFuncName) != nil {
+		return prog.GetAndCreateFunctionBuilder(file, moduleFuncName)
+	}
+
+	raw, err := b.getFileSystem().ReadFile(file)
+	if err != nil {
+		b.NewError(ssa.Warn, TAG, ImportModuleFailed(file, err))
+		return nil
+	}
+	builder := prog.GetAndCreateFunctionBuilder(file, moduleFuncName)
+	if err := prog.Build(file, memedit.NewMemEditor(string(raw)), builder); err != nil {
+		b.NewError(ssa.Warn, TAG, ImportModuleFailed(file, err))
+	}
+	builder.Finish()
+	return builder
+}
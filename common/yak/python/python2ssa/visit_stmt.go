@@ -0,0 +1,508 @@
+package python2ssa
+
+import (
+	pythonparser "github.com/yaklang/yaklang/common/yak/python/parser"
+	"github.com/yaklang/yaklang/common/yak/ssa"
+)
+
+// VisitModule build the module body as the main function of current file
+func (b *builder) VisitModule(module *pythonparser.Module) {
+	if b == nil || module == nil {
+		return
+	}
+	recoverRange := b.SetRangeFromNode(module)
+	defer recoverRange()
+
+	b.buildScope(module.Body)
+}
+
+// buildScope build the body of module or function, the function defined in body is built at the end,
+// so it can use the names defined after it, like python resolve name at runtime
+func (b *builder) buildScope(body []pythonparser.Stmt) {
+	pending := b.pending
+	b.pending = nil
+
+	b.declareLocals(body)
+	b.VisitStmtList(body)
+	for _, build := range b.pending {
+		build()
+	}
+	b.pending = pending
+}
+
+// declareLocals declare the names assigned in nested block at the start of scope,
+// python has no block scope, the value assigned in `if` or `for` can be used after it
+func (b *builder) declareLocals(body []pythonparser.Stmt) {
+	var names []string
+	seen := make(map[string]struct{})
+	add := func(name string) {
+		if _, ok := seen[name]; ok || name == "" || name == "_" {
+			return
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+
+	var walk func(stmts []pythonparser.Stmt, nested bool)
+	walk = func(stmts []pythonparser.Stmt, nested bool) {
+		for _, raw := range stmts {
+			if nested {
+				for _, name := range assignedNames(raw) {
+					add(name)
+				}
+			}
+			switch stmt := raw.(type) {
+			case *pythonparser.Global:
+				for _, name := range stmt.Names {
+					b.globals[name] = struct{}{}
+				}
+			case *pythonparser.Nonlocal:
+				for _, name := range stmt.Names {
+					b.globals[name] = struct{}{}
+				}
+			case *pythonparser.If:
+				walk(stmt.Body, true)
+				walk(stmt.Orelse, true)
+			case *pythonparser.While:
+				walk(stmt.Body, true)
+				walk(stmt.Orelse, true)
+			case *pythonparser.For:
+				// the target is assigned in loop header
+				walkTargetNames(stmt.Target, add)
+				walk(stmt.Body, true)
+				walk(stmt.Orelse, true)
+			case *pythonparser.Try:
+				walk(stmt.Body, true)
+				for _, handler := range stmt.Handlers {
+					walk(handler.Body, true)
+				}
+				walk(stmt.Orelse, true)
+				walk(stmt.Finalbody, true)
+			case *pythonparser.With:
+				walk(stmt.Body, nested)
+			case *pythonparser.Match:
+				for _, c := range stmt.Cases {
+					walkTargetNames(c.Pattern, add)
+					walk(c.Body, true)
+				}
+			}
+		}
+	}
+	walk(body, false)
+
+	for _, name := range names {
+		if _, ok := b.globals[name]; ok {
+			continue
+		}
+		if b.PeekValueInThisFunction(name) != nil {
+			// parameter
+			continue
+		}
+		b.AssignVariable(b.CreateLocalVariable(name), b.EmitValueOnlyDeclare(name))
+	}
+}
+
+// assignedNames get the names bound by the statement itself, the nested block is not included
+func assignedNames(raw pythonparser.Stmt) []string {
+	var names []string
+	add := func(name string) {
+		names = append(names, name)
+	}
+	switch stmt := raw.(type) {
+	case *pythonparser.Assign:
+		for _, target := range stmt.Targets {
+			walkTargetNames(target, add)
+		}
+	case *pythonparser.AugAssign:
+		walkTargetNames(stmt.Target, add)
+	case *pythonparser.AnnAssign:
+		if stmt.Value != nil {
+			walkTargetNames(stmt.Target, add)
+		}
+	case *pythonparser.For:
+		walkTargetNames(stmt.Target, add)
+	case *pythonparser.With:
+		for _, item := range stmt.Items {
+			walkTargetNames(item.OptionalVars, add)
+		}
+	case *pythonparser.Import:
+		for _, alias := range stmt.Names {
+			add(importLocalName(alias))
+		}
+	case *pythonparser.ImportFrom:
+		for _, alias := range stmt.Names {
+			if alias.Name != "*" {
+				add(importLocalName(alias))
+			}
+		}
+	case *pythonparser.FunctionDef:
+		add(stmt.Name)
+	case *pythonparser.ClassDef:
+		add(stmt.Name)
+	}
+	return names
+}
+
+// walkTargetNames call handler with every name in assignment target, `a, (b, *c) = ...`
+func walkTargetNames(expr pythonparser.Expr, handler func(string)) {
+	switch target := expr.(type) {
+	case *pythonparser.Name:
+		handler(target.Id)
+	case *pythonparser.Tuple:
+		for _, elt := range target.Elts {
+			walkTargetNames(elt, handler)
+		}
+	case *pythonparser.List:
+		for _, elt := range target.Elts {
+			walkTargetNames(elt, handler)
+		}
+	case *pythonparser.Starred:
+		walkTargetNames(target.Value, handler)
+	case *pythonparser.NamedExpr:
+		// match pattern `case x as y`
+		walkTargetNames(target.Target, handler)
+	}
+}
+
+func (b *builder) VisitStmtList(list []pythonparser.Stmt) {
+	for _, stmt := range list {
+		if b.IsBlockFinish() {
+			// code after return/break/continue is unreachable
+			return
+		}
+		b.VisitStmt(stmt)
+	}
+}
+
+// visitNestedStmtList build the body of branch or loop, the assignment in it is not local to the block
+func (b *builder) visitNestedStmtList(list []pythonparser.Stmt) {
+	b.blockDepth++
+	b.VisitStmtList(list)
+	b.blockDepth--
+}
+
+func (b *builder) VisitStmt(raw pythonparser.Stmt) {
+	if b == nil || raw == nil {
+		return
+	}
+	recoverRange := b.SetRangeFromNode(raw)
+	defer recoverRange()
+
+	switch stmt := raw.(type) {
+	case *pythonparser.ExprStmt:
+		b.VisitExpr(stmt.Value)
+	case *pythonparser.Assign:
+		value := b.VisitExpr(stmt.Value)
+		for _, target := range stmt.Targets {
+			b.AssignTarget(target, value)
+		}
+	case *pythonparser.AugAssign:
+		op, ok := binaryOpcode[stmt.Op]
+		if !ok {
+			b.NewError(ssa.Error, TAG, BinaryOperatorNotSupport(stmt.Op))
+			return
+		}
+		right := b.VisitExpr(stmt.Value)
+		left := b.VisitExpr(stmt.Target)
+		b.AssignTarget(stmt.Target, b.EmitBinOp(op, left, right))
+	case *pythonparser.AnnAssign:
+		if stmt.Value != nil {
+			b.AssignTarget(stmt.Target, b.VisitExpr(stmt.Value))
+		}
+	case *pythonparser.Return:
+		if stmt.Value == nil {
+			b.EmitReturn(nil)
+			return
+		}
+		if value := b.VisitExpr(stmt.Value); value != nil {
+			b.EmitReturn([]ssa.Value{value})
+		}
+	case *pythonparser.Raise:
+		if stmt.Exc == nil {
+			// re-raise current exception
+			b.EmitPanic(b.EmitUndefined(""))
+			return
+		}
+		if exc := b.VisitExpr(stmt.Exc); exc != nil {
+			b.EmitPanic(exc)
+		}
+	case *pythonparser.Assert:
+		cond := b.VisitExpr(stmt.Test)
+		var msg ssa.Value
+		if stmt.Msg != nil {
+			msg = b.VisitExpr(stmt.Msg)
+		}
+		if cond != nil {
+			b.EmitAssert(cond, msg, "")
+		}
+	case *pythonparser.Break:
+		if !b.Break() {
+			b.NewError(ssa.Error, TAG, UnexpectedBranchStmt("break"))
+		}
+	case *pythonparser.Continue:
+		if !b.Continue() {
+			b.NewError(ssa.Error, TAG, UnexpectedBranchStmt("continue"))
+		}
+	case *pythonparser.Import:
+		b.VisitImport(stmt)
+	case *pythonparser.ImportFrom:
+		b.VisitImportFrom(stmt)
+	case *pythonparser.If:
+		b.VisitIf(stmt)
+	case *pythonparser.While:
+		b.VisitWhile(stmt)
+	case *pythonparser.For:
+		b.VisitFor(stmt)
+	case *pythonparser.Try:
+		b.VisitTry(stmt)
+	case *pythonparser.With:
+		for _, item := range stmt.Items {
+			value := b.VisitExpr(item.ContextExpr)
+			if item.OptionalVars != nil {
+				b.AssignTarget(item.OptionalVars, value)
+			}
+		}
+		b.VisitStmtList(stmt.Body)
+	case *pythonparser.Match:
+		b.VisitMatch(stmt)
+	case *pythonparser.FunctionDef:
+		b.VisitFunctionDef(stmt)
+	case *pythonparser.ClassDef:
+		b.VisitClassDef(stmt)
+	case *pythonparser.Delete:
+		for _, target := range stmt.Targets {
+			b.VisitExpr(target)
+		}
+	case *pythonparser.Pass, *pythonparser.Global, *pythonparser.Nonlocal:
+		// global and nonlocal are collected when declare locals
+	default:
+		b.NewError(ssa.Warn, TAG, UnsupportedStatement(raw))
+	}
+}
+
+// AssignTarget assign value to the target of assignment, `a`, `a.b`, `a[1]` or `a, *b`
+func (b *builder) AssignTarget(target pythonparser.Expr, value ssa.Value) {
+	if value == nil || target == nil {
+		return
+	}
+	recoverRange := b.SetRangeFromNode(target)
+	defer recoverRange()
+
+	switch left := target.(type) {
+	case *pythonparser.Name:
+		b.AssignName(left.Id, value)
+	case *pythonparser.Attribute:
+		object := b.VisitExpr(left.Value)
+		if object == nil {
+			return
+		}
+		b.AssignVariable(b.CreateMemberCallVariable(object, b.EmitConstInst(left.Attr)), value)
+	case *pythonparser.Subscript:
+		object := b.VisitExpr(left.Value)
+		key := b.VisitExpr(left.Slice)
+		if object == nil || key == nil {
+			return
+		}
+		b.AssignVariable(b.CreateMemberCallVariable(object, key), value)
+	case *pythonparser.Tuple:
+		b.assignUnpack(left.Elts, value)
+	case *pythonparser.List:
+		b.assignUnpack(left.Elts, value)
+	case *pythonparser.Starred:
+		b.AssignTarget(left.Value, value)
+	default:
+		b.NewError(ssa.Error, TAG, UnsupportedAssignTarget(target))
+	}
+}
+
+// assignUnpack handle `a, b = value`, every element get the member of value by index
+func (b *builder) assignUnpack(elts []pythonparser.Expr, value ssa.Value) {
+	if c, ok := value.(*ssa.Call); ok {
+		c.Unpack = true
+	}
+	for i, elt := range elts {
+		if starred, ok := elt.(*pythonparser.Starred); ok {
+			// `a, *b = value`, b is the rest of value
+			b.AssignTarget(starred.Value, value)
+			continue
+		}
+		b.AssignTarget(elt, b.ReadMemberCallVariable(value, b.EmitConstInst(i)))
+	}
+}
+
+// AssignName bind value to name, the variable is local unless it's declared by `global` or `nonlocal`,
+// in nested block the variable should be captured by the outer block to generate phi
+func (b *builder) AssignName(name string, value ssa.Value) {
+	if value == nil {
+		return
+	}
+	var variable *ssa.Variable
+	if _, ok := b.globals[name]; ok || b.blockDepth > 0 {
+		variable = b.CreateVariable(name)
+	} else {
+		variable = b.CreateLocalVariable(name)
+	}
+	b.AssignVariable(variable, value)
+}
+
+func (b *builder) VisitIf(stmt *pythonparser.If) {
+	builder := b.CreateIfBuilder()
+	var build func(stmt *pythonparser.If) func()
+	build = func(stmt *pythonparser.If) func() {
+		builder.AppendItem(
+			func() ssa.Value {
+				return b.VisitExpr(stmt.Test)
+			},
+			func() {
+				b.visitNestedStmtList(stmt.Body)
+			},
+		)
+		if len(stmt.Orelse) == 0 {
+			return nil
+		}
+		if elif, ok := stmt.Orelse[0].(*pythonparser.If); ok && len(stmt.Orelse) == 1 {
+			return build(elif)
+		}
+		return func() {
+			b.visitNestedStmtList(stmt.Orelse)
+		}
+	}
+	builder.SetElse(build(stmt))
+	builder.Build()
+}
+
+// VisitWhile build while loop, the else block run when loop exit without break,
+// it's built after the loop
+func (b *builder) VisitWhile(stmt *pythonparser.While) {
+	loop := b.CreateLoopBuilder()
+	loop.SetCondition(func() ssa.Value {
+		condition := b.VisitExpr(stmt.Test)
+		if condition == nil {
+			condition = b.EmitConstInst(true)
+		}
+		return condition
+	})
+	loop.SetBody(func() {
+		b.visitNestedStmtList(stmt.Body)
+	})
+	loop.Finish()
+	b.visitNestedStmtList(stmt.Orelse)
+}
+
+// VisitFor build `for target in iter`, the target is the element of iter like yak `for x in list`
+func (b *builder) VisitFor(stmt *pythonparser.For) {
+	loop := b.CreateLoopBuilder()
+	var iter ssa.Value
+	loop.SetFirst(func() []ssa.Value {
+		iter = b.VisitExpr(stmt.Iter)
+		if iter == nil {
+			iter = b.EmitUndefined("")
+		}
+		return []ssa.Value{iter}
+	})
+	loop.SetCondition(func() ssa.Value {
+		key, field, ok := b.EmitNext(iter, true)
+		b.blockDepth++
+		b.AssignTarget(stmt.Target, key)
+		b.blockDepth--
+		ssa.DeleteInst(field)
+		return ok
+	})
+	loop.SetBody(func() {
+		b.visitNestedStmtList(stmt.Body)
+	})
+	loop.Finish()
+	b.visitNestedStmtList(stmt.Orelse)
+}
+
+// VisitTry build try statement, the else block is built at the end of try block
+func (b *builder) VisitTry(stmt *pythonparser.Try) {
+	tryBuilder := b.BuildTry()
+	tryBuilder.BuildTryBlock(func() {
+		b.visitNestedStmtList(stmt.Body)
+		b.visitNestedStmtList(stmt.Orelse)
+	})
+	for _, handler := range stmt.Handlers {
+		handler := handler
+		tryBuilder.BuildErrorCatch(func() string {
+			if handler.Type != nil {
+				b.VisitExpr(handler.Type)
+			}
+			return handler.Name
+		}, func() {
+			recoverRange := b.SetRangeFromNode(handler)
+			defer recoverRange()
+			b.visitNestedStmtList(handler.Body)
+		})
+	}
+	if len(stmt.Finalbody) > 0 {
+		tryBuilder.BuildFinally(func() {
+			b.visitNestedStmtList(stmt.Finalbody)
+		})
+	}
+	tryBuilder.Finish()
+}
+
+// VisitMatch build match statement as if-elif chain, the capture pattern bind the subject to name
+func (b *builder) VisitMatch(stmt *pythonparser.Match) {
+	subject := b.VisitExpr(stmt.Subject)
+	if subject == nil || len(stmt.Cases) == 0 {
+		return
+	}
+
+	builder := b.CreateIfBuilder()
+	for _, c := range stmt.Cases {
+		c := c
+		builder.AppendItem(
+			func() ssa.Value {
+				recoverRange := b.SetRangeFromNode(c)
+				defer recoverRange()
+				cond := b.matchPattern(c.Pattern, subject)
+				if c.Guard != nil {
+					if guard := b.VisitExpr(c.Guard); guard != nil {
+						cond = b.EmitBinOp(ssa.OpLogicAnd, cond, guard)
+					}
+				}
+				return cond
+			},
+			func() {
+				b.visitNestedStmtList(c.Body)
+			},
+		)
+	}
+	builder.Build()
+}
+
+// matchPattern bind the capture name in pattern and return the condition of pattern
+func (b *builder) matchPattern(pattern pythonparser.Expr, subject ssa.Value) ssa.Value {
+	b.blockDepth++
+	defer func() {
+		b.blockDepth--
+	}()
+
+	switch p := pattern.(type) {
+	case *pythonparser.Name:
+		if p.Id != "_" {
+			b.AssignName(p.Id, subject)
+		}
+		return b.EmitConstInst(true)
+	case *pythonparser.NamedExpr:
+		cond := b.matchPattern(p.Value, subject)
+		b.AssignTarget(p.Target, subject)
+		return cond
+	case *pythonparser.Tuple, *pythonparser.List, *pythonparser.Dict, *pythonparser.Call:
+		// sequence, mapping and class pattern, just bind the capture name
+		walkTargetNames(pattern, func(name string) {
+			if name != "_" {
+				b.AssignName(name, b.ReadMemberCallVariable(subject, b.EmitConstInst(name)))
+			}
+		})
+		return b.EmitUndefined("")
+	default:
+		value := b.VisitExpr(pattern)
+		if value == nil {
+			return b.EmitConstInst(true)
+		}
+		return b.EmitBinOp(ssa.OpEq, subject, value)
+	}
+}
@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"testing"
+
+	test "github.com/yaklang/yaklang/common/yak/ssaapi/test/ssatest"
+)
+
+func TestPython_Basic(t *testing.T) {
+	t.Run("assign and binary", func(t *testing.T) {
+		test.CheckPrintlnValue(`
+a = 1
+b = a + 2
+println(b)
+a, c = 3, "c"
+println(a)
+println(c)
+`, []string{"3", "3", `"c"`}, t)
+	})
+
+	t.Run("augmented assign", func(t *testing.T) {
+		test.CheckPrintlnValue(`
+a = 1
+a += 2
+println(a)
+`, []string{"3"}, t)
+	})
+
+	t.Run("if elif and phi", func(t *testing.T) {
+		test.CheckPrintlnValue(`
+a = 1
+if cond():
+    a = 2
+elif cond2():
+    a = 3
+println(a)
+`, []string{"phi(a)[2,3,1]"}, t)
+	})
+
+	t.Run("variable first defined in block", func(t *testing.T) {
+		test.CheckPrintlnValue(`
+if c:
+    y = 1
+println(y)
+`, []string{"phi(y)[1,Undefined-y]"}, t)
+	})
+
+	t.Run("for loop", func(t *testing.T) {
+		test.CheckPrintlnValue(`
+a = 0
+for i in range(10):
+    a = a + i
+println(a)
+`, []string{"phi(a)[0,add(a, Undefined-i(valid))]"}, t)
+	})
+
+	t.Run("f-string", func(t *testing.T) {
+		test.CheckPrintlnValue(`
+s = f"hello {name}!"
+println(s)
+`, []string{`add(add("hello ", Undefined-name), "!")`}, t)
+	})
+}
+
+func TestPython_Function(t *testing.T) {
+	t.Run("default parameter", func(t *testing.T) {
+		test.CheckPrintlnValue(`
+def f(x, y=2):
+    return x + y
+println(f(1))
+`, []string{"Function-f(1)"}, t)
+	})
+
+	t.Run("closure", func(t *testing.T) {
+		test.CheckPrintlnValue(`
+def outer():
+    v = 1
+    def inner():
+        return v
+    return inner()
+println(outer())
+`, []string{"Function-outer()"}, t)
+	})
+
+	t.Run("class method and member", func(t *testing.T) {
+		test.CheckPrintlnValue(`
+class A:
+    def __init__(self, v):
+        self.v = v
+    def get(self):
+        return self.v
+a = A(1)
+println(a.get())
+println(a.v)
+`, []string{"Undefined-a.get(valid)(Undefined-a)", "Undefined-a.v"}, t)
+	})
+}
@@ -0,0 +1,49 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yaklang/yaklang/common/utils/filesys"
+	"github.com/yaklang/yaklang/common/yak/ssaapi"
+)
+
+func TestPython_Import(t *testing.T) {
+	vfs := filesys.NewVirtualFs()
+	vfs.AddFile("app/__init__.py", "")
+	vfs.AddFile("app/utils.py", `
+import subprocess
+
+def run(cmd):
+    return subprocess.check_output(cmd, shell=True)
+`)
+	vfs.AddFile("app/views.py", `
+from flask import request
+from .utils import run
+from app import utils
+import app.utils as u
+
+def index():
+    run(request.args.get("relative"))
+    utils.run(request.args.get("submodule"))
+    u.run(request.args.get("alias"))
+`)
+
+	progs, err := ssaapi.ParseProject(vfs, ssaapi.WithLanguage("python"))
+	require.NoError(t, err)
+	require.NotEmpty(t, progs)
+
+	var got []string
+	for _, prog := range progs {
+		res, err := prog.SyntaxFlowWithError(`subprocess.check_output(* #-> as $src)`)
+		require.NoError(t, err)
+		for _, v := range res.GetValues("src") {
+			got = append(got, v.String())
+		}
+	}
+	all := strings.Join(got, "\n")
+	for _, want := range []string{`"relative"`, `"submodule"`, `"alias"`} {
+		require.Contains(t, all, want)
+	}
+}
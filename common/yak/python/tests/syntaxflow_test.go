@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/yaklang/yaklang/common/yak/ssaapi"
+	test "github.com/yaklang/yaklang/common/yak/ssaapi/test/ssatest"
+)
+
+func TestPython_SyntaxFlow_Flask(t *testing.T) {
+	code := `
+import os
+import subprocess
+import pickle
+from flask import Flask, request
+
+app = Flask(__name__)
+
+@app.route("/run")
+def run():
+    cmd = request.args.get("cmd")
+    os.system(cmd)
+    subprocess.Popen(cmd, shell=True)
+    data = pickle.loads(request.data)
+    return eval(request.form["expr"])
+`
+	t.Run("command injection", func(t *testing.T) {
+		test.CheckSyntaxFlowContain(t, code,
+			`os.system(* #-> as $system); subprocess.Popen(* #-> as $popen)`,
+			map[string][]string{
+				"system": {"Undefined-request.args.get(valid)", `"cmd"`},
+				"popen":  {"Undefined-request.args.get(valid)", `"cmd"`},
+			}, ssaapi.WithLanguage("python"))
+	})
+
+	t.Run("deserialization and eval", func(t *testing.T) {
+		test.CheckSyntaxFlowContain(t, code,
+			`pickle.loads(* #-> as $loads); eval(* #-> as $eval)`,
+			map[string][]string{
+				"loads": {"ParameterMember-freeValue-request.data"},
+				"eval":  {"ParameterMember-freeValue-request.form"},
+			}, ssaapi.WithLanguage("python"))
+	})
+
+	t.Run("route decorator", func(t *testing.T) {
+		test.CheckSyntaxFlowContain(t, code,
+			`app.route()() as $route`,
+			map[string][]string{
+				"route": {`Undefined-app.route(valid)("/run")(Function-run)`},
+			}, ssaapi.WithLanguage("python"))
+	})
+}
+
+func TestPython_SyntaxFlow_Comprehension(t *testing.T) {
+	test.CheckSyntaxFlowContain(t, `
+import os
+cmds = [c.strip() for c in input().split(",") if c]
+os.system(cmds[0])
+`, `os.system(* #-> as $a)`, map[string][]string{
+		"a": {"Undefined-input"},
+	}, ssaapi.WithLanguage("python"))
+}
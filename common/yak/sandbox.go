@@ -3,19 +3,24 @@ package yak
 import (
 	"sync"
 
+	"github.com/yaklang/yaklang/common/log"
+	"github.com/yaklang/yaklang/common/schema"
 	"github.com/yaklang/yaklang/common/utils"
 	"github.com/yaklang/yaklang/common/yak/antlr4yak"
 	"github.com/yaklang/yaklang/common/yak/yaklang"
+	"github.com/yaklang/yaklang/common/yak/yakpolicy"
 )
 
 type Sandbox struct {
-	config *SandboxConfig
-	engine *antlr4yak.Engine
-	mutex  *sync.Mutex
+	config   *SandboxConfig
+	engine   *antlr4yak.Engine
+	enforcer *yakpolicy.Enforcer
+	mutex    *sync.Mutex
 }
 
 type SandboxConfig struct {
-	lib map[string]any
+	lib    map[string]any
+	policy *yakpolicy.Policy
 }
 
 type SandboxOption func(*SandboxConfig)
@@ -31,6 +36,40 @@ func WithSandbox_ExternalLib(lib map[string]any) SandboxOption {
 	}
 }
 
+// WithSandbox_Policy 使用沙箱策略限制表达式的执行
+func WithSandbox_Policy(p *yakpolicy.Policy) SandboxOption {
+	return func(config *SandboxConfig) {
+		config.policy = p
+	}
+}
+
+// withSandboxPolicyName 使用内置策略名或 JSON 格式的策略，无法解析时使用最严格的 isolated 策略
+func withSandboxPolicyName(s string) SandboxOption {
+	p, err := yakpolicy.Resolve(s)
+	if err != nil {
+		log.Errorf("resolve sandbox policy failed: %v, use %v policy", err, yakpolicy.PolicyIsolated)
+		p, _ = yakpolicy.Named(yakpolicy.PolicyIsolated)
+	}
+	return WithSandbox_Policy(p)
+}
+
+// PluginSandboxPolicy 返回执行插件时使用的沙箱策略，插件没有设置时不限制
+func PluginSandboxPolicy(script *schema.YakScript) string {
+	if script == nil {
+		return ""
+	}
+	return script.SandboxPolicy
+}
+
+// ResolvePluginSandboxPolicy 解析执行插件时使用的沙箱策略，不限制时返回 nil
+func ResolvePluginSandboxPolicy(script *schema.YakScript) (*yakpolicy.Policy, error) {
+	p, err := yakpolicy.Resolve(PluginSandboxPolicy(script))
+	if err != nil {
+		return nil, utils.Errorf("plugin %v: %v", script.ScriptName, err)
+	}
+	return p, nil
+}
+
 func NewSandbox(opts ...SandboxOption) *Sandbox {
 	c := &SandboxConfig{}
 	for _, opt := range opts {
@@ -41,11 +80,16 @@ func NewSandbox(opts ...SandboxOption) *Sandbox {
 		c.lib = make(map[string]any)
 	}
 	s := yaklang.NewSandbox(c.lib)
+	var enforcer *yakpolicy.Enforcer
+	if !c.policy.IsUnrestricted() {
+		enforcer = yakpolicy.Apply(s, c.policy)
+	}
 
 	return &Sandbox{
-		config: c,
-		engine: s,
-		mutex:  new(sync.Mutex),
+		config:   c,
+		engine:   s,
+		enforcer: enforcer,
+		mutex:    new(sync.Mutex),
 	}
 }
 
@@ -53,7 +97,8 @@ func (s Sandbox) ExecuteAsExpressionRaw(code string, vars map[string]any) (ret a
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	return s.engine.ExecuteAsExpression(code, vars)
+	ret, err = s.engine.ExecuteAsExpression(code, vars)
+	return ret, s.enforcer.Err(err)
 }
 
 func (s *Sandbox) ExecuteAsExpression(code string, vars ...any) (ret any, err error) {
@@ -66,7 +111,8 @@ func (s *Sandbox) ExecuteAsExpression(code string, vars ...any) (ret any, err er
 
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	return s.engine.ExecuteAsExpression(code, merged)
+	ret, err = s.engine.ExecuteAsExpression(code, merged)
+	return ret, s.enforcer.Err(err)
 }
 
 func (s *Sandbox) ExecuteAsBoolean(code string, vars ...any) (ret bool, err error) {
@@ -79,10 +125,12 @@ func (s *Sandbox) ExecuteAsBoolean(code string, vars ...any) (ret bool, err erro
 
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	return s.engine.ExecuteAsBooleanExpression(code, merged)
+	ret, err = s.engine.ExecuteAsBooleanExpression(code, merged)
+	return ret, s.enforcer.Err(err)
 }
 
 var SandboxExports = map[string]any{
 	"Create":  NewSandbox,
 	"library": WithSandbox_ExternalLib,
+	"policy":  withSandboxPolicyName,
 }
@@ -3,6 +3,7 @@ package yak
 import (
 	"bytes"
 	"context"
+	"crypto/ed25519"
 	"fmt"
 	"io"
 	"os"
@@ -40,6 +41,7 @@ import (
 	"github.com/yaklang/yaklang/common/pcapx"
 	"github.com/yaklang/yaklang/common/rpa"
 	"github.com/yaklang/yaklang/common/sca"
+	"github.com/yaklang/yaklang/common/schema"
 	"github.com/yaklang/yaklang/common/simulator"
 	"github.com/yaklang/yaklang/common/systemd"
 	"github.com/yaklang/yaklang/common/t3"
@@ -51,11 +53,13 @@ import (
 	"github.com/yaklang/yaklang/common/yak/antlr4yak/yakvm"
 	"github.com/yaklang/yaklang/common/yak/httptpl"
 	"github.com/yaklang/yaklang/common/yak/ssaapi"
+	"github.com/yaklang/yaklang/common/yak/yakbundle"
 	"github.com/yaklang/yaklang/common/yak/yakdoc"
 	"github.com/yaklang/yaklang/common/yak/yaklang"
 	"github.com/yaklang/yaklang/common/yak/yaklang/lib/builtin"
 	"github.com/yaklang/yaklang/common/yak/yaklib"
 	"github.com/yaklang/yaklang/common/yak/yaklib/tools"
+	"github.com/yaklang/yaklang/common/yak/yakpolicy"
 	"github.com/yaklang/yaklang/common/yakgrpc/yakit"
 	"github.com/yaklang/yaklang/common/yserx"
 	"github.com/yaklang/yaklang/common/yso"
@@ -194,6 +198,9 @@ func initYaklangLib() {
 	// json
 	yaklang.Import("json", yaklib.JsonExports)
 
+	// 单元测试断言库，assert 为语言关键字，所以使用 asserts
+	yaklang.Import("asserts", yaklib.AssertExports)
+
 	// yaml
 	yaklang.Import("yaml", yaklib.YamlExports)
 
@@ -239,6 +246,7 @@ func initYaklangLib() {
 	yaklang.Import("js", yaklib.JSOttoExports)
 
 	yaklang.Import("db", yaklib.DatabaseExports)
+	yaklang.Import("har", yaklib.HarExports)
 
 	// 判别
 	yaklang.Import("judge", comparer.Exports)
@@ -350,6 +358,10 @@ type ScriptEngine struct {
 	engineHooks []func(engine *antlr4yak.Engine) error
 	// 存储yakc密钥
 	cryptoKey []byte
+	// 执行 yak bundle 时信任的签名公钥，为空时使用环境变量 YAK_BUNDLE_TRUSTED_KEYS
+	bundleTrustedKeys []ed25519.PublicKey
+	// 沙箱策略，为空时不限制
+	policy *yakpolicy.Policy
 	// debug
 	debug         bool
 	debugInit     func(*yakvm.Debugger)
@@ -462,6 +474,30 @@ func (e *ScriptEngine) HookOsExit() {
 	})
 }
 
+// SetCoverage 统计执行的 yak 代码覆盖率，多次执行的结果会累加到同一个 cov 中
+func (e *ScriptEngine) SetCoverage(cov *yakvm.Coverage) {
+	e.RegisterEngineHooks(func(engine *antlr4yak.Engine) error {
+		engine.GetVM().SetCoverage(cov)
+		return nil
+	})
+}
+
+// SetProfiler 对执行的 yak 代码进行采样，采样的开始与停止由调用者控制
+func (e *ScriptEngine) SetProfiler(p *yakvm.Profiler) {
+	e.RegisterEngineHooks(func(engine *antlr4yak.Engine) error {
+		engine.GetVM().SetProfiler(p)
+		return nil
+	})
+}
+
+// SetPolicy 使用沙箱策略限制执行的 yak 代码，违反策略时执行返回 *yakpolicy.Violation
+func (e *ScriptEngine) SetPolicy(p *yakpolicy.Policy) {
+	if p.IsUnrestricted() {
+		p = nil
+	}
+	e.policy = p
+}
+
 func (e *ScriptEngine) Compile(code string) ([]byte, error) {
 	engine := yaklang.New()
 	code = utils.RemoveBOMForString(code)
@@ -469,6 +505,10 @@ func (e *ScriptEngine) Compile(code string) ([]byte, error) {
 }
 
 func (e *ScriptEngine) exec(ctx context.Context, id string, code string, params map[string]interface{}, cache bool) (*antlr4yak.Engine, error) {
+	return e.execWithPolicy(ctx, id, code, params, cache, e.policy)
+}
+
+func (e *ScriptEngine) execWithPolicy(ctx context.Context, id string, code string, params map[string]interface{}, cache bool, policy *yakpolicy.Policy) (*antlr4yak.Engine, error) {
 	e.swg.Add()
 	defer e.swg.Done()
 
@@ -615,17 +655,32 @@ func (e *ScriptEngine) exec(ctx context.Context, id string, code string, params
 		}
 	}
 
+	// 策略在 hook 之后执行，hook 替换的库函数同样受到限制
+	var enforcer *yakpolicy.Enforcer
+	if !policy.IsUnrestricted() {
+		enforcer = yakpolicy.Apply(engine, policy)
+		ctx = enforcer.WithDeadline(ctx)
+	}
+
 	t.isRunning.Set()
+	if yakbundle.IsBundle([]byte(code)) {
+		var opts []yakbundle.LoadOption
+		if len(e.bundleTrustedKeys) > 0 {
+			opts = append(opts, yakbundle.WithTrustedKeys(e.bundleTrustedKeys...))
+		}
+		return engine, enforcer.Err(yakbundle.SafeExec(ctx, engine, []byte(code), opts...))
+	}
 	if antlr4yak.IsYakc([]byte(code)) {
-		return engine, engine.SafeExecYakc(ctx, []byte(code), e.cryptoKey, code)
+		return engine, enforcer.Err(engine.SafeExecYakc(ctx, []byte(code), e.cryptoKey, code))
 	}
 
-	if !e.debug && cache && !engine.HaveEvaluatedCode() {
+	// yakc 缓存中没有 opcode 的源码位置，统计覆盖率或采样时不使用缓存
+	if !e.debug && cache && !engine.HaveEvaluatedCode() && engine.GetVM().GetCoverage() == nil && engine.GetVM().GetProfiler() == nil {
 		if yakcBytes, ok := antlr4yak.HaveYakcCache(code); ok && antlr4yak.IsYakc(yakcBytes) {
-			return engine, engine.SafeExecYakcWithCode(ctx, yakcBytes, e.cryptoKey, code)
+			return engine, enforcer.Err(engine.SafeExecYakcWithCode(ctx, yakcBytes, e.cryptoKey, code))
 		}
 	}
-	return engine, engine.SafeEval(ctx, code)
+	return engine, enforcer.Err(engine.SafeEval(ctx, code))
 }
 
 func (e *ScriptEngine) ExecuteWithTaskID(taskId, code string) error {
@@ -645,6 +700,19 @@ func (e *ScriptEngine) ExecuteWithTaskIDAndParams(ctx context.Context, taskId, c
 	return nil
 }
 
+// ExecutePluginEx 使用插件的沙箱策略执行插件代码，覆盖引擎通过 SetPolicy 设置的策略
+func (e *ScriptEngine) ExecutePluginEx(script *schema.YakScript, params map[string]interface{}) (*antlr4yak.Engine, error) {
+	policy, err := ResolvePluginSandboxPolicy(script)
+	if err != nil {
+		return nil, err
+	}
+	runtimeId := utils.MapGetStringByManyFields(params, "RUNTIME_ID", "RUNTIME_ID", "runtime_id")
+	if runtimeId == "" {
+		runtimeId = uuid.New().String()
+	}
+	return e.execWithPolicy(context.Background(), runtimeId, script.Content, params, true, policy)
+}
+
 func (e *ScriptEngine) ExecuteWithoutCache(code string, params map[string]interface{}) (*antlr4yak.Engine, error) {
 	runtimeId := utils.MapGetStringByManyFields(params, "RUNTIME_ID", "RUNTIME_ID", "runtime_id")
 	if runtimeId == "" {
@@ -803,3 +871,8 @@ func (s *ScriptEngine) SetCryptoKey(key []byte) error {
 	s.cryptoKey = key
 	return nil
 }
+
+// SetBundleTrustedKeys 设置执行 yak bundle 时信任的签名公钥，设置后只执行由这些公钥签名的 bundle
+func (s *ScriptEngine) SetBundleTrustedKeys(keys ...ed25519.PublicKey) {
+	s.bundleTrustedKeys = keys
+}
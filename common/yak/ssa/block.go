@@ -44,7 +44,7 @@ func (f *Function) newBasicBlockEx(name string, isSealed bool, nodAddToBlocks bo
 		Phis:       make([]Value, 0),
 		Handler:    nil,
 		finish:     false,
-		ScopeTable: NewScope(f.GetProgram().GetProgramName()),
+		ScopeTable: NewScope(f.GetProgram().GetProgramName(), f.GetProgram().Cache.CompileHash),
 	}
 	b.SetName(name)
 	b.SetFunc(f)
@@ -43,9 +43,11 @@ func ParseClassBluePrint(this Value, objectTyp *ObjectType) (ret Type) {
 }
 
 func (c *ClassBluePrint) Apply(obj Value) Type {
-
+	// the value not emitted in function yet, just use the blue print as type
+	if utils.IsNil(obj) || obj.GetFunc() == nil || obj.GetFunc().builder == nil {
+		return c
+	}
 	builder := obj.GetFunc().builder
-	_ = builder
 
 	prog := builder.GetProgram()
 
@@ -57,6 +57,9 @@ type FunctionBuilder struct {
 	MarkedThisObject         Value
 	MarkedThisClassBlueprint *ClassBluePrint
 
+	// has variable with declared type in this function
+	hasDeclaredType bool
+
 	parentBuilder *FunctionBuilder
 }
 
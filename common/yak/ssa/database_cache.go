@@ -39,6 +39,7 @@ type instructionIrCode struct {
 // and load the data from database when the data is not in cache.
 type Cache struct {
 	ProgramName      string // mark which program handled
+	CompileHash      string // mark which compile unit of program handled, for incremental compile
 	DB               *gorm.DB
 	id               *atomic.Int64
 	InstructionCache *utils.CacheWithKey[int64, instructionIrCode] // instructionID to instruction
@@ -91,7 +92,7 @@ func (c *Cache) SetInstruction(inst Instruction) {
 	var instIr instructionIrCode
 	if c.DB != nil {
 		// use database
-		rawID, irCode := ssadb.RequireIrCode(c.DB, c.ProgramName)
+		rawID, irCode := ssadb.RequireIrCode(c.DB, c.ProgramName, c.CompileHash)
 		id = int64(rawID)
 		instIr = instructionIrCode{
 			inst:   inst,
@@ -237,7 +238,7 @@ func (c *Cache) saveVariable(variable string, insts []Instruction) bool {
 		log.Errorf("BUG: saveVariable called when DB is nil")
 		return false
 	}
-	if err := ssadb.SaveVariable(c.DB, c.ProgramName, variable, lo.Map(insts, func(ins Instruction, _ int) ssadb.SSAValue {
+	if err := ssadb.SaveVariable(c.DB, c.ProgramName, c.CompileHash, variable, lo.Map(insts, func(ins Instruction, _ int) ssadb.SSAValue {
 		return ins
 	})); err != nil {
 		log.Errorf("SaveVariable error: %v", err)
@@ -251,7 +252,7 @@ func (c *Cache) saveClassInstance(name string, insts []Instruction) {
 		log.Errorf("BUG: saveClassInstance called when DB is nil")
 		return
 	}
-	if err := ssadb.SaveClassInstance(c.DB, c.ProgramName, name,
+	if err := ssadb.SaveClassInstance(c.DB, c.ProgramName, c.CompileHash, name,
 		lo.Map(insts, func(inst Instruction, _ int) int64 {
 			if inst == nil {
 				log.Errorf("BUG: saveClassInstance called with nil instruction %v", name)
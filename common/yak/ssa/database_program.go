@@ -14,3 +14,13 @@ func NewProgramFromDatabase(db *gorm.DB, program string) *Program {
 
 	return prog
 }
+
+// NewProgramFromDatabaseCompileUnit load one compile unit of database program, used by incremental compile
+func NewProgramFromDatabaseCompileUnit(db *gorm.DB, program, compileHash string) *Program {
+	prog := NewProgramFromDatabase(db, program)
+	prog.Cache.CompileHash = compileHash
+	if prog.Cache.DB != nil {
+		prog.Cache.DB = prog.Cache.DB.Where("program_compile_hash = ?", compileHash)
+	}
+	return prog
+}
@@ -1,13 +1,18 @@
 package ssa
 
 import (
+	"embed"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/samber/lo"
+	"github.com/yaklang/yaklang/common/log"
 	"github.com/yaklang/yaklang/common/utils"
 	"github.com/yaklang/yaklang/common/yak/yakdoc"
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -313,3 +318,215 @@ func (b *FunctionBuilder) CoverReflectFunctionType(itype reflect.Type, level int
 func (b *FunctionBuilder) handlerType(typ reflect.Type, level int) Type {
 	return b.GetProgram().handlerType(typ, level)
 }
+
+// extern summary: the data flow model of extern function (no function body),
+// such as `String.format` in jdk or `sprintf` in php, the analyzer use it to
+// propagate the data flow through the extern call.
+
+//go:embed extern_summary
+var externSummaryFS embed.FS
+
+const (
+	ExternSummaryReturn   = "return"
+	ExternSummaryReceiver = "receiver"
+	ExternSummaryParam    = "param"
+)
+
+// ExternSummaryFlow is a data flow in extern function, `From` and `To` is one of:
+// `return`, `receiver`, `param[N]` and `param[*]` (all parameters, use it for variadic function).
+type ExternSummaryFlow struct {
+	From string `yaml:"from"`
+	To   string `yaml:"to"`
+}
+
+// ExternSummary is the data flow model of a extern function, the `Class` is optional,
+// if the class of receiver is unknown, the summary is matched by method name only.
+type ExternSummary struct {
+	Class  string               `yaml:"class"`
+	Method string               `yaml:"method"`
+	Flows  []*ExternSummaryFlow `yaml:"flows"`
+}
+
+type ExternSummaryTable struct {
+	methods map[string][]*ExternSummary
+}
+
+var (
+	externSummaryMutex  sync.Mutex
+	externSummaryTables = make(map[string]*ExternSummaryTable)
+)
+
+func NewExternSummaryTable() *ExternSummaryTable {
+	return &ExternSummaryTable{methods: make(map[string][]*ExternSummary)}
+}
+
+// ParseExternSummary parse the yaml summaries, a list of `{class, method, flows: [{from, to}]}`
+func ParseExternSummary(raw []byte) ([]*ExternSummary, error) {
+	var summaries []*ExternSummary
+	if err := yaml.Unmarshal(raw, &summaries); err != nil {
+		return nil, utils.Errorf("parse extern summary failed: %v", err)
+	}
+	for _, summary := range summaries {
+		if summary.Method == "" {
+			return nil, utils.Errorf("extern summary of class %v without method", summary.Class)
+		}
+		for _, flow := range summary.Flows {
+			for _, endpoint := range []string{flow.From, flow.To} {
+				if _, _, err := ParseExternSummaryEndpoint(endpoint); err != nil {
+					return nil, utils.Errorf("extern summary %v: %v", summary.Method, err)
+				}
+			}
+		}
+	}
+	return summaries, nil
+}
+
+// ParseExternSummaryEndpoint parse `return`, `receiver`, `param[N]` or `param[*]`,
+// the index of `param[*]` is -1
+func ParseExternSummaryEndpoint(endpoint string) (string, int, error) {
+	endpoint = strings.TrimSpace(endpoint)
+	switch endpoint {
+	case ExternSummaryReturn, ExternSummaryReceiver:
+		return endpoint, 0, nil
+	}
+	if strings.HasPrefix(endpoint, ExternSummaryParam+"[") && strings.HasSuffix(endpoint, "]") {
+		index := endpoint[len(ExternSummaryParam)+1 : len(endpoint)-1]
+		if index == "*" {
+			return ExternSummaryParam, -1, nil
+		}
+		if i, err := strconv.Atoi(index); err == nil && i >= 0 {
+			return ExternSummaryParam, i, nil
+		}
+	}
+	return "", 0, utils.Errorf("invalid extern summary endpoint: %v", endpoint)
+}
+
+func (t *ExternSummaryTable) Add(summaries ...*ExternSummary) {
+	for _, summary := range summaries {
+		t.methods[summary.Method] = append(t.methods[summary.Method], summary)
+	}
+}
+
+// RegisterExternSummary add the yaml summaries to the table of language, it can extend the built-in summaries
+func RegisterExternSummary(language string, raw []byte) error {
+	summaries, err := ParseExternSummary(raw)
+	if err != nil {
+		return err
+	}
+	GetExternSummaryTable(language).Add(summaries...)
+	return nil
+}
+
+// GetExternSummaryTable return the summaries of language, the built-in `extern_summary/<language>.yml` is loaded at first
+func GetExternSummaryTable(language string) *ExternSummaryTable {
+	externSummaryMutex.Lock()
+	defer externSummaryMutex.Unlock()
+	if table, ok := externSummaryTables[language]; ok {
+		return table
+	}
+	table := NewExternSummaryTable()
+	if raw, err := externSummaryFS.ReadFile("extern_summary/" + language + ".yml"); err == nil {
+		summaries, err := ParseExternSummary(raw)
+		if err != nil {
+			log.Errorf("load built-in extern summary of %v failed: %v", language, err)
+		} else {
+			table.Add(summaries...)
+		}
+	}
+	externSummaryTables[language] = table
+	return table
+}
+
+// externSummaryClassOfReceiver guess the class name of receiver: static class reference or constructor call
+func externSummaryClassOfReceiver(receiver Value) string {
+	if receiver == nil {
+		return ""
+	}
+	if typ := receiver.GetType(); typ != nil && typ.GetTypeKind() != AnyTypeKind {
+		name := typ.String()
+		return name[strings.LastIndex(name, ".")+1:]
+	}
+	if un, ok := ToUndefined(receiver); ok && !un.IsMember() {
+		return un.GetName()
+	}
+	if call, ok := ToCall(receiver); ok && call.Method != nil && !call.Method.IsMember() {
+		return call.Method.GetName()
+	}
+	return ""
+}
+
+// Match return the summary of the extern call, the flows of all matched summary are merged,
+// return nil if no summary matched.
+func (t *ExternSummaryTable) Match(call *Call) *ExternSummary {
+	if t == nil || call == nil || call.Method == nil {
+		return nil
+	}
+	method := call.Method
+	var name, class string
+	if method.IsMember() {
+		name = GetKeyString(method.GetKey())
+		class = externSummaryClassOfReceiver(method.GetObject())
+	} else {
+		name = method.GetName()
+	}
+	candidates := t.methods[name]
+	if len(candidates) == 0 {
+		return nil
+	}
+	matched := lo.Filter(candidates, func(summary *ExternSummary, _ int) bool {
+		return class == "" || summary.Class == "" || summary.Class == class
+	})
+	if len(matched) == 0 {
+		return nil
+	}
+	ret := &ExternSummary{Class: class, Method: name}
+	for _, summary := range matched {
+		ret.Flows = append(ret.Flows, summary.Flows...)
+	}
+	return ret
+}
+
+// ArgsOf return the arguments of call which flow to `to`
+func (s *ExternSummary) ArgsOf(call *Call, to string) (args []Value, receiver bool) {
+	for _, flow := range s.Flows {
+		if flow.To != to {
+			continue
+		}
+		kind, index, err := ParseExternSummaryEndpoint(flow.From)
+		if err != nil {
+			continue
+		}
+		switch kind {
+		case ExternSummaryReceiver:
+			receiver = true
+		case ExternSummaryParam:
+			if index < 0 {
+				args = append(args, call.Args...)
+			} else if index < len(call.Args) {
+				args = append(args, call.Args[index])
+			}
+		}
+	}
+	return lo.Uniq(args), receiver
+}
+
+// TargetsOf return the targets (`return` or `receiver`) of the argument index, -1 is the receiver
+func (s *ExternSummary) TargetsOf(index int) []string {
+	var targets []string
+	for _, flow := range s.Flows {
+		kind, i, err := ParseExternSummaryEndpoint(flow.From)
+		if err != nil {
+			continue
+		}
+		switch {
+		case kind == ExternSummaryReceiver && index == -1:
+		case kind == ExternSummaryParam && index >= 0 && (i == -1 || i == index):
+		default:
+			continue
+		}
+		if !lo.Contains(targets, flow.To) {
+			targets = append(targets, flow.To)
+		}
+	}
+	return targets
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/yaklang/yaklang/common/utils/filesys"
 	"github.com/yaklang/yaklang/common/utils/memedit"
 	"github.com/yaklang/yaklang/common/utils/omap"
+	"github.com/yaklang/yaklang/common/yak/ssa/ssadb"
 	"github.com/yaklang/yaklang/common/yak/ssa/ssautil"
 )
 
@@ -125,6 +126,17 @@ func (prog *Program) EachFunction(handler func(*Function)) {
 		}
 	}
 
+	if len(prog.Packages) == 0 && prog.Cache != nil && prog.Cache.HaveDatabaseBackend() {
+		// the program loaded from database has no package, all function are lazy instructions,
+		// closure function is saved as function too, so don't handle child functions again.
+		for _, id := range ssadb.GetIrIdsByOpcode(ssadb.GetDB(), prog.Cache.ProgramName, int64(SSAOpcodeFunction)) {
+			if f, ok := ToFunction(prog.GetInstructionById(id)); ok {
+				handler(f)
+			}
+		}
+		return
+	}
+
 	for _, pkg := range prog.Packages {
 		for _, f := range pkg.Funcs {
 			handFunc(f)
@@ -183,9 +183,13 @@ func (f *Function) Finish() {
 		t := p.GetType()
 		return t
 	})
-	funType.ReturnType = handlerReturnType(lo.FilterMap(f.Return, func(i Value, _ int) (*Return, bool) {
-		return ToReturn(i)
-	}))
+	if f.DeclaredReturnType != nil {
+		funType.ReturnType = f.DeclaredReturnType
+	} else {
+		funType.ReturnType = handlerReturnType(lo.FilterMap(f.Return, func(i Value, _ int) (*Return, bool) {
+			return ToReturn(i)
+		}))
+	}
 	funType.IsVariadic = f.hasEllipsis
 	funType.This = f
 	funType.ReturnValue = lo.FilterMap(f.Return, func(i Value, _ int) (*Return, bool) {
@@ -14,9 +14,9 @@ type ScopeIF ssautil.ScopedVersionedTableIF[Value]
 
 var _ ssautil.ScopedVersionedTableIF[Value] = (*ScopeInstance)(nil)
 
-func NewScope(name string) *ScopeInstance {
+func NewScope(name, compileHash string) *ScopeInstance {
 	s := &ScopeInstance{
-		ScopedVersionedTable: ssautil.NewRootVersionedTable[Value](name, NewVariable),
+		ScopedVersionedTable: ssautil.NewRootVersionedTableEx[Value](name, compileHash, NewVariable),
 	}
 	s.SetThis(s)
 	return s
@@ -42,7 +42,7 @@ func GetScopeFromIrScopeId(i int64) *ScopeInstance {
 		log.Warnf("failed to get ir scope: %v", err)
 		return nil
 	}
-	c := NewScope(node.ProgramName)
+	c := NewScope(node.ProgramName, node.ProgramCompileHash)
 	c.SetPersistentId(i)
 	if err != nil {
 		log.Errorf("failed to sync from database: %v", err)
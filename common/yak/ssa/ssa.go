@@ -234,6 +234,8 @@ type Function struct {
 	ChildFuncs []Value // child function within this function
 
 	Return []Value
+	// return type declared by type annotation, nil if not declared
+	DeclaredReturnType Type
 
 	// BasicBlock list
 	Blocks []Instruction
@@ -100,10 +100,13 @@ func emptyIrCode() *IrCode {
 	return &IrCode{}
 }
 
-func RequireIrCode(db *gorm.DB, program string) (uint, *IrCode) {
+func RequireIrCode(db *gorm.DB, program string, compileHash ...string) (uint, *IrCode) {
 	db = db.Model(&IrCode{})
 	ircode := emptyIrCode()
 	ircode.ProgramName = program
+	if len(compileHash) > 0 {
+		ircode.ProgramCompileHash = compileHash[0]
+	}
 	db.Create(ircode)
 	return ircode.ID, ircode
 }
@@ -132,6 +135,15 @@ func GetIrByVariable(db *gorm.DB, program, name string) []*IrCode {
 	return ret
 }
 
+// GetIrIdsByOpcode return the id of all ir codes with opcode in program
+func GetIrIdsByOpcode(db *gorm.DB, program string, opcode int64) []int64 {
+	var ids []int64
+	if err := db.Model(&IrCode{}).Where("program_name = ? AND opcode = ?", program, opcode).Order("id asc").Pluck("id", &ids).Error; err != nil {
+		log.Errorf("get ir codes by opcode %v failed: %v", opcode, err)
+	}
+	return ids
+}
+
 func (r *IrCode) IsEmptySourceCodeHash() bool {
 	if r == nil {
 		return true
@@ -15,6 +15,10 @@ var SSAProjectTables = []any{
 	&IrScopeNode{},
 	// source code, and type
 	&IrSource{}, &IrType{},
+	// source file of compile unit, for incremental compile
+	&IrProgramSource{},
+	// language of program
+	&IrProgramLanguage{},
 }
 
 func init() {
@@ -29,6 +33,17 @@ func DeleteProgram(db *gorm.DB, program string) {
 	db.Model(&IrCode{}).Where("program_name = ?", program).Unscoped().Delete(&IrCode{})
 	db.Model(&IrVariable{}).Where("program_name = ?", program).Unscoped().Delete(&IrVariable{})
 	db.Model(&IrScopeNode{}).Where("program_name = ?", program).Unscoped().Delete(&IrScopeNode{})
+	db.Model(&IrProgramSource{}).Where("program_name = ?", program).Unscoped().Delete(&IrProgramSource{})
+	db.Model(&IrProgramLanguage{}).Where("program_name = ?", program).Unscoped().Delete(&IrProgramLanguage{})
+}
+
+// DeleteProgramCompileUnit delete the instruction, variable and source record of one compile unit,
+// other compile units of program are kept, so their instruction id is not changed
+func DeleteProgramCompileUnit(db *gorm.DB, program, compileHash string) {
+	db.Model(&IrCode{}).Where("program_name = ? AND program_compile_hash = ?", program, compileHash).Unscoped().Delete(&IrCode{})
+	db.Model(&IrVariable{}).Where("program_name = ? AND program_compile_hash = ?", program, compileHash).Unscoped().Delete(&IrVariable{})
+	db.Model(&IrScopeNode{}).Where("program_name = ? AND program_compile_hash = ?", program, compileHash).Unscoped().Delete(&IrScopeNode{})
+	db.Model(&IrProgramSource{}).Where("program_name = ? AND program_compile_hash = ?", program, compileHash).Unscoped().Delete(&IrProgramSource{})
 }
 
 func AllPrograms(db *gorm.DB) []string {
@@ -0,0 +1,35 @@
+package ssadb
+
+import (
+	"github.com/jinzhu/gorm"
+	"github.com/yaklang/yaklang/common/utils"
+)
+
+// IrProgramLanguage record the languages of program, a program built from project may have many languages
+type IrProgramLanguage struct {
+	gorm.Model
+
+	ProgramName string `json:"program_name" gorm:"index"`
+	Language    string `json:"language"`
+}
+
+// SaveIrProgramLanguage record the language of program, the existed record is skipped
+func SaveIrProgramLanguage(db *gorm.DB, program, language string) error {
+	if program == "" || language == "" {
+		return nil
+	}
+	record := &IrProgramLanguage{}
+	if err := db.Model(&IrProgramLanguage{}).Where(IrProgramLanguage{ProgramName: program, Language: language}).FirstOrCreate(record).Error; err != nil {
+		return utils.Wrapf(err, "save language %v of program %v failed", language, program)
+	}
+	return nil
+}
+
+// GetIrProgramLanguages get the recorded languages of program
+func GetIrProgramLanguages(db *gorm.DB, program string) ([]string, error) {
+	var languages []string
+	if err := db.Model(&IrProgramLanguage{}).Where("program_name = ?", program).Pluck("language", &languages).Error; err != nil {
+		return nil, utils.Wrapf(err, "query languages of program %v failed", program)
+	}
+	return languages, nil
+}
@@ -12,6 +12,9 @@ type IrScopeNode struct {
 	ParentNodeId  int64      `json:"parent_node_id" gorm:"index"`
 	ChildrenNodes Int64Slice `json:"children" gorm:"type:text"`
 	ExtraInfo     string     `json:"extraInfo"`
+
+	// compile unit of this scope, same as IrCode
+	ProgramCompileHash string `json:"program_compile_hash" gorm:"index"`
 }
 
 func RequireScopeNode() (int64, *IrScopeNode) {
@@ -1,6 +1,7 @@
 package ssadb
 
 import (
+	"github.com/jinzhu/gorm"
 	"github.com/yaklang/yaklang/common/log"
 	"github.com/yaklang/yaklang/common/utils"
 	"github.com/yaklang/yaklang/common/utils/memedit"
@@ -96,3 +97,66 @@ func GetIrSourceFromHash(hash string) (*memedit.MemEditor, error) {
 	}
 	return editor, nil
 }
+
+// IrProgramSource record the file built in a compile unit of program,
+// a compile unit is the entry file and all files included by it, they share the same ProgramCompileHash.
+// the SourceCodeHash is md5 of the file content, used to check which unit should be re-compiled.
+type IrProgramSource struct {
+	gorm.Model
+
+	ProgramName        string `json:"program_name" gorm:"index"`
+	ProgramCompileHash string `json:"program_compile_hash" gorm:"index"`
+	FilePath           string `json:"file_path"`
+	SourceCodeHash     string `json:"source_code_hash"`
+	IsEntry            bool   `json:"is_entry"`
+}
+
+// SaveIrProgramSource save the files of compile unit, files is the map of file path to content md5
+func SaveIrProgramSource(db *gorm.DB, program, compileHash, entry string, files map[string]string) error {
+	db = db.Model(&IrProgramSource{})
+	for path, hash := range files {
+		source := &IrProgramSource{
+			ProgramName:        program,
+			ProgramCompileHash: compileHash,
+			FilePath:           path,
+			SourceCodeHash:     hash,
+			IsEntry:            path == entry,
+		}
+		if err := db.Create(source).Error; err != nil {
+			return utils.Wrapf(err, "save program source %v failed", path)
+		}
+	}
+	return nil
+}
+
+// GetIrProgramSources get all files of program, grouped by compile hash
+func GetIrProgramSources(db *gorm.DB, program string) (map[string][]*IrProgramSource, error) {
+	var sources []*IrProgramSource
+	if err := db.Model(&IrProgramSource{}).Where("program_name = ?", program).Find(&sources).Error; err != nil {
+		return nil, utils.Wrapf(err, "query program sources of %v failed", program)
+	}
+	units := make(map[string][]*IrProgramSource)
+	for _, source := range sources {
+		units[source.ProgramCompileHash] = append(units[source.ProgramCompileHash], source)
+	}
+	return units, nil
+}
+
+// GetIrProgramCompileUnitSymbols get the variable names defined in compile unit, and the undefined names referenced by it,
+// the undefined name may be defined by other compile unit of program
+func GetIrProgramCompileUnitSymbols(db *gorm.DB, program, compileHash string) (defined []string, undefined []string) {
+	db.Model(&IrCode{}).Where(
+		"program_name = ? AND program_compile_hash = ? AND opcode_name = ? AND name <> ''", program, compileHash, "Undefined",
+	).Pluck("DISTINCT(name)", &undefined)
+
+	var variables []string
+	db.Model(&IrVariable{}).Where(
+		"program_name = ? AND program_compile_hash = ?", program, compileHash,
+	).Pluck("DISTINCT(variable_name)", &variables)
+	for _, name := range variables {
+		if !utils.StringArrayContains(undefined, name) {
+			defined = append(defined, name)
+		}
+	}
+	return
+}
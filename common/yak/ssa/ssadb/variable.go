@@ -17,6 +17,9 @@ type IrVariable struct {
 	ProgramName  string `json:"program_name" gorm:"index"`
 	VariableName string `json:"variable_name" gorm:"index"`
 
+	// compile unit of this variable, same as IrCode
+	ProgramCompileHash string `json:"program_compile_hash" gorm:"index"`
+
 	IsClassInstance      bool `json:"is_class_instance"`
 	IsAnnotationInstance bool `json:"is_annotation_instance"`
 
@@ -33,7 +36,7 @@ type SSAValue interface {
 	GetId() int64
 }
 
-func SaveVariable(db *gorm.DB, program, variable string, insts []SSAValue) error {
+func SaveVariable(db *gorm.DB, program, compileHash, variable string, insts []SSAValue) error {
 	start := time.Now()
 	defer func() {
 		atomic.AddUint64(&_SSAVariableCost, uint64(time.Now().Sub(start).Nanoseconds()))
@@ -45,6 +48,7 @@ func SaveVariable(db *gorm.DB, program, variable string, insts []SSAValue) error
 	irVariable := &IrVariable{}
 	irVariable.ProgramName = program
 	irVariable.VariableName = variable
+	irVariable.ProgramCompileHash = compileHash
 	instIDs := make([]int64, 0, len(insts))
 	for _, inst := range insts {
 		if inst == nil {
@@ -73,7 +77,7 @@ func SaveVariable(db *gorm.DB, program, variable string, insts []SSAValue) error
 	return db.Save(irVariable).Error
 }
 
-func SaveClassInstance(db *gorm.DB, program, class string, instIDs []int64) error {
+func SaveClassInstance(db *gorm.DB, program, compileHash, class string, instIDs []int64) error {
 	start := time.Now()
 	defer func() {
 		atomic.AddUint64(&_SSAVariableCost, uint64(time.Now().Sub(start).Nanoseconds()))
@@ -84,6 +88,7 @@ func SaveClassInstance(db *gorm.DB, program, class string, instIDs []int64) erro
 	irVariable.IsClassInstance = true
 	irVariable.ProgramName = program
 	irVariable.VariableName = class
+	irVariable.ProgramCompileHash = compileHash
 	irVariable.InstructionID = instIDs
 	return db.Save(irVariable).Error
 }
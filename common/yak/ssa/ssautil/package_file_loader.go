@@ -3,6 +3,7 @@ package ssautil
 import (
 	"github.com/yaklang/yaklang/common/utils/memedit"
 	"io/fs"
+	"path/filepath"
 
 	"github.com/yaklang/yaklang/common/log"
 	"github.com/yaklang/yaklang/common/utils"
@@ -94,6 +95,9 @@ func (p *PackageFileLoader) getPath(want string, once bool, f func(fs.FileInfo)
 	}
 	// found path in current path
 	tmpPath := append([]string{p.currentPath}, p.includePath...)
+	if filepath.IsAbs(want) {
+		tmpPath = []string{""}
+	}
 	for _, path := range tmpPath {
 		filePath := fs.Join(path, want)
 		info, err := fs.Stat(filePath)
@@ -90,7 +90,8 @@ func (s *ScopedVersionedTable[T]) GetPersistentProgramName() string {
 
 type ScopedVersionedTable[T versionedValue] struct {
 	persistentProgramName string
-	persistentId          int64 // > 0 in db
+	persistentCompileHash string // compile unit of program, for incremental compile
+	persistentId          int64  // > 0 in db
 	persistentNode        *ssadb.IrScopeNode
 
 	level         int
@@ -125,7 +126,7 @@ func (s *ScopedVersionedTable[T]) ShouldSaveToDatabase() bool {
 }
 
 func NewScope[T versionedValue](
-	programName string,
+	programName, compileHash string,
 	fetcher func() int,
 	newVersioned VersionedBuilder[T],
 	parent ScopedVersionedTableIF[T],
@@ -137,6 +138,7 @@ func NewScope[T versionedValue](
 	}
 	s := &ScopedVersionedTable[T]{
 		persistentProgramName: programName,
+		persistentCompileHash: compileHash,
 		persistentNode:        treeNode,
 		persistentId:          treeNodeId,
 		offsetFetcher:         fetcher,
@@ -165,6 +167,15 @@ func NewRootVersionedTable[T versionedValue](
 	programName string,
 	newVersioned VersionedBuilder[T],
 	fetcher ...func() int,
+) *ScopedVersionedTable[T] {
+	return NewRootVersionedTableEx[T](programName, "", newVersioned, fetcher...)
+}
+
+// NewRootVersionedTableEx create root scope of compile unit, the scope saved to database is deleted with the unit
+func NewRootVersionedTableEx[T versionedValue](
+	programName, compileHash string,
+	newVersioned VersionedBuilder[T],
+	fetcher ...func() int,
 ) *ScopedVersionedTable[T] {
 	var finalFetcher GlobalIndexFetcher
 	for _, f := range fetcher {
@@ -185,11 +196,11 @@ func NewRootVersionedTable[T versionedValue](
 		}
 	}
 
-	return NewScope[T](programName, finalFetcher, newVersioned, nil)
+	return NewScope[T](programName, compileHash, finalFetcher, newVersioned, nil)
 }
 
 func (v *ScopedVersionedTable[T]) CreateSubScope() ScopedVersionedTableIF[T] {
-	sub := NewScope[T](v.persistentProgramName, v.offsetFetcher, v.newVersioned, v)
+	sub := NewScope[T](v.persistentProgramName, v.persistentCompileHash, v.offsetFetcher, v.newVersioned, v)
 	return sub
 }
 
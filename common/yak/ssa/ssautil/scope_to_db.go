@@ -119,6 +119,7 @@ func (s *ScopedVersionedTable[T]) _SaveToDatabase() error {
 	s.persistentNode.ExtraInfo = string(raw)
 
 	s.persistentNode.ProgramName = s.persistentProgramName
+	s.persistentNode.ProgramCompileHash = s.persistentCompileHash
 	if err := ssadb.GetDB().Save(s.persistentNode).Error; err != nil {
 		return utils.Error(err.Error())
 	}
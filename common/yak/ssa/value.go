@@ -186,9 +186,26 @@ func (b *FunctionBuilder) CreateVariable(name string, pos ...CanStartStopToken)
 	return b.createVariableEx(name, false, pos...)
 }
 
+// DeclareVariableType set the type declared by type annotation, the following assignments to this variable will be checked
+func (b *FunctionBuilder) DeclareVariableType(variable *Variable, typ Type) {
+	if variable == nil || typ == nil {
+		return
+	}
+	variable.SetDeclaredType(typ)
+	b.hasDeclaredType = true
+}
+
 func (b *FunctionBuilder) createVariableEx(name string, isLocal bool, pos ...CanStartStopToken) *Variable {
 	scope := b.CurrentBlock.ScopeTable
+	var declaredType Type
+	if !isLocal && b.hasDeclaredType {
+		// assign to declared variable, keep the declared type
+		if prev := ReadVariableFromScope(scope, name); prev != nil {
+			declaredType = prev.GetDeclaredType()
+		}
+	}
 	ret := scope.CreateVariable(name, isLocal).(*Variable)
+	ret.SetDeclaredType(declaredType)
 	r := b.CurrentRange
 	if r == nil && len(pos) > 0 {
 		r = b.GetCurrentRange(pos[0])
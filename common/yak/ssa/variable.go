@@ -15,6 +15,9 @@ type Variable struct {
 	object      Value
 	key         Value
 	verboseName string
+
+	// type declared by type annotation, inherited by the following assignments
+	declaredType Type
 }
 
 var _ ssautil.VersionedIF[Value] = (*Variable)(nil)
@@ -88,6 +91,14 @@ func (b *Variable) GetMemberCall() (Value, Value) {
 	return b.object, b.key
 }
 
+func (v *Variable) SetDeclaredType(t Type) {
+	v.declaredType = t
+}
+
+func (v *Variable) GetDeclaredType() Type {
+	return v.declaredType
+}
+
 func (v *Variable) SetDefRange(r *Range) {
 	if r == nil {
 		log.Error("SetDefRange: range is nil use fallback")
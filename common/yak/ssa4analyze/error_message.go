@@ -41,3 +41,24 @@ func BlockUnreachable() string {
 func InvalidField(typ, key string) string {
 	return fmt.Sprintf("Invalid operation: unable to access the member or index of variable of type {%s} with name or index {%s}.", typ, key)
 }
+
+func VariableTypeError(name, valueType, wantType string) string {
+	return fmt.Sprintf(
+		`The value (%s), cannot use as (%s) in assignment to variable %s`,
+		valueType, wantType, name,
+	)
+}
+
+func ReturnTypeError(valueType, wantType, funName string) string {
+	return fmt.Sprintf(
+		`The return value (%s), cannot use as (%s) in function %s`,
+		valueType, wantType, funName,
+	)
+}
+
+func TooManyReturnValues(funName string, have int, want string) string {
+	return fmt.Sprintf(
+		`Too many return values in function %s have (%d) want (%s)`,
+		funName, have, want,
+	)
+}
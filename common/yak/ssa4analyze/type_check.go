@@ -3,6 +3,7 @@ package ssa4analyze
 import (
 	"github.com/samber/lo"
 	"github.com/yaklang/yaklang/common/log"
+	"github.com/yaklang/yaklang/common/utils"
 	"github.com/yaklang/yaklang/common/yak/ssa"
 	"golang.org/x/exp/slices"
 )
@@ -10,10 +11,14 @@ import (
 const TypeCheckTAG ssa.ErrorTag = "TypeCheck"
 
 type TypeCheck struct {
+	// variables with type annotation which have been checked
+	checkedDeclared map[*ssa.Variable]struct{}
 }
 
 func NewTypeCheck(config) Analyzer {
-	return &TypeCheck{}
+	return &TypeCheck{
+		checkedDeclared: make(map[*ssa.Variable]struct{}),
+	}
 }
 
 // Analyze(config, *ssa.Program)
@@ -46,6 +51,7 @@ func (t *TypeCheck) Run(prog *ssa.Program) {
 
 func (t *TypeCheck) CheckOnInstruction(inst ssa.Instruction) {
 	if v, ok := inst.(ssa.Value); ok {
+		t.TypeCheckDeclaredVariable(v)
 		switch v.GetType().GetTypeKind() {
 		case ssa.ErrorTypeKind:
 			if len(v.GetUsers()) == 0 {
@@ -83,6 +89,52 @@ func (t *TypeCheck) CheckOnInstruction(inst ssa.Instruction) {
 		t.TypeCheckCall(inst)
 	case *ssa.Undefined:
 		t.TypeCheckUndefine(inst)
+	case *ssa.Return:
+		t.TypeCheckReturn(inst)
+	}
+}
+
+// TypeCheckDeclaredVariable check the value assigned to the variable with type annotation: `var a int = "a"`
+func (t *TypeCheck) TypeCheckDeclaredVariable(v ssa.Value) {
+	got := v.GetType()
+	if got == nil {
+		return
+	}
+	for _, variable := range v.GetAllVariables() {
+		want := variable.GetDeclaredType()
+		if want == nil || ssa.TypeCompare(got, want) {
+			continue
+		}
+		if _, ok := t.checkedDeclared[variable]; ok {
+			continue
+		}
+		t.checkedDeclared[variable] = struct{}{}
+		v.GetFunc().NewErrorWithPos(ssa.Error, TypeCheckTAG, variable.DefRange,
+			VariableTypeError(variable.GetName(), got.String(), want.String()),
+		)
+	}
+}
+
+// TypeCheckReturn check the return value with the return type annotation: `fn() int { return "a" }`
+func (t *TypeCheck) TypeCheckReturn(r *ssa.Return) {
+	f := r.GetFunc()
+	if f == nil || f.DeclaredReturnType == nil {
+		return
+	}
+	want := f.DeclaredReturnType
+	switch len(r.Results) {
+	case 0:
+		// implicit return
+	case 1:
+		value := r.Results[0]
+		if utils.IsNil(value) || value.GetType() == nil {
+			return
+		}
+		if got := value.GetType(); !ssa.TypeCompare(got, want) {
+			r.NewError(ssa.Error, TypeCheckTAG, ReturnTypeError(got.String(), want.String(), f.GetName()))
+		}
+	default:
+		r.NewError(ssa.Error, TypeCheckTAG, TooManyReturnValues(f.GetName(), len(r.Results), want.String()))
 	}
 }
 
@@ -176,3 +176,13 @@ func (a *AnalyzeContext) TheDefaultShouldBeVisited(i *Value) bool {
 	a._visitedDefault[i.GetId()] = struct{}{}
 	return true
 }
+
+// IsBarrier check the value match any barrier in config, the analysis should stop at it
+func (a *AnalyzeContext) IsBarrier(i *Value) bool {
+	for _, barrier := range a.config.Barrier {
+		if barrier(i) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,426 @@
+package ssaapi
+
+import (
+	"sort"
+
+	"github.com/samber/lo"
+	"github.com/yaklang/yaklang/common/yak/ssa"
+)
+
+// CallGraphNode is a function in call graph, the callee which has no function body
+// (such as the library function `os.System` or `Runtime.getRuntime().exec`) is an external node.
+type CallGraphNode struct {
+	// Id is the id of function, the external node has a negative id
+	Id       int64
+	Name     string
+	Method   string
+	External bool
+	Function *Value
+}
+
+// CallGraphEdge is a call site from caller to callee,
+// the edge resolved by class hierarchy or method name is virtual.
+type CallGraphEdge struct {
+	Caller  *CallGraphNode
+	Callee  *CallGraphNode
+	Call    *Value
+	Virtual bool
+}
+
+// CallGraph is the whole-program call graph
+type CallGraph struct {
+	program *Program
+
+	nodes    map[int64]*CallGraphNode
+	external map[string]*CallGraphNode
+	edges    []*CallGraphEdge
+	edgeSet  map[[3]int64]struct{}
+	callees  map[int64][]*CallGraphEdge
+	callers  map[int64][]*CallGraphEdge
+
+	// method name to functions, for virtual dispatch
+	methods map[string][]*ssa.Function
+	classes []*ssa.ClassBluePrint
+}
+
+type callGraphTarget struct {
+	function *ssa.Function
+	external string
+	virtual  bool
+}
+
+// the depth limit to resolve the callee through phi, closure and parameter
+const callGraphResolveDepth = 16
+
+// GetCallGraph build the call graph of program, the result is cached in program
+func (p *Program) GetCallGraph() *CallGraph {
+	p.callGraphOnce.Do(func() {
+		p.callGraph = newCallGraph(p)
+	})
+	return p.callGraph
+}
+
+func newCallGraph(p *Program) *CallGraph {
+	g := &CallGraph{
+		program:  p,
+		nodes:    make(map[int64]*CallGraphNode),
+		external: make(map[string]*CallGraphNode),
+		edgeSet:  make(map[[3]int64]struct{}),
+		callees:  make(map[int64][]*CallGraphEdge),
+		callers:  make(map[int64][]*CallGraphEdge),
+		methods:  make(map[string][]*ssa.Function),
+	}
+
+	var functions []*ssa.Function
+	p.Program.EachFunction(func(f *ssa.Function) {
+		functions = append(functions, f)
+	})
+	sort.SliceStable(functions, func(i, j int) bool {
+		return functions[i].GetId() < functions[j].GetId()
+	})
+	for _, f := range functions {
+		g.functionNode(f)
+		if name := f.GetMethodName(); name != "" {
+			g.methods[name] = append(g.methods[name], f)
+		}
+	}
+	for _, pkg := range p.Program.Packages {
+		for _, class := range pkg.ClassBluePrint {
+			g.classes = append(g.classes, class)
+		}
+	}
+
+	type callSite struct {
+		caller *ssa.Function
+		call   *ssa.Call
+	}
+	var calls []callSite
+	for _, f := range functions {
+		for _, b := range f.Blocks {
+			block, ok := ssa.ToBasicBlock(b)
+			if !ok {
+				continue
+			}
+			for _, inst := range block.Insts {
+				if call, ok := ssa.ToCall(inst); ok {
+					calls = append(calls, callSite{caller: f, call: call})
+				}
+			}
+		}
+	}
+
+	// the callee from parameter depends on the callers of function,
+	// resolve again until no more edge is found.
+	for changed := true; changed; {
+		changed = false
+		for _, site := range calls {
+			for _, target := range g.resolve(site.call.Method, 0, make(map[int64]struct{})) {
+				if g.addEdge(site.caller, site.call, target) {
+					changed = true
+				}
+			}
+		}
+	}
+	return g
+}
+
+func (g *CallGraph) functionNode(f *ssa.Function) *CallGraphNode {
+	if node, ok := g.nodes[f.GetId()]; ok {
+		return node
+	}
+	name := f.GetName()
+	if verbose := f.GetVerboseName(); f.GetParent() != nil && verbose != "" {
+		// closure function is named by the variable, such as `f` in `f = () => {}`
+		name = verbose
+	}
+	node := &CallGraphNode{
+		Id:       f.GetId(),
+		Name:     name,
+		Method:   f.GetMethodName(),
+		Function: g.program.NewValue(f),
+	}
+	g.nodes[f.GetId()] = node
+	return node
+}
+
+func (g *CallGraph) externalNode(name string) *CallGraphNode {
+	if node, ok := g.external[name]; ok {
+		return node
+	}
+	node := &CallGraphNode{
+		Id:       -int64(len(g.external) + 1),
+		Name:     name,
+		External: true,
+	}
+	g.external[name] = node
+	g.nodes[node.Id] = node
+	return node
+}
+
+func (g *CallGraph) addEdge(caller *ssa.Function, call *ssa.Call, target *callGraphTarget) bool {
+	from := g.functionNode(caller)
+	var to *CallGraphNode
+	if target.function != nil {
+		to = g.functionNode(target.function)
+	} else {
+		to = g.externalNode(target.external)
+	}
+	key := [3]int64{from.Id, to.Id, call.GetId()}
+	if _, ok := g.edgeSet[key]; ok {
+		return false
+	}
+	g.edgeSet[key] = struct{}{}
+	edge := &CallGraphEdge{
+		Caller:  from,
+		Callee:  to,
+		Call:    g.program.NewValue(call),
+		Virtual: target.virtual,
+	}
+	g.edges = append(g.edges, edge)
+	g.callees[from.Id] = append(g.callees[from.Id], edge)
+	g.callers[to.Id] = append(g.callers[to.Id], edge)
+	return true
+}
+
+func (g *CallGraph) resolve(method ssa.Value, depth int, visited map[int64]struct{}) []*callGraphTarget {
+	if method == nil || depth > callGraphResolveDepth {
+		return nil
+	}
+	if _, ok := visited[method.GetId()]; ok {
+		return nil
+	}
+	visited[method.GetId()] = struct{}{}
+
+	if f, ok := ssa.ToFunction(method); ok {
+		return []*callGraphTarget{{function: f}}
+	}
+	if phi, ok := ssa.ToPhi(method); ok {
+		var ret []*callGraphTarget
+		for _, edge := range phi.Edge {
+			ret = append(ret, g.resolve(edge, depth+1, visited)...)
+		}
+		return ret
+	}
+	if param, ok := ssa.ToParameter(method); ok {
+		if param.IsFreeValue {
+			// closure captured variable
+			if def := param.GetDefault(); def != nil {
+				return g.resolve(def, depth+1, visited)
+			}
+			return g.externalTarget(method)
+		}
+		// function passed as argument, resolve by the arguments of call sites
+		fun := param.GetFunc()
+		if fun == nil {
+			return nil
+		}
+		var ret []*callGraphTarget
+		for _, edge := range g.callers[fun.GetId()] {
+			call, ok := ssa.ToCall(edge.Call.node)
+			if !ok || param.FormalParameterIndex >= len(call.Args) {
+				continue
+			}
+			ret = append(ret, g.resolve(call.Args[param.FormalParameterIndex], depth+1, visited)...)
+		}
+		return ret
+	}
+	if method.IsMember() {
+		if ret := g.resolveMethod(method); len(ret) > 0 {
+			return ret
+		}
+	}
+	return g.externalTarget(method)
+}
+
+// resolveMethod resolve the member call by the class blue print of object,
+// or by method name if the type of object is unknown.
+func (g *CallGraph) resolveMethod(method ssa.Value) []*callGraphTarget {
+	key := ssa.GetKeyString(method.GetKey())
+	if key == "" {
+		return nil
+	}
+	var functions []*ssa.Function
+	if obj := method.GetObject(); obj != nil {
+		if class, ok := obj.GetType().(*ssa.ClassBluePrint); ok {
+			functions = g.classMethods(class, key)
+		}
+	}
+	if len(functions) == 0 {
+		functions = g.methods[key]
+	}
+	virtual := len(functions) > 1
+	return lo.Map(functions, func(f *ssa.Function, _ int) *callGraphTarget {
+		return &callGraphTarget{function: f, virtual: virtual}
+	})
+}
+
+// classMethods return the method of class (or inherited from parent class),
+// and the override methods in sub classes.
+func (g *CallGraph) classMethods(class *ssa.ClassBluePrint, key string) []*ssa.Function {
+	var ret []*ssa.Function
+	var lookup func(*ssa.ClassBluePrint, int) *ssa.Function
+	lookup = func(c *ssa.ClassBluePrint, depth int) *ssa.Function {
+		if c == nil || depth > callGraphResolveDepth {
+			return nil
+		}
+		if f, ok := c.Method[key]; ok {
+			return f
+		}
+		if f, ok := c.StaticMethod[key]; ok {
+			return f
+		}
+		for _, parent := range c.ParentClass {
+			if f := lookup(parent, depth+1); f != nil {
+				return f
+			}
+		}
+		return nil
+	}
+	if f := lookup(class, 0); f != nil {
+		ret = append(ret, f)
+	}
+	for _, sub := range g.classes {
+		if sub == class || !isSubClass(sub, class, 0) {
+			continue
+		}
+		if f, ok := sub.Method[key]; ok && !lo.Contains(ret, f) {
+			ret = append(ret, f)
+		}
+	}
+	return ret
+}
+
+func isSubClass(sub, class *ssa.ClassBluePrint, depth int) bool {
+	if depth > callGraphResolveDepth {
+		return false
+	}
+	for _, parent := range sub.ParentClass {
+		if parent == class || isSubClass(parent, class, depth+1) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *CallGraph) externalTarget(method ssa.Value) []*callGraphTarget {
+	name := callGraphExternalName(method, 0)
+	if name == "" {
+		return nil
+	}
+	return []*callGraphTarget{{external: name}}
+}
+
+// callGraphExternalName build the name of library function by member path, such as `os.System`
+// and `Runtime.getRuntime().exec`
+func callGraphExternalName(v ssa.Value, depth int) string {
+	if v == nil || depth > callGraphResolveDepth {
+		return ""
+	}
+	if v.IsMember() {
+		key := ssa.GetKeyString(v.GetKey())
+		if obj := callGraphExternalName(v.GetObject(), depth+1); obj != "" {
+			return obj + "." + key
+		}
+		return key
+	}
+	if call, ok := ssa.ToCall(v); ok {
+		if name := callGraphExternalName(call.Method, depth+1); name != "" {
+			return name + "()"
+		}
+		return ""
+	}
+	return v.GetName()
+}
+
+// Nodes return all nodes of call graph, sorted by id
+func (g *CallGraph) Nodes() []*CallGraphNode {
+	nodes := lo.Values(g.nodes)
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].External != nodes[j].External {
+			return !nodes[i].External
+		}
+		if nodes[i].External {
+			return nodes[i].Id > nodes[j].Id
+		}
+		return nodes[i].Id < nodes[j].Id
+	})
+	return nodes
+}
+
+// Edges return all call edges in order of building
+func (g *CallGraph) Edges() []*CallGraphEdge {
+	return g.edges
+}
+
+// GetNodesByName return the nodes matched by function name, method name or external name
+func (g *CallGraph) GetNodesByName(name string) []*CallGraphNode {
+	return lo.Filter(g.Nodes(), func(node *CallGraphNode, _ int) bool {
+		return node.Name == name || (node.Method != "" && node.Method == name)
+	})
+}
+
+// GetNodeByFunction return the node of function value
+func (g *CallGraph) GetNodeByFunction(v *Value) (*CallGraphNode, bool) {
+	if v == nil {
+		return nil, false
+	}
+	node, ok := g.nodes[v.GetId()]
+	return node, ok
+}
+
+// GetCallees return the edges which the node calls
+func (g *CallGraph) GetCallees(node *CallGraphNode) []*CallGraphEdge {
+	return g.callees[node.Id]
+}
+
+// GetCallers return the edges which call the node
+func (g *CallGraph) GetCallers(node *CallGraphNode) []*CallGraphEdge {
+	return g.callers[node.Id]
+}
+
+// FindPath return the shortest call path from entry to sink, nil if sink is unreachable
+func (g *CallGraph) FindPath(entry, sink *CallGraphNode) []*CallGraphNode {
+	if entry == nil || sink == nil {
+		return nil
+	}
+	prev := map[int64]*CallGraphNode{entry.Id: nil}
+	queue := []*CallGraphNode{entry}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if node.Id == sink.Id {
+			var path []*CallGraphNode
+			for n := node; n != nil; n = prev[n.Id] {
+				path = append([]*CallGraphNode{n}, path...)
+			}
+			return path
+		}
+		for _, edge := range g.callees[node.Id] {
+			if _, ok := prev[edge.Callee.Id]; ok {
+				continue
+			}
+			prev[edge.Callee.Id] = node
+			queue = append(queue, edge.Callee)
+		}
+	}
+	return nil
+}
+
+// FindPathByName return the shortest call path from any function named entry to any function named sink
+func (g *CallGraph) FindPathByName(entry, sink string) []*CallGraphNode {
+	var ret []*CallGraphNode
+	for _, from := range g.GetNodesByName(entry) {
+		for _, to := range g.GetNodesByName(sink) {
+			path := g.FindPath(from, to)
+			if path != nil && (ret == nil || len(path) < len(ret)) {
+				ret = path
+			}
+		}
+	}
+	return ret
+}
+
+// CanReach check whether the function named entry can reach the function named sink
+func (g *CallGraph) CanReach(entry, sink string) bool {
+	return g.FindPathByName(entry, sink) != nil
+}
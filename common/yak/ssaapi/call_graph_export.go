@@ -0,0 +1,217 @@
+package ssaapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/utils/dot"
+)
+
+type CallGraphFormat string
+
+const (
+	CallGraphFormatDot     CallGraphFormat = "dot"
+	CallGraphFormatGraphML CallGraphFormat = "graphml"
+	CallGraphFormatJSON    CallGraphFormat = "json"
+)
+
+type callGraphJSONNode struct {
+	Id        int64  `json:"id"`
+	Name      string `json:"name"`
+	Method    string `json:"method,omitempty"`
+	External  bool   `json:"external"`
+	File      string `json:"file,omitempty"`
+	StartLine int    `json:"start_line,omitempty"`
+	EndLine   int    `json:"end_line,omitempty"`
+}
+
+type callGraphJSONEdge struct {
+	Caller  int64  `json:"caller"`
+	Callee  int64  `json:"callee"`
+	Call    int64  `json:"call"`
+	Virtual bool   `json:"virtual"`
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line,omitempty"`
+}
+
+type callGraphJSON struct {
+	Nodes []*callGraphJSONNode `json:"nodes"`
+	Edges []*callGraphJSONEdge `json:"edges"`
+}
+
+func valuePosition(v *Value) (string, int, int) {
+	if v == nil {
+		return "", 0, 0
+	}
+	r := v.GetRange()
+	if r == nil || r.GetEditor() == nil {
+		return "", 0, 0
+	}
+	return r.GetEditor().GetUrl(), r.GetStart().GetLine(), r.GetEnd().GetLine()
+}
+
+func (g *CallGraph) toJSONModel() *callGraphJSON {
+	ret := &callGraphJSON{
+		Nodes: make([]*callGraphJSONNode, 0, len(g.nodes)),
+		Edges: make([]*callGraphJSONEdge, 0, len(g.edges)),
+	}
+	for _, node := range g.Nodes() {
+		file, start, end := valuePosition(node.Function)
+		ret.Nodes = append(ret.Nodes, &callGraphJSONNode{
+			Id:        node.Id,
+			Name:      node.Name,
+			Method:    node.Method,
+			External:  node.External,
+			File:      file,
+			StartLine: start,
+			EndLine:   end,
+		})
+	}
+	for _, edge := range g.edges {
+		file, line, _ := valuePosition(edge.Call)
+		ret.Edges = append(ret.Edges, &callGraphJSONEdge{
+			Caller:  edge.Caller.Id,
+			Callee:  edge.Callee.Id,
+			Call:    edge.Call.GetId(),
+			Virtual: edge.Virtual,
+			File:    file,
+			Line:    line,
+		})
+	}
+	return ret
+}
+
+// JSON export call graph as json with `nodes` and `edges`
+func (g *CallGraph) JSON() ([]byte, error) {
+	return json.MarshalIndent(g.toJSONModel(), "", "  ")
+}
+
+// Dot export call graph as graphviz dot, external node is a box and virtual call is dashed
+func (g *CallGraph) Dot() string {
+	graph := dot.New()
+	graph.MakeDirected()
+	graph.GraphAttribute("rankdir", "LR")
+
+	ids := make(map[int64]int)
+	for _, node := range g.Nodes() {
+		id := graph.AddNode(node.Name)
+		if node.External {
+			graph.NodeAttribute(id, "shape", "box")
+			graph.NodeAttribute(id, "style", "dashed")
+		}
+		ids[node.Id] = id
+	}
+	for _, edge := range g.edges {
+		if len(graph.GetEdges(ids[edge.Caller.Id], ids[edge.Callee.Id])) > 0 {
+			continue
+		}
+		if edge.Virtual {
+			graph.AddDashEdge(ids[edge.Caller.Id], ids[edge.Callee.Id], "")
+		} else {
+			graph.AddEdge(ids[edge.Caller.Id], ids[edge.Callee.Id], "")
+		}
+	}
+	var buf bytes.Buffer
+	graph.GenerateDOT(&buf)
+	return buf.String()
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+type graphMLNode struct {
+	Id   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLKey struct {
+	Id       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   struct {
+		Id          string        `xml:"id,attr"`
+		EdgeDefault string        `xml:"edgedefault,attr"`
+		Nodes       []graphMLNode `xml:"node"`
+		Edges       []graphMLEdge `xml:"edge"`
+	} `xml:"graph"`
+}
+
+// GraphML export call graph as GraphML
+func (g *CallGraph) GraphML() (string, error) {
+	ret := &graphML{
+		Xmlns: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{Id: "name", For: "node", AttrName: "name", AttrType: "string"},
+			{Id: "method", For: "node", AttrName: "method", AttrType: "string"},
+			{Id: "external", For: "node", AttrName: "external", AttrType: "boolean"},
+			{Id: "file", For: "node", AttrName: "file", AttrType: "string"},
+			{Id: "line", For: "node", AttrName: "line", AttrType: "int"},
+			{Id: "virtual", For: "edge", AttrName: "virtual", AttrType: "boolean"},
+			{Id: "call_line", For: "edge", AttrName: "call_line", AttrType: "int"},
+		},
+	}
+	ret.Graph.Id = "callgraph"
+	ret.Graph.EdgeDefault = "directed"
+
+	model := g.toJSONModel()
+	for _, node := range model.Nodes {
+		ret.Graph.Nodes = append(ret.Graph.Nodes, graphMLNode{
+			Id: fmt.Sprintf("n%d", node.Id),
+			Data: []graphMLData{
+				{Key: "name", Value: node.Name},
+				{Key: "method", Value: node.Method},
+				{Key: "external", Value: fmt.Sprint(node.External)},
+				{Key: "file", Value: node.File},
+				{Key: "line", Value: fmt.Sprint(node.StartLine)},
+			},
+		})
+	}
+	for _, edge := range model.Edges {
+		ret.Graph.Edges = append(ret.Graph.Edges, graphMLEdge{
+			Source: fmt.Sprintf("n%d", edge.Caller),
+			Target: fmt.Sprintf("n%d", edge.Callee),
+			Data: []graphMLData{
+				{Key: "virtual", Value: fmt.Sprint(edge.Virtual)},
+				{Key: "call_line", Value: fmt.Sprint(edge.Line)},
+			},
+		})
+	}
+	raw, err := xml.MarshalIndent(ret, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return xml.Header + string(raw), nil
+}
+
+// Export call graph in format: dot, graphml or json
+func (g *CallGraph) Export(format CallGraphFormat) (string, error) {
+	switch format {
+	case CallGraphFormatDot, "":
+		return g.Dot(), nil
+	case CallGraphFormatGraphML:
+		return g.GraphML()
+	case CallGraphFormatJSON:
+		raw, err := g.JSON()
+		return string(raw), err
+	default:
+		return "", utils.Errorf("unsupported call graph format: %v", format)
+	}
+}
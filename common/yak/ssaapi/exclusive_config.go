@@ -9,6 +9,9 @@ type OperationConfig struct {
 	// Hook
 	HookEveryNode        []func(*Value) error
 	AllowIgnoreCallStack bool
+
+	// Barrier stop the analysis at the value, if any of them return true
+	Barrier []func(*Value) bool
 }
 
 type OperationOption func(*OperationConfig)
@@ -49,6 +52,12 @@ func WithHookEveryNode(hookNode func(*Value) error) OperationOption {
 	}
 }
 
+func WithBarrier(barrier func(*Value) bool) OperationOption {
+	return func(operationConfig *OperationConfig) {
+		operationConfig.Barrier = append(operationConfig.Barrier, barrier)
+	}
+}
+
 func NewOperations(opt ...OperationOption) *OperationConfig {
 	config := &OperationConfig{
 		MaxDepth:             -1,
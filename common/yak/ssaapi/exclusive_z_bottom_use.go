@@ -29,6 +29,7 @@ func (v *Value) visitUserFallback(actx *AnalyzeContext) Values {
 			vals = append(vals, ret...)
 		}
 	})
+	vals = append(vals, v.getBottomUsesOfExternSummaryReceiver(actx)...)
 
 	// member.IsUndefined()
 	undefineMember := false
@@ -74,6 +75,9 @@ func (v *Value) getBottomUses(actx *AnalyzeContext, opt ...OperationOption) Valu
 			}
 		}
 	}
+	if actx.IsBarrier(v) {
+		return Values{}
+	}
 
 	if ValueCompare(v, actx.Self) {
 		return v.visitUserFallback(actx)
@@ -107,6 +111,10 @@ func (v *Value) getBottomUses(actx *AnalyzeContext, opt ...OperationOption) Valu
 			return v.visitUserFallback(actx)
 		}
 
+		if call, summary := v.getExternSummary(); summary != nil {
+			return v.getBottomUsesByExternSummary(actx, call, summary)
+		}
+
 		// enter function via call
 		f, ok := ssa.ToFunction(ins.Method)
 		if !ok {
@@ -108,6 +108,9 @@ func (i *Value) getTopDefs(actx *AnalyzeContext, opt ...OperationOption) Values
 			}
 		}
 	}
+	if actx.IsBarrier(i) {
+		return Values{}
+	}
 
 	{
 		obj, key, member := actx.GetCurrentObject()
@@ -170,6 +173,9 @@ func (i *Value) getTopDefs(actx *AnalyzeContext, opt ...OperationOption) Values
 		if caller == nil {
 			return Values{i} // return self
 		}
+		if call, summary := i.getExternSummary(); summary != nil {
+			return i.getTopDefsByExternSummary(call, summary, opt...)
+		}
 
 		// TODO: trace the specific return-values
 		callerValue := i.NewValue(caller)
@@ -0,0 +1,167 @@
+package ssaapi
+
+import (
+	"github.com/yaklang/yaklang/common/yak/ssa"
+)
+
+// languages return the languages of program, from config or the source files in database
+func (p *Program) languages() []Language {
+	if p.config != nil && p.config.language != "" {
+		return []Language{p.config.language}
+	}
+	if p.config != nil && p.config.DatabaseProgramName != "" {
+		return GetDatabaseProgramLanguages(p.config.DatabaseProgramName)
+	}
+	return nil
+}
+
+func (p *Program) getExternSummaryTables() []*ssa.ExternSummaryTable {
+	p.externSummaryOnce.Do(func() {
+		for _, language := range p.languages() {
+			p.externSummaries = append(p.externSummaries, ssa.GetExternSummaryTable(string(language)))
+		}
+	})
+	return p.externSummaries
+}
+
+// GetExternSummary return the data flow summary of the call to extern function (without function body),
+// return nil if the callee has body or no summary matched.
+func (p *Program) GetExternSummary(call *ssa.Call) *ssa.ExternSummary {
+	if p == nil || call == nil || call.Method == nil {
+		return nil
+	}
+	if f, ok := ssa.ToFunction(call.Method); ok && !f.IsExtern() {
+		return nil
+	}
+	for _, table := range p.getExternSummaryTables() {
+		if summary := table.Match(call); summary != nil {
+			return summary
+		}
+	}
+	return nil
+}
+
+func (v *Value) getExternSummary() (*ssa.Call, *ssa.ExternSummary) {
+	if v.ParentProgram == nil {
+		return nil, nil
+	}
+	call, ok := ssa.ToCall(v.node)
+	if !ok {
+		return nil, nil
+	}
+	if summary := v.ParentProgram.GetExternSummary(call); summary != nil {
+		return call, summary
+	}
+	return nil, nil
+}
+
+// getExternSummaryMemberCalls return the calls of the method of v, which have summary,
+// such as `sb.append(x)` and `sb.toString()` for `sb`
+func (v *Value) getExternSummaryMemberCalls() Values {
+	if v.ParentProgram == nil || len(v.ParentProgram.getExternSummaryTables()) == 0 {
+		return nil
+	}
+	var ret Values
+	for _, member := range v.node.GetAllMember() {
+		for _, user := range member.GetUsers() {
+			call, ok := ssa.ToCall(user)
+			if !ok || call.Method == nil || call.Method.GetId() != member.GetId() {
+				continue
+			}
+			if v.ParentProgram.GetExternSummary(call) != nil {
+				ret = append(ret, v.NewValue(call))
+			}
+		}
+	}
+	return ret
+}
+
+// getExternSummaryReceiverDefs return the arguments flow into the receiver v by the extern calls
+func (v *Value) getExternSummaryReceiverDefs() Values {
+	var ret Values
+	for _, callValue := range v.getExternSummaryMemberCalls() {
+		call, summary := callValue.getExternSummary()
+		if call == nil {
+			continue
+		}
+		args, _ := summary.ArgsOf(call, ssa.ExternSummaryReceiver)
+		for _, arg := range args {
+			ret = append(ret, v.NewValue(arg).AppendEffectOn(callValue))
+		}
+	}
+	return ret
+}
+
+// getBottomUsesOfExternSummaryReceiver return the bottom uses by the extern calls which flow from receiver v to return
+func (v *Value) getBottomUsesOfExternSummaryReceiver(actx *AnalyzeContext) Values {
+	var vals Values
+	for _, callValue := range v.getExternSummaryMemberCalls() {
+		_, summary := callValue.getExternSummary()
+		if summary == nil || len(summary.TargetsOf(-1)) == 0 {
+			continue
+		}
+		vals = append(vals, callValue.AppendDependOn(v).getBottomUses(actx)...)
+	}
+	return vals
+}
+
+// getTopDefsByExternSummary trace the callee and arguments as the extern call does, the summary
+// don't prune any argument, but add the receiver and the arguments flow into the receiver, such as
+// `sb.append(x); sb.toString()`
+func (i *Value) getTopDefsByExternSummary(call *ssa.Call, summary *ssa.ExternSummary, opt ...OperationOption) Values {
+	nodes := Values{i.NewValue(call.Method)}
+	for _, arg := range call.Args {
+		nodes = append(nodes, i.NewValue(arg))
+	}
+	for _, value := range call.Binding {
+		nodes = append(nodes, i.NewValue(value))
+	}
+	if _, receiver := summary.ArgsOf(call, ssa.ExternSummaryReturn); receiver && call.Method.IsMember() {
+		obj := i.NewValue(call.Method.GetObject())
+		nodes = append(nodes, obj)
+		nodes = append(nodes, obj.getExternSummaryReceiverDefs()...)
+	}
+	var results Values
+	for _, node := range nodes {
+		i.AppendDependOn(node)
+		// same as extern call, use a new context for the arguments
+		results = append(results, node.GetTopDefs(opt...).AppendEffectOn(node)...)
+	}
+	return results
+}
+
+func (v *Value) getBottomUsesByExternSummary(actx *AnalyzeContext, call *ssa.Call, summary *ssa.ExternSummary) Values {
+	existed := make(map[int64]struct{})
+	v.DependOn.ForEach(func(value *Value) {
+		existed[value.GetId()] = struct{}{}
+	})
+	var targets []string
+	for index, arg := range call.Args {
+		if _, ok := existed[arg.GetId()]; ok {
+			targets = append(targets, summary.TargetsOf(index)...)
+		}
+	}
+	var receiver *Value
+	if call.Method.IsMember() {
+		receiver = v.NewValue(call.Method.GetObject())
+		if _, ok := existed[receiver.GetId()]; ok {
+			targets = append(targets, summary.TargetsOf(-1)...)
+		}
+	}
+
+	var vals Values
+	for _, target := range targets {
+		switch target {
+		case ssa.ExternSummaryReturn:
+			vals = append(vals, v.visitUserFallback(actx)...)
+		case ssa.ExternSummaryReceiver:
+			if receiver != nil {
+				vals = append(vals, receiver.AppendDependOn(v).visitUserFallback(actx)...)
+			}
+		}
+	}
+	if len(vals) == 0 {
+		return Values{v}
+	}
+	return vals
+}
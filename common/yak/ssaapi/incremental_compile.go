@@ -0,0 +1,169 @@
+package ssaapi
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/yaklang/yaklang/common/log"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/utils/memedit"
+	"github.com/yaklang/yaklang/common/yak/ssa"
+	"github.com/yaklang/yaklang/common/yak/ssa/ssadb"
+)
+
+// compileUnit is the entry file and the files included by it, they are compiled as one program
+type compileUnit struct {
+	hash  string
+	entry string
+	files []string
+}
+
+// incrementalCompile record the state of database program between compile
+type incrementalCompile struct {
+	// entry file -> unit, the unit is not changed and loaded from database
+	unchanged map[string]*compileUnit
+	// entry file -> index of program in result
+	index map[string]int
+	// the names defined by changed, removed or new compile unit,
+	// the unchanged unit referencing any of them should be re-compiled
+	changed map[string]struct{}
+}
+
+// checkCompileUnits compare the compile units of database program with file system,
+// the unit with any file changed or removed is deleted from database to re-compile.
+// if no compile unit recorded, the program is built by older version or broken, delete it to re-build fully, and return nil.
+// the database program is not touched when the compile units cannot be queried.
+func (c *config) checkCompileUnits() (*incrementalCompile, error) {
+	db := ssadb.GetDB()
+	programName := c.DatabaseProgramName
+	units, err := ssadb.GetIrProgramSources(db, programName)
+	if err != nil {
+		return nil, utils.Wrapf(err, "query compile units of program %v failed", programName)
+	}
+	if len(units) == 0 {
+		log.Infof("program %v has no compile unit recorded, re-build it", programName)
+		ssadb.DeleteProgram(db, programName)
+		return nil, nil
+	}
+
+	inc := &incrementalCompile{
+		unchanged: make(map[string]*compileUnit),
+		index:     make(map[string]int),
+		changed:   make(map[string]struct{}),
+	}
+	for compileHash, sources := range units {
+		unit := &compileUnit{hash: compileHash}
+		var allFiles []string
+		changed := false
+		for _, source := range sources {
+			allFiles = append(allFiles, source.FilePath)
+			if source.IsEntry {
+				unit.entry = source.FilePath
+			} else {
+				unit.files = append(unit.files, source.FilePath)
+			}
+			raw, err := c.fs.ReadFile(source.FilePath)
+			if err != nil || utils.CalcMd5(string(raw)) != source.SourceCodeHash {
+				changed = true
+			}
+		}
+		if unit.entry == "" || changed {
+			log.Infof("compile unit [%v] of program %v changed, re-compile it", unit.entry, programName)
+			c.addChangedSymbols(inc, compileHash, allFiles)
+			ssadb.DeleteProgramCompileUnit(db, programName, compileHash)
+			continue
+		}
+		inc.unchanged[unit.entry] = unit
+	}
+	return inc, nil
+}
+
+// addChangedSymbols collect the names defined by compile unit, the module or class name from file name is included
+func (c *config) addChangedSymbols(inc *incrementalCompile, compileHash string, files []string) {
+	defined, _ := ssadb.GetIrProgramCompileUnitSymbols(ssadb.GetDB(), c.DatabaseProgramName, compileHash)
+	for _, name := range defined {
+		inc.changed[name] = struct{}{}
+	}
+	for _, file := range files {
+		_, name := c.fs.PathSplit(file)
+		name = strings.TrimSuffix(name, c.fs.Ext(name))
+		if name != "" {
+			inc.changed[name] = struct{}{}
+		}
+	}
+}
+
+// loadCompileUnit load the unchanged compile unit from database
+func (c *config) loadCompileUnit(unit *compileUnit) *Program {
+	prog := NewProgram(ssa.NewProgramFromDatabaseCompileUnit(ssadb.GetDB(), c.DatabaseProgramName, unit.hash), c)
+	prog.comeFromDatabase = true
+	return prog
+}
+
+// recompileDependents re-compile the unchanged compile unit which reference the names defined by changed unit,
+// and delete the unchanged unit not compiled this time (its entry is included by other unit now).
+func (c *config) recompileDependents(inc *incrementalCompile, ret []*Program) error {
+	db := ssadb.GetDB()
+	programName := c.DatabaseProgramName
+
+	entries := make([]string, 0, len(inc.unchanged))
+	for entry := range inc.unchanged {
+		entries = append(entries, entry)
+	}
+	sort.Strings(entries)
+
+	for _, entry := range entries {
+		unit := inc.unchanged[entry]
+		index, ok := inc.index[entry]
+		if !ok {
+			log.Infof("compile unit [%v] of program %v is not an entry now, delete it", entry, programName)
+			ssadb.DeleteProgramCompileUnit(db, programName, unit.hash)
+			continue
+		}
+
+		_, undefined := ssadb.GetIrProgramCompileUnitSymbols(db, programName, unit.hash)
+		depend := false
+		for _, name := range undefined {
+			if _, ok := inc.changed[name]; ok {
+				depend = true
+				break
+			}
+		}
+		if !depend {
+			continue
+		}
+
+		log.Infof("compile unit [%v] of program %v depends on changed unit, re-compile it", entry, programName)
+		ssadb.DeleteProgramCompileUnit(db, programName, unit.hash)
+		raw, err := c.fs.ReadFile(entry)
+		if err != nil {
+			return utils.Wrapf(err, "read file %s error", entry)
+		}
+		prog, err := c.parseSimple(entry, memedit.NewMemEditor(string(raw)))
+		if err != nil {
+			return utils.Wrapf(err, "parse file %s error", entry)
+		}
+		c.saveCompileUnit(entry, prog)
+		ret[index] = NewProgram(prog, c)
+	}
+	return nil
+}
+
+// saveCompileUnit record the files built by entry file, with the md5 of content
+func (c *config) saveCompileUnit(path string, prog *ssa.Program) {
+	if c.DatabaseProgramName == "" || prog.Cache.CompileHash == "" {
+		return
+	}
+	files := make(map[string]string)
+	for _, file := range prog.GetIncludeFiles() {
+		if file == "" {
+			continue
+		}
+		if editor, ok := prog.GetEditor(file); ok {
+			files[file] = utils.CalcMd5(editor.GetSourceCode())
+		}
+	}
+	if err := ssadb.SaveIrProgramSource(ssadb.GetDB(), c.DatabaseProgramName, prog.Cache.CompileHash, path, files); err != nil {
+		log.Warnf("save compile unit of %v failed: %v", path, err)
+	}
+}
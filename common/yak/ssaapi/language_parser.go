@@ -5,13 +5,17 @@ import (
 	"io"
 	"time"
 
+	"github.com/samber/lo"
 	"github.com/yaklang/yaklang/common/log"
 	"github.com/yaklang/yaklang/common/utils"
 	"github.com/yaklang/yaklang/common/utils/memedit"
 	js2ssa "github.com/yaklang/yaklang/common/yak/JS2ssa"
+	"github.com/yaklang/yaklang/common/yak/go/go2ssa"
 	"github.com/yaklang/yaklang/common/yak/java/java2ssa"
 	"github.com/yaklang/yaklang/common/yak/php/php2ssa"
+	"github.com/yaklang/yaklang/common/yak/python/python2ssa"
 	"github.com/yaklang/yaklang/common/yak/ssa"
+	"github.com/yaklang/yaklang/common/yak/ssa/ssadb"
 	"github.com/yaklang/yaklang/common/yak/ssa4analyze"
 	"github.com/yaklang/yaklang/common/yak/ssaapi/ssareducer"
 	"github.com/yaklang/yaklang/common/yak/yak2ssa"
@@ -20,27 +24,78 @@ import (
 type Language string
 
 const (
-	Yak  Language = "yak"
-	JS   Language = "js"
-	PHP  Language = "php"
-	JAVA Language = "java"
+	Yak    Language = "yak"
+	JS     Language = "js"
+	PHP    Language = "php"
+	JAVA   Language = "java"
+	Go     Language = "go"
+	PYTHON Language = "python"
 )
 
 var LanguageBuilders = map[Language]ssa.Builder{
-	Yak:  yak2ssa.Builder,
-	JS:   js2ssa.Builder,
-	PHP:  php2ssa.Builder,
-	JAVA: java2ssa.Builder,
+	Yak:    yak2ssa.Builder,
+	JS:     js2ssa.Builder,
+	PHP:    php2ssa.Builder,
+	JAVA:   java2ssa.Builder,
+	Go:     go2ssa.Builder,
+	PYTHON: python2ssa.Builder,
 }
 
 var AllLanguageBuilders = []ssa.Builder{
 	php2ssa.Builder,
 	java2ssa.Builder,
+	go2ssa.Builder,
+	python2ssa.Builder,
 
 	yak2ssa.Builder,
 	js2ssa.Builder,
 }
 
+// the order to guess language of file, same as AllLanguageBuilders
+var languagesByFile = []Language{PHP, JAVA, Go, PYTHON, Yak, JS}
+
+// GetLanguageByFile return the language which can compile the file
+func GetLanguageByFile(path string) (Language, bool) {
+	for _, language := range languagesByFile {
+		if LanguageBuilders[language].FilterFile(path) {
+			return language, true
+		}
+	}
+	return "", false
+}
+
+// GetDatabaseProgramLanguages get the recorded languages of database program, or guess them by its source files
+func GetDatabaseProgramLanguages(programName string) []Language {
+	var languages []Language
+	recorded, err := ssadb.GetIrProgramLanguages(ssadb.GetDB(), programName)
+	if err != nil {
+		log.Warnf("get languages of program %v failed: %v", programName, err)
+	}
+	for _, language := range recorded {
+		if !lo.Contains(languages, Language(language)) {
+			languages = append(languages, Language(language))
+		}
+	}
+	if len(languages) > 0 {
+		return languages
+	}
+
+	units, err := ssadb.GetIrProgramSources(ssadb.GetDB(), programName)
+	if err != nil {
+		log.Warnf("get source files of program %v failed: %v", programName, err)
+		return nil
+	}
+	for _, sources := range units {
+		for _, source := range sources {
+			language, ok := GetLanguageByFile(source.FilePath)
+			if ok && !lo.Contains(languages, language) {
+				languages = append(languages, language)
+			}
+		}
+	}
+	return languages
+}
+
 func (c *config) parseProject() ([]*Program, error) {
 	ret := make([]*Program, 0)
 
@@ -48,12 +103,28 @@ func (c *config) parseProject() ([]*Program, error) {
 
 	log.Infof("parse project in fs: %T, localpath: %v", c.fs, programPath)
 
+	var inc *incrementalCompile
+	if c.incrementalCompile && c.DatabaseProgramName != "" {
+		var err error
+		if inc, err = c.checkCompileUnits(); err != nil {
+			return nil, err
+		}
+	}
+
 	// parse project
 	err := ssareducer.ReducerCompile(
 		programPath, // base
 		ssareducer.WithFileSystem(c.fs),
 		ssareducer.WithEntryFiles(c.entryFile...),
 		ssareducer.WithCompileMethod(func(path string, f io.Reader) (includeFiles []string, err error) {
+			if inc != nil {
+				if unit, ok := inc.unchanged[path]; ok {
+					log.Infof("skip compile unchanged file: %v", path)
+					inc.index[path] = len(ret)
+					ret = append(ret, c.loadCompileUnit(unit))
+					return unit.files, nil
+				}
+			}
 			log.Debugf("start to compile from: %v", path)
 			startTime := time.Now()
 
@@ -70,6 +141,10 @@ func (c *config) parseProject() ([]*Program, error) {
 			}
 			log.Infof("compile %s cost: %v", path, endTime.Sub(startTime))
 			ret = append(ret, NewProgram(prog, c))
+			c.saveCompileUnit(path, prog)
+			if inc != nil {
+				c.addChangedSymbols(inc, prog.Cache.CompileHash, prog.GetIncludeFiles())
+			}
 			exclude := prog.GetIncludeFiles()
 			if len(exclude) > 0 {
 				log.Infof("program include files: %v will not be as the entry from project", len(exclude))
@@ -80,6 +155,11 @@ func (c *config) parseProject() ([]*Program, error) {
 	if err != nil {
 		return nil, utils.Wrap(err, "parse project error")
 	}
+	if inc != nil {
+		if err := c.recompileDependents(inc, ret); err != nil {
+			return nil, utils.Wrap(err, "parse project error")
+		}
+	}
 	return ret, nil
 }
 
@@ -122,9 +202,24 @@ func (c *config) parseSimple(path string, r *memedit.MemEditor) (ret *ssa.Progra
 	builder.Finish()
 	ssa4analyze.RunAnalyzer(prog)
 	prog.Finish()
+	c.saveProgramLanguage(path)
 	return prog, nil
 }
 
+// saveProgramLanguage record the language of database program, the program from database can't guess it by source code
+func (c *config) saveProgramLanguage(path string) {
+	if c.DatabaseProgramName == "" {
+		return
+	}
+	language := c.language
+	if language == "" {
+		language, _ = GetLanguageByFile(path)
+	}
+	if err := ssadb.SaveIrProgramLanguage(ssadb.GetDB(), c.DatabaseProgramName, string(language)); err != nil {
+		log.Warnf("save program language failed: %v", err)
+	}
+}
+
 var SkippedError = ssareducer.SkippedError
 
 func (c *config) init(path string, editor *memedit.MemEditor) (*ssa.Program, *ssa.FunctionBuilder, error) {
@@ -169,6 +264,9 @@ func (c *config) init(path string, editor *memedit.MemEditor) (*ssa.Program, *ss
 	}
 
 	prog := ssa.NewProgram(programName, c.fs, c.programPath)
+	if programName != "" && path != "" {
+		prog.Cache.CompileHash = utils.CalcSha1(programName, path, editor.GetSourceCode())
+	}
 	prog.Build = func(filePath string, src *memedit.MemEditor, fb *ssa.FunctionBuilder) error {
 		// check builder
 		if LanguageBuilder == nil {
@@ -9,6 +9,7 @@ import (
 	"github.com/davecgh/go-spew/spew"
 	"github.com/stretchr/testify/require"
 	"github.com/yaklang/yaklang/common/consts"
+	"github.com/yaklang/yaklang/common/yak/yakmod"
 )
 
 func TestMultiFile(t *testing.T) {
@@ -19,10 +20,10 @@ a = () => {
 `)
 	defer os.Remove(outterFile)
 
-	check := func(t *testing.T, filename string) {
+	checkStmt := func(t *testing.T, stmt, filename string) {
 		filename = strconv.Quote(filename)
 		prog, err := Parse(`
-include ` + filename + `
+` + stmt + ` ` + filename + `
 
 result = a()
 dump(result)
@@ -37,6 +38,9 @@ dump(result)
 			t.Fatal("result is not abc")
 		}
 	}
+	check := func(t *testing.T, filename string) {
+		checkStmt(t, "include", filename)
+	}
 
 	t.Run("absolute path", func(t *testing.T) {
 		check(t, outterFile)
@@ -49,4 +53,15 @@ dump(result)
 		require.NoError(t, err, "filepath.Rel() failed")
 		check(t, path)
 	})
+
+	t.Run("module path", func(t *testing.T) {
+		cacheDir := t.TempDir()
+		t.Setenv(yakmod.ModCacheEnv, cacheDir)
+		moduleDir := filepath.Join(cacheDir, "github.com", "org", "lib@v1.0.0")
+		require.NoError(t, os.MkdirAll(moduleDir, 0o755))
+		raw, err := os.ReadFile(outterFile)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(moduleDir, "a.yak"), raw, 0o644))
+		checkStmt(t, "import", "github.com/org/lib@v1.0.0")
+	})
 }
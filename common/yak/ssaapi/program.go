@@ -2,6 +2,7 @@ package ssaapi
 
 import (
 	"sort"
+	"sync"
 
 	"github.com/samber/lo"
 	"github.com/yaklang/yaklang/common/log"
@@ -17,6 +18,15 @@ type Program struct {
 
 	// come from database will affect search operation
 	comeFromDatabase bool
+
+	callGraph     *CallGraph
+	callGraphOnce sync.Once
+
+	httpSources    Values
+	httpSourceOnce sync.Once
+
+	externSummaries   []*ssa.ExternSummaryTable
+	externSummaryOnce sync.Once
 }
 
 func (p *Program) GetNames() []string {
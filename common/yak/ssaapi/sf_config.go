@@ -1,6 +1,11 @@
 package ssaapi
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/gobwas/glob"
+	"github.com/yaklang/yaklang/common/log"
 	sf "github.com/yaklang/yaklang/common/syntaxflow/sfvm"
 	"github.com/yaklang/yaklang/common/utils"
 	"github.com/yaklang/yaklang/common/yak/yaklib/codec"
@@ -80,6 +85,10 @@ func WithSyntaxFlowConfig(
 			runSyntaxFlow(op, func(m map[string]Values, v *Value) error {
 				return nil
 			})
+		case sf.RecursiveConfig_Barrier:
+			if barrier := newSyntaxFlowBarrier(sfResult, op); barrier != nil {
+				options = append(options, WithBarrier(barrier))
+			}
 		}
 	}
 
@@ -89,3 +98,85 @@ func WithSyntaxFlowConfig(
 	}
 	return cb(options...)
 }
+
+// newSyntaxFlowBarrier create the barrier (sanitizer) for data flow analysis:
+// the identifier is the name of called function, glob is supported, like `#{barrier: htmlspecialchars}->`;
+// the syntaxflow rule is searched in the whole program, like `#{barrier: `*.setString()`}->`,
+// the value found or the call to it is barrier. the pruned value is recorded in result.
+func newSyntaxFlowBarrier(sfResult *sf.SFFrameResult, op *sf.RecursiveConfigItem) func(*Value) bool {
+	if op.Value == "" {
+		return nil
+	}
+
+	var match func(*Value) bool
+	if op.SyntaxFlowRule {
+		var barriers Values
+		searched := false
+		match = func(v *Value) bool {
+			if !searched {
+				searched = true
+				if v.ParentProgram != nil {
+					res, err := SyntaxFlowWithError(v.ParentProgram, op.Value)
+					if err != nil {
+						log.Warnf("search barrier %v failed: %v", op.Value, err)
+					} else {
+						barriers = res.GetAllValuesChain()
+					}
+				}
+			}
+			var callee *Value
+			if v.IsCall() {
+				callee = v.GetCallee()
+			}
+			for _, barrier := range barriers {
+				if ValueCompare(barrier, v) || (callee != nil && ValueCompare(barrier, callee)) {
+					return true
+				}
+			}
+			return false
+		}
+	} else {
+		matcher, err := glob.Compile(op.Value)
+		if err != nil {
+			log.Warnf("invalid barrier name %v: %v", op.Value, err)
+			return nil
+		}
+		match = func(v *Value) bool {
+			if !v.IsCall() {
+				return false
+			}
+			callee := v.GetCallee()
+			if callee == nil {
+				return false
+			}
+			for _, name := range []string{callee.GetName(), callee.GetVerboseName()} {
+				if name == "" {
+					continue
+				}
+				if matcher.Match(name) {
+					return true
+				}
+				// method name, like `stmt.setString`
+				if idx := strings.LastIndex(name, "."); idx >= 0 && matcher.Match(name[idx+1:]) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	pruned := make(map[int64]struct{})
+	return func(v *Value) bool {
+		if !match(v) {
+			return false
+		}
+		if _, ok := pruned[v.GetId()]; !ok && sfResult != nil {
+			pruned[v.GetId()] = struct{}{}
+			sfResult.PrunedPaths = append(sfResult.PrunedPaths, &sf.PrunedPath{
+				Barrier: v,
+				Reason:  fmt.Sprintf("data flow stopped at barrier [%v]: %v", op.Value, v.String()),
+			})
+		}
+		return true
+	}
+}
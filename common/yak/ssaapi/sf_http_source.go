@@ -0,0 +1,130 @@
+package ssaapi
+
+import (
+	"strings"
+
+	"github.com/yaklang/yaklang/common/log"
+	"github.com/yaklang/yaklang/common/syntaxflow/sfvm"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/yak/ssa"
+)
+
+// NativeCall_HttpSource is used to get the http entry points (taint sources) by built-in framework models,
+// `*<httpSource>` return all sources of program, `$values<httpSource>` keep the values which are sources.
+const NativeCall_HttpSource = "httpSource"
+
+// httpSourceModels is the built-in SyntaxFlow models of http entry points, every value in `$source` is a source.
+var httpSourceModels = map[Language]string{
+	JAVA: `
+// Spring MVC: the parameters of request mapping method
+*Mapping.__ref__?{opcode: function}<getFormalParams> as $source;
+// Spring MVC and JAX-RS: the annotated parameters
+/^(RequestParam|PathVariable|RequestBody|RequestHeader|CookieValue|ModelAttribute|RequestPart|MatrixVariable)$/.__ref__ as $source;
+/^(QueryParam|PathParam|FormParam|HeaderParam|CookieParam|MatrixParam|BeanParam)$/.__ref__ as $source;
+// JAX-RS: the parameters of resource method
+/^(GET|POST|PUT|DELETE|PATCH|HEAD|OPTIONS)$/.__ref__?{opcode: function}<getFormalParams> as $source;
+// Servlet: HttpServletRequest accessor
+./^(getParameter|getParameterValues|getParameterMap|getParameterNames|getHeader|getHeaders|getCookies|getQueryString|getInputStream|getReader|getRequestURI|getRequestURL|getPathInfo|getPart|getParts)$/() as $source;
+`,
+	PHP: `
+// super globals and the members
+/^\$_(GET|POST|REQUEST|COOKIE|FILES|SERVER)$/ as $source;
+*_GET[*] as $source;
+*_POST[*] as $source;
+*_REQUEST[*] as $source;
+*_COOKIE[*] as $source;
+*_FILES[*] as $source;
+*_SERVER[*] as $source;
+// raw request body
+file_get_contents()?{have: 'php://input'} as $source;
+// Laravel and ThinkPHP: request object, facade and helper
+/(?i)request/./^(input|query|post|all|only|except|cookie|header|file|json|route|param|get|put|delete|request|server)$/() as $source;
+/^Request_(input|query|post|all|only|except|cookie|header|file|json|route|param|get)$/() as $source;
+request() as $source;
+input() as $source;
+`,
+}
+
+func init() {
+	sfvm.RegisterNativeCall(NativeCall_HttpSource, func(v sfvm.ValueOperator, frame *sfvm.SFFrame) (bool, sfvm.ValueOperator, error) {
+		var vals []sfvm.ValueOperator
+		if prog, ok := v.(*Program); ok {
+			for _, source := range prog.GetHttpSources() {
+				val := source.NewValue(source.node)
+				val.AppendPredecessor(v, frame.WithPredecessorContext("httpSource"))
+				vals = append(vals, val)
+			}
+		} else {
+			v.Recursive(func(operator sfvm.ValueOperator) error {
+				val, ok := operator.(*Value)
+				if !ok || val.ParentProgram == nil {
+					return nil
+				}
+				if val.ParentProgram.IsHttpSource(val) {
+					vals = append(vals, val)
+				}
+				return nil
+			})
+		}
+		if len(vals) > 0 {
+			return true, sfvm.NewValues(vals), nil
+		}
+		return false, nil, utils.Error("no value(http source) found")
+	})
+}
+
+// GetHttpSources return the http entry points of program found by the built-in framework models
+// (Spring MVC, Servlet, JAX-RS for java, super globals, Laravel and ThinkPHP for php), the result is cached in program.
+func (p *Program) GetHttpSources() Values {
+	p.httpSourceOnce.Do(func() {
+		p.httpSources = p.findHttpSources()
+	})
+	return p.httpSources
+}
+
+// IsHttpSource check whether the value is a http entry point
+func (p *Program) IsHttpSource(v *Value) bool {
+	for _, source := range p.GetHttpSources() {
+		if source.GetId() == v.GetId() {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Program) httpSourceLanguages() []Language {
+	if languages := p.languages(); len(languages) > 0 {
+		return languages
+	}
+	return []Language{JAVA, PHP}
+}
+
+func (p *Program) findHttpSources() Values {
+	var ret Values
+	visited := make(map[int64]struct{})
+	for _, language := range p.httpSourceLanguages() {
+		model, ok := httpSourceModels[language]
+		if !ok {
+			continue
+		}
+		result, err := p.SyntaxFlowWithError(model)
+		if err != nil {
+			log.Debugf("http source model of %v: %v", language, err)
+		}
+		if result == nil {
+			continue
+		}
+		for _, source := range result.GetValues("source") {
+			if _, ok := visited[source.GetId()]; ok {
+				continue
+			}
+			visited[source.GetId()] = struct{}{}
+			// the receiver of method is not a source
+			if param, ok := ssa.ToParameter(source.node); ok && !param.IsFreeValue && strings.EqualFold(param.GetName(), "this") {
+				continue
+			}
+			ret = append(ret, source)
+		}
+	}
+	return ret
+}
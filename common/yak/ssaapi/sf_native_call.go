@@ -17,6 +17,9 @@ var ExistedNativeCall = []string{
 	NativeCall_GetObject,
 	NativeCall_GetMembers,
 	NativeCall_GetSiblings,
+	NativeCall_GetCallers,
+	NativeCall_GetCallees,
+	NativeCall_HttpSource,
 }
 
 const (
@@ -52,8 +55,33 @@ const (
 
 	// NativeCall_GetSiblings is used to get the siblings of a value
 	NativeCall_GetSiblings = "getSiblings"
+
+	// NativeCall_GetCallers is used to get the functions which call the value in call graph,
+	// if the value is not a function, use the function which the value is in
+	NativeCall_GetCallers = "callers"
+
+	// NativeCall_GetCallees is used to get the functions called by the value in call graph,
+	// if the value is not a function, use the function which the value is in.
+	// the library function has no body, the callee of call instruction is returned
+	NativeCall_GetCallees = "callees"
 )
 
+func callGraphNodeOfValue(val *Value) (*CallGraph, *CallGraphNode) {
+	if val.ParentProgram == nil {
+		return nil, nil
+	}
+	fun := val
+	if !val.IsFunction() {
+		fun = val.GetFunction()
+	}
+	graph := val.ParentProgram.GetCallGraph()
+	node, ok := graph.GetNodeByFunction(fun)
+	if !ok {
+		return nil, nil
+	}
+	return graph, node
+}
+
 func init() {
 	sfvm.RegisterNativeCall(NativeCall_GetFormalParams, func(v sfvm.ValueOperator, frame *sfvm.SFFrame) (bool, sfvm.ValueOperator, error) {
 		var vals []sfvm.ValueOperator
@@ -164,6 +192,60 @@ func init() {
 		}
 		return false, nil, utils.Error("no value(current func) found")
 	})
+	sfvm.RegisterNativeCall(NativeCall_GetCallers, func(v sfvm.ValueOperator, frame *sfvm.SFFrame) (bool, sfvm.ValueOperator, error) {
+		var vals []sfvm.ValueOperator
+		v.Recursive(func(operator sfvm.ValueOperator) error {
+			val, ok := operator.(*Value)
+			if !ok {
+				return nil
+			}
+			graph, node := callGraphNodeOfValue(val)
+			if node == nil {
+				return nil
+			}
+			for _, edge := range graph.GetCallers(node) {
+				caller := val.NewValue(edge.Caller.Function.node)
+				caller.AppendPredecessor(val, frame.WithPredecessorContext("callers"))
+				vals = append(vals, caller)
+			}
+			return nil
+		})
+		if len(vals) > 0 {
+			return true, sfvm.NewValues(vals), nil
+		}
+		return false, nil, utils.Error("no value(callers) found")
+	})
+	sfvm.RegisterNativeCall(NativeCall_GetCallees, func(v sfvm.ValueOperator, frame *sfvm.SFFrame) (bool, sfvm.ValueOperator, error) {
+		var vals []sfvm.ValueOperator
+		v.Recursive(func(operator sfvm.ValueOperator) error {
+			val, ok := operator.(*Value)
+			if !ok {
+				return nil
+			}
+			graph, node := callGraphNodeOfValue(val)
+			if node == nil {
+				return nil
+			}
+			for _, edge := range graph.GetCallees(node) {
+				var callee *Value
+				if edge.Callee.External {
+					callee = edge.Call.GetCallee()
+				} else {
+					callee = val.NewValue(edge.Callee.Function.node)
+				}
+				if callee == nil {
+					continue
+				}
+				callee.AppendPredecessor(val, frame.WithPredecessorContext("callees"))
+				vals = append(vals, callee)
+			}
+			return nil
+		})
+		if len(vals) > 0 {
+			return true, sfvm.NewValues(vals), nil
+		}
+		return false, nil, utils.Error("no value(callees) found")
+	})
 	sfvm.RegisterNativeCall(NativeCall_GetSiblings, func(v sfvm.ValueOperator, frame *sfvm.SFFrame) (bool, sfvm.ValueOperator, error) {
 		var vals []sfvm.ValueOperator
 		v.Recursive(func(operator sfvm.ValueOperator) error {
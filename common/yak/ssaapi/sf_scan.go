@@ -0,0 +1,77 @@
+package ssaapi
+
+import (
+	"sort"
+
+	"github.com/yaklang/yaklang/common/log"
+	"github.com/yaklang/yaklang/common/schema"
+	"github.com/yaklang/yaklang/common/syntaxflow/sfvm"
+	"github.com/yaklang/yaklang/common/utils"
+)
+
+// SyntaxFlowFinding is a value reported by a SyntaxFlow rule
+type SyntaxFlowFinding struct {
+	Program  *Program
+	Rule     *schema.SyntaxFlowRule
+	Variable string
+	Message  string
+	Value    *Value
+}
+
+// SyntaxFlowRuleFindings execute every rule on program and collect the values in `alert` variables
+// (or `check` variables if rule has no alert).
+func (p *Program) SyntaxFlowRuleFindings(rules []*schema.SyntaxFlowRule, opts ...sfvm.Option) []*SyntaxFlowFinding {
+	var findings []*SyntaxFlowFinding
+	for _, rule := range rules {
+		result, err := p.SyntaxFlowWithError(rule.Content, opts...)
+		if err != nil {
+			// `check` failed is normal when nothing matched
+			log.Debugf("SyntaxFlow rule %v on program %v: %v", rule.RuleName, p.GetProgramName(), err)
+		}
+		if result == nil {
+			continue
+		}
+
+		variables := make([]string, 0, len(result.AlertSymbolTable))
+		for name := range result.AlertSymbolTable {
+			variables = append(variables, name)
+		}
+		sort.Strings(variables)
+		if len(variables) == 0 {
+			variables = utils.RemoveRepeatStringSlice(result.CheckParams)
+		}
+
+		for _, name := range variables {
+			for _, value := range result.GetValues(name) {
+				findings = append(findings, &SyntaxFlowFinding{
+					Program:  p,
+					Rule:     rule,
+					Variable: name,
+					Message:  result.AlertMsgTable[name],
+					Value:    value,
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// GetProgramName return the name of program, the program loaded from database use the database program name
+func (p *Program) GetProgramName() string {
+	if name := p.Program.GetProgramName(); name != "" {
+		return name
+	}
+	if p.config != nil {
+		return p.config.DatabaseProgramName
+	}
+	return ""
+}
+
+// GetPosition return the file and line range of the finding value
+func (f *SyntaxFlowFinding) GetPosition() (file string, start, end int) {
+	r := f.Value.GetRange()
+	if r == nil || r.GetEditor() == nil {
+		return "", 0, 0
+	}
+	return r.GetEditor().GetUrl(), r.GetStart().GetLine(), r.GetEnd().GetLine()
+}
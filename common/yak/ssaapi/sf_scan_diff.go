@@ -0,0 +1,74 @@
+package ssaapi
+
+import (
+	"sort"
+
+	"github.com/yaklang/yaklang/common/schema"
+	"github.com/yaklang/yaklang/common/syntaxflow/sfvm"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/utils/filesys"
+)
+
+// DataFlowHash is the fingerprint of finding without any position, it is built by rule, variable,
+// the enclosing function, the value and the top definitions of the value, so the finding is still
+// matched after the code is moved to other lines.
+func (f *SyntaxFlowFinding) DataFlowHash() string {
+	var function string
+	if fun := f.Value.GetFunction(); fun != nil {
+		function = fun.String()
+	}
+	topDefs := make([]string, 0)
+	for _, def := range f.Value.GetTopDefs() {
+		topDefs = append(topDefs, def.String())
+	}
+	topDefs = utils.RemoveRepeatStringSlice(topDefs)
+	sort.Strings(topDefs)
+	return utils.CalcSha1(f.Rule.RuleName, f.Variable, function, f.Value.String(), topDefs)
+}
+
+// DiffSyntaxFlowFindings return the findings in target which are not in base, the findings are
+// matched by DataFlowHash. If the same data flow appears more times in target than in base,
+// the extra ones are reported.
+func DiffSyntaxFlowFindings(base, target []*SyntaxFlowFinding) []*SyntaxFlowFinding {
+	count := make(map[string]int)
+	for _, finding := range base {
+		count[finding.DataFlowHash()]++
+	}
+	var ret []*SyntaxFlowFinding
+	for _, finding := range target {
+		hash := finding.DataFlowHash()
+		if count[hash] > 0 {
+			count[hash]--
+			continue
+		}
+		ret = append(ret, finding)
+	}
+	return ret
+}
+
+// ScanFileSystemWithSyntaxFlowRule compile the file system in memory and execute the rules on every program
+func ScanFileSystemWithSyntaxFlowRule(fs filesys.FileSystem, rules []*schema.SyntaxFlowRule, opts []Option, sfOpts ...sfvm.Option) ([]*SyntaxFlowFinding, error) {
+	progs, err := ParseProject(fs, opts...)
+	if err != nil {
+		return nil, err
+	}
+	var findings []*SyntaxFlowFinding
+	for _, prog := range progs {
+		findings = append(findings, prog.SyntaxFlowRuleFindings(rules, sfOpts...)...)
+	}
+	return findings, nil
+}
+
+// DiffScanWithSyntaxFlowRule compile base and target file system, return the findings only exist in target,
+// it is used to report the vulnerabilities introduced by a change.
+func DiffScanWithSyntaxFlowRule(base, target filesys.FileSystem, rules []*schema.SyntaxFlowRule, opts []Option, sfOpts ...sfvm.Option) ([]*SyntaxFlowFinding, error) {
+	baseFindings, err := ScanFileSystemWithSyntaxFlowRule(base, rules, opts, sfOpts...)
+	if err != nil {
+		return nil, utils.Wrap(err, "scan base failed")
+	}
+	targetFindings, err := ScanFileSystemWithSyntaxFlowRule(target, rules, opts, sfOpts...)
+	if err != nil {
+		return nil, utils.Wrap(err, "scan target failed")
+	}
+	return DiffSyntaxFlowFindings(baseFindings, targetFindings), nil
+}
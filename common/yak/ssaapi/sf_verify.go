@@ -0,0 +1,40 @@
+package ssaapi
+
+import (
+	"path"
+
+	"github.com/yaklang/yaklang/common/syntaxflow/sfvm"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/utils/filesys"
+)
+
+// CompileRuleTestSample compile the sample embedded in SyntaxFlow rule in memory,
+// the language is decided by the file extension of sample name, or the `lang` in rule
+func CompileRuleTestSample(sample *sfvm.RuleTestSample) (sfvm.ValueOperator, error) {
+	if sample == nil {
+		return nil, utils.Error("sample is nil")
+	}
+	if path.Ext(sample.Name) == "" {
+		var opts []Option
+		if sample.Language != "" {
+			opts = append(opts, WithLanguage(Language(sample.Language)))
+		}
+		return Parse(sample.Code, append(opts, WithDisableCache(true))...)
+	}
+
+	vfs := filesys.NewVirtualFs()
+	vfs.AddFile(sample.Name, sample.Code)
+	progs, err := ParseProject(vfs)
+	if err != nil {
+		return nil, err
+	}
+	if len(progs) == 0 {
+		return nil, utils.Errorf("no program compiled from sample %v", sample.Name)
+	}
+	return progs[0], nil
+}
+
+// RunSyntaxFlowRuleTest run the test embedded in SyntaxFlow rule, see sfvm.RuleTest
+func RunSyntaxFlowRuleTest(rule string, opts ...sfvm.Option) (*sfvm.RuleTest, []*sfvm.RuleTestResult, error) {
+	return sfvm.RunRuleTest(rule, CompileRuleTestSample, opts...)
+}
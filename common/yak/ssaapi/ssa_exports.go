@@ -36,6 +36,8 @@ type config struct {
 	DatabaseProgramName        string
 	DatabaseProgramCacheHitter func(any)
 	DisableCache               bool
+	// only re-compile the changed compile unit of database program
+	incrementalCompile bool
 	// for hash
 	externInfo string
 }
@@ -171,6 +173,18 @@ func WithDatabaseProgramCacheHitter(h func(i any)) Option {
 	}
 }
 
+// WithIncrementalCompile re-compile the database program only for changed, added or removed file,
+// the compile unit (entry file and its included files) not changed is kept in database and not returned
+func WithIncrementalCompile(b ...bool) Option {
+	return func(c *config) {
+		if len(b) > 0 {
+			c.incrementalCompile = b[0]
+		} else {
+			c.incrementalCompile = true
+		}
+	}
+}
+
 func WithDisableCache(b bool) Option {
 	return func(c *config) {
 		c.DisableCache = b
@@ -269,4 +283,5 @@ var Exports = map[string]any{
 	"Yak":        Yak,
 	"PHP":        PHP,
 	"Java":       JAVA,
+	"Go":         Go,
 }
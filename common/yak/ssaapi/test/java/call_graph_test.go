@@ -0,0 +1,67 @@
+package java
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yaklang/yaklang/common/yak/ssaapi"
+	"github.com/yaklang/yaklang/common/yak/ssaapi/test/ssatest"
+)
+
+const callGraphCode = `
+package com.example;
+
+interface Animal { void speak(String s); }
+
+class Dog implements Animal {
+	public void speak(String s) { Runtime.getRuntime().exec(s); }
+}
+
+class Cat implements Animal {
+	public void speak(String s) { System.out.println(s); }
+}
+
+class Main {
+	public void main(Animal animal, String s) {
+		animal.speak(s);
+		helper(s);
+	}
+	public void helper(String s) { }
+	public void onlyCat(String s) { new Cat().speak(s); }
+}
+`
+
+func TestCallGraph_VirtualDispatch(t *testing.T) {
+	prog, err := ssaapi.Parse(callGraphCode, ssaapi.WithLanguage(ssaapi.JAVA))
+	require.NoError(t, err)
+	graph := prog.GetCallGraph()
+
+	callees := make(map[string]bool)
+	for _, main := range graph.GetNodesByName("Main_main") {
+		for _, edge := range graph.GetCallees(main) {
+			callees[edge.Callee.Name] = edge.Virtual
+		}
+	}
+	// the type of interface parameter is unknown, dispatch to all implementations
+	require.Equal(t, map[string]bool{"Dog_speak": true, "Cat_speak": true, "Main_helper": false}, callees)
+
+	require.True(t, graph.CanReach("main", "Runtime.getRuntime().exec"))
+	// the class of object is known, dispatch to Cat only
+	require.False(t, graph.CanReach("onlyCat", "Runtime.getRuntime().exec"))
+	require.True(t, graph.CanReach("onlyCat", "System.out.println"))
+}
+
+func TestCallGraph_NativeCall(t *testing.T) {
+	// the class blue print is not saved in database, the program from database
+	// dispatch the method by name, so only check contain here
+	ssatest.CheckSyntaxFlowContain(t, callGraphCode, `
+Runtime.getRuntime().exec() as $sink;
+$sink<getCurrentFunc> as $current;
+$current<callers> as $callers;
+$callers<callees> as $callees;
+`, map[string][]string{
+		"current": {"Function-Dog.speak"},
+		"callers": {"Function-Main.main"},
+		"callees": {"Function-Cat.speak", "Function-Dog.speak", "Function-Main.helper"},
+	}, ssaapi.WithLanguage(ssaapi.JAVA))
+}
@@ -0,0 +1,81 @@
+package java
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yaklang/yaklang/common/yak/ssa"
+	"github.com/yaklang/yaklang/common/yak/ssaapi"
+	"github.com/yaklang/yaklang/common/yak/ssaapi/test/ssatest"
+)
+
+const externSummaryCode = `
+package com.example;
+
+public class Summary {
+	public void run(String x, String y, String z) {
+		StringBuilder sb = new StringBuilder();
+		sb.append(y);
+		Runtime.getRuntime().exec(sb.toString());
+
+		String t = z.trim();
+		Runtime.getRuntime().exec(t);
+
+		Runtime.getRuntime().exec(String.format("cmd %s", x));
+	}
+}
+`
+
+func TestExternSummary_TopDef(t *testing.T) {
+	ssatest.CheckSyntaxFlowContain(t, externSummaryCode,
+		`Runtime.getRuntime().exec(* #-> as $source)`,
+		map[string][]string{
+			// y flow into the receiver by append, and out by toString
+			"source": {"Parameter-x", "Parameter-y", "Parameter-z"},
+		},
+		ssaapi.WithLanguage(ssaapi.JAVA),
+	)
+}
+
+func TestExternSummary_BottomUse(t *testing.T) {
+	ssatest.CheckSyntaxFlowContain(t, externSummaryCode,
+		`run<getFormalParams> as $param; $param --> as $sink`,
+		map[string][]string{
+			"sink": {
+				`Undefined-.exec(valid)(Undefined-sb.toString(valid)())`,
+				`Undefined-.exec(valid)(ParameterMember-parameter[3].trim())`,
+				`Undefined-.exec(valid)(Undefined-String.format(valid)("cmd %s",Parameter-x))`,
+			},
+		},
+		ssaapi.WithLanguage(ssaapi.JAVA),
+	)
+}
+
+func TestExternSummary_Register(t *testing.T) {
+	err := ssa.RegisterExternSummary(string(ssaapi.JAVA), []byte(`
+- class: TaintBox
+  method: fill
+  flows: [{from: "param[0]", to: receiver}]
+- class: TaintBox
+  method: take
+  flows: [{from: receiver, to: return}]
+`))
+	require.NoError(t, err)
+
+	ssatest.CheckSyntaxFlowContain(t, `
+package com.example;
+
+public class Box {
+	public void run(String a) {
+		TaintBox box = new TaintBox();
+		box.fill(a);
+		Runtime.getRuntime().exec(box.take());
+	}
+}
+`, `Runtime.getRuntime().exec(* #-> as $source)`, map[string][]string{
+		"source": {"Parameter-a"},
+	}, ssaapi.WithLanguage(ssaapi.JAVA))
+
+	_, err = ssa.ParseExternSummary([]byte(`[{method: bad, flows: [{from: "arg[0]", to: return}]}]`))
+	require.Error(t, err)
+}
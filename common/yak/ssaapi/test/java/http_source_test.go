@@ -0,0 +1,56 @@
+package java
+
+import (
+	"testing"
+
+	"github.com/yaklang/yaklang/common/yak/ssaapi"
+	"github.com/yaklang/yaklang/common/yak/ssaapi/test/ssatest"
+)
+
+const httpSourceCode = `
+package com.example;
+
+@RestController
+public class UserController {
+	@RequestMapping(value = "/exec")
+	public String exec(@RequestParam(value = "cmd") String cmd, String name) {
+		Runtime.getRuntime().exec(cmd);
+		return name;
+	}
+
+	@GetMapping("/hello")
+	public String hello(String msg) { return msg; }
+
+	public String notEntry(String w) {
+		Runtime.getRuntime().exec(w);
+		return w;
+	}
+
+	public void servlet(HttpServletRequest req) {
+		String p = req.getParameter("p");
+		Runtime.getRuntime().exec(p);
+	}
+}
+
+@Path("/resource")
+public class Resource {
+	@GET
+	public String get(@QueryParam("q") String q, @PathParam("id") String id) { return q; }
+}
+`
+
+func TestHttpSource_Java(t *testing.T) {
+	ssatest.CheckSyntaxFlowContain(t, httpSourceCode, `*<httpSource> as $source`, map[string][]string{
+		"source": {"Parameter-cmd", "Parameter-name", "Parameter-msg", "Parameter-q", "Parameter-id", `ParameterMember-parameter[1].getParameter("p")`},
+	}, ssaapi.WithLanguage(ssaapi.JAVA))
+}
+
+func TestHttpSource_Java_Filter(t *testing.T) {
+	ssatest.CheckSyntaxFlow(t, httpSourceCode, `
+Runtime.getRuntime().exec(* #-> as $param);
+$param<httpSource> as $source;
+`, map[string][]string{
+		// the parameter of notEntry is not a source
+		"source": {"Parameter-cmd"},
+	}, ssaapi.WithLanguage(ssaapi.JAVA))
+}
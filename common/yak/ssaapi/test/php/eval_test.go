@@ -21,7 +21,12 @@ $c($fun);
 	_ = code
 	ssatest.CheckSyntaxFlow(t, code,
 		"*_GET[*] -{until: `* ?{opcode:call}`}-> * as $func",
-		map[string][]string{"func": {`add("as", Undefined-$_GET."func2"(valid))(Function-base64_decode(Undefined-$_GET.'func'(valid)))`, `Function-base64_decode(Undefined-$_GET.'func'(valid))`}},
+		map[string][]string{"func": {
+			`add("as", Undefined-$_GET."func2"(valid))(Function-base64_decode(Undefined-$_GET.'func'(valid)))`,
+			`Function-base64_decode(Undefined-$_GET.'func'(valid))`,
+			// base64_decode is summarized, the decoded value flow to strlen
+			`Function-strlen(Function-base64_decode(Undefined-$_GET.'func'(valid)))`,
+		}},
 		ssaapi.WithLanguage(ssaapi.PHP),
 	)
 }
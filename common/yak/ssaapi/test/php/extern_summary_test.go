@@ -0,0 +1,28 @@
+package php
+
+import (
+	"testing"
+
+	"github.com/yaklang/yaklang/common/yak/ssaapi"
+	"github.com/yaklang/yaklang/common/yak/ssaapi/test/ssatest"
+)
+
+func TestExternSummary_PHP(t *testing.T) {
+	code := `<?php
+$a = trim(sprintf("ping %s", $_GET['a']));
+system($a);
+$b = str_replace($_GET['b'], "y", "z");
+system($b);
+`
+	ssatest.CheckSyntaxFlowContain(t, code,
+		`*_GET[*] --> as $sink`,
+		map[string][]string{
+			"sink": {
+				`Undefined-trim(Function-sprintf("ping %s",Undefined-$_GET.'a'(valid)))`,
+				// the search of str_replace don't flow to return
+				`Function-str_replace(Undefined-$_GET.'b'(valid),"y","z")`,
+			},
+		},
+		ssaapi.WithLanguage(ssaapi.PHP),
+	)
+}
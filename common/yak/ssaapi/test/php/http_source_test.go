@@ -0,0 +1,45 @@
+package php
+
+import (
+	"testing"
+
+	"github.com/yaklang/yaklang/common/yak/ssaapi"
+	"github.com/yaklang/yaklang/common/yak/ssaapi/test/ssatest"
+)
+
+func TestHttpSource_PHP(t *testing.T) {
+	code := `<?php
+$a = $_POST['x'];
+$b = file_get_contents("php://input");
+$c = $request->input('name');
+$d = Request::input('name2');
+$e = $this->request->param('id');
+$f = request('q');
+$g = file_get_contents("/etc/passwd");
+`
+	ssatest.CheckSyntaxFlowContain(t, code, `*<httpSource> as $source`, map[string][]string{
+		"source": {
+			"Undefined-$_POST",
+			`Function-file_get_contents("php://input")`,
+			`Undefined-$request.input(valid)("name")`,
+			`Undefined-Request_input("name2")`,
+			`Undefined-$this.request.param(valid)("id")`,
+			`Undefined-request("q")`,
+		},
+	}, ssaapi.WithLanguage(ssaapi.PHP))
+}
+
+func TestHttpSource_PHP_Filter(t *testing.T) {
+	code := `<?php
+system("ping ".$_GET["ip"]);
+$h = request('q');
+system($h);
+system("ls");
+`
+	ssatest.CheckSyntaxFlow(t, code, `
+system(* #-> as $param);
+$param<httpSource> as $source;
+`, map[string][]string{
+		"source": {"Undefined-$_GET", `Undefined-$_GET."ip"(valid)`},
+	}, ssaapi.WithLanguage(ssaapi.PHP))
+}
@@ -0,0 +1,79 @@
+package syntaxflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yaklang/yaklang/common/yak/ssaapi"
+	"github.com/yaklang/yaklang/common/yak/ssaapi/test/ssatest"
+)
+
+func Test_Barrier(t *testing.T) {
+	code := `
+	f = (para) => {
+		safe = htmlspecialchars(para)
+		echo(safe)
+		echo("<a>" + para)
+	}
+	`
+
+	t.Run("without barrier", func(t *testing.T) {
+		ssatest.CheckSyntaxFlow(t, code,
+			"echo(* #-> * as $target)",
+			map[string][]string{
+				"target": {"Parameter-para", "FreeValue-htmlspecialchars", `"<a>"`},
+			},
+		)
+	})
+
+	t.Run("barrier by name", func(t *testing.T) {
+		ssatest.CheckSyntaxFlow(t, code,
+			"echo(* #{barrier: htmlspecialchars}-> * as $target)",
+			map[string][]string{
+				"target": {"Parameter-para", `"<a>"`},
+			},
+		)
+	})
+
+	t.Run("barrier by glob", func(t *testing.T) {
+		ssatest.CheckSyntaxFlow(t, code,
+			"echo(* #{barrier: 'html*'}-> * as $target)",
+			map[string][]string{
+				"target": {"Parameter-para", `"<a>"`},
+			},
+		)
+	})
+
+	t.Run("barrier by syntaxflow", func(t *testing.T) {
+		ssatest.CheckSyntaxFlow(t, code,
+			"echo(* #{barrier: `htmlspecialchars`}-> * as $target)",
+			map[string][]string{
+				"target": {"Parameter-para", `"<a>"`},
+			},
+		)
+	})
+
+	t.Run("barrier in bottom use", func(t *testing.T) {
+		ssatest.CheckSyntaxFlow(t, `
+		a = getParam()
+		b = escape(a)
+		exec(b)
+		system(a)
+		`,
+			"getParam() -{barrier: escape}-> *?{opcode: call} as $sink",
+			map[string][]string{
+				"sink": {"Undefined-system(Undefined-getParam())"},
+			},
+		)
+	})
+
+	t.Run("pruned path in result", func(t *testing.T) {
+		ssatest.Check(t, code, func(prog *ssaapi.Program) error {
+			result, err := prog.SyntaxFlowWithError("echo(* #{barrier: htmlspecialchars}-> * as $target)")
+			require.NoError(t, err)
+			require.Len(t, result.PrunedPaths, 1)
+			require.Contains(t, result.PrunedPaths[0].Reason, "htmlspecialchars")
+			return nil
+		})
+	})
+}
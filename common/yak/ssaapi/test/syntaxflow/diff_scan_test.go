@@ -0,0 +1,82 @@
+package syntaxflow
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklang/yaklang/common/schema"
+	"github.com/yaklang/yaklang/common/utils/yakgit"
+	"github.com/yaklang/yaklang/common/yak/ssaapi"
+)
+
+func TestDiffScanWithSyntaxFlowRule(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	require.NoError(t, err)
+	tree, err := repo.Worktree()
+	require.NoError(t, err)
+	commit := func(code string) string {
+		fp, err := tree.Filesystem.OpenFile("main.yak", os.O_CREATE|os.O_TRUNC|os.O_RDWR, 0o755)
+		require.NoError(t, err)
+		fp.Write([]byte(code))
+		fp.Close()
+		_, err = tree.Add("main.yak")
+		require.NoError(t, err)
+		hash, err := tree.Commit("update", &git.CommitOptions{Author: &object.Signature{Name: "yaklang"}})
+		require.NoError(t, err)
+		return hash.String()
+	}
+
+	commit(`
+a = getParam()
+os.System(a)
+`)
+	// the old finding is moved to other lines, and a new finding is introduced
+	target := commit(`
+// run command
+cmd = "ls"
+os.System(cmd)
+
+a = getParam()
+os.System(a)
+
+b = getEnv()
+os.System(b)
+`)
+
+	baseFS, err := yakgit.FileSystemFromRevision(repo, "HEAD~1")
+	require.NoError(t, err)
+	targetFS, err := yakgit.FileSystemFromRevision(repo, target)
+	require.NoError(t, err)
+
+	rules := []*schema.SyntaxFlowRule{{
+		RuleName: "yak-command-injection",
+		Title:    "command injection",
+		Content: `
+os.System(* as $sink);
+$sink?{!opcode: const} as $source;
+alert $source for "user input to os.System";
+`,
+	}}
+	opts := []ssaapi.Option{ssaapi.WithLanguage(ssaapi.Yak)}
+	baseFindings, err := ssaapi.ScanFileSystemWithSyntaxFlowRule(baseFS, rules, opts)
+	require.NoError(t, err)
+	require.Len(t, baseFindings, 1)
+
+	findings, err := ssaapi.DiffScanWithSyntaxFlowRule(baseFS, targetFS, rules, opts)
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	require.Contains(t, findings[0].Value.String(), "getEnv")
+	file, start, _ := findings[0].GetPosition()
+	require.Equal(t, "main.yak", file)
+	require.Equal(t, 9, start)
+
+	// nothing is new when compare with itself
+	findings, err = ssaapi.DiffScanWithSyntaxFlowRule(targetFS, targetFS, rules, opts)
+	require.NoError(t, err)
+	require.Len(t, findings, 0)
+}
@@ -0,0 +1,99 @@
+package syntaxflow
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yaklang/yaklang/common/syntaxflow/sfvm"
+	"github.com/yaklang/yaklang/common/yak/ssaapi"
+)
+
+func TestRuleTest_Embedded(t *testing.T) {
+	t.Run("java sample by file extension", func(t *testing.T) {
+		rule := `
+desc(
+	title: "rce",
+	'positive://A.java': "class A { void f(String c) { Runtime.getRuntime().exec(c); } }",
+	'negative://B.java': "class B { void f(String c) { System.out.println(c); } }",
+)
+Runtime.getRuntime().exec(* as $sink);
+check $sink;
+`
+		test, results, err := ssaapi.RunSyntaxFlowRuleTest(rule)
+		require.NoError(t, err)
+		require.Equal(t, "rce", test.Title)
+		require.Equal(t, []*sfvm.RuleTestExpect{{Variable: "sink", Match: true}}, test.Expect)
+		require.Len(t, results, 2)
+		for _, result := range results {
+			require.True(t, result.Pass, "%v: %v", result.Sample, result.Reason)
+		}
+	})
+
+	t.Run("yak sample by lang and expect", func(t *testing.T) {
+		rule := `
+desc(
+	lang: yak,
+	expect: "$sink, $high",
+	positive: "a = getParam(); os.System(a)",
+	negative: "println('ls')",
+	'negative://const.yak': "os.System('ls')",
+)
+os.System(* as $sink);
+$sink #-> * as $high;
+`
+		test, results, err := ssaapi.RunSyntaxFlowRuleTest(rule)
+		require.NoError(t, err)
+		require.Equal(t, []*sfvm.RuleTestExpect{{Variable: "sink", Match: true}, {Variable: "high", Match: true}}, test.Expect)
+		require.Len(t, results, 3)
+		require.True(t, results[0].Pass, results[0].Reason)
+		require.True(t, results[1].Pass, results[1].Reason)
+		require.False(t, results[2].Pass)
+		require.Equal(t, "negative://const.yak", results[2].Sample.String())
+		require.Contains(t, results[2].Reason, "$sink should not be matched")
+	})
+
+	t.Run("expect of one sample", func(t *testing.T) {
+		rule := `
+desc(
+	lang: yak,
+	expect: "$sink, $high",
+	'positive://param.yak': "a = getParam(); os.System(a)",
+	'positive://const.yak': "os.System('ls')",
+	'expect://const.yak': "$sink, !$high",
+)
+os.System(* as $sink);
+$sink #-> getParam() as $high;
+`
+		test, results, err := ssaapi.RunSyntaxFlowRuleTest(rule)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		require.Equal(t, "[$sink !$high]", fmt.Sprint(test.Samples[1].Expect))
+		for _, result := range results {
+			require.True(t, result.Pass, "%v: %v", result.Sample, result.Reason)
+		}
+	})
+
+	t.Run("exact sample key", func(t *testing.T) {
+		rule := `
+desc(
+	lang: yak,
+	positive: "os.System('ls')",
+	positiveCase: "println('ls')",
+	'negatives://a.yak': "println('ls')",
+)
+os.System(* as $sink);
+check $sink;
+`
+		test, results, err := ssaapi.RunSyntaxFlowRuleTest(rule)
+		require.NoError(t, err)
+		require.Len(t, test.Samples, 1)
+		require.Len(t, results, 1)
+		require.True(t, results[0].Pass, results[0].Reason)
+	})
+
+	t.Run("no sample", func(t *testing.T) {
+		_, _, err := ssaapi.RunSyntaxFlowRuleTest(`a as $a; check $a`)
+		require.ErrorIs(t, err, sfvm.RuleTestNotFoundError)
+	})
+}
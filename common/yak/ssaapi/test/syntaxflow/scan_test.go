@@ -0,0 +1,96 @@
+package syntaxflow
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklang/yaklang/common/consts"
+	"github.com/yaklang/yaklang/common/schema"
+	"github.com/yaklang/yaklang/common/utils/filesys"
+	"github.com/yaklang/yaklang/common/yak/cmd/yakcmds"
+	"github.com/yaklang/yaklang/common/yak/ssa/ssadb"
+	"github.com/yaklang/yaklang/common/yak/ssaapi"
+	"github.com/yaklang/yaklang/common/yakgrpc/yakit"
+	"github.com/yaklang/yaklang/common/yakgrpc/ypb"
+)
+
+func TestSyntaxFlowFindingToRisk(t *testing.T) {
+	progName := uuid.NewString()
+	defer ssadb.DeleteProgram(ssadb.GetDB(), progName)
+
+	code := filesys.NewVirtualFs()
+	code.AddFile("main.yak", `
+a = getParam()
+os.System(a)
+os.System("ls")
+`)
+	_, err := ssaapi.ParseProject(code, ssaapi.WithDatabaseProgramName(progName))
+	require.NoError(t, err)
+	require.Equal(t, []ssaapi.Language{ssaapi.Yak}, ssaapi.GetDatabaseProgramLanguages(progName))
+
+	prefix := uuid.NewString()
+	rules := filesys.NewVirtualFs()
+	rules.AddFile("yak/"+prefix+"-alert.sf", `
+desc(title: "command injection", level: high, type: vuln)
+getParam() as $source;
+alert $source for "user input to os.System";
+`)
+	rules.AddFile("yak/"+prefix+"-check.sf", `
+desc(title: "os.System call", lang: yak)
+os.System(* as $sink);
+check $sink;
+`)
+	rules.AddFile(prefix+"-java.sf", `
+desc(title: "java rce", lang: java)
+Runtime.getRuntime().exec(* as $sink);
+check $sink;
+`)
+	profile := consts.GetGormProfileDatabase()
+	filter := &ypb.SyntaxFlowRuleFilter{Keyword: prefix}
+	defer yakit.DeleteSyntaxFlowRule(profile, filter)
+	count, err := yakit.ImportSyntaxFlowRuleFromFileSystem(profile, rules, ".")
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+
+	_, saved, err := yakit.QuerySyntaxFlowRule(profile, &ypb.QuerySyntaxFlowRuleRequest{
+		Pagination: &ypb.Paging{Page: 1, Limit: 10},
+		Filter:     &ypb.SyntaxFlowRuleFilter{Keyword: prefix, Language: []string{"yak"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, saved, 2)
+
+	prog, err := ssaapi.FromDatabase(progName)
+	require.NoError(t, err)
+	scan := func() []*schema.Risk {
+		var risks []*schema.Risk
+		for _, finding := range prog.SyntaxFlowRuleFindings(saved) {
+			risks = append(risks, yakcmds.SyntaxFlowFindingToRisk(finding))
+		}
+		return risks
+	}
+	risks := scan()
+
+	byRule := make(map[string][]*schema.Risk)
+	for _, risk := range risks {
+		byRule[risk.FromYakScript] = append(byRule[risk.FromYakScript], risk)
+		require.Equal(t, progName, risk.TaskName)
+		require.Equal(t, "main.yak", risk.Url)
+	}
+
+	alert := byRule[prefix+"-alert"]
+	require.Len(t, alert, 1)
+	require.Equal(t, "command injection", alert[0].Title)
+	require.Equal(t, "user input to os.System", alert[0].TitleVerbose)
+	require.Equal(t, "high", alert[0].Severity)
+	require.Equal(t, "source", alert[0].Parameter)
+
+	check := byRule[prefix+"-check"]
+	require.Len(t, check, 2)
+	require.NotEqual(t, check[0].Hash, check[1].Hash)
+
+	// the risk of the same value is stable for re-scan
+	again := scan()
+	require.Len(t, again, len(risks))
+	require.Equal(t, risks[0].Hash, again[0].Hash)
+}
@@ -0,0 +1,83 @@
+package ssaapi
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklang/yaklang/common/yak/ssa/ssadb"
+	"github.com/yaklang/yaklang/common/yak/ssaapi"
+)
+
+const callGraphCode = `
+f = (a) => { os.System(a) }
+g = () => { f(getParam()) }
+run = (cb) => { cb() }
+run(g)
+safe = () => { println("safe") }
+`
+
+func checkCallGraph(t *testing.T, prog *ssaapi.Program) {
+	graph := prog.GetCallGraph()
+	// main -> run -> g (callback parameter) -> f (closure) -> os.System
+	path := graph.FindPathByName("main", "os.System")
+	names := make([]string, 0, len(path))
+	for _, node := range path {
+		names = append(names, node.Name)
+	}
+	require.Equal(t, []string{"main", "run", "g", "f", "os.System"}, names)
+	require.True(t, graph.CanReach("g", "getParam"))
+	require.False(t, graph.CanReach("safe", "os.System"))
+	require.False(t, graph.CanReach("f", "getParam"))
+}
+
+func TestCallGraph_Closure(t *testing.T) {
+	prog, err := ssaapi.Parse(callGraphCode)
+	require.NoError(t, err)
+	checkCallGraph(t, prog)
+
+	t.Run("export", func(t *testing.T) {
+		graph := prog.GetCallGraph()
+		dot := graph.Dot()
+		require.Contains(t, dot, `label="os.System", shape="box"`)
+
+		raw, err := graph.JSON()
+		require.NoError(t, err)
+		var result struct {
+			Nodes []struct {
+				Id       int64  `json:"id"`
+				Name     string `json:"name"`
+				External bool   `json:"external"`
+			} `json:"nodes"`
+			Edges []struct {
+				Caller int64 `json:"caller"`
+				Callee int64 `json:"callee"`
+			} `json:"edges"`
+		}
+		require.NoError(t, json.Unmarshal(raw, &result))
+		require.Len(t, result.Nodes, len(graph.Nodes()))
+		require.Len(t, result.Edges, len(graph.Edges()))
+
+		graphML, err := graph.Export(ssaapi.CallGraphFormatGraphML)
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(graphML, "<?xml"))
+		require.Contains(t, graphML, `<data key="name">os.System</data>`)
+
+		_, err = graph.Export("png")
+		require.Error(t, err)
+	})
+}
+
+func TestCallGraph_Database(t *testing.T) {
+	progName := uuid.NewString()
+	defer ssadb.DeleteProgram(ssadb.GetDB(), progName)
+	// the same code is parsed in memory by other test, skip the parse cache
+	_, err := ssaapi.Parse(callGraphCode, ssaapi.WithDatabaseProgramName(progName), ssaapi.WithDisableCache(true))
+	require.NoError(t, err)
+
+	prog, err := ssaapi.FromDatabase(progName)
+	require.NoError(t, err)
+	checkCallGraph(t, prog)
+}
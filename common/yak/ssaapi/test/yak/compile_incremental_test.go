@@ -0,0 +1,169 @@
+package ssaapi
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/utils/filesys"
+	"github.com/yaklang/yaklang/common/yak/ssa/ssadb"
+	"github.com/yaklang/yaklang/common/yak/ssaapi"
+)
+
+func TestCompileWithDatabase_Incremental(t *testing.T) {
+	progName := uuid.NewString()
+	defer ssadb.DeleteProgram(ssadb.GetDB(), progName)
+
+	codeA := `a = 1; println(a)`
+	codeB := `b = 2; println(b)`
+	vfs := filesys.NewVirtualFs()
+	vfs.AddFile("a.yak", codeA)
+	vfs.AddFile("b.yak", codeB)
+
+	// ir code of compile unit which entry file is the code
+	irCodeIDs := func(code string) []uint {
+		units, err := ssadb.GetIrProgramSources(ssadb.GetDB(), progName)
+		require.NoError(t, err)
+		var ids []uint
+		for compileHash, sources := range units {
+			for _, source := range sources {
+				if !source.IsEntry || source.SourceCodeHash != utils.CalcMd5(code) {
+					continue
+				}
+				ssadb.GetDB().Model(&ssadb.IrCode{}).Where(
+					"program_name = ? AND program_compile_hash = ?", progName, compileHash,
+				).Pluck("id", &ids)
+			}
+		}
+		return ids
+	}
+	existed := func(ids []uint) int {
+		var count int
+		ssadb.GetDB().Model(&ssadb.IrCode{}).Where("id IN (?)", ids).Count(&count)
+		return count
+	}
+	compile := func(opts ...ssaapi.Option) []*ssaapi.Program {
+		opts = append(opts, ssaapi.WithLanguage(ssaapi.Yak), ssaapi.WithDatabaseProgramName(progName))
+		progs, err := ssaapi.ParseProject(vfs, opts...)
+		require.NoError(t, err)
+		return progs
+	}
+
+	progs := compile()
+	require.Len(t, progs, 2)
+	idsA := irCodeIDs(codeA)
+	require.NotEmpty(t, idsA)
+	idsB := irCodeIDs(codeB)
+	require.NotEmpty(t, idsB)
+
+	t.Run("nothing changed", func(t *testing.T) {
+		progs := compile(ssaapi.WithIncrementalCompile())
+		require.Len(t, progs, 2)
+		require.Equal(t, idsA, irCodeIDs(codeA))
+	})
+
+	t.Run("one file changed", func(t *testing.T) {
+		newCodeB := `b = 3; println(b)`
+		vfs.AddFile("b.yak", newCodeB)
+		progs := compile(ssaapi.WithIncrementalCompile())
+		require.Len(t, progs, 2)
+		require.Equal(t, idsA, irCodeIDs(codeA))
+		require.Equal(t, 0, existed(idsB))
+		idsB = irCodeIDs(newCodeB)
+		require.NotEmpty(t, idsB)
+	})
+
+	t.Run("one file removed", func(t *testing.T) {
+		require.NoError(t, vfs.RemoveFileOrDir("b.yak"))
+		progs := compile(ssaapi.WithIncrementalCompile())
+		require.Len(t, progs, 1)
+		require.Equal(t, idsA, irCodeIDs(codeA))
+		require.Equal(t, len(idsA), existed(idsA))
+		require.Equal(t, 0, existed(idsB))
+	})
+}
+
+func TestCompileWithDatabase_Incremental_Dependency(t *testing.T) {
+	progName := uuid.NewString()
+	defer ssadb.DeleteProgram(ssadb.GetDB(), progName)
+
+	codeA := `println(foo)`
+	codeB := `foo = 1`
+	codeC := `c = 1; println(c)`
+	vfs := filesys.NewVirtualFs()
+	vfs.AddFile("a.yak", codeA)
+	vfs.AddFile("b.yak", codeB)
+	vfs.AddFile("c.yak", codeC)
+
+	irCodeIDs := func(entry string) []uint {
+		var compileHash []string
+		ssadb.GetDB().Model(&ssadb.IrProgramSource{}).Where(
+			"program_name = ? AND file_path = ? AND is_entry = ?", progName, entry, true,
+		).Pluck("program_compile_hash", &compileHash)
+		require.Len(t, compileHash, 1)
+		var ids []uint
+		ssadb.GetDB().Model(&ssadb.IrCode{}).Where(
+			"program_name = ? AND program_compile_hash = ?", progName, compileHash[0],
+		).Pluck("id", &ids)
+		require.NotEmpty(t, ids)
+		return ids
+	}
+	compile := func(opts ...ssaapi.Option) []*ssaapi.Program {
+		opts = append(opts, ssaapi.WithLanguage(ssaapi.Yak), ssaapi.WithDatabaseProgramName(progName))
+		progs, err := ssaapi.ParseProject(vfs, opts...)
+		require.NoError(t, err)
+		return progs
+	}
+
+	scopeNodes := func() int {
+		var count int
+		ssadb.GetDB().Model(&ssadb.IrScopeNode{}).Where("program_name = ?", progName).Count(&count)
+		return count
+	}
+
+	require.Len(t, compile(), 3)
+	idsA, idsC := irCodeIDs("a.yak"), irCodeIDs("c.yak")
+	nodes := scopeNodes()
+	require.NotZero(t, nodes)
+
+	t.Run("unchanged unit loaded from database", func(t *testing.T) {
+		progs := compile(ssaapi.WithIncrementalCompile())
+		require.Len(t, progs, 3)
+		require.Equal(t, idsA, irCodeIDs("a.yak"))
+		require.Equal(t, idsC, irCodeIDs("c.yak"))
+	})
+
+	t.Run("dependent unit re-compiled", func(t *testing.T) {
+		vfs.AddFile("b.yak", `foo = 2`)
+		progs := compile(ssaapi.WithIncrementalCompile())
+		require.Len(t, progs, 3)
+		require.NotEqual(t, idsA, irCodeIDs("a.yak"))
+		require.Equal(t, idsC, irCodeIDs("c.yak"))
+		// scope of deleted compile unit is not left in database
+		require.Equal(t, nodes, scopeNodes())
+	})
+}
+
+func TestCompileWithDatabase_Incremental_FullRebuild(t *testing.T) {
+	progName := uuid.NewString()
+	defer ssadb.DeleteProgram(ssadb.GetDB(), progName)
+
+	vfs := filesys.NewVirtualFs()
+	vfs.AddFile("a.yak", `a = 1; println(a)`)
+	_, err := ssaapi.ParseProject(vfs, ssaapi.WithLanguage(ssaapi.Yak), ssaapi.WithDatabaseProgramName(progName))
+	require.NoError(t, err)
+
+	// program without compile unit record, built by older version
+	ssadb.GetDB().Model(&ssadb.IrProgramSource{}).Where("program_name = ?", progName).Unscoped().Delete(&ssadb.IrProgramSource{})
+	var before int
+	ssadb.GetDB().Model(&ssadb.IrCode{}).Where("program_name = ?", progName).Count(&before)
+	require.NotZero(t, before)
+
+	progs, err := ssaapi.ParseProject(vfs, ssaapi.WithLanguage(ssaapi.Yak), ssaapi.WithDatabaseProgramName(progName), ssaapi.WithIncrementalCompile())
+	require.NoError(t, err)
+	require.Len(t, progs, 1)
+	var after int
+	ssadb.GetDB().Model(&ssadb.IrCode{}).Where("program_name = ?", progName).Count(&after)
+	require.Equal(t, before, after, "old instructions should be deleted")
+}
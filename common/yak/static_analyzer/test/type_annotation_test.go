@@ -0,0 +1,95 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/yaklang/yaklang/common/yak/ssa4analyze"
+)
+
+func TestTypeAnnotation(t *testing.T) {
+	t.Run("parameter type", func(t *testing.T) {
+		check(t, `
+		f = func(a int) {
+			codec.DecodeBase64(a)
+		}
+		f(1)
+		`, []string{
+			ssa4analyze.ArgumentTypeError(1, "number", "string", "codec.DecodeBase64"),
+		})
+	})
+
+	t.Run("call function with typed parameter", func(t *testing.T) {
+		check(t, `
+		func f(a string, b ...int) {
+			println(a, b)
+		}
+		f("a", 1, 2)
+		f(1)
+		`, []string{
+			ssa4analyze.ArgumentTypeError(1, "number", "string", "f"),
+		})
+	})
+
+	t.Run("declare variable", func(t *testing.T) {
+		check(t, `
+		var a int = 1
+		var b string = 1
+		var c, d []string
+		println(a, b, c, d)
+		`, []string{
+			ssa4analyze.VariableTypeError("b", "number", "string"),
+		})
+	})
+
+	t.Run("assign to declared variable", func(t *testing.T) {
+		check(t, `
+		f = func(c) {
+			var a int
+			a = 1
+			if c {
+				a = "a"
+			}
+			println(a)
+		}
+		f(true)
+		`, []string{
+			ssa4analyze.VariableTypeError("a", "string", "number"),
+		})
+	})
+
+	t.Run("return type", func(t *testing.T) {
+		check(t, `
+		func f(c) string {
+			if c {
+				return "a"
+			}
+			return 1
+		}
+		println(f(true))
+		`, []string{
+			ssa4analyze.ReturnTypeError("number", "string", "f"),
+		})
+	})
+
+	t.Run("return type used by caller", func(t *testing.T) {
+		check(t, `
+		f = func(a) int {
+			return a
+		}
+		codec.DecodeBase64(f(1))
+		`, []string{
+			ssa4analyze.ArgumentTypeError(1, "number", "string", "codec.DecodeBase64"),
+		})
+	})
+
+	t.Run("any type", func(t *testing.T) {
+		check(t, `
+		var a any = 1
+		a = "a"
+		f = func(b var) var {
+			return b
+		}
+		println(a, f(1))
+		`, []string{})
+	})
+}
@@ -163,6 +163,12 @@ func (b *astbuilder) buildStatement(stmt *yak.StatementContext) {
 		return
 	}
 
+	// import stmt
+	if s, ok := stmt.ImportStmt().(*yak.ImportStmtContext); ok {
+		b.buildImport(s)
+		return
+	}
+
 	// defer stmt
 	if s, ok := stmt.DeferStmt().(*yak.DeferStmtContext); ok {
 		b.buildDeferStmt(s)
@@ -826,11 +832,21 @@ func (b *astbuilder) buildDeclareVariableExpression(stmt *yak.DeclareVariableExp
 func (b *astbuilder) buildDeclareVariableOnly(stmt *yak.DeclareVariableOnlyContext) {
 	recoverRange := b.SetRange(stmt.BaseParserRuleContext)
 	defer recoverRange()
+	// var a, b int
+	var typ ssa.Type
+	if s, ok := stmt.TypeLiteral().(*yak.TypeLiteralContext); ok {
+		typ = b.buildTypeLiteral(s)
+	}
 	for _, idstmt := range stmt.AllIdentifier() {
 		recoverRange := b.SetRangeFromTerminalNode(idstmt)
 		id := idstmt.GetText()
 		v := b.CreateLocalVariable(id)
-		b.AssignVariable(v, b.EmitValueOnlyDeclare(id))
+		value := b.EmitValueOnlyDeclare(id)
+		if typ != nil {
+			value.SetType(typ)
+			b.DeclareVariableType(v, typ)
+		}
+		b.AssignVariable(v, value)
 		recoverRange()
 	}
 }
@@ -838,7 +854,25 @@ func (b *astbuilder) buildDeclareVariableOnly(stmt *yak.DeclareVariableOnlyConte
 func (b *astbuilder) buildDeclareAndAssignExpression(stmt *yak.DeclareAndAssignExpressionContext) {
 	recoverRange := b.SetRange(stmt.BaseParserRuleContext)
 	defer recoverRange()
-	b.AssignList(true, stmt)
+	values := b.AssignList(true, stmt)
+
+	// var a, b int = 1, 2
+	s, ok := stmt.TypeLiteral().(*yak.TypeLiteralContext)
+	if !ok {
+		return
+	}
+	typ := b.buildTypeLiteral(s)
+	list, ok := stmt.LeftExpressionList().(*yak.LeftExpressionListContext)
+	if typ == nil || !ok {
+		return
+	}
+	for i, left := range list.AllLeftExpression() {
+		left, ok := left.(*yak.LeftExpressionContext)
+		if !ok || left.Identifier() == nil || i >= len(values) || utils.IsNil(values[i]) {
+			continue
+		}
+		b.DeclareVariableType(values[i].GetVariable(left.Identifier().GetText()), typ)
+	}
 }
 
 // left expression list
@@ -1425,6 +1459,9 @@ func (b *astbuilder) buildAnonymousFunctionDecl(stmt *yak.AnonymousFunctionDeclC
 			if para, ok := stmt.FunctionParamDecl().(*yak.FunctionParamDeclContext); ok {
 				b.buildFunctionParamDecl(para)
 			}
+			if s, ok := stmt.TypeLiteral().(*yak.TypeLiteralContext); ok {
+				b.DeclaredReturnType = b.buildTypeLiteral(s)
+			}
 			// handler markedFunction
 			handleFunctionType(b.Function)
 
@@ -1459,15 +1496,30 @@ func (b *astbuilder) buildFunctionParamDecl(stmt *yak.FunctionParamDeclContext)
 	defer recoverRange()
 	ellipsis := stmt.Ellipsis() // if has "...",  use array pass this argument
 	ids := stmt.AllIdentifier()
+	// type annotation: fn(a int, b ...string)
+	types := make([]ssa.Type, len(ids))
+	for i, t := range stmt.ParamTypes() {
+		if s, ok := t.(*yak.TypeLiteralContext); ok && i < len(types) {
+			types[i] = b.buildTypeLiteral(s)
+		}
+	}
 
-	for _, id := range ids {
+	var params []*ssa.Parameter
+	for i, id := range ids {
 		recoverRange := b.SetRangeFromTerminalNode(id)
-		b.NewParam(id.GetText())
+		p := b.NewParam(id.GetText())
+		if types[i] != nil {
+			p.SetType(types[i])
+		}
+		params = append(params, p)
 		recoverRange()
 	}
 	if ellipsis != nil {
 		// handler "..." to array
 		b.HandlerEllipsis()
+		if last := len(params) - 1; last >= 0 && types[last] != nil {
+			params[last].SetType(ssa.NewSliceType(types[last]))
+		}
 	}
 }
 
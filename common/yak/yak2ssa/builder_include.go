@@ -5,6 +5,7 @@ import (
 
 	"github.com/yaklang/yaklang/common/log"
 	yak "github.com/yaklang/yaklang/common/yak/antlr4yak/parser"
+	"github.com/yaklang/yaklang/common/yak/yakmod"
 )
 
 func (s *astbuilder) buildInclude(i *yak.IncludeStmtContext) {
@@ -15,3 +16,40 @@ func (s *astbuilder) buildInclude(i *yak.IncludeStmtContext) {
 		log.Errorf("yaklang builder include %v failed: %v", targetFile, err)
 	}
 }
+
+// buildImport resolve the module of import statement (github.com/org/lib@v1.2.0) by yak.mod,
+// the yak files of module are built into program once, so the symbols of module can be resolved in compile time
+func (s *astbuilder) buildImport(i *yak.ImportStmtContext) {
+	path := i.StringLiteral().GetText()
+	path, _ = strconv.Unquote(path)
+
+	if err := s.buildModule(path); err != nil {
+		log.Errorf("yaklang builder import %v failed: %v", path, err)
+	}
+}
+
+func (s *astbuilder) buildModule(path string) error {
+	loader := s.GetProgram().Loader
+	dir := loader.GetCurrentPath()
+	if dir == "" {
+		dir = "."
+	}
+	resolver, err := yakmod.NewResolver(dir)
+	if err != nil {
+		return err
+	}
+	files, err := resolver.Resolve(path)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if _, err := loader.FilePath(file, true); err != nil {
+			// already imported
+			continue
+		}
+		if err := s.BuildFilePackage(file, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
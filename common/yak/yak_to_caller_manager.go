@@ -38,6 +38,7 @@ import (
 	"github.com/yaklang/yaklang/common/yak/yaklib"
 	"github.com/yaklang/yaklang/common/yak/yaklib/tools"
 	"github.com/yaklang/yaklang/common/yak/yaklib/yakhttp"
+	"github.com/yaklang/yaklang/common/yak/yakpolicy"
 	"github.com/yaklang/yaklang/common/yakgrpc/yakit"
 	"github.com/yaklang/yaklang/common/yakgrpc/ypb"
 
@@ -136,6 +137,13 @@ func FetchFunctionFromSourceCode(y *YakToCallerManager, pluginContext *YakitPlug
 		BindYakitPluginContextToEngine(engine, pluginContext)
 		return nil
 	})
+	if script != nil {
+		policy, err := ResolvePluginSandboxPolicy(script)
+		if err != nil {
+			return nil, utils.Errorf("load plugin failed: %s", err)
+		}
+		engine.SetPolicy(policy)
+	}
 	// engine.HookOsExit()
 	// timeoutCtx, cancel := context.WithTimeout(ctx, loadTimeout)
 	// defer func() { cancel() }()
@@ -152,6 +160,9 @@ func FetchFunctionFromSourceCode(y *YakToCallerManager, pluginContext *YakitPlug
 	})
 	if err != nil {
 		log.Errorf("init execute plugin finished: %s", err)
+		if v, ok := yakpolicy.AsViolation(err); ok {
+			return nil, v
+		}
 		return nil, utils.Errorf("load plugin failed: %s", err)
 	}
 
@@ -175,6 +186,9 @@ func FetchFunctionFromSourceCode(y *YakToCallerManager, pluginContext *YakitPlug
 			Handler: func(args ...interface{}) {
 				defer func() {
 					if err := recover(); err != nil {
+						if v := yakpolicy.FromEngine(nIns).Violation(); v != nil {
+							err = v
+						}
 						log.Errorf("call hook function `%v` of `%v` plugin failed: %s", funcName, scriptName, err)
 						fmt.Println()
 						if os.Getenv("YAK_IN_TERMINAL_MODE") == "" {
@@ -186,6 +200,7 @@ func FetchFunctionFromSourceCode(y *YakToCallerManager, pluginContext *YakitPlug
 				subCtx, _ := context.WithTimeout(pluginContext.Ctx, y.callTimeout)
 				subCtx = context.WithValue(subCtx, "pluginName", scriptName)
 				_, err = nIns.CallYakFunctionNative(subCtx, f, args...)
+				err = yakpolicy.FromEngine(nIns).Err(err)
 				if err != nil && !errors.Is(err, context.Canceled) {
 					log.Errorf("call YakFunction (DividedCTX) error: \n%v", err)
 				}
@@ -1601,24 +1616,23 @@ func init() {
 			return "", utils.Errorf("plugin %v is not codec plugin", script.ScriptName)
 		}
 
+		policy, err := ResolvePluginSandboxPolicy(script)
+		if err != nil {
+			return "", err
+		}
+
 		engineRoot := NewScriptEngine(1)
 		engineRoot.RegisterEngineHooks(func(engine *antlr4yak.Engine) error {
 			engine.SetVar("scriptName", script.ScriptName)
+			engine.SetVar("pluginContent", script.Content)
 			engine.SetVar("param", utils.InterfaceToString(s))
 			return nil
 		})
 		engineRoot.HookOsExit()
+		engineRoot.SetPolicy(policy)
 		engine, err := engineRoot.ExecuteWithoutCache(`
-plugin,err = db.GetYakitPluginByName(scriptName)
 var result
-if err {
-    die("query plugin failed: %v"%err)
-}
-if plugin.Type != "codec"{
-    die("only support codec plugin")
-}
-
-eval(plugin.Content)
+eval(pluginContent)
 if handle{
     result = handle(param)
 }else{
@@ -0,0 +1,367 @@
+package yakbundle
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/semver"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/yaklang/yaklang/common/consts"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/yak/antlr4yak/yakvm"
+	"github.com/yaklang/yaklang/common/yak/yaklang"
+)
+
+// FileExt is the extension of yak bundle file
+const FileExt = ".ybc"
+
+// FormatVersion is the bundle format version written by Build, the loader refuses the newer format
+const FormatVersion = 1
+
+// MagicNumber is the header of yak bundle, different from the header of yakc
+var MagicNumber = []byte{0xdc, 0xed}
+
+// Manifest describe the bundled plugin, it is covered by the signature
+type Manifest struct {
+	Name string `json:"name"`
+	// EngineVersion is the version of engine which build the bundle
+	EngineVersion string `json:"engine_version"`
+	// MinEngineVersion is the minimum version of engine which can run the bundle, empty means no limit
+	MinEngineVersion string `json:"min_engine_version,omitempty"`
+	// Libraries is the yak libraries (http, poc, str ...) used by the plugin
+	Libraries []string   `json:"libraries"`
+	SourceMap *SourceMap `json:"source_map,omitempty"`
+	// PayloadSha256 is the hex sha256 of yakc payload
+	PayloadSha256 string `json:"payload_sha256"`
+	BuildTime     int64  `json:"build_time"`
+	// PublicKey is the hex ed25519 public key of signer, empty if not signed
+	PublicKey string `json:"public_key,omitempty"`
+}
+
+// SourceMap record the source file of every opcode, which is not kept by yakc
+type SourceMap struct {
+	Files []*SourceFile `json:"files"`
+	// Mappings is run-length encoded file index of opcodes in walk order: [index, count, index, count ...],
+	// index -1 means the opcode has no source file
+	Mappings []int `json:"mappings"`
+	Opcodes  int   `json:"opcodes"`
+}
+
+type SourceFile struct {
+	// Path is relative to the directory of main file if possible
+	Path   string `json:"path"`
+	Sha256 string `json:"sha256"`
+	// Source is embedded only when building with source
+	Source string `json:"source,omitempty"`
+}
+
+// Bundle is the decoded yak bundle
+type Bundle struct {
+	FormatVersion int
+	Manifest      *Manifest
+	// Payload is yakc bytes
+	Payload   []byte
+	Signature []byte
+
+	signed []byte
+}
+
+// IsBundle check the magic number of yak bundle
+func IsBundle(b []byte) bool {
+	return bytes.HasPrefix(b, MagicNumber)
+}
+
+type buildConfig struct {
+	name             string
+	minEngineVersion string
+	signingKey       ed25519.PrivateKey
+	embedSource      bool
+}
+
+type BuildOption func(*buildConfig)
+
+// WithName set the name of bundle, default is the base name of main file
+func WithName(name string) BuildOption {
+	return func(c *buildConfig) {
+		c.name = name
+	}
+}
+
+// WithMinEngineVersion set the minimum engine version, default is the version of current engine
+func WithMinEngineVersion(version string) BuildOption {
+	return func(c *buildConfig) {
+		c.minEngineVersion = version
+	}
+}
+
+// WithSigningKey sign the bundle with ed25519 private key
+func WithSigningKey(key ed25519.PrivateKey) BuildOption {
+	return func(c *buildConfig) {
+		c.signingKey = key
+	}
+}
+
+// WithEmbedSource embed the source code into source map, the error of plugin can show the source line
+func WithEmbedSource(b bool) BuildOption {
+	return func(c *buildConfig) {
+		c.embedSource = b
+	}
+}
+
+// Build compile the yak code (file is the path of code, used for include and source map) into bundle,
+// the relative include is resolved from the directory of file first, so the bundle does not depend on working directory
+func Build(code string, file string, opts ...BuildOption) (*Bundle, error) {
+	config := &buildConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	if config.name == "" && file != "" {
+		config.name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+	minVersion := config.minEngineVersion
+	if minVersion == "" {
+		// dev engine has no valid version, the bundle has no limit
+		if _, ok := normalizeVersion(consts.GetYakVersion()); ok {
+			minVersion = consts.GetYakVersion()
+		}
+	} else if _, ok := normalizeVersion(minVersion); !ok {
+		return nil, utils.Errorf("invalid min engine version: %v", minVersion)
+	}
+
+	engine := yaklang.New()
+	absFile := ""
+	if file != "" {
+		var err error
+		if absFile, err = filepath.Abs(file); err != nil {
+			return nil, err
+		}
+		engine.SetSourceFilePath(absFile)
+	}
+	code = utils.RemoveBOMForString(code)
+	payload, codes, err := engine.MarshalWithOpcodes(code, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(payload)
+	manifest := &Manifest{
+		Name:             config.name,
+		EngineVersion:    consts.GetYakVersion(),
+		MinEngineVersion: minVersion,
+		Libraries:        requiredLibraries(codes, engine.GetFntable()),
+		SourceMap:        buildSourceMap(codes, absFile, code, config.embedSource),
+		PayloadSha256:    hex.EncodeToString(sum[:]),
+		BuildTime:        time.Now().Unix(),
+	}
+	if config.signingKey != nil {
+		manifest.PublicKey = hex.EncodeToString(config.signingKey.Public().(ed25519.PublicKey))
+	}
+
+	b := &Bundle{FormatVersion: FormatVersion, Manifest: manifest, Payload: payload}
+	if b.signed, err = encodeSigned(b); err != nil {
+		return nil, err
+	}
+	if config.signingKey != nil {
+		b.Signature = ed25519.Sign(config.signingKey, b.signed)
+	}
+	return b, nil
+}
+
+// Bytes encode the bundle: magic | varint(format version) | bytes(manifest json) | bytes(yakc) | bytes(signature),
+// the signature is made on all the bytes before it
+func (b *Bundle) Bytes() []byte {
+	buf := append([]byte{}, b.signed...)
+	return protowire.AppendBytes(buf, b.Signature)
+}
+
+func encodeSigned(b *Bundle) ([]byte, error) {
+	manifest, err := json.Marshal(b.Manifest)
+	if err != nil {
+		return nil, err
+	}
+	buf := append([]byte{}, MagicNumber...)
+	buf = protowire.AppendVarint(buf, uint64(b.FormatVersion))
+	buf = protowire.AppendBytes(buf, manifest)
+	buf = protowire.AppendBytes(buf, b.Payload)
+	return buf, nil
+}
+
+// Decode parse the bundle bytes without validation, use Load to validate it
+func Decode(raw []byte) (*Bundle, error) {
+	if !IsBundle(raw) {
+		return nil, utils.Error("invalid yak bundle: bad magic number")
+	}
+	buf := raw[len(MagicNumber):]
+	version, n := protowire.ConsumeVarint(buf)
+	if n < 0 {
+		return nil, utils.Error("invalid yak bundle: bad format version")
+	}
+	if version > FormatVersion {
+		return nil, utils.Errorf("yak bundle format version %v is not supported (max %v), upgrade yak engine", version, FormatVersion)
+	}
+	buf = buf[n:]
+
+	manifestRaw, n := protowire.ConsumeBytes(buf)
+	if n < 0 {
+		return nil, utils.Error("invalid yak bundle: bad manifest")
+	}
+	buf = buf[n:]
+	var manifest Manifest
+	if err := json.Unmarshal(manifestRaw, &manifest); err != nil {
+		return nil, utils.Errorf("invalid yak bundle manifest: %v", err)
+	}
+
+	payload, n := protowire.ConsumeBytes(buf)
+	if n < 0 {
+		return nil, utils.Error("invalid yak bundle: bad payload")
+	}
+	buf = buf[n:]
+	signedLen := len(raw) - len(buf)
+
+	signature, n := protowire.ConsumeBytes(buf)
+	if n < 0 || n != len(buf) {
+		return nil, utils.Error("invalid yak bundle: bad signature")
+	}
+	return &Bundle{
+		FormatVersion: int(version),
+		Manifest:      &manifest,
+		Payload:       payload,
+		Signature:     signature,
+		signed:        raw[:signedLen],
+	}, nil
+}
+
+// walkCodes visit the opcodes and the opcodes of functions / defer blocks in operands, the order is
+// same as the yakc marshaller, so the source map can be applied to the unmarshalled opcodes
+func walkCodes(codes []*yakvm.Code, handle func(*yakvm.Code)) {
+	for _, code := range codes {
+		if code == nil {
+			continue
+		}
+		handle(code)
+		for _, op := range []*yakvm.Value{code.Op1, code.Op2} {
+			if op == nil {
+				continue
+			}
+			switch ret := op.Value.(type) {
+			case *yakvm.Function:
+				walkCodes(ret.GetCodes(), handle)
+			case []*yakvm.Code:
+				walkCodes(ret, handle)
+			}
+		}
+	}
+}
+
+// requiredLibraries return the yak libraries referenced by opcodes, the library is the map imported into engine
+func requiredLibraries(codes []*yakvm.Code, globals map[string]interface{}) []string {
+	libs := make(map[string]struct{})
+	walkCodes(codes, func(code *yakvm.Code) {
+		if code.Opcode != yakvm.OpPushId || code.Op1 == nil {
+			return
+		}
+		name := code.Op1.String()
+		if v, ok := globals[name]; ok && v != nil && reflect.TypeOf(v).Kind() == reflect.Map {
+			libs[name] = struct{}{}
+		}
+	})
+	ret := make([]string, 0, len(libs))
+	for lib := range libs {
+		ret = append(ret, lib)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// buildSourceMap record the source files of opcodes, the main file is always the first one
+func buildSourceMap(codes []*yakvm.Code, mainFile, mainCode string, embedSource bool) *SourceMap {
+	sm := &SourceMap{}
+	baseDir := filepath.Dir(mainFile)
+	index := make(map[string]int)
+	addFile := func(path, source string) int {
+		f := &SourceFile{Path: filepath.ToSlash(path)}
+		if rel, err := filepath.Rel(baseDir, path); path != "" && err == nil && !strings.HasPrefix(rel, "..") {
+			f.Path = filepath.ToSlash(rel)
+		}
+		sum := sha256.Sum256([]byte(source))
+		f.Sha256 = hex.EncodeToString(sum[:])
+		if embedSource {
+			f.Source = source
+		}
+		index[path] = len(sm.Files)
+		sm.Files = append(sm.Files, f)
+		return index[path]
+	}
+	addFile(mainFile, mainCode)
+
+	last := -2
+	walkCodes(codes, func(code *yakvm.Code) {
+		sm.Opcodes++
+		i := -1
+		if code.SourceCodeFilePath != nil && *code.SourceCodeFilePath != "" {
+			var ok bool
+			if i, ok = index[*code.SourceCodeFilePath]; !ok {
+				source := ""
+				if code.SourceCodePointer != nil {
+					source = *code.SourceCodePointer
+				}
+				i = addFile(*code.SourceCodeFilePath, source)
+			}
+		}
+		if i == last {
+			sm.Mappings[len(sm.Mappings)-1]++
+			return
+		}
+		last = i
+		sm.Mappings = append(sm.Mappings, i, 1)
+	})
+	return sm
+}
+
+// apply set the source file of opcodes, false if the opcodes do not match the source map
+func (sm *SourceMap) apply(codes []*yakvm.Code) bool {
+	var all []*yakvm.Code
+	walkCodes(codes, func(code *yakvm.Code) { all = append(all, code) })
+	if len(all) != sm.Opcodes || len(sm.Mappings)%2 != 0 {
+		return false
+	}
+
+	paths := make([]string, len(sm.Files))
+	sources := make([]string, len(sm.Files))
+	for i, f := range sm.Files {
+		paths[i], sources[i] = f.Path, f.Source
+	}
+	pos := 0
+	for i := 0; i < len(sm.Mappings); i += 2 {
+		index, n := sm.Mappings[i], sm.Mappings[i+1]
+		if index >= len(sm.Files) || n < 0 || pos+n > len(all) {
+			return false
+		}
+		for _, code := range all[pos : pos+n] {
+			if index < 0 {
+				continue
+			}
+			code.SourceCodeFilePath = &paths[index]
+			if sources[index] != "" {
+				code.SourceCodePointer = &sources[index]
+			}
+		}
+		pos += n
+	}
+	return pos == len(all)
+}
+
+// normalizeVersion convert yak version (1.3.2-beta1 / v1.3.2) to semver, false if it is not valid (dev)
+func normalizeVersion(v string) (string, bool) {
+	v = "v" + strings.TrimPrefix(strings.TrimSpace(v), "v")
+	return v, semver.IsValid(v)
+}
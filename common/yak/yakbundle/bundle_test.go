@@ -0,0 +1,157 @@
+package yakbundle
+
+import (
+	"context"
+	"crypto/ed25519"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklang/yaklang/common/yak/antlr4yak"
+	"github.com/yaklang/yaklang/common/yak/antlr4yak/yakvm"
+	"github.com/yaklang/yaklang/common/yak/yaklang"
+)
+
+var bundleResults []interface{}
+
+func init() {
+	yaklang.Import("bundletest", map[string]interface{}{
+		"Record": func(v interface{}) { bundleResults = append(bundleResults, v) },
+	})
+}
+
+func buildTestBundle(t *testing.T, opts ...BuildOption) ([]byte, string) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "lib.yak"), []byte("add = (a, b) => a + b\n"), 0o644))
+	main := filepath.Join(dir, "main.yak")
+	code := "include \"lib.yak\"\n\nf = () => {\n    bundletest.Record(add(1, 2))\n}\nf()\n"
+	require.NoError(t, os.WriteFile(main, []byte(code), 0o644))
+
+	b, err := Build(code, main, opts...)
+	require.NoError(t, err)
+	return b.Bytes(), code
+}
+
+func TestBuildAndExec(t *testing.T) {
+	t.Setenv(TrustedKeysEnv, "")
+	privPEM, pubPEM, err := GenerateKey()
+	require.NoError(t, err)
+	priv, err := ParsePrivateKey(privPEM)
+	require.NoError(t, err)
+	pub, err := ParsePublicKey(pubPEM)
+	require.NoError(t, err)
+
+	raw, code := buildTestBundle(t, WithSigningKey(priv), WithEmbedSource(true), WithMinEngineVersion("1.3.0"))
+	require.True(t, IsBundle(raw))
+
+	b, err := Load(raw, WithTrustedKeys(pub), WithEngineVersion("1.3.2-beta1"))
+	require.NoError(t, err)
+	m := b.Manifest
+	require.Equal(t, "main", m.Name)
+	require.Equal(t, "1.3.0", m.MinEngineVersion)
+	require.Equal(t, []string{"bundletest"}, m.Libraries)
+	require.Len(t, m.SourceMap.Files, 2)
+	require.Equal(t, "main.yak", m.SourceMap.Files[0].Path)
+	require.Equal(t, "lib.yak", m.SourceMap.Files[1].Path)
+	require.Equal(t, code, b.MainSource())
+	require.NotEmpty(t, b.Signer())
+
+	// source map is applied to the unmarshalled opcodes
+	_, codes, err := yaklang.New().UnMarshal(b.Payload, nil, code)
+	require.NoError(t, err)
+	require.True(t, m.SourceMap.apply(codes))
+	files := make(map[string]bool)
+	walkCodes(codes, func(c *yakvm.Code) {
+		if c.SourceCodeFilePath != nil {
+			files[*c.SourceCodeFilePath] = true
+		}
+	})
+	require.Equal(t, map[string]bool{"main.yak": true, "lib.yak": true}, files)
+
+	bundleResults = nil
+	require.NoError(t, Exec(context.Background(), yaklang.New(), raw, WithTrustedKeys(pub)))
+	require.Equal(t, []interface{}{3}, bundleResults)
+}
+
+func TestBuild_IncludeRelativeToMainFile(t *testing.T) {
+	t.Setenv(TrustedKeysEnv, "")
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "lib.yak"), []byte("add = (a, b) => a + b\n"), 0o644))
+	main := filepath.Join(dir, "main.yak")
+	code := "include \"lib.yak\"\nbundletest.Record(add(1, 2))\n"
+	require.NoError(t, os.WriteFile(main, []byte(code), 0o644))
+
+	// the lib.yak in working directory should not be bundled
+	workDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(workDir, "lib.yak"), []byte("add = (a, b) => a - b\n"), 0o644))
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(workDir))
+	defer os.Chdir(wd)
+
+	b, err := Build(code, main)
+	require.NoError(t, err)
+	bundleResults = nil
+	require.NoError(t, Exec(context.Background(), yaklang.New(), b.Bytes()))
+	require.Equal(t, []interface{}{3}, bundleResults)
+
+	// the relative path of main file is the same
+	require.NoError(t, os.Chdir(dir))
+	relative, err := Build(code, "main.yak")
+	require.NoError(t, err)
+	require.Equal(t, b.Manifest.SourceMap.Files, relative.Manifest.SourceMap.Files)
+}
+
+func TestLoad_Validate(t *testing.T) {
+	t.Setenv(TrustedKeysEnv, "")
+	pub, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	otherPub, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	signed, _ := buildTestBundle(t, WithSigningKey(priv), WithMinEngineVersion("v1.4.0"))
+	unsigned, _ := buildTestBundle(t)
+
+	_, err = Load(signed, WithTrustedKeys(otherPub))
+	require.ErrorContains(t, err, "signature verification failed")
+	_, err = Load(unsigned, WithTrustedKeys(pub))
+	require.ErrorContains(t, err, "is not signed")
+	_, err = Load(unsigned)
+	require.NoError(t, err)
+
+	// the trusted keys of environment
+	pubFile := filepath.Join(t.TempDir(), "team.pub")
+	_, pubPEM, err := GenerateKey()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(pubFile, pubPEM, 0o644))
+	t.Setenv(TrustedKeysEnv, pubFile)
+	_, err = Load(unsigned)
+	require.ErrorContains(t, err, "is not signed")
+	_, err = Load(signed, WithEngineVersion("1.4.0"))
+	require.ErrorContains(t, err, "not signed by trusted keys")
+	t.Setenv(TrustedKeysEnv, "")
+
+	// min engine version
+	_, err = Load(signed, WithEngineVersion("1.3.9"))
+	require.ErrorContains(t, err, "requires engine version >= v1.4.0")
+	_, err = Load(signed, WithEngineVersion("1.4.0"))
+	require.NoError(t, err)
+	_, err = Load(signed, WithEngineVersion("dev"))
+	require.NoError(t, err)
+
+	// tampered payload
+	tampered := append([]byte{}, signed...)
+	tampered[len(tampered)-ed25519.SignatureSize-1-10] ^= 0xff
+	_, err = Load(tampered, WithEngineVersion("1.4.0"))
+	require.Error(t, err)
+
+	// format version
+	_, err = Decode(append(append([]byte{}, MagicNumber...), FormatVersion+1))
+	require.ErrorContains(t, err, "not supported")
+
+	// library is not available
+	err = Exec(context.Background(), antlr4yak.New(), unsigned)
+	require.ErrorContains(t, err, "requires libraries [bundletest]")
+}
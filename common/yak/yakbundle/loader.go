@@ -0,0 +1,186 @@
+package yakbundle
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/samber/lo"
+	"golang.org/x/mod/semver"
+
+	"github.com/yaklang/yaklang/common/consts"
+	"github.com/yaklang/yaklang/common/log"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/yak/antlr4yak"
+	"github.com/yaklang/yaklang/common/yak/antlr4yak/yakvm"
+)
+
+// TrustedKeysEnv set the trusted public key files (separated by os.PathListSeparator), if it is set,
+// only the bundles signed by one of the keys can be loaded
+const TrustedKeysEnv = "YAK_BUNDLE_TRUSTED_KEYS"
+
+// DefaultTrustedKeys load the trusted public keys from TrustedKeysEnv
+func DefaultTrustedKeys() ([]ed25519.PublicKey, error) {
+	return LoadPublicKeyFiles(lo.Compact(filepath.SplitList(os.Getenv(TrustedKeysEnv)))...)
+}
+
+type loadConfig struct {
+	trustedKeys   []ed25519.PublicKey
+	engineVersion string
+}
+
+type LoadOption func(*loadConfig)
+
+// WithTrustedKeys only load the bundles signed by one of the keys, default is the keys of TrustedKeysEnv
+func WithTrustedKeys(keys ...ed25519.PublicKey) LoadOption {
+	return func(c *loadConfig) {
+		c.trustedKeys = append(c.trustedKeys, keys...)
+	}
+}
+
+// WithEngineVersion set the engine version compared with the min engine version of bundle, default is current version
+func WithEngineVersion(version string) LoadOption {
+	return func(c *loadConfig) {
+		c.engineVersion = version
+	}
+}
+
+// Load decode and validate the bundle: format version, payload hash, signature and min engine version
+func Load(raw []byte, opts ...LoadOption) (*Bundle, error) {
+	b, err := Decode(raw)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.Verify(opts...); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Verify validate the payload hash, signature and min engine version of bundle
+func (b *Bundle) Verify(opts ...LoadOption) error {
+	config := &loadConfig{engineVersion: consts.GetYakVersion()}
+	for _, opt := range opts {
+		opt(config)
+	}
+	if config.trustedKeys == nil {
+		keys, err := DefaultTrustedKeys()
+		if err != nil {
+			return utils.Errorf("load trusted keys from %v failed: %v", TrustedKeysEnv, err)
+		}
+		config.trustedKeys = keys
+	}
+	m := b.Manifest
+
+	sum := sha256.Sum256(b.Payload)
+	if hex.EncodeToString(sum[:]) != m.PayloadSha256 {
+		return utils.Errorf("yak bundle %v is broken: payload sha256 mismatch", m.Name)
+	}
+
+	if err := b.verifySignature(config.trustedKeys); err != nil {
+		return err
+	}
+
+	if m.MinEngineVersion != "" {
+		minVersion, _ := normalizeVersion(m.MinEngineVersion)
+		if current, ok := normalizeVersion(config.engineVersion); !ok {
+			log.Debugf("engine version %v is not semver, skip the min engine version(%v) check of %v", config.engineVersion, m.MinEngineVersion, m.Name)
+		} else if semver.Compare(current, minVersion) < 0 {
+			return utils.Errorf("yak bundle %v requires engine version >= %v, current is %v", m.Name, m.MinEngineVersion, config.engineVersion)
+		}
+	}
+	return nil
+}
+
+func (b *Bundle) verifySignature(trustedKeys []ed25519.PublicKey) error {
+	name := b.Manifest.Name
+	if len(b.Signature) == 0 {
+		if len(trustedKeys) > 0 {
+			return utils.Errorf("yak bundle %v is not signed, only signed bundles are allowed", name)
+		}
+		return nil
+	}
+
+	if len(trustedKeys) == 0 {
+		// no trusted keys, the signature can only check the integrity
+		signer, err := hex.DecodeString(b.Manifest.PublicKey)
+		if err != nil || len(signer) != ed25519.PublicKeySize {
+			return utils.Errorf("yak bundle %v has invalid signer public key", name)
+		}
+		trustedKeys = []ed25519.PublicKey{signer}
+	}
+	for _, key := range trustedKeys {
+		if ed25519.Verify(key, b.signed, b.Signature) {
+			return nil
+		}
+	}
+	return utils.Errorf("yak bundle %v signature verification failed: not signed by trusted keys", name)
+}
+
+// Signer return the hex public key of signer, empty if not signed
+func (b *Bundle) Signer() string {
+	if len(b.Signature) == 0 {
+		return ""
+	}
+	return b.Manifest.PublicKey
+}
+
+// CheckLibraries check whether the libraries required by bundle are available in engine
+func (b *Bundle) CheckLibraries(engine *antlr4yak.Engine) error {
+	globals := engine.GetFntable()
+	var missing []string
+	for _, lib := range b.Manifest.Libraries {
+		if _, ok := globals[lib]; !ok {
+			missing = append(missing, lib)
+		}
+	}
+	if len(missing) > 0 {
+		return utils.Errorf("yak bundle %v requires libraries %v which are not available in current engine", b.Manifest.Name, missing)
+	}
+	return nil
+}
+
+// MainSource return the embedded source code of main file, empty if the source is not embedded
+func (b *Bundle) MainSource() string {
+	if sm := b.Manifest.SourceMap; sm != nil && len(sm.Files) > 0 {
+		return sm.Files[0].Source
+	}
+	return ""
+}
+
+// Exec validate the bundle and execute it in engine
+func Exec(ctx context.Context, engine *antlr4yak.Engine, raw []byte, opts ...LoadOption) error {
+	b, err := Load(raw, opts...)
+	if err != nil {
+		return err
+	}
+	if err := b.CheckLibraries(engine); err != nil {
+		return err
+	}
+
+	source := b.MainSource()
+	symtbl, codes, err := engine.UnMarshal(b.Payload, nil, source)
+	if err != nil {
+		return utils.Errorf("load yak bundle %v failed: %v", b.Manifest.Name, err)
+	}
+	if sm := b.Manifest.SourceMap; sm != nil && !sm.apply(codes) {
+		log.Warnf("the source map of yak bundle %v does not match the opcodes, ignored", b.Manifest.Name)
+	}
+	vm := engine.GetVM()
+	vm.SetSymboltable(symtbl)
+	return vm.ExecYakCode(ctx, source, codes, yakvm.None)
+}
+
+// SafeExec is Exec which recovers the panic of execution
+func SafeExec(ctx context.Context, engine *antlr4yak.Engine, raw []byte, opts ...LoadOption) (fErr error) {
+	defer func() {
+		if err := recover(); err != nil {
+			fErr = fmt.Errorf("exec yak bundle failed: %s", err)
+		}
+	}()
+	return Exec(ctx, engine, raw, opts...)
+}
@@ -0,0 +1,95 @@
+package yakbundle
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+
+	"github.com/yaklang/yaklang/common/utils"
+)
+
+// GenerateKey generate ed25519 key pair for signing bundles, the keys are PEM encoded
+// (PKCS#8 private key / PKIX public key, same as `openssl genpkey -algorithm ed25519`)
+func GenerateKey() (privatePEM []byte, publicPEM []byte, err error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return nil, nil, err
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, nil, err
+	}
+	privatePEM = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER})
+	publicPEM = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER})
+	return privatePEM, publicPEM, nil
+}
+
+// ParsePrivateKey parse the PEM encoded ed25519 private key
+func ParsePrivateKey(raw []byte) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, utils.Error("invalid private key: no PEM block")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, utils.Errorf("invalid private key: %v", err)
+	}
+	ret, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, utils.Errorf("invalid private key: %T is not ed25519 key", key)
+	}
+	return ret, nil
+}
+
+// ParsePublicKey parse the PEM encoded ed25519 public key
+func ParsePublicKey(raw []byte) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, utils.Error("invalid public key: no PEM block")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, utils.Errorf("invalid public key: %v", err)
+	}
+	ret, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, utils.Errorf("invalid public key: %T is not ed25519 key", key)
+	}
+	return ret, nil
+}
+
+// LoadPrivateKeyFile read the PEM encoded ed25519 private key file
+func LoadPrivateKeyFile(path string) (ed25519.PrivateKey, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := ParsePrivateKey(raw)
+	if err != nil {
+		return nil, utils.Errorf("load %v failed: %v", path, err)
+	}
+	return key, nil
+}
+
+// LoadPublicKeyFiles read the PEM encoded ed25519 public key files
+func LoadPublicKeyFiles(paths ...string) ([]ed25519.PublicKey, error) {
+	keys := make([]ed25519.PublicKey, 0, len(paths))
+	for _, path := range paths {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		key, err := ParsePublicKey(raw)
+		if err != nil {
+			return nil, utils.Errorf("load %v failed: %v", path, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
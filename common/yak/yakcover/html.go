@@ -0,0 +1,97 @@
+package yakcover
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+)
+
+var htmlTemplate = template.Must(template.New("coverage").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Yak Coverage Report</title>
+<style>
+body { background: #1e1e1e; color: #808080; font-family: Menlo, Consolas, monospace; margin: 0; }
+#topbar { background: #2d2d2d; padding: 8px 16px; position: sticky; top: 0; }
+#topbar select { background: #1e1e1e; color: #d4d4d4; border: 1px solid #555; padding: 2px; }
+#legend { display: inline-block; margin-left: 16px; }
+.file { display: none; margin: 0; padding: 8px 0; }
+.file.active { display: block; }
+.line { display: block; white-space: pre; }
+.line .no { display: inline-block; width: 5em; padding-right: 1em; text-align: right; color: #555; user-select: none; }
+.cov0 { color: #e06c75; }
+.cov1 { color: #98c379; }
+.none { color: #808080; }
+</style>
+</head>
+<body>
+<div id="topbar">
+<select id="files" onchange="select(this.value)">
+{{- range $i, $f := .Files}}
+<option value="file{{$i}}">{{$f.Name}} ({{$f.Percent}})</option>
+{{- end}}
+</select>
+<span id="legend">total: {{.Percent}} <span class="cov0">not covered</span> <span class="cov1">covered</span> <span class="none">not tracked</span></span>
+</div>
+{{- range $i, $f := .Files}}
+<pre class="file{{if eq $i 0}} active{{end}}" id="file{{$i}}">
+{{- range $f.Lines}}<span class="line {{.Class}}"{{if .Title}} title="{{.Title}}"{{end}}><span class="no">{{.No}}</span>{{.Text}}</span>{{end -}}
+</pre>
+{{- end}}
+<script>
+function select(id) {
+  document.querySelectorAll(".file").forEach(function (e) { e.classList.remove("active"); });
+  document.getElementById(id).classList.add("active");
+}
+</script>
+</body>
+</html>
+`))
+
+type htmlLine struct {
+	No    int
+	Text  string
+	Class string
+	Title string
+}
+
+type htmlFile struct {
+	Name    string
+	Percent string
+	Lines   []*htmlLine
+}
+
+func percent(p float64) string {
+	return fmt.Sprintf("%.1f%%", p)
+}
+
+// WriteHTML render the source of every file, covered lines are green and not covered lines are red
+func (p *Profile) WriteHTML(w io.Writer) error {
+	data := struct {
+		Percent string
+		Files   []*htmlFile
+	}{Percent: percent(p.Percent())}
+
+	for _, file := range p.Files {
+		counts := make(map[int]uint64)
+		for _, line := range file.Lines() {
+			counts[line.Line] = line.Count
+		}
+		f := &htmlFile{Name: file.File, Percent: percent(file.Percent())}
+		for i, text := range strings.Split(strings.TrimRight(file.Source, "\n"), "\n") {
+			line := &htmlLine{No: i + 1, Text: strings.TrimRight(text, "\r"), Class: "none"}
+			if count, ok := counts[i+1]; ok {
+				line.Class = "cov0"
+				if count > 0 {
+					line.Class = "cov1"
+				}
+				line.Title = fmt.Sprintf("%d hits", count)
+			}
+			f.Lines = append(f.Lines, line)
+		}
+		data.Files = append(data.Files, f)
+	}
+	return htmlTemplate.Execute(w, data)
+}
@@ -0,0 +1,147 @@
+package yakcover
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/yak/antlr4yak/yakvm"
+)
+
+const (
+	FormatGo   = "go"
+	FormatLCOV = "lcov"
+	FormatHTML = "html"
+)
+
+// FormatByPath guess the profile format by file extension, `.info`/`.lcov` is LCOV, `.html` is HTML report,
+// others are go style profile
+func FormatByPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".info", ".lcov":
+		return FormatLCOV
+	case ".html", ".htm":
+		return FormatHTML
+	default:
+		return FormatGo
+	}
+}
+
+// Profile is the snapshot of yakvm coverage
+type Profile struct {
+	Files []*yakvm.FileCoverage
+}
+
+// NewProfile take the snapshot of coverage, only the file accepted by filter is kept, nil filter keep all files
+func NewProfile(cov *yakvm.Coverage, filter func(file string) bool) *Profile {
+	p := &Profile{}
+	if cov == nil {
+		return p
+	}
+	for _, file := range cov.Files() {
+		if filter != nil && !filter(file) {
+			continue
+		}
+		if f, ok := cov.File(file); ok {
+			p.Files = append(p.Files, f)
+		}
+	}
+	return p
+}
+
+// Percent return the line coverage of all files
+func (p *Profile) Percent() float64 {
+	total, covered := 0, 0
+	for _, file := range p.Files {
+		for _, line := range file.Lines() {
+			total++
+			if line.Count > 0 {
+				covered++
+			}
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(covered) * 100 / float64(total)
+}
+
+func (p *Profile) Write(w io.Writer, format string) error {
+	switch format {
+	case FormatGo, "":
+		return p.WriteGo(w)
+	case FormatLCOV:
+		return p.WriteLCOV(w)
+	case FormatHTML:
+		return p.WriteHTML(w)
+	default:
+		return utils.Errorf("unsupported coverage format: %v", format)
+	}
+}
+
+// Save write the profile to file, empty format means guess by extension
+func (p *Profile) Save(path string, format string) error {
+	if format == "" {
+		format = FormatByPath(path)
+	}
+	fp, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer fp.Close()
+	buf := bufio.NewWriter(fp)
+	if err := p.Write(buf, format); err != nil {
+		return err
+	}
+	return buf.Flush()
+}
+
+// WriteGo write the profile as `go test -coverprofile` (mode: count), every executable line is a block
+func (p *Profile) WriteGo(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, "mode: count"); err != nil {
+		return err
+	}
+	for _, file := range p.Files {
+		lines := strings.Split(file.Source, "\n")
+		for _, line := range file.Lines() {
+			start, end := 1, 2
+			if line.Line <= len(lines) {
+				text := strings.TrimRight(lines[line.Line-1], " \t\r")
+				if trimmed := strings.TrimLeft(text, " \t"); trimmed != "" {
+					start = len(text) - len(trimmed) + 1
+					end = len(text) + 1
+				}
+			}
+			_, err := fmt.Fprintf(w, "%s:%d.%d,%d.%d 1 %d\n", file.File, line.Line, start, line.Line, end, line.Count)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// WriteLCOV write the profile as LCOV tracefile
+func (p *Profile) WriteLCOV(w io.Writer) error {
+	var buf strings.Builder
+	for _, file := range p.Files {
+		buf.WriteString("TN:\n")
+		fmt.Fprintf(&buf, "SF:%s\n", file.File)
+		lines := file.Lines()
+		hit := 0
+		for _, line := range lines {
+			fmt.Fprintf(&buf, "DA:%d,%d\n", line.Line, line.Count)
+			if line.Count > 0 {
+				hit++
+			}
+		}
+		fmt.Fprintf(&buf, "LF:%d\nLH:%d\n", len(lines), hit)
+		buf.WriteString("end_of_record\n")
+	}
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
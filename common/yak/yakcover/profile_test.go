@@ -0,0 +1,88 @@
+package yakcover
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yaklang/yaklang/common/yak"
+	"github.com/yaklang/yaklang/common/yak/antlr4yak/yakvm"
+)
+
+const code = `check = func(a) {
+    if a > 10 {
+        return "big"
+    }
+    return "small"
+}
+
+never = func() {
+    println("never")
+}
+
+for i in 3 {
+    check(i)
+}
+`
+
+func runWithCoverage(t *testing.T, cov *yakvm.Coverage, file string) {
+	engine := yak.NewScriptEngine(1)
+	engine.SetCoverage(cov)
+	require.NoError(t, engine.ExecuteMain(code, file))
+}
+
+func TestCoverage(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "a.yak")
+	cov := yakvm.NewCoverage()
+	runWithCoverage(t, cov, file)
+
+	require.Equal(t, []string{file}, cov.Files())
+	f, ok := cov.File(file)
+	require.True(t, ok)
+	require.Equal(t, code, f.Source)
+	lines := make(map[int]uint64)
+	for _, line := range f.Lines() {
+		lines[line.Line] = line.Count
+	}
+	// the entry opcodes of function body and loop header are executed on every call / iteration
+	require.Equal(t, map[int]uint64{
+		1: 3, 2: 3, 3: 0, 5: 3,
+		8: 1, 9: 0,
+		12: 3, 13: 3,
+	}, lines)
+
+	// the counts of same code in different engine are merged
+	runWithCoverage(t, cov, file)
+	f, _ = cov.File(file)
+	require.Equal(t, uint64(6), f.Lines()[1].Count)
+
+	profile := NewProfile(cov, nil)
+	require.InDelta(t, 75.0, profile.Percent(), 0.01)
+
+	var buf bytes.Buffer
+	require.NoError(t, profile.WriteGo(&buf))
+	require.True(t, strings.HasPrefix(buf.String(), "mode: count\n"))
+	require.Contains(t, buf.String(), fmt.Sprintf("%s:2.5,2.16 1 6\n", file))
+	require.Contains(t, buf.String(), fmt.Sprintf("%s:9.5,9.21 1 0\n", file))
+
+	buf.Reset()
+	require.NoError(t, profile.WriteLCOV(&buf))
+	require.Contains(t, buf.String(), "SF:"+file+"\nDA:1,6\n")
+	require.Contains(t, buf.String(), "LF:8\nLH:6\nend_of_record\n")
+
+	buf.Reset()
+	require.NoError(t, profile.WriteHTML(&buf))
+	require.Contains(t, buf.String(), `<span class="line cov0" title="0 hits"><span class="no">9</span>    println(&#34;never&#34;)</span>`)
+	require.Contains(t, buf.String(), `<span class="line none"><span class="no">7</span></span>`)
+
+	require.Empty(t, NewProfile(cov, func(string) bool { return false }).Files)
+}
+
+func TestFormatByPath(t *testing.T) {
+	require.Equal(t, FormatGo, FormatByPath("cover.out"))
+	require.Equal(t, FormatLCOV, FormatByPath("lcov.info"))
+	require.Equal(t, FormatHTML, FormatByPath("cover.HTML"))
+}
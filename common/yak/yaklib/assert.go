@@ -0,0 +1,469 @@
+package yaklib
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/utils/lowhttp"
+	"github.com/yaklang/yaklang/common/utils/lowhttp/http_struct"
+	"github.com/yaklang/yaklang/common/yak/yaklang/spec"
+)
+
+// AssertionError is the panic value of failed assertion, test runner use it to tell failure from error
+type AssertionError struct {
+	Message string
+}
+
+func (e *AssertionError) Error() string {
+	return e.Message
+}
+
+func assertFail(msgAndArgs []any, format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	if extra := assertMessage(msgAndArgs); extra != "" {
+		msg = extra + ": " + msg
+	}
+	panic(&AssertionError{Message: msg})
+}
+
+func assertMessage(msgAndArgs []any) string {
+	if len(msgAndArgs) == 0 {
+		return ""
+	}
+	if format, ok := msgAndArgs[0].(string); ok && len(msgAndArgs) > 1 && strings.Contains(format, "%") {
+		return fmt.Sprintf(format, msgAndArgs[1:]...)
+	}
+	return strings.TrimSpace(fmt.Sprintln(msgAndArgs...))
+}
+
+func assertFormat(i any) string {
+	switch ret := i.(type) {
+	case string:
+		return strconv.Quote(ret)
+	case []byte:
+		return "b" + strconv.Quote(string(ret))
+	}
+	if assertIsNil(i) {
+		return "nil"
+	}
+	return fmt.Sprintf("%v (%T)", i, i)
+}
+
+func assertIsNil(i any) bool {
+	return i == nil || i == spec.Undefined || utils.IsNil(i)
+}
+
+func assertEqual(expected, actual any) bool {
+	if assertIsNil(expected) || assertIsNil(actual) {
+		return assertIsNil(expected) && assertIsNil(actual)
+	}
+	// string and []byte are equal if the content is the same
+	switch expected.(type) {
+	case string, []byte:
+		switch actual.(type) {
+		case string, []byte:
+			return utils.InterfaceToString(expected) == utils.InterfaceToString(actual)
+		}
+	}
+	return assert.ObjectsAreEqualValues(expected, actual)
+}
+
+func assertEmpty(i any) bool {
+	if assertIsNil(i) {
+		return true
+	}
+	v := reflect.ValueOf(i)
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array, reflect.Chan:
+		return v.Len() == 0
+	case reflect.Ptr:
+		return assertEmpty(v.Elem().Interface())
+	}
+	return v.IsZero()
+}
+
+func assertContains(container, element any) (bool, bool) {
+	switch c := container.(type) {
+	case string, []byte:
+		return strings.Contains(utils.InterfaceToString(c), utils.InterfaceToString(element)), true
+	}
+	if assertIsNil(container) {
+		return false, false
+	}
+	v := reflect.ValueOf(container)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if assertEqual(v.Index(i).Interface(), element) {
+				return true, true
+			}
+		}
+		return false, true
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			if assertEqual(key.Interface(), element) {
+				return true, true
+			}
+		}
+		return false, true
+	}
+	return false, false
+}
+
+// Equal 断言 expected 与 actual 相等，否则崩溃并打印错误信息，可以传入额外的信息用于描述断言
+// 不同类型的数字会按值比较，字符串与字节切片会按内容比较
+// Example:
+// ```
+// asserts.Equal(1, 1)
+// asserts.Equal("abc", b"abc", "string should equal bytes")
+// ```
+func _assertsEqual(expected, actual any, msgAndArgs ...any) {
+	if !assertEqual(expected, actual) {
+		assertFail(msgAndArgs, "not equal:\n  expected: %s\n  actual  : %s", assertFormat(expected), assertFormat(actual))
+	}
+}
+
+// NotEqual 断言 expected 与 actual 不相等，否则崩溃并打印错误信息
+// Example:
+// ```
+// asserts.NotEqual(1, 2)
+// ```
+func _assertsNotEqual(expected, actual any, msgAndArgs ...any) {
+	if assertEqual(expected, actual) {
+		assertFail(msgAndArgs, "should not be: %s", assertFormat(actual))
+	}
+}
+
+// True 断言传入的值为 true，否则崩溃并打印错误信息
+// Example:
+// ```
+// asserts.True(1 < 2)
+// ```
+func _assertsTrue(b bool, msgAndArgs ...any) {
+	if !b {
+		assertFail(msgAndArgs, "should be true")
+	}
+}
+
+// False 断言传入的值为 false，否则崩溃并打印错误信息
+// Example:
+// ```
+// asserts.False(1 > 2)
+// ```
+func _assertsFalse(b bool, msgAndArgs ...any) {
+	if b {
+		assertFail(msgAndArgs, "should be false")
+	}
+}
+
+// Nil 断言传入的值为 nil 或 undefined，否则崩溃并打印错误信息
+// Example:
+// ```
+// asserts.Nil(nil)
+// ```
+func _assertsNil(i any, msgAndArgs ...any) {
+	if !assertIsNil(i) {
+		assertFail(msgAndArgs, "expected nil, but got: %s", assertFormat(i))
+	}
+}
+
+// NotNil 断言传入的值不为 nil 或 undefined，否则崩溃并打印错误信息
+// Example:
+// ```
+// asserts.NotNil(1)
+// ```
+func _assertsNotNil(i any, msgAndArgs ...any) {
+	if assertIsNil(i) {
+		assertFail(msgAndArgs, "expected value not to be nil")
+	}
+}
+
+// Empty 断言传入的值为空（nil、零值或长度为 0），否则崩溃并打印错误信息
+// Example:
+// ```
+// asserts.Empty("")
+// asserts.Empty([])
+// ```
+func _assertsEmpty(i any, msgAndArgs ...any) {
+	if !assertEmpty(i) {
+		assertFail(msgAndArgs, "should be empty, but was: %s", assertFormat(i))
+	}
+}
+
+// NotEmpty 断言传入的值不为空，否则崩溃并打印错误信息
+// Example:
+// ```
+// asserts.NotEmpty("abc")
+// ```
+func _assertsNotEmpty(i any, msgAndArgs ...any) {
+	if assertEmpty(i) {
+		assertFail(msgAndArgs, "should not be empty, but was: %s", assertFormat(i))
+	}
+}
+
+// Contains 断言 container 包含 element，否则崩溃并打印错误信息
+// container 为字符串或字节切片时判断子串，为切片时判断元素，为字典时判断键
+// Example:
+// ```
+// asserts.Contains("hello yak", "yak")
+// asserts.Contains([1, 2, 3], 2)
+// asserts.Contains({"a": 1}, "a")
+// ```
+func _assertsContains(container, element any, msgAndArgs ...any) {
+	found, ok := assertContains(container, element)
+	if !ok {
+		assertFail(msgAndArgs, "%s could not be applied builtin len()", assertFormat(container))
+	}
+	if !found {
+		assertFail(msgAndArgs, "%s does not contain %s", assertFormat(container), assertFormat(element))
+	}
+}
+
+// NotContains 断言 container 不包含 element，否则崩溃并打印错误信息
+// Example:
+// ```
+// asserts.NotContains("hello yak", "go")
+// ```
+func _assertsNotContains(container, element any, msgAndArgs ...any) {
+	found, ok := assertContains(container, element)
+	if !ok {
+		assertFail(msgAndArgs, "%s could not be applied builtin len()", assertFormat(container))
+	}
+	if found {
+		assertFail(msgAndArgs, "%s should not contain %s", assertFormat(container), assertFormat(element))
+	}
+}
+
+// Regexp 断言字符串匹配正则表达式，否则崩溃并打印错误信息
+// Example:
+// ```
+// asserts.Regexp(`^\d+$`, "123")
+// ```
+func _assertsRegexp(pattern string, s any, msgAndArgs ...any) {
+	r, err := regexp.Compile(pattern)
+	if err != nil {
+		assertFail(msgAndArgs, "compile regexp %v failed: %v", pattern, err)
+	}
+	if !r.MatchString(utils.InterfaceToString(s)) {
+		assertFail(msgAndArgs, "%s does not match %v", assertFormat(s), pattern)
+	}
+}
+
+// Error 断言传入的错误不为空，否则崩溃并打印错误信息
+// Example:
+// ```
+// _, err = file.ReadFile("/not/exist")
+// asserts.Error(err)
+// ```
+func _assertsError(err error, msgAndArgs ...any) {
+	if err == nil {
+		assertFail(msgAndArgs, "an error is expected but got nil")
+	}
+}
+
+// NoError 断言传入的错误为空，否则崩溃并打印错误信息
+// Example:
+// ```
+// rsp, req, err = poc.Get("http://example.com")
+// asserts.NoError(err)
+// ```
+func _assertsNoError(err error, msgAndArgs ...any) {
+	if err != nil {
+		assertFail(msgAndArgs, "received unexpected error: %v", err)
+	}
+}
+
+// ErrorContains 断言传入的错误不为空且错误信息包含 contains，否则崩溃并打印错误信息
+// Example:
+// ```
+// _, err = file.ReadFile("/not/exist")
+// asserts.ErrorContains(err, "no such file")
+// ```
+func _assertsErrorContains(err error, contains string, msgAndArgs ...any) {
+	if err == nil {
+		assertFail(msgAndArgs, "an error containing %s is expected but got nil", strconv.Quote(contains))
+	}
+	if !strings.Contains(err.Error(), contains) {
+		assertFail(msgAndArgs, "error %s does not contain %s", strconv.Quote(err.Error()), strconv.Quote(contains))
+	}
+}
+
+func assertCall(f func()) (panicked bool, value any) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicked, value = true, r
+		}
+	}()
+	f()
+	return false, nil
+}
+
+// Panics 断言传入的函数执行时会崩溃，否则崩溃并打印错误信息
+// Example:
+// ```
+// asserts.Panics(func() { die("boom") })
+// ```
+func _assertsPanics(f func(), msgAndArgs ...any) {
+	if panicked, _ := assertCall(f); !panicked {
+		assertFail(msgAndArgs, "func should panic")
+	}
+}
+
+// PanicsContains 断言传入的函数执行时会崩溃，且崩溃信息包含 contains，否则崩溃并打印错误信息
+// Example:
+// ```
+// asserts.PanicsContains(func() { die("boom") }, "boom")
+// ```
+func _assertsPanicsContains(f func(), contains string, msgAndArgs ...any) {
+	panicked, value := assertCall(f)
+	if !panicked {
+		assertFail(msgAndArgs, "func should panic")
+	}
+	if msg := fmt.Sprint(value); !strings.Contains(msg, contains) {
+		assertFail(msgAndArgs, "panic %s does not contain %s", strconv.Quote(msg), strconv.Quote(contains))
+	}
+}
+
+// NotPanics 断言传入的函数执行时不会崩溃，否则崩溃并打印错误信息
+// Example:
+// ```
+// asserts.NotPanics(func() { println("ok") })
+// ```
+func _assertsNotPanics(f func(), msgAndArgs ...any) {
+	if panicked, value := assertCall(f); panicked {
+		assertFail(msgAndArgs, "func should not panic, but panic with: %v", value)
+	}
+}
+
+// Fail 直接使断言失败，崩溃并打印错误信息
+// Example:
+// ```
+// asserts.Fail("unreachable")
+// ```
+func _assertsFail(msgAndArgs ...any) {
+	assertFail(msgAndArgs, "failed")
+}
+
+// assertResponsePacket get raw response packet from []byte, string, *http.Response, lowhttp response and http library response
+func assertResponsePacket(rsp any, msgAndArgs []any) []byte {
+	switch ret := rsp.(type) {
+	case []byte:
+		return ret
+	case string:
+		return []byte(ret)
+	case *lowhttp.LowhttpResponse:
+		if ret != nil {
+			return ret.RawPacket
+		}
+	case *http_struct.YakHttpResponse:
+		if ret != nil && ret.Response != nil {
+			raw, err := utils.DumpHTTPResponse(ret.Response, true)
+			if err == nil {
+				return raw
+			}
+		}
+	case *http.Response:
+		if ret != nil {
+			raw, err := utils.DumpHTTPResponse(ret, true)
+			if err == nil {
+				return raw
+			}
+		}
+	}
+	assertFail(msgAndArgs, "cannot get http response packet from %T", rsp)
+	return nil
+}
+
+// HTTPStatusCode 断言 HTTP 响应的状态码为 code，否则崩溃并打印错误信息
+// 响应可以是原始响应报文，poc 库的 *LowhttpResponse 或者 http 库的响应
+// Example:
+// ```
+// rsp, req = poc.Get("http://example.com")~
+// asserts.HTTPStatusCode(rsp, 200)
+// ```
+func _assertsHTTPStatusCode(rsp any, code int, msgAndArgs ...any) {
+	packet := assertResponsePacket(rsp, msgAndArgs)
+	if actual := lowhttp.GetStatusCodeFromResponse(packet); actual != code {
+		assertFail(msgAndArgs, "http status code expected %d, but got %d", code, actual)
+	}
+}
+
+// HTTPHeader 断言 HTTP 响应包含请求头 key，且其值包含 value，否则崩溃并打印错误信息
+// Example:
+// ```
+// rsp, req = poc.Get("http://example.com")~
+// asserts.HTTPHeader(rsp, "Content-Type", "text/html")
+// ```
+func _assertsHTTPHeader(rsp any, key, value string, msgAndArgs ...any) {
+	packet := assertResponsePacket(rsp, msgAndArgs)
+	for k, v := range lowhttp.GetHTTPPacketHeaders(packet) {
+		if strings.EqualFold(k, key) && strings.Contains(v, value) {
+			return
+		}
+	}
+	assertFail(msgAndArgs, "http header %s: %s not found", key, strconv.Quote(value))
+}
+
+// HTTPBodyContains 断言 HTTP 响应体包含 contains，否则崩溃并打印错误信息
+// Example:
+// ```
+// rsp, req = poc.Get("http://example.com")~
+// asserts.HTTPBodyContains(rsp, "Example Domain")
+// ```
+func _assertsHTTPBodyContains(rsp any, contains any, msgAndArgs ...any) {
+	body := lowhttp.GetHTTPPacketBody(assertResponsePacket(rsp, msgAndArgs))
+	if !bytes.Contains(body, utils.InterfaceToBytes(contains)) {
+		assertFail(msgAndArgs, "http body does not contain %s", assertFormat(contains))
+	}
+}
+
+// HTTPBodyRegexp 断言 HTTP 响应体匹配正则表达式，否则崩溃并打印错误信息
+// Example:
+// ```
+// rsp, req = poc.Get("http://example.com")~
+// asserts.HTTPBodyRegexp(rsp, `<title>.*</title>`)
+// ```
+func _assertsHTTPBodyRegexp(rsp any, pattern string, msgAndArgs ...any) {
+	r, err := regexp.Compile(pattern)
+	if err != nil {
+		assertFail(msgAndArgs, "compile regexp %v failed: %v", pattern, err)
+	}
+	body := lowhttp.GetHTTPPacketBody(assertResponsePacket(rsp, msgAndArgs))
+	if !r.Match(body) {
+		assertFail(msgAndArgs, "http body does not match %v", pattern)
+	}
+}
+
+// AssertExports is the `asserts` library, the name `assert` is a keyword of yaklang (assert statement)
+var AssertExports = map[string]interface{}{
+	"Equal":          _assertsEqual,
+	"NotEqual":       _assertsNotEqual,
+	"True":           _assertsTrue,
+	"False":          _assertsFalse,
+	"Nil":            _assertsNil,
+	"NotNil":         _assertsNotNil,
+	"Empty":          _assertsEmpty,
+	"NotEmpty":       _assertsNotEmpty,
+	"Contains":       _assertsContains,
+	"NotContains":    _assertsNotContains,
+	"Regexp":         _assertsRegexp,
+	"Error":          _assertsError,
+	"NoError":        _assertsNoError,
+	"ErrorContains":  _assertsErrorContains,
+	"Panics":         _assertsPanics,
+	"PanicsContains": _assertsPanicsContains,
+	"NotPanics":      _assertsNotPanics,
+	"Fail":           _assertsFail,
+
+	"HTTPStatusCode":   _assertsHTTPStatusCode,
+	"HTTPHeader":       _assertsHTTPHeader,
+	"HTTPBodyContains": _assertsHTTPBodyContains,
+	"HTTPBodyRegexp":   _assertsHTTPBodyRegexp,
+}
@@ -1078,13 +1078,13 @@ func (flow *CodecExecFlow) CodecPlugin(pluginName string) error {
 	if err != nil {
 		return err
 	}
-	engine, err := yak.NewScriptEngine(1000).ExecuteEx(script.Content, map[string]interface{}{
+	engine, err := yak.NewScriptEngine(1000).ExecutePluginEx(script, map[string]interface{}{
 		"YAK_FILENAME": pluginName,
 	})
 	if err != nil {
 		return utils.Errorf("execute file %s code failed: %s", pluginName, err.Error())
 	}
-	pluginRes, err := engine.CallYakFunction(context.Background(), "handle", []interface{}{string(flow.Text)})
+	pluginRes, err := engine.SafeCallYakFunction(context.Background(), "handle", []interface{}{string(flow.Text)})
 	if err != nil {
 		return utils.Errorf("import %v' s handle failed: %s", pluginName, err)
 	}
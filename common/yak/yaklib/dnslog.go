@@ -8,6 +8,7 @@ import (
 	"github.com/yaklang/yaklang/common/cybertunnel"
 	"github.com/yaklang/yaklang/common/cybertunnel/tpb"
 	"github.com/yaklang/yaklang/common/netx"
+	"github.com/yaklang/yaklang/common/schema"
 	"github.com/yaklang/yaklang/common/utils"
 	"github.com/yaklang/yaklang/common/yak/antlr4yak"
 	"github.com/yaklang/yaklang/common/yakgrpc/yakit"
@@ -25,6 +26,8 @@ type CustomDNSLog struct {
 
 type IEngine interface {
 	ExecuteEx(code string, params map[string]interface{}) (*antlr4yak.Engine, error)
+	// ExecutePluginEx 使用插件的沙箱策略执行插件代码
+	ExecutePluginEx(script *schema.YakScript, params map[string]interface{}) (*antlr4yak.Engine, error)
 }
 
 var EngineInterface IEngine
@@ -151,13 +154,13 @@ func customGet(name string) (string, string, string, error) {
 		return "", "", "", err
 	}
 
-	engine, err := EngineInterface.ExecuteEx(script.Content, map[string]interface{}{
+	engine, err := EngineInterface.ExecutePluginEx(script, map[string]interface{}{
 		"YAK_FILENAME": name,
 	})
 	if err != nil {
 		return "", "", "", utils.Errorf("execute file %s code failed: %s", name, err.Error())
 	}
-	result, err := engine.CallYakFunction(context.Background(), "requireDomain", []interface{}{})
+	result, err := engine.SafeCallYakFunction(context.Background(), "requireDomain", []interface{}{})
 	if err != nil {
 		return "", "", "", utils.Errorf("import %v' s handle failed: %s", name, err)
 	}
@@ -175,13 +178,13 @@ func customCheck(name, token, mode string, timeout ...float64) ([]*tpb.DNSLogEve
 		return nil, err
 	}
 
-	engine, err := EngineInterface.ExecuteEx(script.Content, map[string]interface{}{
+	engine, err := EngineInterface.ExecutePluginEx(script, map[string]interface{}{
 		"YAK_FILENAME": name,
 	})
 	if err != nil {
 		return nil, utils.Errorf("execute file %s code failed: %s", name, err.Error())
 	}
-	result, err := engine.CallYakFunction(context.Background(), "getResults", []interface{}{token})
+	result, err := engine.SafeCallYakFunction(context.Background(), "getResults", []interface{}{token})
 	if err != nil {
 		return nil, utils.Errorf("import %v' s handle failed: %s", name, err)
 	}
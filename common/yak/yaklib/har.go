@@ -0,0 +1,141 @@
+package yaklib
+
+import (
+	"bufio"
+	"context"
+	"os"
+
+	"github.com/yaklang/yaklang/common/consts"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/yakgrpc/yakit"
+	"github.com/yaklang/yaklang/common/yakgrpc/ypb"
+)
+
+type _harConfig struct {
+	ctx    context.Context
+	filter *ypb.QueryHTTPFlowRequest
+}
+
+type harOption func(c *_harConfig)
+
+// context 是一个选项参数，用于指定导入导出使用的上下文，上下文取消时停止导入导出
+// Example:
+// ```
+// ctx, cancel = context.WithTimeout(context.Background(), 10)
+// har.Import("/tmp/a.har", har.context(ctx))~
+// ```
+func _harContext(ctx context.Context) harOption {
+	return func(c *_harConfig) {
+		c.ctx = ctx
+	}
+}
+
+// ids 是一个选项参数，用于指定导出的 HTTP 流量 ID
+// Example:
+// ```
+// har.Export("/tmp/a.har", har.ids(1, 2, 3))~
+// ```
+func _harIds(ids ...int64) harOption {
+	return func(c *_harConfig) {
+		c.filter.IncludeId = append(c.filter.IncludeId, ids...)
+	}
+}
+
+// keyword 是一个选项参数，用于指定导出包含关键字的 HTTP 流量
+// Example:
+// ```
+// har.Export("/tmp/a.har", har.keyword("example.com"))~
+// ```
+func _harKeyword(keyword string) harOption {
+	return func(c *_harConfig) {
+		c.filter.Keyword = keyword
+	}
+}
+
+// runtimeID 是一个选项参数，用于指定导出某次插件执行产生的 HTTP 流量
+// Example:
+// ```
+// har.Export("/tmp/a.har", har.runtimeID(runtimeID))~
+// ```
+func _harRuntimeID(runtimeID string) harOption {
+	return func(c *_harConfig) {
+		c.filter.RuntimeId = runtimeID
+	}
+}
+
+// sourceType 是一个选项参数，用于指定导出的 HTTP 流量来源，多个来源使用逗号分隔，例如 mitm,scan,har
+// Example:
+// ```
+// har.Export("/tmp/a.har", har.sourceType("mitm"))~
+// ```
+func _harSourceType(sourceType string) harOption {
+	return func(c *_harConfig) {
+		c.filter.SourceType = sourceType
+	}
+}
+
+func newHARConfig(opts ...harOption) *_harConfig {
+	c := &_harConfig{ctx: context.Background(), filter: &ypb.QueryHTTPFlowRequest{}}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Import 流式读取 HAR 1.2 文件(例如浏览器 DevTools 导出的 HAR)，将其中的请求保存为 HTTP 流量，来源为 har，返回成功导入的数量与错误
+// 重复导入同一个 HAR 文件不会产生重复的 HTTP 流量，不支持的协议(例如 data:)会被跳过
+// Example:
+// ```
+// count = har.Import("/tmp/devtools.har")~
+// println(count)
+// ```
+func ImportHAR(path string, opts ...harOption) (int, error) {
+	db := consts.GetGormProjectDatabase()
+	if db == nil {
+		return 0, utils.Error("empty database")
+	}
+	c := newHARConfig(opts...)
+	fp, err := os.Open(path)
+	if err != nil {
+		return 0, utils.Errorf("open har file failed: %s", err)
+	}
+	defer fp.Close()
+	return yakit.ImportHTTPFlowsFromHAR(c.ctx, db, bufio.NewReader(fp), nil)
+}
+
+// Export 将 HTTP 流量导出为 HAR 1.2 文件，包含请求耗时，https 连接信息与 websocket 消息(_webSocketMessages)，返回导出的数量与错误
+// 不指定筛选条件时导出所有 HTTP 流量
+// Example:
+// ```
+// count = har.Export("/tmp/a.har", har.keyword("example.com"))~
+// count = har.Export("/tmp/b.har", har.ids(1, 2, 3))~
+// ```
+func ExportHAR(path string, opts ...harOption) (int, error) {
+	db := consts.GetGormProjectDatabase()
+	if db == nil {
+		return 0, utils.Error("empty database")
+	}
+	c := newHARConfig(opts...)
+	fp, err := os.Create(path)
+	if err != nil {
+		return 0, utils.Errorf("create har file failed: %s", err)
+	}
+	defer fp.Close()
+	w := bufio.NewWriter(fp)
+	count, err := yakit.ExportHTTPFlowsToHAR(c.ctx, yakit.FilterHTTPFlow(db, c.filter), w, nil)
+	if err != nil {
+		return count, err
+	}
+	return count, w.Flush()
+}
+
+var HarExports = map[string]interface{}{
+	"Import": ImportHAR,
+	"Export": ExportHAR,
+
+	"context":    _harContext,
+	"ids":        _harIds,
+	"keyword":    _harKeyword,
+	"runtimeID":  _harRuntimeID,
+	"sourceType": _harSourceType,
+}
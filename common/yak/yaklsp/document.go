@@ -0,0 +1,224 @@
+package yaklsp
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/yaklang/yaklang/common/yakgrpc/ypb"
+)
+
+type document struct {
+	uri     string
+	version int
+	text    string
+}
+
+func (d *document) lines() []string {
+	return strings.Split(d.text, "\n")
+}
+
+func (d *document) line(line int) string {
+	lines := d.lines()
+	if line < 0 || line >= len(lines) {
+		return ""
+	}
+	return strings.TrimSuffix(lines[line], "\r")
+}
+
+// offset convert lsp position to byte offset of text
+func (d *document) offset(pos Position) int {
+	offset := 0
+	for i, line := range d.lines() {
+		if i == pos.Line {
+			return offset + len(string([]rune(line)[:runeColumn(line, pos.Character)]))
+		}
+		offset += len(line) + 1
+	}
+	return len(d.text)
+}
+
+// position convert byte offset of text to lsp position
+func (d *document) position(offset int) Position {
+	if offset > len(d.text) {
+		offset = len(d.text)
+	}
+	before := d.text[:offset]
+	line := strings.Count(before, "\n")
+	lineStart := strings.LastIndex(before, "\n") + 1
+	return Position{Line: line, Character: utf16Length(before[lineStart:])}
+}
+
+// applyChange apply the full or incremental content change
+func (d *document) applyChange(change TextDocumentContentChangeEvent) {
+	if change.Range == nil {
+		d.text = change.Text
+		return
+	}
+	start, end := d.offset(change.Range.Start), d.offset(change.Range.End)
+	if end < start {
+		start, end = end, start
+	}
+	d.text = d.text[:start] + change.Text + d.text[end:]
+}
+
+// runeColumn convert utf-16 character offset to rune column of line
+func runeColumn(line string, character int) int {
+	units := 0
+	for i, r := range []rune(line) {
+		if units >= character {
+			return i
+		}
+		units += utf16RuneLen(r)
+	}
+	return utf8.RuneCountInString(line)
+}
+
+// utf16Column convert rune column to utf-16 character offset of line
+func utf16Column(line string, column int) int {
+	runes := []rune(line)
+	if column > len(runes) {
+		column = len(runes)
+	}
+	if column < 0 {
+		column = 0
+	}
+	return utf16Length(string(runes[:column]))
+}
+
+func utf16Length(s string) int {
+	n := 0
+	for _, r := range s {
+		n += utf16RuneLen(r)
+	}
+	return n
+}
+
+// utf16RuneLen return the number of utf-16 code units of r, rune outside the BMP takes a surrogate pair
+func utf16RuneLen(r rune) int {
+	if r >= 0x10000 {
+		return 2
+	}
+	return 1
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// wordRange return the rune columns [start, end) of word around the cursor.
+// the start is extended over the `.` chain, so hover on `HTTP` of `poc.HTTP` got `poc.HTTP`;
+// when untilCursor is true (for completion), the word end at the cursor and keep the trailing dot.
+func wordRange(line string, column int, untilCursor bool) (int, int) {
+	runes := []rune(line)
+	if column > len(runes) {
+		column = len(runes)
+	}
+	start, end := column, column
+	if !untilCursor {
+		for end < len(runes) && isWordRune(runes[end]) {
+			end++
+		}
+	}
+	for start > 0 && (isWordRune(runes[start-1]) || runes[start-1] == '.') {
+		start--
+	}
+	return start, end
+}
+
+// grpcRange build the range that yakgrpc language server functions want (1-based line and column)
+func grpcRange(line, start, end int, code string) *ypb.Range {
+	if start == end {
+		end++
+	}
+	return &ypb.Range{
+		Code:        code,
+		StartLine:   int64(line + 1),
+		StartColumn: int64(start + 1),
+		EndLine:     int64(line + 1),
+		EndColumn:   int64(end + 1),
+	}
+}
+
+type documentStore struct {
+	sync.RWMutex
+	docs map[string]*document
+}
+
+func newDocumentStore() *documentStore {
+	return &documentStore{docs: make(map[string]*document)}
+}
+
+func (s *documentStore) open(item TextDocumentItem) *document {
+	s.Lock()
+	defer s.Unlock()
+	doc := &document{uri: item.URI, version: item.Version, text: item.Text}
+	s.docs[item.URI] = doc
+	return doc
+}
+
+func (s *documentStore) change(uri string, version int, changes []TextDocumentContentChangeEvent) *document {
+	s.Lock()
+	defer s.Unlock()
+	doc, ok := s.docs[uri]
+	if !ok {
+		doc = &document{uri: uri}
+		s.docs[uri] = doc
+	}
+	for _, change := range changes {
+		doc.applyChange(change)
+	}
+	doc.version = version
+	return &document{uri: doc.uri, version: doc.version, text: doc.text}
+}
+
+func (s *documentStore) close(uri string) {
+	s.Lock()
+	defer s.Unlock()
+	delete(s.docs, uri)
+}
+
+// get return the snapshot of document
+func (s *documentStore) get(uri string) (*document, bool) {
+	s.RLock()
+	defer s.RUnlock()
+	doc, ok := s.docs[uri]
+	if !ok {
+		return nil, false
+	}
+	return &document{uri: doc.uri, version: doc.version, text: doc.text}, true
+}
+
+func (s *documentStore) all() []*document {
+	s.RLock()
+	defer s.RUnlock()
+	ret := make([]*document, 0, len(s.docs))
+	for _, doc := range s.docs {
+		ret = append(ret, &document{uri: doc.uri, version: doc.version, text: doc.text})
+	}
+	return ret
+}
+
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return ""
+	}
+	path := u.Path
+	// file:///C:/foo on windows
+	if len(path) > 2 && path[0] == '/' && path[2] == ':' {
+		path = path[1:]
+	}
+	return filepath.FromSlash(path)
+}
+
+func pathToURI(path string) string {
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
@@ -0,0 +1,398 @@
+package yaklsp
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/yaklang/yaklang/common/log"
+	"github.com/yaklang/yaklang/common/utils/memedit"
+	pta "github.com/yaklang/yaklang/common/yak/static_analyzer"
+	"github.com/yaklang/yaklang/common/yak/static_analyzer/result"
+	"github.com/yaklang/yaklang/common/yakgrpc"
+)
+
+var identifierRegexp = regexp.MustCompile(`^[\p{L}_][\p{L}\p{N}_]*$`)
+
+var completionKinds = map[string]CompletionItemKind{
+	"Method":   CompletionKindMethod,
+	"Function": CompletionKindFunction,
+	"Field":    CompletionKindField,
+	"Variable": CompletionKindVariable,
+	"Class":    CompletionKindClass,
+	"Module":   CompletionKindModule,
+	"Keyword":  CompletionKindKeyword,
+	"Constant": CompletionKindConstant,
+}
+
+var diagnosticSeverities = map[result.MarkerSeverity]DiagnosticSeverity{
+	result.Error: SeverityError,
+	result.Warn:  SeverityWarning,
+	result.Info:  SeverityInformation,
+	result.Hint:  SeverityHint,
+}
+
+// publishDiagnostics run static analyzer and publish the result, the stale result of old version is dropped
+func (s *Server) publishDiagnostics(doc *document) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Errorf("static analyze %v panic: %v", doc.uri, r)
+		}
+	}()
+
+	diagnostics := make([]*Diagnostic, 0)
+	for _, res := range pta.StaticAnalyzeYaklang(doc.text, s.scriptType) {
+		diagnostic := &Diagnostic{
+			Range:    analyzeResultRange(doc, res),
+			Severity: diagnosticSeverities[res.Severity],
+			Source:   "yak",
+			Message:  res.Message,
+		}
+		switch res.Tag {
+		case result.Deprecated:
+			diagnostic.Tags = []DiagnosticTag{TagDeprecated}
+		case result.Unnecessary:
+			diagnostic.Tags = []DiagnosticTag{TagUnnecessary}
+		}
+		diagnostics = append(diagnostics, diagnostic)
+	}
+
+	if current, ok := s.docs.get(doc.uri); !ok || current.version != doc.version || current.text != doc.text {
+		return
+	}
+	if err := s.conn.notify("textDocument/publishDiagnostics", &PublishDiagnosticsParams{
+		URI:         doc.uri,
+		Version:     doc.version,
+		Diagnostics: diagnostics,
+	}); err != nil {
+		log.Errorf("publish diagnostics failed: %v", err)
+	}
+}
+
+// analyzeResultRange convert the 1-based position of static analyze result, no line means the whole first line
+func analyzeResultRange(doc *document, res *result.StaticAnalyzeResult) Range {
+	if res.StartLineNumber <= 0 {
+		return Range{End: Position{Line: 0, Character: utf16Length(doc.line(0))}}
+	}
+	startLine, endLine := int(res.StartLineNumber)-1, int(res.EndLineNumber)-1
+	if endLine < startLine {
+		endLine = startLine
+	}
+	return Range{
+		Start: Position{Line: startLine, Character: utf16Column(doc.line(startLine), int(res.StartColumn)-1)},
+		End:   Position{Line: endLine, Character: utf16Column(doc.line(endLine), int(res.EndColumn)-1)},
+	}
+}
+
+// toRange convert memedit range (1-based line, 0-based rune column) to lsp range
+func toRange(doc *document, rng memedit.RangeIf) Range {
+	start, end := rng.GetStart(), rng.GetEnd()
+	startLine, endLine := start.GetLine()-1, end.GetLine()-1
+	return Range{
+		Start: Position{Line: startLine, Character: utf16Column(doc.line(startLine), start.GetColumn())},
+		End:   Position{Line: endLine, Character: utf16Column(doc.line(endLine), end.GetColumn())},
+	}
+}
+
+// analyze the program with the word at position, return nil if there is no word
+func (s *Server) analyze(doc *document, pos Position, inspectType string) (*yakgrpc.LanguageServerAnalyzerResult, Range, error) {
+	line := doc.line(pos.Line)
+	column := runeColumn(line, pos.Character)
+	start, end := wordRange(line, column, inspectType == yakgrpc.COMPLETION)
+	wordRng := Range{
+		Start: Position{Line: pos.Line, Character: utf16Column(line, start)},
+		End:   Position{Line: pos.Line, Character: utf16Column(line, end)},
+	}
+	if start == end && inspectType != yakgrpc.COMPLETION {
+		return nil, wordRng, nil
+	}
+
+	res, err := yakgrpc.LanguageServerAnalyzeProgram(doc.text, inspectType, s.scriptType, grpcRange(pos.Line, start, end, string([]rune(line)[start:end])))
+	if err != nil {
+		return nil, wordRng, err
+	}
+	if res.Value == nil {
+		return nil, wordRng, nil
+	}
+	return res, wordRng, nil
+}
+
+func (s *Server) completion(ctx context.Context, params json.RawMessage) (any, error) {
+	var p TextDocumentPositionParams
+	if err := unmarshalParams(params, &p); err != nil {
+		return nil, err
+	}
+	doc, err := s.document(p.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &CompletionList{Items: make([]*CompletionItem, 0)}
+	res, _, err := s.analyze(doc, p.Position, yakgrpc.COMPLETION)
+	if err != nil || res == nil {
+		return list, nil
+	}
+	for _, suggestion := range yakgrpc.OnCompletion(res.Program, res.Word, res.ContainPoint, res.Range, res.Value) {
+		item := &CompletionItem{
+			Label:            suggestion.Label,
+			Kind:             completionKinds[suggestion.Kind],
+			InsertText:       suggestion.InsertText,
+			InsertTextFormat: InsertTextFormatSnippet,
+		}
+		if item.Kind == 0 {
+			item.Kind = CompletionKindText
+		}
+		if suggestion.Description != "" {
+			item.Documentation = &MarkupContent{Kind: "markdown", Value: suggestion.Description}
+		}
+		list.Items = append(list.Items, item)
+	}
+	return list, nil
+}
+
+func (s *Server) hover(ctx context.Context, params json.RawMessage) (any, error) {
+	var p TextDocumentPositionParams
+	if err := unmarshalParams(params, &p); err != nil {
+		return nil, err
+	}
+	doc, err := s.document(p.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	res, wordRng, err := s.analyze(doc, p.Position, yakgrpc.HOVER)
+	if err != nil || res == nil {
+		return nil, err
+	}
+	for _, suggestion := range yakgrpc.OnHover(res.Program, res.Word, res.ContainPoint, res.Range, res.Value) {
+		if suggestion.Label == "" {
+			continue
+		}
+		return &Hover{
+			Contents: MarkupContent{Kind: "markdown", Value: suggestion.Label},
+			Range:    &wordRng,
+		}, nil
+	}
+	return nil, nil
+}
+
+// callContext find the unclosed `(` before the cursor, return the position of callee end and the index of argument
+func callContext(doc *document, pos Position) (Position, int, bool) {
+	text := doc.text[:doc.offset(pos)]
+	depth, commas := 0, 0
+	for i := len(text) - 1; i >= 0; i-- {
+		switch text[i] {
+		case ')', ']', '}':
+			depth++
+		case '[', '{':
+			if depth == 0 {
+				return Position{}, 0, false
+			}
+			depth--
+		case '(':
+			if depth == 0 {
+				callee := strings.TrimRight(text[:i], " \t")
+				return doc.position(len(callee)), commas, true
+			}
+			depth--
+		case ',':
+			if depth == 0 {
+				commas++
+			}
+		case '\n':
+			// arguments of multi-line call is allowed, but a blank line stop searching
+			if i > 0 && text[i-1] == '\n' {
+				return Position{}, 0, false
+			}
+		}
+	}
+	return Position{}, 0, false
+}
+
+// signatureParameters split `func name(a, b ...any) ret` to parameters
+func signatureParameters(signature string) []*ParameterInformation {
+	start := strings.Index(signature, "(")
+	if start < 0 {
+		return nil
+	}
+	depth, last := 0, start+1
+	var ret []*ParameterInformation
+	for i := start; i < len(signature); i++ {
+		switch signature[i] {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+			if depth == 0 {
+				if param := strings.TrimSpace(signature[last:i]); param != "" {
+					ret = append(ret, &ParameterInformation{Label: param})
+				}
+				return ret
+			}
+		case ',':
+			if depth == 1 {
+				ret = append(ret, &ParameterInformation{Label: strings.TrimSpace(signature[last:i])})
+				last = i + 1
+			}
+		}
+	}
+	return ret
+}
+
+func (s *Server) signatureHelp(ctx context.Context, params json.RawMessage) (any, error) {
+	var p TextDocumentPositionParams
+	if err := unmarshalParams(params, &p); err != nil {
+		return nil, err
+	}
+	doc, err := s.document(p.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	calleePos, argIndex, ok := callContext(doc, p.Position)
+	if !ok {
+		return nil, nil
+	}
+	// the callee word end at `(`, move back into the word
+	if calleePos.Character == 0 {
+		return nil, nil
+	}
+	calleePos.Character--
+	res, _, err := s.analyze(doc, calleePos, yakgrpc.SIGNATURE)
+	if err != nil || res == nil {
+		return nil, err
+	}
+
+	help := &SignatureHelp{Signatures: make([]*SignatureInformation, 0)}
+	for _, suggestion := range yakgrpc.OnSignature(res.Program, res.Word, res.ContainPoint, res.Range, res.Value) {
+		signature := &SignatureInformation{
+			Label:      suggestion.Label,
+			Parameters: signatureParameters(suggestion.Label),
+		}
+		if suggestion.Description != "" {
+			signature.Documentation = &MarkupContent{Kind: "markdown", Value: suggestion.Description}
+		}
+		help.Signatures = append(help.Signatures, signature)
+	}
+	if len(help.Signatures) == 0 {
+		return nil, nil
+	}
+	// the variadic parameter accept all the rest arguments
+	if params := help.Signatures[0].Parameters; len(params) > 0 && argIndex >= len(params) {
+		argIndex = len(params) - 1
+	}
+	help.ActiveParameter = argIndex
+	return help, nil
+}
+
+func (s *Server) find(doc *document, pos Position, inspectType string) ([]memedit.RangeIf, error) {
+	res, _, err := s.analyze(doc, pos, inspectType)
+	if err != nil || res == nil {
+		return nil, err
+	}
+	if inspectType == yakgrpc.REFERENCES {
+		return yakgrpc.OnFindReferences(res.Program, res.Word, res.ContainPoint, res.Range, res.Value)
+	}
+	return yakgrpc.OnFindDefinition(res.Program, res.Word, res.ContainPoint, res.Range, res.Value)
+}
+
+func (s *Server) definition(ctx context.Context, params json.RawMessage) (any, error) {
+	var p TextDocumentPositionParams
+	if err := unmarshalParams(params, &p); err != nil {
+		return nil, err
+	}
+	doc, err := s.document(p.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	ranges, err := s.find(doc, p.Position, yakgrpc.DEFINITION)
+	if err != nil {
+		return nil, err
+	}
+	locations := make([]*Location, 0, len(ranges))
+	for _, rng := range ranges {
+		locations = append(locations, &Location{URI: doc.uri, Range: toRange(doc, rng)})
+	}
+	return locations, nil
+}
+
+func (s *Server) references(ctx context.Context, params json.RawMessage) (any, error) {
+	var p ReferenceParams
+	if err := unmarshalParams(params, &p); err != nil {
+		return nil, err
+	}
+	doc, err := s.document(p.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	ranges, err := s.find(doc, p.Position, yakgrpc.REFERENCES)
+	if err != nil {
+		return nil, err
+	}
+	declarations := make(map[Range]struct{})
+	if !p.Context.IncludeDeclaration {
+		definitions, err := s.find(doc, p.Position, yakgrpc.DEFINITION)
+		if err != nil {
+			return nil, err
+		}
+		for _, rng := range definitions {
+			declarations[toRange(doc, rng)] = struct{}{}
+		}
+	}
+
+	locations := make([]*Location, 0, len(ranges))
+	visited := make(map[Range]struct{})
+	for _, rng := range ranges {
+		lspRange := toRange(doc, rng)
+		if _, ok := declarations[lspRange]; ok {
+			continue
+		}
+		if _, ok := visited[lspRange]; ok {
+			continue
+		}
+		visited[lspRange] = struct{}{}
+		locations = append(locations, &Location{URI: doc.uri, Range: lspRange})
+	}
+	return locations, nil
+}
+
+func (s *Server) rename(ctx context.Context, params json.RawMessage) (any, error) {
+	var p RenameParams
+	if err := unmarshalParams(params, &p); err != nil {
+		return nil, err
+	}
+	if !identifierRegexp.MatchString(p.NewName) {
+		return nil, newResponseError(CodeInvalidParams, "invalid identifier: %v", p.NewName)
+	}
+	doc, err := s.document(p.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	definitions, err := s.find(doc, p.Position, yakgrpc.DEFINITION)
+	if err != nil {
+		return nil, err
+	}
+	if len(definitions) == 0 {
+		return nil, newResponseError(CodeRequestFailed, "no symbol defined in document can be renamed")
+	}
+	ranges, err := s.find(doc, p.Position, yakgrpc.REFERENCES)
+	if err != nil {
+		return nil, err
+	}
+
+	edits := make([]*TextEdit, 0, len(ranges))
+	visited := make(map[Range]struct{})
+	for _, rng := range append(definitions, ranges...) {
+		lspRange := toRange(doc, rng)
+		if _, ok := visited[lspRange]; ok {
+			continue
+		}
+		visited[lspRange] = struct{}{}
+		edits = append(edits, &TextEdit{Range: lspRange, NewText: p.NewName})
+	}
+	return &WorkspaceEdit{Changes: map[string][]*TextEdit{doc.uri: edits}}, nil
+}
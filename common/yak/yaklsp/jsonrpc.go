@@ -0,0 +1,141 @@
+package yaklsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/yaklang/yaklang/common/utils"
+)
+
+// json-rpc 2.0 error codes used by language server protocol
+const (
+	CodeParseError           = -32700
+	CodeInvalidRequest       = -32600
+	CodeMethodNotFound       = -32601
+	CodeInvalidParams        = -32602
+	CodeInternalError        = -32603
+	CodeServerNotInitialized = -32002
+	CodeRequestFailed        = -32803
+)
+
+// ResponseError is the error object of json-rpc response
+type ResponseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("jsonrpc error(%d): %s", e.Code, e.Message)
+}
+
+func newResponseError(code int, format string, args ...any) *ResponseError {
+	return &ResponseError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// message is the incoming request or notification, notification has no id
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+func (m *message) isNotification() bool {
+	return len(m.ID) == 0
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result"`
+}
+
+type errorResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Error   *ResponseError  `json:"error"`
+}
+
+type notification struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  any    `json:"params"`
+}
+
+// conn read and write json-rpc messages with `Content-Length` header framing (base protocol of LSP)
+type conn struct {
+	reader *textproto.Reader
+	writer io.Writer
+	mu     sync.Mutex
+}
+
+func newConn(r io.Reader, w io.Writer) *conn {
+	return &conn{
+		reader: textproto.NewReader(bufio.NewReader(r)),
+		writer: w,
+	}
+}
+
+// readBody read the body of next message
+func (c *conn) readBody() ([]byte, error) {
+	header, err := c.reader.ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+	length, err := strconv.Atoi(strings.TrimSpace(header.Get("Content-Length")))
+	if err != nil || length < 0 {
+		return nil, utils.Errorf("invalid Content-Length header: %v", header.Get("Content-Length"))
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(c.reader.R, body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+func (c *conn) read() (*message, error) {
+	body, err := c.readBody()
+	if err != nil {
+		return nil, err
+	}
+	var msg message
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, newResponseError(CodeParseError, "parse message failed: %v", err)
+	}
+	return &msg, nil
+}
+
+func (c *conn) write(v any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := fmt.Fprintf(c.writer, "Content-Length: %d\r\n\r\n", len(body)); err != nil {
+		return err
+	}
+	_, err = c.writer.Write(body)
+	return err
+}
+
+func (c *conn) reply(id json.RawMessage, result any, err error) error {
+	if err == nil {
+		return c.write(&response{JSONRPC: "2.0", ID: id, Result: result})
+	}
+	respErr, ok := err.(*ResponseError)
+	if !ok {
+		respErr = &ResponseError{Code: CodeRequestFailed, Message: err.Error()}
+	}
+	return c.write(&errorResponse{JSONRPC: "2.0", ID: id, Error: respErr})
+}
+
+func (c *conn) notify(method string, params any) error {
+	return c.write(&notification{JSONRPC: "2.0", Method: method, Params: params})
+}
@@ -0,0 +1,270 @@
+package yaklsp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/yaklang/yaklang/common/consts"
+	"github.com/yaklang/yaklang/common/log"
+	"github.com/yaklang/yaklang/common/utils"
+)
+
+type handlerFunc func(ctx context.Context, params json.RawMessage) (any, error)
+
+// Server is the yaklang language server, it speaks language server protocol over a reader and writer (stdio),
+// the analysis reuse the yakgrpc language server functions and static analyzer.
+type Server struct {
+	conn       *conn
+	scriptType string
+	docs       *documentStore
+	handlers   map[string]handlerFunc
+
+	mu          sync.Mutex
+	roots       []string
+	initialized bool
+	shutdown    bool
+
+	symbolCache sync.Map // uri -> *symbolCacheItem
+}
+
+// NewServer create a language server, scriptType is the plugin type of scripts (yak, mitm, port-scan, codec ...)
+func NewServer(scriptType string) *Server {
+	if scriptType == "" {
+		scriptType = "yak"
+	}
+	s := &Server{
+		scriptType: scriptType,
+		docs:       newDocumentStore(),
+	}
+	s.handlers = map[string]handlerFunc{
+		"initialize":  s.initialize,
+		"initialized": s.nop,
+		"shutdown":    s.onShutdown,
+
+		"textDocument/didOpen":   s.didOpen,
+		"textDocument/didChange": s.didChange,
+		"textDocument/didClose":  s.didClose,
+		"textDocument/didSave":   s.didSave,
+
+		"textDocument/completion":     s.completion,
+		"textDocument/hover":          s.hover,
+		"textDocument/signatureHelp":  s.signatureHelp,
+		"textDocument/definition":     s.definition,
+		"textDocument/references":     s.references,
+		"textDocument/rename":         s.rename,
+		"textDocument/documentSymbol": s.documentSymbol,
+		"workspace/symbol":            s.workspaceSymbol,
+	}
+	return s
+}
+
+// Serve read requests from in and write responses to out until the client send `exit` or the input is closed
+func (s *Server) Serve(ctx context.Context, in io.Reader, out io.Writer) error {
+	s.conn = newConn(in, out)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		msg, err := s.conn.read()
+		if err != nil {
+			var respErr *ResponseError
+			if errors.As(err, &respErr) {
+				s.conn.reply(json.RawMessage("null"), nil, respErr)
+				continue
+			}
+			if errors.Is(err, io.EOF) && s.isShutdown() {
+				return nil
+			}
+			return err
+		}
+
+		if msg.Method == "exit" {
+			if s.isShutdown() {
+				return nil
+			}
+			return utils.Error("language server exit without shutdown")
+		}
+		s.handle(ctx, msg)
+	}
+}
+
+func (s *Server) handle(ctx context.Context, msg *message) {
+	reply := func(result any, err error) {
+		if msg.isNotification() {
+			if err != nil {
+				log.Debugf("language server handle %v failed: %v", msg.Method, err)
+			}
+			return
+		}
+		if err := s.conn.reply(msg.ID, result, err); err != nil {
+			log.Errorf("language server reply %v failed: %v", msg.Method, err)
+		}
+	}
+
+	handler, ok := s.handlers[msg.Method]
+	if !ok {
+		// `$/` prefixed notification and unknown notification can be ignored
+		reply(nil, newResponseError(CodeMethodNotFound, "method not found: %v", msg.Method))
+		return
+	}
+	if msg.Method != "initialize" && !s.isInitialized() {
+		reply(nil, newResponseError(CodeServerNotInitialized, "server not initialized"))
+		return
+	}
+
+	var (
+		result any
+		err    error
+	)
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Errorf("language server handle %v panic: %v", msg.Method, r)
+				err = newResponseError(CodeInternalError, "%v", r)
+			}
+		}()
+		result, err = handler(ctx, msg.Params)
+	}()
+	reply(result, err)
+}
+
+func unmarshalParams(raw json.RawMessage, v any) error {
+	if err := json.Unmarshal(raw, v); err != nil {
+		return newResponseError(CodeInvalidParams, "invalid params: %v", err)
+	}
+	return nil
+}
+
+func (s *Server) isInitialized() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.initialized
+}
+
+func (s *Server) isShutdown() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.shutdown
+}
+
+func (s *Server) nop(ctx context.Context, params json.RawMessage) (any, error) {
+	return nil, nil
+}
+
+func (s *Server) initialize(ctx context.Context, params json.RawMessage) (any, error) {
+	var p InitializeParams
+	if err := unmarshalParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, folder := range p.WorkspaceFolders {
+		if path := uriToPath(folder.URI); path != "" {
+			s.roots = append(s.roots, path)
+		}
+	}
+	if len(s.roots) == 0 {
+		if path := uriToPath(p.RootURI); path != "" {
+			s.roots = append(s.roots, path)
+		} else if p.RootPath != "" {
+			s.roots = append(s.roots, p.RootPath)
+		}
+	}
+	if p.InitializationOptions != nil && p.InitializationOptions.ScriptType != "" {
+		s.scriptType = p.InitializationOptions.ScriptType
+	}
+	s.initialized = true
+
+	return &InitializeResult{
+		Capabilities: ServerCapabilities{
+			TextDocumentSync: TextDocumentSyncOptions{
+				OpenClose: true,
+				Change:    TextDocumentSyncIncremental,
+				Save:      true,
+			},
+			CompletionProvider: &CompletionOptions{TriggerCharacters: []string{"."}},
+			HoverProvider:      true,
+			SignatureHelpProvider: &SignatureHelpOptions{
+				TriggerCharacters:   []string{"("},
+				RetriggerCharacters: []string{","},
+			},
+			DefinitionProvider:      true,
+			ReferencesProvider:      true,
+			RenameProvider:          true,
+			DocumentSymbolProvider:  true,
+			WorkspaceSymbolProvider: true,
+		},
+		ServerInfo: &ServerInfo{Name: "yak-lsp", Version: consts.GetYakVersion()},
+	}, nil
+}
+
+func (s *Server) onShutdown(ctx context.Context, params json.RawMessage) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.shutdown = true
+	return nil, nil
+}
+
+func (s *Server) didOpen(ctx context.Context, params json.RawMessage) (any, error) {
+	var p DidOpenTextDocumentParams
+	if err := unmarshalParams(params, &p); err != nil {
+		return nil, err
+	}
+	doc := s.docs.open(p.TextDocument)
+	go s.publishDiagnostics(doc)
+	return nil, nil
+}
+
+func (s *Server) didChange(ctx context.Context, params json.RawMessage) (any, error) {
+	var p DidChangeTextDocumentParams
+	if err := unmarshalParams(params, &p); err != nil {
+		return nil, err
+	}
+	doc := s.docs.change(p.TextDocument.URI, p.TextDocument.Version, p.ContentChanges)
+	go s.publishDiagnostics(doc)
+	return nil, nil
+}
+
+func (s *Server) didClose(ctx context.Context, params json.RawMessage) (any, error) {
+	var p DidCloseTextDocumentParams
+	if err := unmarshalParams(params, &p); err != nil {
+		return nil, err
+	}
+	s.docs.close(p.TextDocument.URI)
+	return nil, s.conn.notify("textDocument/publishDiagnostics", &PublishDiagnosticsParams{
+		URI:         p.TextDocument.URI,
+		Diagnostics: []*Diagnostic{},
+	})
+}
+
+func (s *Server) didSave(ctx context.Context, params json.RawMessage) (any, error) {
+	var p DidSaveTextDocumentParams
+	if err := unmarshalParams(params, &p); err != nil {
+		return nil, err
+	}
+	doc, ok := s.docs.get(p.TextDocument.URI)
+	if !ok {
+		return nil, nil
+	}
+	if p.Text != nil && *p.Text != doc.text {
+		doc = s.docs.change(doc.uri, doc.version, []TextDocumentContentChangeEvent{{Text: *p.Text}})
+	}
+	go s.publishDiagnostics(doc)
+	return nil, nil
+}
+
+// document return the opened document, or an error response for request
+func (s *Server) document(uri string) (*document, error) {
+	doc, ok := s.docs.get(uri)
+	if !ok {
+		return nil, newResponseError(CodeInvalidParams, "document not opened: %v", uri)
+	}
+	return doc, nil
+}
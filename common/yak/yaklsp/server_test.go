@@ -0,0 +1,282 @@
+package yaklsp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+type testMessage struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *ResponseError  `json:"error"`
+}
+
+type testClient struct {
+	t             *testing.T
+	conn          *conn
+	id            int
+	done          chan error
+	notifications chan *testMessage
+	responses     chan *testMessage
+}
+
+func newTestClient(t *testing.T, root string) *testClient {
+	serverIn, clientOut := io.Pipe()
+	clientIn, serverOut := io.Pipe()
+	c := &testClient{
+		t:             t,
+		conn:          newConn(clientIn, clientOut),
+		done:          make(chan error, 1),
+		notifications: make(chan *testMessage, 64),
+		responses:     make(chan *testMessage, 64),
+	}
+	go func() {
+		c.done <- NewServer("yak").Serve(context.Background(), serverIn, serverOut)
+		serverOut.Close()
+	}()
+	go func() {
+		for {
+			body, err := c.conn.readBody()
+			if err != nil {
+				return
+			}
+			var msg testMessage
+			if err := json.Unmarshal(body, &msg); err != nil {
+				return
+			}
+			if msg.Method != "" {
+				c.notifications <- &msg
+			} else {
+				c.responses <- &msg
+			}
+		}
+	}()
+	t.Cleanup(func() {
+		clientOut.Close()
+	})
+
+	var result InitializeResult
+	require.Nil(t, c.call("initialize", map[string]any{"rootUri": pathToURI(root)}, &result))
+	require.True(t, result.Capabilities.RenameProvider)
+	require.Equal(t, TextDocumentSyncIncremental, result.Capabilities.TextDocumentSync.Change)
+	c.notify("initialized", map[string]any{})
+	return c
+}
+
+func (c *testClient) call(method string, params any, result any) *ResponseError {
+	c.t.Helper()
+	c.id++
+	require.NoError(c.t, c.conn.write(map[string]any{
+		"jsonrpc": "2.0", "id": c.id, "method": method, "params": params,
+	}))
+	select {
+	case msg := <-c.responses:
+		require.Equal(c.t, string(lo.Must(json.Marshal(c.id))), string(msg.ID))
+		if msg.Error != nil {
+			return msg.Error
+		}
+		if result != nil {
+			require.NoError(c.t, json.Unmarshal(msg.Result, result))
+		}
+		return nil
+	case <-time.After(time.Minute):
+		c.t.Fatalf("wait %v response timeout", method)
+	}
+	return nil
+}
+
+func (c *testClient) notify(method string, params any) {
+	c.t.Helper()
+	require.NoError(c.t, c.conn.notify(method, params))
+}
+
+func (c *testClient) waitDiagnostics(uri string, version int) *PublishDiagnosticsParams {
+	c.t.Helper()
+	timeout := time.After(time.Minute)
+	for {
+		select {
+		case msg := <-c.notifications:
+			if msg.Method != "textDocument/publishDiagnostics" {
+				continue
+			}
+			var params PublishDiagnosticsParams
+			require.NoError(c.t, json.Unmarshal(msg.Params, &params))
+			if params.URI == uri && params.Version == version {
+				return &params
+			}
+		case <-timeout:
+			c.t.Fatalf("wait diagnostics of %v timeout", uri)
+			return nil
+		}
+	}
+}
+
+func position(uri string, line, character int) map[string]any {
+	return map[string]any{
+		"textDocument": map[string]any{"uri": uri},
+		"position":     Position{Line: line, Character: character},
+	}
+}
+
+const testCode = `a = 1
+b = a + 1
+func add(x, y) {
+    return x + y
+}
+c = add(a, b)
+s = str.ToUpper("abc")
+println(c, s, undefinedVariable)
+`
+
+func TestLanguageServer(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "other.yak"), []byte("func otherAdd(a) { return a }\n"), 0o644))
+	c := newTestClient(t, root)
+
+	uri := pathToURI(filepath.Join(root, "main.yak"))
+	c.notify("textDocument/didOpen", map[string]any{
+		"textDocument": TextDocumentItem{URI: uri, LanguageID: "yak", Version: 1, Text: testCode},
+	})
+
+	t.Run("diagnostics", func(t *testing.T) {
+		diagnostics := c.waitDiagnostics(uri, 1)
+		require.True(t, lo.ContainsBy(diagnostics.Diagnostics, func(d *Diagnostic) bool {
+			return strings.Contains(d.Message, "undefinedVariable") && d.Range.Start.Line == 7
+		}), "diagnostics: %v", string(lo.Must(json.Marshal(diagnostics))))
+	})
+
+	t.Run("hover", func(t *testing.T) {
+		var hover Hover
+		require.Nil(t, c.call("textDocument/hover", position(uri, 6, 10), &hover))
+		require.Contains(t, hover.Contents.Value, "ToUpper")
+		require.Equal(t, Range{Start: Position{Line: 6, Character: 4}, End: Position{Line: 6, Character: 15}}, *hover.Range)
+	})
+
+	t.Run("definition", func(t *testing.T) {
+		var locations []*Location
+		require.Nil(t, c.call("textDocument/definition", position(uri, 5, 8), &locations))
+		require.Len(t, locations, 1)
+		require.Equal(t, uri, locations[0].URI)
+		require.Equal(t, Range{End: Position{Character: 1}}, locations[0].Range)
+	})
+
+	t.Run("references", func(t *testing.T) {
+		var locations []*Location
+		params := position(uri, 0, 0)
+		params["context"] = map[string]any{"includeDeclaration": true}
+		require.Nil(t, c.call("textDocument/references", params, &locations))
+		lines := lo.Map(locations, func(l *Location, _ int) int { return l.Range.Start.Line })
+		require.Equal(t, []int{0, 1, 5}, lines)
+
+		params["context"] = map[string]any{"includeDeclaration": false}
+		require.Nil(t, c.call("textDocument/references", params, &locations))
+		require.Len(t, locations, 2)
+	})
+
+	t.Run("rename", func(t *testing.T) {
+		var edit WorkspaceEdit
+		params := position(uri, 1, 4)
+		params["newName"] = "first"
+		require.Nil(t, c.call("textDocument/rename", params, &edit))
+		require.Len(t, edit.Changes[uri], 3)
+		for _, e := range edit.Changes[uri] {
+			require.Equal(t, "first", e.NewText)
+			require.Equal(t, 1, e.Range.End.Character-e.Range.Start.Character)
+		}
+
+		params["newName"] = "1a"
+		require.NotNil(t, c.call("textDocument/rename", params, nil))
+	})
+
+	t.Run("signature help", func(t *testing.T) {
+		var help SignatureHelp
+		require.Nil(t, c.call("textDocument/signatureHelp", position(uri, 5, 11), &help))
+		require.Len(t, help.Signatures, 1)
+		require.Len(t, help.Signatures[0].Parameters, 2)
+		require.Equal(t, 1, help.ActiveParameter)
+	})
+
+	t.Run("symbols", func(t *testing.T) {
+		var symbols []*SymbolInformation
+		require.Nil(t, c.call("textDocument/documentSymbol", map[string]any{"textDocument": map[string]any{"uri": uri}}, &symbols))
+		names := lo.Map(symbols, func(s *SymbolInformation, _ int) string { return s.Name })
+		require.Subset(t, names, []string{"a", "b", "add", "c", "s"})
+		require.NotContains(t, names, "x")
+
+		require.Nil(t, c.call("workspace/symbol", map[string]any{"query": "add"}, &symbols))
+		names = lo.Map(symbols, func(s *SymbolInformation, _ int) string { return s.Name })
+		require.ElementsMatch(t, []string{"add", "otherAdd"}, names)
+	})
+
+	t.Run("incremental change and completion", func(t *testing.T) {
+		c.notify("textDocument/didChange", map[string]any{
+			"textDocument": VersionedTextDocumentIdentifier{URI: uri, Version: 2},
+			"contentChanges": []TextDocumentContentChangeEvent{
+				{Range: &Range{Start: Position{Line: 8}, End: Position{Line: 8}}, Text: "d = str."},
+			},
+		})
+		c.waitDiagnostics(uri, 2)
+
+		var list CompletionList
+		require.Nil(t, c.call("textDocument/completion", position(uri, 8, 8), &list))
+		item, ok := lo.Find(list.Items, func(item *CompletionItem) bool { return item.Label == "ToUpper" })
+		require.True(t, ok)
+		require.Equal(t, CompletionKindFunction, item.Kind)
+	})
+
+	require.Nil(t, c.call("shutdown", nil, nil))
+	c.notify("exit", nil)
+	select {
+	case err := <-c.done:
+		require.NoError(t, err)
+	case <-time.After(time.Minute):
+		t.Fatal("wait server exit timeout")
+	}
+}
+
+func TestLanguageServer_NotInitialized(t *testing.T) {
+	serverIn, clientOut := io.Pipe()
+	clientIn, serverOut := io.Pipe()
+	go NewServer("").Serve(context.Background(), serverIn, serverOut)
+	defer clientOut.Close()
+
+	c := newConn(clientIn, clientOut)
+	go c.write(map[string]any{"jsonrpc": "2.0", "id": 1, "method": "textDocument/hover", "params": map[string]any{}})
+	body, err := c.readBody()
+	require.NoError(t, err)
+	var msg testMessage
+	require.NoError(t, json.Unmarshal(body, &msg))
+	require.NotNil(t, msg.Error)
+	require.Equal(t, CodeServerNotInitialized, msg.Error.Code)
+}
+
+func TestDocument_Position(t *testing.T) {
+	doc := &document{text: "a = \"中文😀\"\nb = a"}
+	// 😀 is two utf-16 code units
+	require.Equal(t, 8, runeColumn(doc.line(0), 9))
+	require.Equal(t, 9, utf16Column(doc.line(0), 8))
+	require.Equal(t, len("a = \"中文😀\""), doc.offset(Position{Line: 0, Character: 10}))
+	require.Equal(t, Position{Line: 1, Character: 5}, doc.position(len(doc.text)))
+
+	doc.applyChange(TextDocumentContentChangeEvent{
+		Range: &Range{Start: Position{Line: 1, Character: 4}, End: Position{Line: 1, Character: 5}},
+		Text:  "len(a)",
+	})
+	require.Equal(t, "a = \"中文😀\"\nb = len(a)", doc.text)
+
+	start, end := wordRange("x = poc.HTTP(a)", 10, false)
+	require.Equal(t, "poc.HTTP", "x = poc.HTTP(a)"[start:end])
+	start, end = wordRange("x = str.", 8, true)
+	require.Equal(t, "str.", "x = str."[start:end])
+}
@@ -0,0 +1,175 @@
+package yaklsp
+
+import (
+	"context"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/yaklang/yaklang/common/log"
+	"github.com/yaklang/yaklang/common/utils/memedit"
+	"github.com/yaklang/yaklang/common/yak/ssa"
+	"github.com/yaklang/yaklang/common/yak/ssaapi"
+	pta "github.com/yaklang/yaklang/common/yak/static_analyzer"
+)
+
+// maxWorkspaceFiles limit the yak files scanned for workspace symbols
+const maxWorkspaceFiles = 2000
+
+type symbolCacheItem struct {
+	text    string
+	symbols []*SymbolInformation
+}
+
+// symbols return the top level functions and variables of document, cached by document text
+func (s *Server) symbols(doc *document) []*SymbolInformation {
+	if item, ok := s.symbolCache.Load(doc.uri); ok && item.(*symbolCacheItem).text == doc.text {
+		return item.(*symbolCacheItem).symbols
+	}
+
+	opts := append(pta.GetPluginSSAOpt(s.scriptType), ssaapi.WithIgnoreSyntaxError(true))
+	prog, err := ssaapi.Parse(doc.text, opts...)
+	if err != nil {
+		log.Debugf("parse %v for symbols failed: %v", doc.uri, err)
+		return nil
+	}
+
+	type symbolKey struct {
+		name  string
+		start Position
+	}
+	symbols := make([]*SymbolInformation, 0)
+	visited := make(map[symbolKey]struct{})
+	for _, offset := range prog.Program.OffsetSortedSlice {
+		item := prog.Program.OffsetMap[offset]
+		variable := item.GetVariable()
+		if variable == nil || variable.DefRange == nil {
+			continue
+		}
+		name := variable.GetName()
+		if name == "" || strings.ContainsAny(name, ".#") {
+			continue
+		}
+		kind, ok := symbolKind(item.GetValue())
+		if !ok {
+			continue
+		}
+		rng := toRange(doc, memedit.NewRange(variable.DefRange.GetStart(), variable.DefRange.GetEnd()))
+		key := symbolKey{name: name, start: rng.Start}
+		if _, ok := visited[key]; ok {
+			continue
+		}
+		visited[key] = struct{}{}
+		symbols = append(symbols, &SymbolInformation{
+			Name:     name,
+			Kind:     kind,
+			Location: Location{URI: doc.uri, Range: rng},
+		})
+	}
+	sort.SliceStable(symbols, func(i, j int) bool {
+		a, b := symbols[i].Location.Range.Start, symbols[j].Location.Range.Start
+		return a.Line < b.Line || (a.Line == b.Line && a.Character < b.Character)
+	})
+
+	s.symbolCache.Store(doc.uri, &symbolCacheItem{text: doc.text, symbols: symbols})
+	return symbols
+}
+
+// symbolKind only the symbols in main function are reported, the locals in function are skipped
+func symbolKind(value ssa.Value) (SymbolKind, bool) {
+	if value == nil {
+		return 0, false
+	}
+	if fun, ok := ssa.ToFunction(value); ok {
+		if parent := fun.GetParent(); parent != nil && !parent.IsMain() {
+			return 0, false
+		}
+		return SymbolKindFunction, true
+	}
+	if fun := value.GetFunc(); fun != nil && !fun.IsMain() {
+		return 0, false
+	}
+	return SymbolKindVariable, true
+}
+
+func (s *Server) documentSymbol(ctx context.Context, params json.RawMessage) (any, error) {
+	var p DocumentSymbolParams
+	if err := unmarshalParams(params, &p); err != nil {
+		return nil, err
+	}
+	doc, err := s.document(p.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+	return s.symbols(doc), nil
+}
+
+// workspaceDocuments return the opened documents and the yak files in workspace folders
+func (s *Server) workspaceDocuments() []*document {
+	docs := s.docs.all()
+	opened := make(map[string]struct{}, len(docs))
+	for _, doc := range docs {
+		opened[doc.uri] = struct{}{}
+	}
+
+	s.mu.Lock()
+	roots := append([]string{}, s.roots...)
+	s.mu.Unlock()
+
+	count := 0
+	for _, root := range roots {
+		filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if d.IsDir() {
+				if path != root && (strings.HasPrefix(d.Name(), ".") || d.Name() == "node_modules") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if filepath.Ext(path) != ".yak" {
+				return nil
+			}
+			if count >= maxWorkspaceFiles {
+				return filepath.SkipAll
+			}
+			count++
+			uri := pathToURI(path)
+			if _, ok := opened[uri]; ok {
+				return nil
+			}
+			raw, err := os.ReadFile(path)
+			if err != nil {
+				return nil
+			}
+			docs = append(docs, &document{uri: uri, text: string(raw)})
+			return nil
+		})
+	}
+	return docs
+}
+
+func (s *Server) workspaceSymbol(ctx context.Context, params json.RawMessage) (any, error) {
+	var p WorkspaceSymbolParams
+	if err := unmarshalParams(params, &p); err != nil {
+		return nil, err
+	}
+	query := strings.ToLower(p.Query)
+
+	ret := make([]*SymbolInformation, 0)
+	for _, doc := range s.workspaceDocuments() {
+		if ctx.Err() != nil {
+			break
+		}
+		for _, symbol := range s.symbols(doc) {
+			if strings.Contains(strings.ToLower(symbol.Name), query) {
+				ret = append(ret, symbol)
+			}
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,132 @@
+package yakmod
+
+import (
+	"os"
+	"path/filepath"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/yaklang/yaklang/common/utils"
+)
+
+// ModFileName is the manifest of yak module, the syntax is same as go.mod (module / require / replace)
+//
+//	module github.com/org/plugin
+//
+//	require github.com/org/lib v1.2.0
+//
+//	replace github.com/org/other => ../other
+const ModFileName = "yak.mod"
+
+type File struct {
+	*modfile.File
+	// Path is the absolute path of yak.mod
+	Path string
+}
+
+// ParseFile parse the yak.mod file
+func ParseFile(path string) (*File, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := modfile.Parse(path, raw, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &File{File: f, Path: path}, nil
+}
+
+// FindFile find yak.mod in dir and its parent directories, return nil if not found
+func FindFile(dir string) (*File, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		path := filepath.Join(dir, ModFileName)
+		if utils.IsFile(path) {
+			return ParseFile(path)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// InitFile create yak.mod in dir with module path
+func InitFile(dir string, modulePath string) (*File, error) {
+	path, err := filepath.Abs(filepath.Join(dir, ModFileName))
+	if err != nil {
+		return nil, err
+	}
+	if utils.IsFile(path) {
+		return nil, utils.Errorf("%v already exists", path)
+	}
+	if modulePath == "" {
+		return nil, utils.Error("module path is empty")
+	}
+	f := &File{File: new(modfile.File), Path: path}
+	if err := f.AddModuleStmt(modulePath); err != nil {
+		return nil, err
+	}
+	return f, f.Save()
+}
+
+// Dir return the root directory of module
+func (f *File) Dir() string {
+	return filepath.Dir(f.Path)
+}
+
+// ModulePath return the path declared by module statement
+func (f *File) ModulePath() string {
+	if f.Module == nil {
+		return ""
+	}
+	return f.Module.Mod.Path
+}
+
+// RequiredVersion return the version of module in require statements
+func (f *File) RequiredVersion(module string) (string, bool) {
+	for _, req := range f.Require {
+		if req.Mod.Path == module {
+			return req.Mod.Version, true
+		}
+	}
+	return "", false
+}
+
+// ReplacedDir return the local directory which replace the module
+func (f *File) ReplacedDir(module, version string) (string, bool) {
+	for _, rep := range f.Replace {
+		if rep.Old.Path != module || (rep.Old.Version != "" && rep.Old.Version != version) {
+			continue
+		}
+		// only local directory replacement is supported
+		if rep.New.Version != "" {
+			continue
+		}
+		dir := filepath.FromSlash(rep.New.Path)
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(f.Dir(), dir)
+		}
+		return dir, true
+	}
+	return "", false
+}
+
+// Save format and write the yak.mod
+func (f *File) Save() error {
+	f.Cleanup()
+	raw, err := f.Format()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(f.Path, raw, 0o644)
+}
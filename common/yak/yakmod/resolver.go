@@ -0,0 +1,300 @@
+package yakmod
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
+	"golang.org/x/mod/semver"
+
+	"github.com/yaklang/yaklang/common/consts"
+	"github.com/yaklang/yaklang/common/utils"
+)
+
+const (
+	// VendorDir is the directory of vendored modules in module root
+	VendorDir         = "vendor"
+	vendorModulesFile = "modules.txt"
+
+	// ModCacheEnv set the cache directory of downloaded modules
+	ModCacheEnv = "YAK_MODCACHE"
+	// ModRegistryEnv set the local registry directories (separated by os.PathListSeparator),
+	// module is stored as `<registry>/<module>@<version>` or `<registry>/<module>/<version>`
+	ModRegistryEnv = "YAK_MODREGISTRY"
+)
+
+// CacheDir return the module cache directory, default is `yakit-projects/yakmod`
+func CacheDir() string {
+	if dir := os.Getenv(ModCacheEnv); dir != "" {
+		return dir
+	}
+	return filepath.Join(consts.GetDefaultYakitBaseDir(), "yakmod")
+}
+
+// Registries return the local registry directories
+func Registries() []string {
+	return lo.Compact(filepath.SplitList(os.Getenv(ModRegistryEnv)))
+}
+
+// IsModulePath report whether the path is a module import path like
+// `github.com/org/lib@v1.2.0/utils.yak`, the first element must be a domain
+func IsModulePath(p string) bool {
+	if p == "" || strings.Contains(p, "\\") || path.IsAbs(p) || filepath.IsAbs(p) {
+		return false
+	}
+	elems := strings.Split(p, "/")
+	if len(elems) < 2 {
+		return false
+	}
+	first := strings.Split(elems[0], "@")[0]
+	if !strings.Contains(first, ".") || strings.HasPrefix(first, ".") || strings.HasSuffix(first, ".yak") {
+		return false
+	}
+	for _, elem := range elems {
+		if elem == "" || elem == "." || elem == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+// ImportPath is the parsed module import path
+type ImportPath struct {
+	Module  string
+	Version string
+	// SubPath is the file or directory in module, empty means the module root
+	SubPath string
+}
+
+func (i *ImportPath) String() string {
+	ret := i.Module
+	if i.Version != "" {
+		ret += "@" + i.Version
+	}
+	if i.SubPath != "" {
+		ret += "/" + i.SubPath
+	}
+	return ret
+}
+
+// ParseImportPath parse the import path with explicit version: `<module>@<version>[/<subpath>]`,
+// the version is empty if not specified
+func ParseImportPath(p string) (*ImportPath, error) {
+	if !IsModulePath(p) {
+		return nil, utils.Errorf("invalid module import path: %v", p)
+	}
+	before, after, ok := strings.Cut(p, "@")
+	if !ok {
+		return &ImportPath{Module: p}, nil
+	}
+	version, sub, _ := strings.Cut(after, "/")
+	if !semver.IsValid(version) {
+		return nil, utils.Errorf("invalid version %v in module import path: %v", version, p)
+	}
+	return &ImportPath{Module: before, Version: version, SubPath: sub}, nil
+}
+
+// Resolver resolve the module import path to local yak files
+type Resolver struct {
+	mod        *File
+	cacheDir   string
+	registries []string
+}
+
+// NewResolver create resolver for the code in dir, yak.mod is found in dir or its parent directories
+func NewResolver(dir string) (*Resolver, error) {
+	mod, err := FindFile(dir)
+	if err != nil {
+		return nil, err
+	}
+	return NewResolverWithModFile(mod), nil
+}
+
+// NewResolverWithModFile create resolver with yak.mod, mod can be nil
+func NewResolverWithModFile(mod *File) *Resolver {
+	return &Resolver{
+		mod:        mod,
+		cacheDir:   CacheDir(),
+		registries: Registries(),
+	}
+}
+
+// ModFile return the yak.mod used by resolver, may be nil
+func (r *Resolver) ModFile() *File {
+	return r.mod
+}
+
+// Split split the import path into module, version and subpath, the version and module
+// of import path without explicit version is decided by the require and replace statements of yak.mod
+func (r *Resolver) Split(p string) (*ImportPath, error) {
+	ip, err := ParseImportPath(p)
+	if err != nil {
+		return nil, err
+	}
+	if ip.Version != "" {
+		return ip, nil
+	}
+	if r.mod == nil {
+		return nil, utils.Errorf("%v not found, can not resolve module version of %v, import with version like <module>@v1.0.0", ModFileName, p)
+	}
+
+	versions := make(map[string]string)
+	for _, req := range r.mod.Require {
+		versions[req.Mod.Path] = req.Mod.Version
+	}
+	for _, rep := range r.mod.Replace {
+		if _, ok := versions[rep.Old.Path]; !ok {
+			versions[rep.Old.Path] = rep.Old.Version
+		}
+	}
+	module, ok := matchModule(lo.Keys(versions), p)
+	if !ok {
+		return nil, utils.Errorf("module of %v is not required in %v, import with version like <module>@v1.0.0 and run `yak mod tidy`", p, r.mod.Path)
+	}
+	version := versions[module]
+	return &ImportPath{
+		Module:  module,
+		Version: version,
+		SubPath: strings.TrimPrefix(strings.TrimPrefix(p, module), "/"),
+	}, nil
+}
+
+// matchModule return the longest module path which is the prefix of import path
+func matchModule(modules []string, p string) (string, bool) {
+	var ret string
+	for _, m := range modules {
+		if (p == m || strings.HasPrefix(p, m+"/")) && len(m) > len(ret) {
+			ret = m
+		}
+	}
+	return ret, ret != ""
+}
+
+// Resolve return the yak files of import path, the module root or directory in module is expanded to
+// all the yak files (except *_test.yak) in it
+func (r *Resolver) Resolve(p string) ([]string, error) {
+	ip, err := r.Split(p)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := r.ModuleDir(ip.Module, ip.Version)
+	if err != nil {
+		return nil, err
+	}
+	files, err := yakFiles(filepath.Join(dir, filepath.FromSlash(ip.SubPath)))
+	if err != nil {
+		return nil, utils.Errorf("resolve %v failed: %v", p, err)
+	}
+	return files, nil
+}
+
+// ModuleDir find the module directory in replace, vendor, cache and registries in order
+func (r *Resolver) ModuleDir(module, version string) (string, error) {
+	if r.mod != nil {
+		if dir, ok := r.mod.ReplacedDir(module, version); ok {
+			return dir, nil
+		}
+		if vendored, ok := readVendorModules(r.mod.Dir())[module]; ok && vendored == version {
+			return filepath.Join(r.mod.Dir(), VendorDir, filepath.FromSlash(module)), nil
+		}
+	}
+	if dir := r.cachedDir(module, version); utils.IsDir(dir) {
+		return dir, nil
+	}
+	if dir, ok := r.registryDir(module, version); ok {
+		return dir, nil
+	}
+	return "", utils.Errorf("module %v@%v not found in cache %v or registries %v", module, version, r.cacheDir, r.registries)
+}
+
+// Download copy the module from registry into cache if it is not replaced or cached, return the module directory
+func (r *Resolver) Download(module, version string) (string, error) {
+	if r.mod != nil {
+		if dir, ok := r.mod.ReplacedDir(module, version); ok {
+			return dir, nil
+		}
+	}
+	dir := r.cachedDir(module, version)
+	if utils.IsDir(dir) {
+		return dir, nil
+	}
+	src, ok := r.registryDir(module, version)
+	if !ok {
+		return "", utils.Errorf("module %v@%v not found in registries %v", module, version, r.registries)
+	}
+	if err := copyModule(src, dir); err != nil {
+		os.RemoveAll(dir)
+		return "", utils.Errorf("copy module %v@%v to cache failed: %v", module, version, err)
+	}
+	return dir, nil
+}
+
+func (r *Resolver) cachedDir(module, version string) string {
+	return filepath.Join(r.cacheDir, filepath.FromSlash(module)+"@"+version)
+}
+
+func (r *Resolver) registryDir(module, version string) (string, bool) {
+	for _, registry := range r.registries {
+		for _, dir := range []string{
+			filepath.Join(registry, filepath.FromSlash(module)+"@"+version),
+			filepath.Join(registry, filepath.FromSlash(module), version),
+		} {
+			if utils.IsDir(dir) {
+				return dir, true
+			}
+		}
+	}
+	return "", false
+}
+
+// yakFiles return the yak file, or the yak files in directory, `.yak` extension can be omitted
+func yakFiles(target string) ([]string, error) {
+	if utils.IsFile(target) {
+		return []string{target}, nil
+	}
+	if !utils.IsDir(target) {
+		if utils.IsFile(target + ".yak") {
+			return []string{target + ".yak"}, nil
+		}
+		return nil, utils.Errorf("%v not found", target)
+	}
+	entries, err := os.ReadDir(target)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".yak") || strings.HasSuffix(name, "_test.yak") {
+			continue
+		}
+		files = append(files, filepath.Join(target, name))
+	}
+	if len(files) == 0 {
+		return nil, utils.Errorf("no yak file in %v", target)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// readVendorModules read `vendor/modules.txt`, the line is `# <module> <version>`
+func readVendorModules(root string) map[string]string {
+	ret := make(map[string]string)
+	fp, err := os.Open(filepath.Join(root, VendorDir, vendorModulesFile))
+	if err != nil {
+		return ret
+	}
+	defer fp.Close()
+	scanner := bufio.NewScanner(fp)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 3 && fields[0] == "#" {
+			ret[fields[1]] = fields[2]
+		}
+	}
+	return ret
+}
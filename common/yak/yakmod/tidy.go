@@ -0,0 +1,261 @@
+package yakmod
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+
+	"github.com/yaklang/yaklang/common/log"
+	"github.com/yaklang/yaklang/common/utils"
+)
+
+var importRegexp = regexp.MustCompile("(?m)^\\s*import\\s+(\"(?:[^\"\\\\]|\\\\.)*\"|`[^`]*`)")
+
+// ScanImports return the module import paths in `import` statements of yak code
+func ScanImports(code string) []string {
+	var ret []string
+	for _, match := range importRegexp.FindAllStringSubmatch(code, -1) {
+		p, err := strconv.Unquote(match[1])
+		if err != nil || !IsModulePath(p) {
+			continue
+		}
+		ret = append(ret, p)
+	}
+	return ret
+}
+
+// scanDirImports scan the module imports in yak files of dir recursively, vendor and hidden directories are skipped
+func scanDirImports(dir string) ([]string, error) {
+	var ret []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != dir && (d.Name() == VendorDir || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(d.Name(), ".yak") {
+			return nil
+		}
+		raw, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		ret = append(ret, ScanImports(string(raw))...)
+		return nil
+	})
+	return ret, err
+}
+
+// Tidy make yak.mod match the imports of module: the missing requirements (include the requirements of
+// dependencies) are added with the max version, the unused are removed, and the modules are downloaded
+// from registries into cache.
+func Tidy(dir string) (*File, error) {
+	mod, err := FindFile(dir)
+	if err != nil {
+		return nil, err
+	}
+	if mod == nil {
+		return nil, utils.Errorf("%v not found in %v or its parent directories, run `yak mod init` first", ModFileName, dir)
+	}
+	r := NewResolverWithModFile(mod)
+
+	required := make(map[string]string)
+	direct := make(map[string]bool)
+	require := func(ip *ImportPath, isDirect bool) {
+		if ip.Module == mod.ModulePath() {
+			return
+		}
+		if ip.Version == "" {
+			// replaced module without version
+			ip.Version = "v0.0.0"
+		}
+		if isDirect {
+			direct[ip.Module] = true
+		}
+		if current, ok := required[ip.Module]; ok && semver.Compare(current, ip.Version) >= 0 {
+			return
+		}
+		required[ip.Module] = ip.Version
+	}
+
+	imports, err := scanDirImports(mod.Dir())
+	if err != nil {
+		return nil, err
+	}
+	// the import without version is matched with the versioned imports first, then yak.mod
+	var unversioned []string
+	for _, p := range imports {
+		ip, err := ParseImportPath(p)
+		if err != nil {
+			return nil, err
+		}
+		if ip.Version == "" {
+			unversioned = append(unversioned, p)
+			continue
+		}
+		require(ip, true)
+	}
+	for _, p := range unversioned {
+		if m, ok := matchModule(lo.Keys(required), p); ok {
+			direct[m] = true
+			continue
+		}
+		ip, err := r.Split(p)
+		if err != nil {
+			return nil, err
+		}
+		require(ip, true)
+	}
+
+	// resolve the requirements of dependencies until no version changes
+	visited := make(map[string]bool)
+	for {
+		var pending []*ImportPath
+		for m, v := range required {
+			if key := m + "@" + v; !visited[key] {
+				visited[key] = true
+				pending = append(pending, &ImportPath{Module: m, Version: v})
+			}
+		}
+		if len(pending) == 0 {
+			break
+		}
+		for _, ip := range pending {
+			moduleDir, err := r.Download(ip.Module, ip.Version)
+			if err != nil {
+				return nil, err
+			}
+			deps, err := dependencies(moduleDir)
+			if err != nil {
+				return nil, utils.Errorf("load dependencies of %v failed: %v", ip, err)
+			}
+			for _, dep := range deps {
+				require(dep, false)
+			}
+		}
+	}
+
+	modules := make([]string, 0, len(required))
+	for m := range required {
+		modules = append(modules, m)
+	}
+	sort.Strings(modules)
+	reqs := make([]*modfile.Require, 0, len(modules))
+	for _, m := range modules {
+		reqs = append(reqs, &modfile.Require{
+			Mod:      module.Version{Path: m, Version: required[m]},
+			Indirect: !direct[m],
+		})
+	}
+	mod.SetRequire(reqs)
+	mod.SortBlocks()
+	if err := mod.Save(); err != nil {
+		return nil, err
+	}
+	return mod, nil
+}
+
+// dependencies return the requirements in yak.mod of module, and the versioned imports of module code
+func dependencies(moduleDir string) ([]*ImportPath, error) {
+	var ret []*ImportPath
+	if path := filepath.Join(moduleDir, ModFileName); utils.IsFile(path) {
+		f, err := ParseFile(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, req := range f.Require {
+			ret = append(ret, &ImportPath{Module: req.Mod.Path, Version: req.Mod.Version})
+		}
+	}
+	imports, err := scanDirImports(moduleDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range imports {
+		// the import without version is decided by the yak.mod of module
+		if ip, err := ParseImportPath(p); err == nil && ip.Version != "" {
+			ret = append(ret, ip)
+		}
+	}
+	return ret, nil
+}
+
+// Vendor copy the required modules into the vendor directory of module root, the vendored modules
+// are used before cache when resolving imports
+func Vendor(dir string) (*File, error) {
+	mod, err := FindFile(dir)
+	if err != nil {
+		return nil, err
+	}
+	if mod == nil {
+		return nil, utils.Errorf("%v not found in %v or its parent directories, run `yak mod init` first", ModFileName, dir)
+	}
+	r := NewResolverWithModFile(mod)
+
+	vendorDir := filepath.Join(mod.Dir(), VendorDir)
+	if err := os.RemoveAll(vendorDir); err != nil {
+		return nil, err
+	}
+	if len(mod.Require) == 0 {
+		return mod, nil
+	}
+
+	var modules strings.Builder
+	for _, req := range mod.Require {
+		src, err := r.Download(req.Mod.Path, req.Mod.Version)
+		if err != nil {
+			return nil, err
+		}
+		// yak.mod of module is not copied, the imports in vendor are resolved by the main yak.mod
+		if err := copyModule(src, filepath.Join(vendorDir, filepath.FromSlash(req.Mod.Path)), ModFileName); err != nil {
+			return nil, utils.Errorf("vendor %v@%v failed: %v", req.Mod.Path, req.Mod.Version, err)
+		}
+		fmt.Fprintf(&modules, "# %s %s\n", req.Mod.Path, req.Mod.Version)
+		log.Infof("vendor %v@%v", req.Mod.Path, req.Mod.Version)
+	}
+	if err := os.WriteFile(filepath.Join(vendorDir, vendorModulesFile), []byte(modules.String()), 0o644); err != nil {
+		return nil, err
+	}
+	return mod, nil
+}
+
+// copyModule copy the module directory, hidden and vendor directories, test files and excluded files are skipped
+func copyModule(src, dst string, exclude ...string) error {
+	return filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != src && (d.Name() == VendorDir || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(filepath.Join(dst, rel), 0o755)
+		}
+		if strings.HasSuffix(d.Name(), "_test.yak") || utils.StringArrayContains(exclude, rel) {
+			return nil
+		}
+		raw, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(dst, rel), raw, 0o644)
+	})
+}
@@ -0,0 +1,122 @@
+package yakmod
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+func TestIsModulePath(t *testing.T) {
+	for p, expected := range map[string]bool{
+		"github.com/org/lib":                   true,
+		"github.com/org/lib@v1.2.0":            true,
+		"github.com/org/lib@v1.2.0/a/util.yak": true,
+		"lib.yak":                              false,
+		"utils/a.yak":                          false,
+		"./github.com/org/lib":                 false,
+		"/github.com/org/lib":                  false,
+		"github.com/org/../lib":                false,
+		"a.yak/b.yak":                          false,
+	} {
+		require.Equal(t, expected, IsModulePath(p), p)
+	}
+
+	ip, err := ParseImportPath("github.com/org/lib@v1.2.0/a/util.yak")
+	require.NoError(t, err)
+	require.Equal(t, &ImportPath{Module: "github.com/org/lib", Version: "v1.2.0", SubPath: "a/util.yak"}, ip)
+	require.Equal(t, "github.com/org/lib@v1.2.0/a/util.yak", ip.String())
+
+	_, err = ParseImportPath("github.com/org/lib@latest")
+	require.Error(t, err)
+}
+
+func TestResolveAndTidy(t *testing.T) {
+	root := t.TempDir()
+	registry := filepath.Join(root, "registry")
+	t.Setenv(ModCacheEnv, filepath.Join(root, "cache"))
+	t.Setenv(ModRegistryEnv, registry)
+
+	writeFiles(t, registry, map[string]string{
+		"github.com/org/lib@v1.2.0/yak.mod":      "module github.com/org/lib\n\nrequire github.com/org/dep v0.1.0\n",
+		"github.com/org/lib@v1.2.0/lib.yak":      `import "github.com/org/dep"`,
+		"github.com/org/lib@v1.2.0/util/a.yak":   `a = 1`,
+		"github.com/org/lib@v1.2.0/lib_test.yak": `die(1)`,
+		// layout of `<registry>/<module>/<version>`
+		"github.com/org/dep/v0.1.0/dep.yak": `dep = 1`,
+		"github.com/org/dep/v0.2.0/dep.yak": `dep = 2`,
+	})
+
+	app := filepath.Join(root, "app")
+	writeFiles(t, app, map[string]string{
+		"main.yak":     "import \"github.com/org/lib@v1.2.0\"\nimport `github.com/org/lib/util/a.yak`\n",
+		"sub/b.yak":    `import "github.com/org/dep@v0.2.0/dep.yak"`,
+		"local/l.yak":  `l = 1`,
+		"vendor/x.yak": `import "github.com/org/unused@v1.0.0"`,
+	})
+	_, err := InitFile(app, "example.com/app")
+	require.NoError(t, err)
+	_, err = InitFile(app, "example.com/app")
+	require.Error(t, err)
+
+	mod, err := Tidy(filepath.Join(app, "sub"))
+	require.NoError(t, err)
+	raw, err := mod.Format()
+	require.NoError(t, err)
+	require.Equal(t, `module example.com/app
+
+require (
+	github.com/org/dep v0.2.0
+	github.com/org/lib v1.2.0
+)
+`, string(raw))
+	// downloaded into cache
+	require.DirExists(t, filepath.Join(root, "cache", "github.com", "org", "lib@v1.2.0"))
+	require.NoFileExists(t, filepath.Join(root, "cache", "github.com", "org", "lib@v1.2.0", "lib_test.yak"))
+
+	r, err := NewResolver(filepath.Join(app, "sub"))
+	require.NoError(t, err)
+	files, err := r.Resolve("github.com/org/lib")
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join(root, "cache", "github.com", "org", "lib@v1.2.0", "lib.yak")}, files)
+	files, err = r.Resolve("github.com/org/lib/util/a")
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join(root, "cache", "github.com", "org", "lib@v1.2.0", "util", "a.yak")}, files)
+	_, err = r.Resolve("github.com/org/other/a.yak")
+	require.ErrorContains(t, err, "is not required")
+
+	// replace with local directory
+	mod.AddReplace("github.com/org/dep", "", "./local", "")
+	require.NoError(t, mod.Save())
+	r, err = NewResolver(app)
+	require.NoError(t, err)
+	files, err = r.Resolve("github.com/org/dep")
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join(app, "local", "l.yak")}, files)
+	require.NoError(t, mod.DropReplace("github.com/org/dep", ""))
+	require.NoError(t, mod.Save())
+
+	// vendor is used before cache
+	_, err = Vendor(app)
+	require.NoError(t, err)
+	require.FileExists(t, filepath.Join(app, "vendor", "github.com", "org", "dep", "dep.yak"))
+	require.NoFileExists(t, filepath.Join(app, "vendor", "github.com", "org", "lib", ModFileName))
+	require.NoError(t, os.RemoveAll(filepath.Join(root, "cache")))
+	t.Setenv(ModRegistryEnv, "")
+	r, err = NewResolver(app)
+	require.NoError(t, err)
+	files, err = r.Resolve("github.com/org/lib/util")
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join(app, "vendor", "github.com", "org", "lib", "util", "a.yak")}, files)
+	_, err = r.Resolve("github.com/org/lib@v1.0.0")
+	require.ErrorContains(t, err, "not found")
+}
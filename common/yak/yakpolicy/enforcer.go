@@ -0,0 +1,194 @@
+package yakpolicy
+
+import (
+	"context"
+	"fmt"
+	"runtime/metrics"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/yaklang/yaklang/common/yak/antlr4yak"
+	"github.com/yaklang/yaklang/common/yak/antlr4yak/yakvm"
+)
+
+const (
+	// 每执行多少个 opcode 检查一次时间与内存
+	timeCheckInterval   = 256
+	memoryCheckInterval = 4096
+)
+
+// Enforcer 在一个 yak 引擎上执行策略，实现 yakvm.Guard
+type Enforcer struct {
+	policy *Policy
+	roots  []string
+	hosts  []*hostPattern
+
+	mu       sync.Mutex
+	active   int
+	start    atomic.Int64
+	opcodes  atomic.Uint64
+	baseHeap atomic.Uint64
+	deadline time.Time
+	// 超时后 context 会自行释放，不需要调用
+	cancel context.CancelFunc
+
+	last      atomic.Pointer[yakvm.Code]
+	violation atomic.Pointer[Violation]
+}
+
+// Apply 在引擎上执行策略：设置虚拟机的 guard，并替换引擎中的库函数，需要在执行代码之前调用
+func Apply(engine *antlr4yak.Engine, p *Policy) *Enforcer {
+	e := &Enforcer{policy: p}
+	if p.FileRoots != nil {
+		e.roots = make([]string, 0, len(p.FileRoots))
+		for _, root := range p.FileRoots {
+			if resolved, err := resolvePath(root); err == nil {
+				e.roots = append(e.roots, resolved)
+			}
+		}
+	}
+	for _, host := range p.Hosts {
+		e.hosts = append(e.hosts, parseHostPattern(host))
+	}
+	e.start.Store(time.Now().UnixNano())
+	engine.GetVM().SetGuard(e)
+	e.hookLibraries(engine)
+	return e
+}
+
+// FromEngine 返回引擎上的 Enforcer，没有设置策略时返回 nil
+func FromEngine(engine *antlr4yak.Engine) *Enforcer {
+	if engine == nil {
+		return nil
+	}
+	e, _ := engine.GetVM().GetGuard().(*Enforcer)
+	return e
+}
+
+func (e *Enforcer) Policy() *Policy {
+	return e.policy
+}
+
+// Violation 返回第一次违反策略的记录
+func (e *Enforcer) Violation() *Violation {
+	if e == nil {
+		return nil
+	}
+	return e.violation.Load()
+}
+
+// Err 将执行的错误转换为 Violation：违反策略的 panic 在虚拟机中会被转换为字符串，这里返回记录的结构化错误
+func (e *Enforcer) Err(err error) error {
+	if e == nil {
+		return err
+	}
+	if v := e.violation.Load(); v != nil {
+		return v
+	}
+	e.mu.Lock()
+	deadline := e.deadline
+	e.mu.Unlock()
+	if err != nil && !deadline.IsZero() && !time.Now().Before(deadline) {
+		return e.fail(&Violation{Kind: ViolationTimeout, Resource: e.elapsed().String(), Limit: time.Duration(e.policy.MaxWallTime).String()})
+	}
+	return err
+}
+
+// WithDeadline 为执行设置 MaxWallTime，使阻塞在库函数中（不执行 opcode）的脚本也可以超时，
+// 执行结束后 context 不会被取消，脚本启动的异步任务可以继续运行到超时
+func (e *Enforcer) WithDeadline(ctx context.Context) context.Context {
+	if e == nil || e.policy.MaxWallTime <= 0 {
+		return ctx
+	}
+	deadline := time.Now().Add(time.Duration(e.policy.MaxWallTime))
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.deadline = deadline
+	ctx, e.cancel = context.WithDeadline(ctx, deadline)
+	return ctx
+}
+
+func (e *Enforcer) Enter() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.active++
+	if e.active > 1 {
+		return
+	}
+	e.start.Store(time.Now().UnixNano())
+	e.opcodes.Store(0)
+	if e.policy.MaxMemory > 0 {
+		e.baseHeap.Store(heapBytes())
+	}
+}
+
+func (e *Enforcer) Leave() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.active--
+}
+
+func (e *Enforcer) Check(_ *yakvm.Frame, code *yakvm.Code) error {
+	e.last.Store(code)
+	if v := e.violation.Load(); v != nil {
+		return v
+	}
+	p := e.policy
+	n := e.opcodes.Add(1)
+	if p.MaxOpcodes > 0 && n > p.MaxOpcodes {
+		return e.fail(&Violation{Kind: ViolationOpcodes, Resource: fmt.Sprint(n), Limit: fmt.Sprint(p.MaxOpcodes)})
+	}
+	if p.MaxWallTime > 0 && n%timeCheckInterval == 0 {
+		if elapsed := e.elapsed(); elapsed > time.Duration(p.MaxWallTime) {
+			return e.fail(&Violation{Kind: ViolationTimeout, Resource: elapsed.String(), Limit: time.Duration(p.MaxWallTime).String()})
+		}
+	}
+	if p.MaxMemory > 0 && n%memoryCheckInterval == 0 {
+		if used := e.heapGrowth(); used > p.MaxMemory {
+			return e.fail(&Violation{Kind: ViolationMemory, Resource: fmt.Sprint(used), Limit: fmt.Sprint(p.MaxMemory)})
+		}
+	}
+	return nil
+}
+
+func (e *Enforcer) elapsed() time.Duration {
+	return time.Duration(time.Now().UnixNano() - e.start.Load())
+}
+
+func (e *Enforcer) heapGrowth() uint64 {
+	heap, base := heapBytes(), e.baseHeap.Load()
+	if heap < base {
+		return 0
+	}
+	return heap - base
+}
+
+// fail 记录第一次违反策略，返回记录的 Violation
+func (e *Enforcer) fail(v *Violation) *Violation {
+	v.Policy = e.policy.Name
+	if v.Policy == "" {
+		v.Policy = "custom"
+	}
+	if code := e.last.Load(); code != nil {
+		v.Line = code.StartLineNumber
+		if code.SourceCodeFilePath != nil {
+			v.File = *code.SourceCodeFilePath
+		}
+	}
+	if e.violation.CompareAndSwap(nil, v) {
+		return v
+	}
+	return e.violation.Load()
+}
+
+// heapBytes 返回上一次 GC 后进程中存活的堆内存，不包含未回收的垃圾，也不会主动触发 GC；
+// 这是进程级别的统计，同时运行的 MITM 与其他插件分配的内存同样会被计入
+func heapBytes() uint64 {
+	sample := []metrics.Sample{{Name: "/gc/heap/live:bytes"}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return sample[0].Value.Uint64()
+}
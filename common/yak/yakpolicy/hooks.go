@@ -0,0 +1,460 @@
+package yakpolicy
+
+import (
+	"net/http"
+	"path/filepath"
+	"reflect"
+
+	"github.com/samber/lo"
+
+	"github.com/yaklang/yaklang/common/consts"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/utils/lowhttp/http_struct"
+	"github.com/yaklang/yaklang/common/utils/lowhttp/poc"
+	"github.com/yaklang/yaklang/common/yak/antlr4yak"
+)
+
+// resourceFunc 从库函数的参数中取出需要检查的文件路径或主机
+type resourceFunc func(args []any) []string
+
+// all 表示库中的其余所有函数
+const all = "*"
+
+// libraryAccess 描述一个库访问文件与网络的方式
+type libraryAccess struct {
+	// files 与 hosts 为访问文件或发起连接的函数，限制访问时检查它们的路径或主机（包括代理）
+	files map[string]resourceFunc
+	hosts map[string]resourceFunc
+	// fileFunctions 与 networkFunctions 为访问文件或网络但是无法检查的函数，限制访问时禁止调用，all 表示其余所有函数
+	fileFunctions    []string
+	networkFunctions []string
+	// pure 为不访问文件与网络的函数，不受 all 的影响
+	pure []string
+}
+
+// libraries 为所有已知的库，未知的库（以及库中新增的、未分类的函数）在限制文件或网络访问时默认禁止调用；
+// 空字符串为全局函数
+var libraries = map[string]*libraryAccess{
+	"": {files: map[string]resourceFunc{"import": stringArgs(0)}},
+
+	// 不访问文件与网络的库
+	"asserts": {}, "bin": {}, "bufio": {}, "codec": {}, "context": {}, "csrf": {}, "cwe": {}, "dictutil": {},
+	"env": {}, "gzip": {}, "ja3": {}, "java": {}, "js": {}, "json": {}, "judge": {}, "jwt": {}, "log": {},
+	"math": {}, "openapi": {}, "orderedmap": {}, "re": {}, "re2": {}, "regen": {}, "report": {}, "sync": {},
+	"time": {}, "timezone": {}, "twofa": {}, "xhtml": {}, "xml": {}, "xpath": {}, "yaml": {}, "yso": {},
+
+	// 文件
+	"file": {
+		files: map[string]resourceFunc{
+			"Cat": stringArgs(0), "Cp": stringArgs(0, 1), "Create": stringArgs(0), "Dir": stringArgs(0),
+			"IsDir": stringArgs(0), "IsExisted": stringArgs(0), "IsFile": stringArgs(0), "IsLink": stringArgs(0),
+			"Ls": stringArgs(0), "Lstat": stringArgs(0), "Mkdir": stringArgs(0), "MkdirAll": stringArgs(0),
+			"Mv": stringArgs(0, 1), "NewMultiFileLineReader": allStringArgs, "Open": stringArgs(0), "OpenFile": stringArgs(0),
+			"ReadDirInfoInDirectory": stringArgs(0), "ReadFile": stringArgs(0), "ReadFileInfoInDirectory": stringArgs(0),
+			"ReadLines": stringArgs(0), "ReadLinesWithCallback": stringArgs(0), "Remove": stringArgs(0), "Rename": stringArgs(0, 1),
+			"Rm": stringArgs(0), "Save": stringArgs(0), "SaveJson": stringArgs(0), "Stat": stringArgs(0), "TailF": stringArgs(0),
+			"TempFile": tempFileDir, "TempFileName": tempFileDir, "Walk": stringArgs(0),
+		},
+		fileFunctions: []string{all},
+		pure:          []string{"Abs", "Clean", "GetBase", "GetDirPath", "GetExt", "IsAbs", "Join", "Split", "ReadAll"},
+	},
+	"filesys": {
+		files:         map[string]resourceFunc{"Recursive": stringArgs(0), "dir": stringArgs(0)},
+		fileFunctions: []string{all},
+		pure:          []string{"onDirStat", "onFileStat", "onReady", "onStat"},
+	},
+	"io": {
+		files:         map[string]resourceFunc{"ReadFile": stringArgs(0)},
+		fileFunctions: []string{all},
+		pure: []string{
+			"Copy", "CopyN", "LimitReader", "MultiReader", "NopCloser", "Pipe", "ReadAll", "ReadEvery1s",
+			"ReadStable", "TeeReader", "WriteString",
+		},
+	},
+	"zip":   {files: map[string]resourceFunc{"Compress": allStringArgs, "Decompress": stringArgs(0, 1)}, fileFunctions: []string{all}},
+	"mmdb":  {files: map[string]resourceFunc{"Open": stringArgs(0)}, fileFunctions: []string{all}, pure: []string{"QueryIPCity"}},
+	"pprof": {files: map[string]resourceFunc{"cpuProfilePath": stringArgs(0), "memProfilePath": stringArgs(0)}},
+	"har": {
+		files:         map[string]resourceFunc{"Import": stringArgs(0), "Export": stringArgs(0)},
+		fileFunctions: []string{all},
+		pure:          []string{"context", "ids", "keyword", "runtimeID", "sourceType"},
+	},
+	"cli": {
+		// 路径来自命令行参数（包括默认值），无法在调用时检查
+		fileFunctions: []string{"File", "FileNames", "FileOrContent", "LineDict"},
+	},
+	"db": {
+		fileFunctions:    []string{"SavePayloadByFile", "DownloadGeoIP"},
+		networkFunctions: []string{"DownloadGeoIP"},
+	},
+	"yakit": {
+		files:            map[string]resourceFunc{"File": stringArgs(0)},
+		fileFunctions:    []string{"SavePayloadByFile", "UpdateYakitStoreLocal", "UpdateYakitStoreFromGit"},
+		networkFunctions: []string{"NewClient", "UpdateOnlineYakitStore", "UpdateYakitStore", "UpdateYakitStoreFromGit"},
+	},
+	"os": {
+		files: map[string]resourceFunc{
+			"Chdir": stringArgs(0), "Chmod": stringArgs(0), "Chown": stringArgs(0),
+			"Remove": stringArgs(0), "RemoveAll": stringArgs(0), "Rename": stringArgs(0, 1),
+		},
+		hosts: map[string]resourceFunc{
+			"IsRemoteTCPPortOpen": stringArgs(0), "WaitConnect": stringArgs(0), "LookupHost": stringArgs(0), "LookupIP": stringArgs(0),
+		},
+		fileFunctions:    []string{all},
+		networkFunctions: []string{all},
+		pure: []string{
+			"Clearenv", "Environ", "Executable", "Exit", "ExpandEnv", "GetDefaultDNSServers", "GetLocalAddress",
+			"GetLocalIPv4Address", "GetLocalIPv6Address", "GetMachineID", "GetRandomAvailableTCPPort",
+			"GetRandomAvailableUDPPort", "Getegid", "Getenv", "Geteuid", "Getgid", "Getpid", "Getppid", "Getuid",
+			"Getwd", "Hostname", "IsTCPPortAvailable", "IsTCPPortOpen", "IsUDPPortAvailable", "IsUDPPortOpen",
+			"LookupEnv", "Pipe", "Setenv", "TempDir", "Unsetenv",
+		},
+	},
+
+	// 网络
+	"http": {
+		hosts: map[string]resourceFunc{
+			"Get": httpURLTarget(0), "Post": httpURLTarget(0), "Request": httpURLTarget(1),
+			"Do": httpDoTarget, "proxy": allStringArgs, "RequestFaviconHash": stringArgs(0),
+			"RequestToMD5": stringArgs(0), "RequestToMMH3Hash128": stringArgs(0), "RequestToMMH3Hash128x64": stringArgs(0),
+			"RequestToSha1": stringArgs(0), "RequestToSha256": stringArgs(0),
+		},
+		networkFunctions: []string{all},
+		pure: []string{
+			"ExtractFaviconURL", "GetAllBody", "NewRequest", "Raw", "body", "context", "cookie", "dump", "dumphead",
+			"fakeua", "fromPlugin", "header", "json", "noredirect", "params", "postparams", "redirect", "runtimeID",
+			"save", "session", "show", "showhead", "source", "timeout", "ua", "uarand", "useragent",
+		},
+	},
+	"poc": {
+		// 其余函数只处理报文或者是请求选项
+		hosts: map[string]resourceFunc{
+			"HTTP": pocRequestTarget, "HTTPEx": pocRequestTarget, "Websocket": pocRequestTarget,
+			"Get": pocURLTarget(0), "Post": pocURLTarget(0), "Head": pocURLTarget(0), "Delete": pocURLTarget(0),
+			"Options": pocURLTarget(0), "Do": pocURLTarget(1), "proxy": allStringArgs,
+		},
+	},
+	"tcp": {
+		hosts:            map[string]resourceFunc{"Connect": stringArgs(0), "Forward": stringArgs(1), "clientProxy": stringArgs(0)},
+		networkFunctions: []string{all},
+		pure:             []string{"clientLocal", "clientTimeout", "clientTls", "serverCallback", "serverContext", "serverTls"},
+	},
+	"udp": {
+		hosts:            map[string]resourceFunc{"Connect": stringArgs(0)},
+		networkFunctions: []string{all},
+		pure:             []string{"clientLocalAddr", "clientTimeout", "serverCallback", "serverContext", "serverTimeout"},
+	},
+	"tls": {
+		hosts: map[string]resourceFunc{
+			"Inspect": stringArgs(0), "InspectForceHttp1_1": stringArgs(0), "InspectForceHttp2": stringArgs(0),
+		},
+	},
+	"str": {hosts: map[string]resourceFunc{"IsTLSServer": allStringArgs}},
+	"x":   {hosts: map[string]resourceFunc{"WaitConnect": stringArgs(0)}},
+	"risk": {
+		// 反连检查需要访问公网的反连服务器
+		networkFunctions: []string{
+			"CheckDNSLogByToken", "CheckHTTPLogByToken", "CheckICMPTriggerByLength", "CheckRandomTriggerByToken",
+			"CheckServerReachable", "HaveReverseRisk", "NewDNSLogDomain", "NewHTTPLog", "NewPublicReverseHTTPSUrl",
+			"NewPublicReverseHTTPUrl", "NewPublicReverseRMIUrl", "NewRandomPortTrigger",
+		},
+	},
+	"ai": {networkFunctions: []string{all}}, "bot": {networkFunctions: []string{all}},
+	"brute": {networkFunctions: []string{all}}, "crawler": {networkFunctions: []string{all}},
+	"dns": {networkFunctions: []string{all}}, "facades": {networkFunctions: []string{all}},
+	"finscan": {networkFunctions: []string{all}}, "iiop": {networkFunctions: []string{all}},
+	"ldap": {networkFunctions: []string{all}}, "mitm": {networkFunctions: []string{all}},
+	"openai": {networkFunctions: []string{all}}, "ping": {networkFunctions: []string{all}},
+	"rdp": {networkFunctions: []string{all}}, "redis": {networkFunctions: []string{all}},
+	"servicescan": {networkFunctions: []string{all}}, "smb": {networkFunctions: []string{all}},
+	"spacengine": {networkFunctions: []string{all}}, "subdomain": {networkFunctions: []string{all}},
+	"suricata": {networkFunctions: []string{all}}, "synscan": {networkFunctions: []string{all}},
+	"t3": {networkFunctions: []string{all}}, "tools": {networkFunctions: []string{all}},
+	"traceroute": {networkFunctions: []string{all}},
+	"httpserver": {networkFunctions: []string{all}, fileFunctions: []string{"LocalFileSystemServe"}},
+
+	// 同时访问文件与网络：模糊测试标签可以读取文件，浏览器与扫描引擎会读写本地文件
+	"fuzz":      {fileFunctions: []string{all}, networkFunctions: []string{all}},
+	"httpool":   {fileFunctions: []string{all}, networkFunctions: []string{all}},
+	"crawlerx":  {fileFunctions: []string{all}, networkFunctions: []string{all}},
+	"simulator": {fileFunctions: []string{all}, networkFunctions: []string{all}},
+	"rpa":       {fileFunctions: []string{all}, networkFunctions: []string{all}},
+	"nuclei":    {fileFunctions: []string{all}, networkFunctions: []string{all}},
+	"git":       {fileFunctions: []string{all}, networkFunctions: []string{all}},
+	"sca":       {fileFunctions: []string{all}, networkFunctions: []string{all}},
+	"cve":       {fileFunctions: []string{all}, networkFunctions: []string{"Download"}},
+	"dnslog":    {fileFunctions: []string{all}, networkFunctions: []string{all}},
+	"pcapx":     {fileFunctions: []string{all}, networkFunctions: []string{all}},
+	"ssa":       {fileFunctions: []string{all}},
+	"hids":      {fileFunctions: []string{all}},
+}
+
+// escapeLibraries 可以执行命令或在新的引擎中执行代码，绕过文件与网络限制，限制文件或网络访问时禁止使用
+var escapeLibraries = []string{"exec", "dyn", "sandbox", "hook", "systemd"}
+
+// hookLibraries 复制引擎中的库并替换其中的函数，不影响其他引擎
+func (e *Enforcer) hookLibraries(engine *antlr4yak.Engine) {
+	libs := make(map[string]map[string]any)
+	globals := make(map[string]any)
+	for name, value := range engine.GetFntable() {
+		if lib, ok := value.(map[string]any); ok {
+			libs[name] = lib
+		} else if value != nil && reflect.TypeOf(value).Kind() == reflect.Func {
+			globals[name] = value
+		}
+	}
+	for name, lib := range libs {
+		hooked := make(map[string]any, len(lib))
+		for fn, value := range lib {
+			hooked[fn] = e.hookFunction(name, fn, value)
+		}
+		engine.SetVar(name, hooked)
+	}
+	for name, value := range globals {
+		if hooked := e.hookFunction("", name, value); reflect.ValueOf(hooked).Pointer() != reflect.ValueOf(value).Pointer() {
+			engine.SetVar(name, hooked)
+		}
+	}
+}
+
+func (e *Enforcer) hookFunction(lib, fn string, value any) any {
+	if value == nil || reflect.TypeOf(value).Kind() != reflect.Func {
+		return value
+	}
+	p := e.policy
+	qualified := fn
+	if lib != "" {
+		qualified = lib + "." + fn
+	}
+	if kind, denied := e.libraryDenied(lib); denied {
+		return e.deny(value, &Violation{Kind: kind, Function: qualified, Resource: lib})
+	}
+	access, known := libraries[lib]
+	var checks []func(args []any)
+	if p.FileRoots != nil {
+		if !known {
+			return e.deny(value, &Violation{Kind: ViolationFile, Function: qualified, Resource: lib})
+		}
+		paths, denied := access.check(fn, access.files, access.fileFunctions)
+		if denied {
+			return e.deny(value, &Violation{Kind: ViolationFile, Function: qualified, Resource: lib})
+		}
+		if paths != nil {
+			checks = append(checks, func(args []any) {
+				for _, path := range paths(args) {
+					if !e.allowPath(path) {
+						panic(e.fail(&Violation{Kind: ViolationFile, Function: qualified, Resource: path}))
+					}
+				}
+			})
+		}
+	}
+	if p.Hosts != nil {
+		if !known {
+			return e.deny(value, &Violation{Kind: ViolationNetwork, Function: qualified, Resource: lib})
+		}
+		hosts, denied := access.check(fn, access.hosts, access.networkFunctions)
+		if denied {
+			return e.deny(value, &Violation{Kind: ViolationNetwork, Function: qualified, Resource: lib})
+		}
+		if hosts != nil {
+			checks = append(checks, func(args []any) {
+				for _, target := range hosts(args) {
+					if !e.allowHost(target) {
+						panic(e.fail(&Violation{Kind: ViolationNetwork, Function: qualified, Resource: target}))
+					}
+				}
+			})
+		}
+	}
+	if len(checks) == 0 {
+		return value
+	}
+	return wrapFunc(value, func(args []any) {
+		for _, check := range checks {
+			check(args)
+		}
+	})
+}
+
+// check 返回函数需要检查的资源，denied 表示函数访问了无法检查的资源
+func (a *libraryAccess) check(fn string, checked map[string]resourceFunc, unchecked []string) (resource resourceFunc, denied bool) {
+	if f, ok := checked[fn]; ok {
+		return f, false
+	}
+	if lo.Contains(unchecked, fn) {
+		return nil, true
+	}
+	if lo.Contains(unchecked, all) && !lo.Contains(a.pure, fn) {
+		// 访问另一种资源的函数不受 all 影响，例如 os.Remove 不访问网络
+		_, isFile := a.files[fn]
+		_, isHost := a.hosts[fn]
+		return nil, !isFile && !isHost
+	}
+	return nil, false
+}
+
+func (e *Enforcer) libraryDenied(lib string) (ViolationKind, bool) {
+	if lib == "" {
+		return "", false
+	}
+	p := e.policy
+	if len(p.Libraries) > 0 && !lo.Contains(p.Libraries, lib) {
+		return ViolationLibrary, true
+	}
+	if lo.Contains(p.DeniedLibraries, lib) {
+		return ViolationLibrary, true
+	}
+	if (p.FileRoots != nil || p.Hosts != nil) && lo.Contains(escapeLibraries, lib) {
+		return ViolationLibrary, true
+	}
+	return "", false
+}
+
+// deny 返回同类型的函数，调用时违反策略
+func (e *Enforcer) deny(value any, v *Violation) any {
+	return wrapFunc(value, func([]any) {
+		copied := *v
+		panic(e.fail(&copied))
+	})
+}
+
+// wrapFunc 返回与 fn 类型相同的函数，在调用 fn 之前使用展开后的参数调用 before
+func wrapFunc(fn any, before func(args []any)) any {
+	v := reflect.ValueOf(fn)
+	t := v.Type()
+	return reflect.MakeFunc(t, func(in []reflect.Value) []reflect.Value {
+		args := make([]any, 0, len(in))
+		for i, arg := range in {
+			if t.IsVariadic() && i == len(in)-1 {
+				for j := 0; j < arg.Len(); j++ {
+					args = append(args, arg.Index(j).Interface())
+				}
+				continue
+			}
+			args = append(args, arg.Interface())
+		}
+		before(args)
+		if t.IsVariadic() {
+			return v.CallSlice(in)
+		}
+		return v.Call(in)
+	}).Interface()
+}
+
+func stringArgs(index ...int) resourceFunc {
+	return func(args []any) []string {
+		var ret []string
+		for _, i := range index {
+			if i < len(args) {
+				if s, ok := args[i].(string); ok {
+					ret = append(ret, s)
+				}
+			}
+		}
+		return ret
+	}
+}
+
+func allStringArgs(args []any) []string {
+	var ret []string
+	for _, arg := range args {
+		if s, ok := arg.(string); ok {
+			ret = append(ret, s)
+		}
+	}
+	return ret
+}
+
+// tempFileDir 是 file.TempFile 创建文件的目录，参数会被拼接为目录
+func tempFileDir(args []any) []string {
+	parts := allStringArgs(args)
+	if len(parts) == 0 {
+		return []string{consts.GetDefaultYakitBaseTempDir()}
+	}
+	return []string{filepath.Join(parts...)}
+}
+
+func pocOptions(args []any) []poc.PocConfigOption {
+	var opts []poc.PocConfigOption
+	for _, arg := range args {
+		if opt, ok := arg.(poc.PocConfigOption); ok {
+			opts = append(opts, opt)
+		}
+	}
+	return opts
+}
+
+func pocURLTarget(index int) resourceFunc {
+	return func(args []any) []string {
+		if index >= len(args) {
+			return nil
+		}
+		return targetOf(poc.URLTarget(utils.InterfaceToString(args[index]), pocOptions(args[index+1:])...))
+	}
+}
+
+func pocRequestTarget(args []any) []string {
+	if len(args) == 0 {
+		return nil
+	}
+	return targetOf(poc.RequestTarget(args[0], pocOptions(args[1:])...))
+}
+
+// httpOptions 将 http 库的请求选项转换为 poc 选项
+func httpOptions(args []any) []poc.PocConfigOption {
+	config := http_struct.NewHTTPConfig()
+	for _, arg := range args {
+		if opt, ok := arg.(http_struct.HttpOption); ok {
+			opt(config)
+		}
+	}
+	return pocOptions(config.PocOpts)
+}
+
+func httpURLTarget(index int) resourceFunc {
+	return func(args []any) []string {
+		if index >= len(args) {
+			return nil
+		}
+		return targetOf(poc.URLTarget(utils.InterfaceToString(args[index]), httpOptions(args[index+1:])...))
+	}
+}
+
+func httpDoTarget(args []any) []string {
+	if len(args) == 0 {
+		return nil
+	}
+	var (
+		req  *http.Request
+		opts []poc.PocConfigOption
+	)
+	switch ret := args[0].(type) {
+	case *http.Request:
+		req = ret
+	case http.Request:
+		req = &ret
+	case *http_struct.YakHttpRequest:
+		req = ret.Request
+		if ret.Config != nil {
+			opts = pocOptions(ret.Config.PocOpts)
+		}
+	case http_struct.YakHttpRequest:
+		req = ret.Request
+		if ret.Config != nil {
+			opts = pocOptions(ret.Config.PocOpts)
+		}
+	}
+	if req == nil || req.URL == nil {
+		return nil
+	}
+	return targetOf(poc.URLTarget(req.URL.String(), opts...))
+}
+
+// targetOf 返回解析出的主机，解析失败时库函数同样无法发起请求，不需要检查
+func targetOf(host string, _ int, err error) []string {
+	if err != nil {
+		return nil
+	}
+	return []string{host}
+}
@@ -0,0 +1,116 @@
+package yakpolicy
+
+import (
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// resolvePath 返回绝对路径，并解析其中已经存在的部分的符号链接，避免通过链接访问允许目录以外的文件
+func resolvePath(path string) (string, error) {
+	abs, err := filepath.Abs(os.ExpandEnv(path))
+	if err != nil {
+		return "", err
+	}
+	var rest []string
+	for dir := abs; ; {
+		if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+			return filepath.Join(append([]string{resolved}, rest...)...), nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return abs, nil
+		}
+		rest = append([]string{filepath.Base(dir)}, rest...)
+		dir = parent
+	}
+}
+
+func (e *Enforcer) allowPath(path string) bool {
+	if e.policy.FileRoots == nil {
+		return true
+	}
+	resolved, err := resolvePath(path)
+	if err != nil {
+		return false
+	}
+	for _, root := range e.roots {
+		if resolved == root {
+			return true
+		}
+		prefix := root
+		if !strings.HasSuffix(prefix, string(filepath.Separator)) {
+			prefix += string(filepath.Separator)
+		}
+		if strings.HasPrefix(resolved, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+type hostPattern struct {
+	host   string
+	suffix string
+	ipNet  *net.IPNet
+}
+
+func parseHostPattern(s string) *hostPattern {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if _, ipNet, err := net.ParseCIDR(s); err == nil {
+		return &hostPattern{ipNet: ipNet}
+	}
+	if strings.HasPrefix(s, "*.") {
+		return &hostPattern{suffix: s[1:]}
+	}
+	return &hostPattern{host: normalizeHost(s)}
+}
+
+func (p *hostPattern) match(host string) bool {
+	switch {
+	case p.ipNet != nil:
+		ip := net.ParseIP(host)
+		return ip != nil && p.ipNet.Contains(ip)
+	case p.suffix != "":
+		return strings.HasSuffix(host, p.suffix)
+	default:
+		if p.host == host {
+			return true
+		}
+		// 允许列表中的 IP 与目标的不同写法（例如 IPv6 的缩写）
+		a, b := net.ParseIP(p.host), net.ParseIP(host)
+		return a != nil && b != nil && a.Equal(b)
+	}
+}
+
+// normalizeHost 从 url、host:port 或者 host 中取出小写的主机名
+func normalizeHost(target string) string {
+	target = strings.ToLower(strings.TrimSpace(target))
+	if strings.Contains(target, "://") {
+		if u, err := url.Parse(target); err == nil {
+			target = u.Host
+		}
+	}
+	if host, _, err := net.SplitHostPort(target); err == nil {
+		target = host
+	}
+	return strings.TrimSuffix(strings.Trim(target, "[]"), ".")
+}
+
+func (e *Enforcer) allowHost(target string) bool {
+	if e.policy.Hosts == nil {
+		return true
+	}
+	host := normalizeHost(target)
+	if host == "" {
+		return false
+	}
+	for _, p := range e.hosts {
+		if p.match(host) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package yakpolicy
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yaklang/yaklang/common/consts"
+	"github.com/yaklang/yaklang/common/utils"
+)
+
+const (
+	// PolicyUnrestricted 不做任何限制，与不设置策略相同
+	PolicyUnrestricted = "unrestricted"
+	// PolicyRestricted 可以为插件商店下载的插件选择：禁止执行命令与动态加载代码，只能读写 yakit 临时目录，网络不受限制
+	PolicyRestricted = "restricted"
+	// PolicyIsolated 只能使用数据处理相关的库，禁止访问文件与网络，并限制资源用量
+	PolicyIsolated = "isolated"
+)
+
+// Policy 是声明式的 yak 脚本能力策略，通过虚拟机（opcode、内存与时间）与库函数 hook（库、文件与网络）执行
+type Policy struct {
+	Name string `json:"name,omitempty"`
+	// Libraries 为允许使用的库，为空时不限制；DeniedLibraries 为禁止使用的库
+	Libraries       []string `json:"libraries,omitempty"`
+	DeniedLibraries []string `json:"denied_libraries,omitempty"`
+	// FileRoots 为允许访问的目录，支持环境变量；nil 表示不限制，空列表表示禁止访问文件
+	FileRoots []string `json:"file_roots"`
+	// Hosts 为允许连接的主机，支持域名、*.example.com、IP 与 CIDR，域名不会解析后再匹配；
+	// nil 表示不限制，空列表表示禁止访问网络
+	Hosts []string `json:"hosts"`
+	// MaxMemory 为一次执行中进程存活堆内存增长的上限（字节）。Go 无法统计单个脚本的内存，
+	// 这是进程级别的近似值：同时运行的 MITM 或其他插件分配的内存也会被计入，适合作为防止内存耗尽的兜底限制
+	MaxMemory uint64 `json:"max_memory,omitempty"`
+	// MaxOpcodes 与 MaxWallTime 为一次执行的 opcode 数量与时长上限，
+	// 一次执行指从外部进入虚拟机（主代码或插件 hook 调用）到全部返回
+	MaxOpcodes  uint64   `json:"max_opcodes,omitempty"`
+	MaxWallTime Duration `json:"max_wall_time,omitempty"`
+}
+
+// Duration 在 JSON 中表示为 "30s" 形式的字符串，也可以是秒数
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(raw []byte) error {
+	var i any
+	if err := json.Unmarshal(raw, &i); err != nil {
+		return err
+	}
+	switch ret := i.(type) {
+	case float64:
+		*d = Duration(ret * float64(time.Second))
+	case string:
+		duration, err := time.ParseDuration(ret)
+		if err != nil {
+			return utils.Errorf("invalid duration %#v: %v", ret, err)
+		}
+		*d = Duration(duration)
+	default:
+		return utils.Errorf("invalid duration: %s", raw)
+	}
+	return nil
+}
+
+var presets = map[string]func() *Policy{
+	PolicyUnrestricted: func() *Policy {
+		return &Policy{Name: PolicyUnrestricted}
+	},
+	PolicyRestricted: func() *Policy {
+		return &Policy{
+			Name:            PolicyRestricted,
+			DeniedLibraries: []string{"exec", "dyn", "sandbox", "hook", "systemd", "hids"},
+			FileRoots:       []string{consts.GetDefaultYakitBaseTempDir(), os.TempDir()},
+		}
+	},
+	PolicyIsolated: func() *Policy {
+		return &Policy{
+			Name: PolicyIsolated,
+			Libraries: []string{
+				"str", "math", "re", "re2", "regen", "codec", "json", "yaml", "xml", "xpath", "xhtml",
+				"time", "timezone", "log", "sync", "context", "x", "orderedmap", "judge", "bufio", "gzip",
+			},
+			FileRoots:   []string{},
+			Hosts:       []string{},
+			MaxMemory:   256 << 20,
+			MaxOpcodes:  100_000_000,
+			MaxWallTime: Duration(time.Minute),
+		}
+	},
+}
+
+// Presets 返回内置策略的名字
+func Presets() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Named 返回内置策略
+func Named(name string) (*Policy, error) {
+	f, ok := presets[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return nil, utils.Errorf("unknown sandbox policy: %v (presets: %v)", name, strings.Join(Presets(), ", "))
+	}
+	return f(), nil
+}
+
+// Parse 解析 JSON 格式的策略
+func Parse(raw []byte) (*Policy, error) {
+	var p Policy
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return nil, utils.Errorf("parse sandbox policy failed: %v", err)
+	}
+	if p.Name == "" {
+		p.Name = "custom"
+	}
+	return &p, nil
+}
+
+// Resolve 解析内置策略名或者 JSON 格式的策略，空字符串返回 nil 表示不限制
+func Resolve(s string) (*Policy, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "":
+		return nil, nil
+	case strings.HasPrefix(s, "{"):
+		return Parse([]byte(s))
+	default:
+		return Named(s)
+	}
+}
+
+// IsUnrestricted 判断策略是否不做任何限制
+func (p *Policy) IsUnrestricted() bool {
+	return p == nil || (len(p.Libraries) == 0 && len(p.DeniedLibraries) == 0 && p.FileRoots == nil && p.Hosts == nil &&
+		p.MaxMemory == 0 && p.MaxOpcodes == 0 && p.MaxWallTime == 0)
+}
@@ -0,0 +1,332 @@
+package yakpolicy_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklang/yaklang/common/schema"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/yak"
+	"github.com/yaklang/yaklang/common/yak/antlr4yak"
+	"github.com/yaklang/yaklang/common/yak/yakpolicy"
+)
+
+func exec(t *testing.T, p *yakpolicy.Policy, code string) *yakpolicy.Violation {
+	t.Helper()
+	engine := yak.NewScriptEngine(1)
+	engine.SetPolicy(p)
+	err := engine.ExecuteMain(code, filepath.Join(t.TempDir(), "policy.yak"))
+	if err == nil {
+		return nil
+	}
+	v, ok := yakpolicy.AsViolation(err)
+	require.True(t, ok, "not a violation: %v", err)
+	return v
+}
+
+func TestResolve(t *testing.T) {
+	p, err := yakpolicy.Resolve("")
+	require.NoError(t, err)
+	require.Nil(t, p)
+
+	p, err = yakpolicy.Resolve("Isolated")
+	require.NoError(t, err)
+	require.Equal(t, yakpolicy.PolicyIsolated, p.Name)
+	require.NotNil(t, p.FileRoots)
+	require.Empty(t, p.FileRoots)
+
+	_, err = yakpolicy.Resolve("unknown")
+	require.Error(t, err)
+
+	p, err = yakpolicy.Resolve(`{"libraries": ["str"], "hosts": [], "max_wall_time": "1m30s", "max_opcodes": 100}`)
+	require.NoError(t, err)
+	require.Equal(t, "custom", p.Name)
+	require.Nil(t, p.FileRoots)
+	require.NotNil(t, p.Hosts)
+	require.Equal(t, yakpolicy.Duration(90*time.Second), p.MaxWallTime)
+	require.False(t, p.IsUnrestricted())
+
+	p, err = yakpolicy.Resolve(`{"max_wall_time": 2}`)
+	require.NoError(t, err)
+	require.Equal(t, yakpolicy.Duration(2*time.Second), p.MaxWallTime)
+
+	p, err = yakpolicy.Resolve(yakpolicy.PolicyUnrestricted)
+	require.NoError(t, err)
+	require.True(t, p.IsUnrestricted())
+}
+
+func TestLibrary(t *testing.T) {
+	p := &yakpolicy.Policy{Name: "test", DeniedLibraries: []string{"exec"}}
+	v := exec(t, p, `a = 1
+exec.System("id")`)
+	require.NotNil(t, v)
+	require.Equal(t, yakpolicy.ViolationLibrary, v.Kind)
+	require.Equal(t, "exec.System", v.Function)
+	require.Equal(t, "test", v.Policy)
+	require.Equal(t, 2, v.Line)
+
+	// the violation can not be caught by try-catch
+	v = exec(t, p, `try { exec.System("id") } catch e { println(e) }
+a = 1`)
+	require.NotNil(t, v)
+	require.Equal(t, yakpolicy.ViolationLibrary, v.Kind)
+
+	// allowlist
+	p = &yakpolicy.Policy{Libraries: []string{"str"}}
+	require.Nil(t, exec(t, p, `assert str.ToUpper("a") == "A"`))
+	v = exec(t, p, `codec.EncodeBase64("a")`)
+	require.NotNil(t, v)
+	require.Equal(t, "codec.EncodeBase64", v.Function)
+
+	// the policy does not affect other engines
+	require.Nil(t, exec(t, nil, `codec.EncodeBase64("a")`))
+}
+
+func TestFileRoots(t *testing.T) {
+	root := t.TempDir()
+	outside := filepath.Join(t.TempDir(), "secret.txt")
+	require.NoError(t, os.WriteFile(outside, []byte("secret"), 0o644))
+	require.NoError(t, os.Symlink(filepath.Dir(outside), filepath.Join(root, "link")))
+
+	p := &yakpolicy.Policy{FileRoots: []string{root}}
+	require.Nil(t, exec(t, p, fmt.Sprintf(`
+path = file.Join(%q, "a.txt")
+file.Save(path, "hello")~
+assert string(file.ReadFile(path)~) == "hello"
+`, root)))
+
+	for _, code := range []string{
+		fmt.Sprintf(`file.ReadFile(%q)`, outside),
+		fmt.Sprintf(`io.ReadFile(%q)`, outside),
+		fmt.Sprintf(`file.ReadFile(%q)`, filepath.Join(root, "link", "secret.txt")),
+		fmt.Sprintf(`file.ReadFile(%q)`, filepath.Join(root, "..", filepath.Base(filepath.Dir(outside)), "secret.txt")),
+		fmt.Sprintf(`os.Remove(%q)`, outside),
+	} {
+		v := exec(t, p, code)
+		require.NotNil(t, v, code)
+		require.Equal(t, yakpolicy.ViolationFile, v.Kind, code)
+	}
+	require.FileExists(t, outside)
+
+	// no file access, exec is denied because it can bypass the file roots
+	p = &yakpolicy.Policy{FileRoots: []string{}}
+	v := exec(t, p, fmt.Sprintf(`file.IsExisted(%q)`, root))
+	require.NotNil(t, v)
+	require.Equal(t, root, v.Resource)
+	v = exec(t, p, `exec.System("id")`)
+	require.NotNil(t, v)
+	require.Equal(t, yakpolicy.ViolationLibrary, v.Kind)
+
+	// functions of other libraries that access files are checked or denied
+	exported := filepath.Join(filepath.Dir(outside), "exported.har")
+	p = &yakpolicy.Policy{FileRoots: []string{root}}
+	for code, resource := range map[string]string{
+		fmt.Sprintf(`pprof.cpuProfilePath(%q)`, outside):          outside,
+		fmt.Sprintf(`import(%q, "a")`, outside):                   outside,
+		fmt.Sprintf(`har.Import(%q)`, outside):                    outside,
+		fmt.Sprintf(`har.Export(%q, har.ids(1))`, exported):       exported,
+		fmt.Sprintf(`cli.File("a", cli.setDefault(%q))`, outside): "cli",
+		`fuzz.Strings("{{file(/etc/passwd)}}")`:                   "fuzz",
+	} {
+		v := exec(t, p, code)
+		require.NotNil(t, v, code)
+		require.Equal(t, yakpolicy.ViolationFile, v.Kind, code)
+		require.Equal(t, resource, v.Resource, code)
+	}
+	require.NoFileExists(t, exported)
+	require.Nil(t, exec(t, p, `assert file.GetExt("a.txt") == ".txt"; assert str.ToUpper("a") == "A"`))
+
+	// unknown libraries are denied by default
+	engine := yak.NewScriptEngine(1)
+	engine.RegisterEngineHooks(func(engine *antlr4yak.Engine) error {
+		engine.SetVar("unknown", map[string]any{"Read": func(string) string { return "" }})
+		return nil
+	})
+	engine.SetPolicy(p)
+	err := engine.ExecuteMain(`unknown.Read("a")`, filepath.Join(t.TempDir(), "policy.yak"))
+	v, ok := yakpolicy.AsViolation(err)
+	require.True(t, ok, "%v", err)
+	require.Equal(t, yakpolicy.ViolationFile, v.Kind)
+	require.Equal(t, "unknown.Read", v.Function)
+}
+
+func TestHosts(t *testing.T) {
+	host, port := utils.DebugMockHTTP([]byte("HTTP/1.1 200 OK\r\nContent-Length: 2\r\n\r\nok"))
+	p := &yakpolicy.Policy{Hosts: []string{"127.0.0.1", "*.example.com", "10.1.0.0/16"}}
+	require.Nil(t, exec(t, p, fmt.Sprintf(`
+rsp, req = poc.Get("http://%v:%v/")~
+assert rsp.GetStatusCode() == 200
+rsp = http.Get("http://%v:%v/")~
+assert rsp.StatusCode == 200
+`, host, port, host, port)))
+
+	for code, target := range map[string]string{
+		`poc.Get("http://yaklang.io/")`: "yaklang.io",
+		`poc.HTTP("GET / HTTP/1.1\r\nHost: www.example.com\r\n\r\n", poc.host("yaklang.io"))`: "yaklang.io",
+		`http.Get("http://10.2.0.1:8080/")`:                                                   "10.2.0.1",
+		`tcp.Connect("192.168.1.1", 22)`:                                                      "192.168.1.1",
+		`poc.proxy("http://192.168.1.1:8080")`:                                                "http://192.168.1.1:8080",
+	} {
+		v := exec(t, p, code)
+		require.NotNil(t, v, code)
+		require.Equal(t, yakpolicy.ViolationNetwork, v.Kind, code)
+		require.Equal(t, target, v.Resource, code)
+	}
+
+	// the targets are checked before connecting
+	for _, code := range []string{
+		`poc.HTTP("GET / HTTP/1.1\r\nHost: yaklang.io\r\n\r\n", poc.host("a.example.com"), poc.port(1), poc.timeout(0.1))`,
+		`tcp.Connect("10.1.2.3", 1, tcp.clientTimeout(0.1))`,
+	} {
+		require.Nil(t, exec(t, p, "try {"+code+"} catch e {}"), code)
+	}
+
+	v := exec(t, p, `fuzz.HTTPRequest("GET / HTTP/1.1\r\nHost: a.example.com\r\n\r\n")`)
+	require.NotNil(t, v)
+	require.Equal(t, yakpolicy.ViolationNetwork, v.Kind)
+	require.Equal(t, "fuzz", v.Resource)
+
+	// network functions of other libraries are checked or denied
+	for code, resource := range map[string]string{
+		`risk.CheckDNSLogByToken("token")`:                         "risk",
+		`str.IsTLSServer("yaklang.io:443")`:                        "yaklang.io:443",
+		`http.RequestFaviconHash("http://yaklang.io/favicon.ico")`: "http://yaklang.io/favicon.ico",
+		`os.LookupHost("yaklang.io")`:                              "yaklang.io",
+	} {
+		v := exec(t, p, code)
+		require.NotNil(t, v, code)
+		require.Equal(t, yakpolicy.ViolationNetwork, v.Kind, code)
+		require.Equal(t, resource, v.Resource, code)
+	}
+	require.Nil(t, exec(t, p, `assert risk.NewLocalReverseHTTPUrl != nil; assert poc.GetHTTPRequestMethod("GET / HTTP/1.1\r\n\r\n") == "GET"`))
+}
+
+func TestLimits(t *testing.T) {
+	v := exec(t, &yakpolicy.Policy{MaxOpcodes: 10000}, `for { a = 1 }`)
+	require.NotNil(t, v)
+	require.Equal(t, yakpolicy.ViolationOpcodes, v.Kind)
+	require.Equal(t, "10000", v.Limit)
+
+	start := time.Now()
+	v = exec(t, &yakpolicy.Policy{MaxWallTime: yakpolicy.Duration(200 * time.Millisecond)}, `for { a = 1 }`)
+	require.NotNil(t, v)
+	require.Equal(t, yakpolicy.ViolationTimeout, v.Kind)
+	require.Less(t, time.Since(start), 5*time.Second)
+
+	v = exec(t, &yakpolicy.Policy{MaxMemory: 16 << 20}, `
+m = {}
+for i in 100000 { m[string(i)] = str.Repeat("a", 1024) }`)
+	require.NotNil(t, v)
+	require.Equal(t, yakpolicy.ViolationMemory, v.Kind)
+}
+
+func TestSandboxLimitPerExecution(t *testing.T) {
+	sandbox := yak.NewSandbox(yak.WithSandbox_Policy(&yakpolicy.Policy{MaxOpcodes: 5000}))
+	// every execution has its own opcodes limit
+	for i := 0; i < 5; i++ {
+		ret, err := sandbox.ExecuteAsExpression(`(func() { s = 0; for i in 200 { s += i }; return s })()`)
+		require.NoError(t, err)
+		require.EqualValues(t, 19900, ret)
+	}
+	_, err := sandbox.ExecuteAsExpression(`(func() { s = 0; for i in 2000 { s += i }; return s })()`)
+	v, ok := yakpolicy.AsViolation(err)
+	require.True(t, ok, "%v", err)
+	require.Equal(t, yakpolicy.ViolationOpcodes, v.Kind)
+}
+
+func TestYakScriptPolicy(t *testing.T) {
+	manager := yak.NewYakToCallerManager()
+	ctx := context.WithValue(context.Background(), "ctx_info", map[string]any{})
+	script := &schema.YakScript{ScriptName: "policy-test", SandboxPolicy: yakpolicy.PolicyIsolated}
+	err := manager.Add(ctx, script, nil, `exec.System("id")`, nil, "handle")
+	require.ErrorContains(t, err, "sandbox policy[isolated] violation: library in exec.System")
+
+	script = &schema.YakScript{ScriptName: "policy-test", SandboxPolicy: "unknown"}
+	err = manager.Add(ctx, script, nil, `handle = func() {}`, nil, "handle")
+	require.ErrorContains(t, err, "unknown sandbox policy")
+
+	script = &schema.YakScript{ScriptName: "policy-test", SandboxPolicy: `{"denied_libraries": ["exec"]}`}
+	require.NoError(t, manager.Add(ctx, script, nil, `handle = func() {}`, nil, "handle"))
+}
+
+func TestStorePluginPolicy(t *testing.T) {
+	require.Equal(t, "", yak.PluginSandboxPolicy(&schema.YakScript{}))
+	require.Equal(t, "", yak.PluginSandboxPolicy(&schema.YakScript{FromStore: true}))
+	require.Equal(t, yakpolicy.PolicyIsolated, yak.PluginSandboxPolicy(&schema.YakScript{FromStore: true, SandboxPolicy: yakpolicy.PolicyIsolated}))
+
+	// 插件设置的策略在执行插件的其他入口同样生效，restricted 策略只允许访问临时目录
+	outside, err := filepath.Abs("policy_test.go")
+	require.NoError(t, err)
+	script := &schema.YakScript{ScriptName: "policy-test", FromStore: true, SandboxPolicy: yakpolicy.PolicyRestricted, Content: fmt.Sprintf(`handle = s => string(file.ReadFile(%q)~)`, outside)}
+	engine := yak.NewScriptEngine(1)
+	ins, err := engine.ExecutePluginEx(script, nil)
+	require.NoError(t, err)
+	_, err = ins.SafeCallYakFunction(context.Background(), "handle", []any{""})
+	require.ErrorContains(t, err, "sandbox policy[restricted] violation: file in file.ReadFile")
+
+	script.SandboxPolicy = ""
+	ins, err = engine.ExecutePluginEx(script, nil)
+	require.NoError(t, err)
+	result, err := ins.CallYakFunction(context.Background(), "handle", []any{""})
+	require.NoError(t, err)
+	require.Contains(t, result, "package yakpolicy_test")
+}
+
+// 商店插件默认不限制，常用的 fuzz / httpool / nuclei 等库可以正常使用
+func TestStorePluginDefaultPolicy(t *testing.T) {
+	host, port := utils.DebugMockHTTP([]byte("HTTP/1.1 200 OK\r\nContent-Length: 12\r\n\r\nstore-plugin"))
+	target := utils.HostPort(host, port)
+	template := `id: store-plugin-test
+info:
+  name: store-plugin-test
+  author: yaklang
+  severity: info
+requests:
+  - method: GET
+    path:
+      - "{{BaseURL}}/"
+    matchers:
+      - type: word
+        words:
+          - "store-plugin"
+`
+	script := &schema.YakScript{ScriptName: "store-plugin-test", FromStore: true, Content: fmt.Sprintf(`
+handle = func(target) {
+	packet = "GET / HTTP/1.1\r\nHost: " + target + "\r\n\r\n"
+
+	rsp = fuzz.HTTPRequest(packet)~.ExecFirst()~
+	assert string(rsp.ResponseRaw).Contains("store-plugin")
+
+	count = 0
+	for result in httpool.Pool(packet)~ {
+		assert string(result.ResponseRaw).Contains("store-plugin")
+		count++
+	}
+	assert count == 1
+
+	tpl = %q
+	matched = 0
+	for result in nuclei.Scan(target, nuclei.rawTemplate(tpl))~ {
+		matched++
+	}
+	assert matched == 1
+	return "done"
+}`, template)}
+
+	// 加载插件与执行插件的入口都不限制
+	manager := yak.NewYakToCallerManager()
+	ctx := context.WithValue(context.Background(), "ctx_info", map[string]any{})
+	require.NoError(t, manager.Add(ctx, script, nil, script.Content, nil, "handle"))
+
+	ins, err := yak.NewScriptEngine(1).ExecutePluginEx(script, nil)
+	require.NoError(t, err)
+	result, err := ins.CallYakFunction(context.Background(), "handle", []any{target})
+	require.NoError(t, err)
+	require.Equal(t, "done", result)
+}
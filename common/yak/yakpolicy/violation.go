@@ -0,0 +1,64 @@
+package yakpolicy
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+type ViolationKind string
+
+const (
+	ViolationLibrary ViolationKind = "library"
+	ViolationFile    ViolationKind = "file"
+	ViolationNetwork ViolationKind = "network"
+	ViolationMemory  ViolationKind = "memory"
+	ViolationOpcodes ViolationKind = "opcodes"
+	ViolationTimeout ViolationKind = "timeout"
+)
+
+// Violation 是违反沙箱策略的结构化错误，脚本在第一次违反策略后终止，之后的执行都会返回同一个 Violation
+type Violation struct {
+	Policy string        `json:"policy"`
+	Kind   ViolationKind `json:"kind"`
+	// Function 为触发检查的库函数，例如 file.ReadFile
+	Function string `json:"function,omitempty"`
+	// Resource 为被拒绝的库、文件路径或主机，或者超出限制时的用量
+	Resource string `json:"resource,omitempty"`
+	Limit    string `json:"limit,omitempty"`
+	// File 与 Line 为违反策略时执行的 yak 代码位置
+	File string `json:"file,omitempty"`
+	Line int    `json:"line,omitempty"`
+}
+
+func (v *Violation) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "sandbox policy[%v] violation: %v", v.Policy, v.Kind)
+	if v.Function != "" {
+		fmt.Fprintf(&b, " in %v", v.Function)
+	}
+	if v.Resource != "" {
+		fmt.Fprintf(&b, ": %v", v.Resource)
+	}
+	if v.Limit != "" {
+		fmt.Fprintf(&b, " (limit: %v)", v.Limit)
+	}
+	if v.Line > 0 {
+		if v.File != "" {
+			fmt.Fprintf(&b, " at %v:%v", filepath.Base(v.File), v.Line)
+		} else {
+			fmt.Fprintf(&b, " at line %v", v.Line)
+		}
+	}
+	return b.String()
+}
+
+// AsViolation 从 err 中取出 Violation
+func AsViolation(err error) (*Violation, bool) {
+	var v *Violation
+	if errors.As(err, &v) {
+		return v, true
+	}
+	return nil, false
+}
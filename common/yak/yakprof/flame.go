@@ -0,0 +1,122 @@
+package yakprof
+
+import (
+	"fmt"
+	"hash/fnv"
+	"html"
+	"io"
+	"sort"
+	"strings"
+)
+
+const (
+	flameWidth       = 1200.0
+	flameFrameHeight = 18
+	flamePadding     = 10
+	flameTitleHeight = 30
+	// the frame narrower than it is not drawn
+	flameMinWidth = 0.3
+)
+
+type flameNode struct {
+	name     string
+	count    int64
+	children map[string]*flameNode
+}
+
+func (n *flameNode) child(name string) *flameNode {
+	if n.children == nil {
+		n.children = make(map[string]*flameNode)
+	}
+	c, ok := n.children[name]
+	if !ok {
+		c = &flameNode{name: name}
+		n.children[name] = c
+	}
+	return c
+}
+
+func (n *flameNode) sortedChildren() []*flameNode {
+	ret := make([]*flameNode, 0, len(n.children))
+	for _, c := range n.children {
+		ret = append(ret, c)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].name < ret[j].name
+	})
+	return ret
+}
+
+func (n *flameNode) depth() int {
+	d := 0
+	for _, c := range n.children {
+		if cd := c.depth(); cd > d {
+			d = cd
+		}
+	}
+	return d + 1
+}
+
+// WriteSVG write the flame graph of samples, the frames of same function are merged (the lines are in pprof and
+// folded stacks), the root is at the bottom and the width of frame is its sample count
+func (p *Profile) WriteSVG(w io.Writer) error {
+	root := &flameNode{name: "all"}
+	for _, s := range p.Samples {
+		root.count += s.Count
+		n := root
+		for _, frame := range foldedFrames(s.Stack, false) {
+			n = n.child(frame)
+			n.count += s.Count
+		}
+	}
+
+	depth := root.depth()
+	height := flameTitleHeight + depth*flameFrameHeight + flamePadding*2
+	var b strings.Builder
+	fmt.Fprintf(&b, `<?xml version="1.0" standalone="no"?>
+<svg version="1.1" width="%d" height="%d" viewBox="0 0 %d %d" xmlns="http://www.w3.org/2000/svg">
+<style>text { font-family: Verdana, sans-serif; font-size: 12px; fill: #000; } rect:hover { stroke: #000; stroke-width: 0.5; }</style>
+<rect x="0" y="0" width="100%%" height="100%%" fill="#fdfdf6"/>
+<text x="%d" y="20" style="font-size: 16px">Yak Flame Graph (%d samples, interval %v)</text>
+`, int(flameWidth)+flamePadding*2, height, int(flameWidth)+flamePadding*2, height, flamePadding, root.count, p.Interval)
+
+	if root.count > 0 {
+		scale := flameWidth / float64(root.count)
+		var draw func(n *flameNode, x float64, level int)
+		draw = func(n *flameNode, x float64, level int) {
+			width := float64(n.count) * scale
+			if width < flameMinWidth {
+				return
+			}
+			y := height - flamePadding - (level+1)*flameFrameHeight
+			title := html.EscapeString(fmt.Sprintf("%s (%d samples, %.2f%%)", n.name, n.count, float64(n.count)*100/float64(root.count)))
+			fmt.Fprintf(&b, `<g><title>%s</title><rect x="%.2f" y="%d" width="%.2f" height="%d" fill="%s" rx="2" ry="2"/>`,
+				title, x+flamePadding, y, width, flameFrameHeight-1, flameColor(n.name))
+			// about 7px per character
+			if chars := int(width / 7); chars >= 3 {
+				label := n.name
+				if len(label) > chars {
+					label = label[:chars-2] + ".."
+				}
+				fmt.Fprintf(&b, `<text x="%.2f" y="%d">%s</text>`, x+flamePadding+3, y+flameFrameHeight-5, html.EscapeString(label))
+			}
+			b.WriteString("</g>\n")
+			for _, c := range n.sortedChildren() {
+				draw(c, x, level+1)
+				x += float64(c.count) * scale
+			}
+		}
+		draw(root, 0, 0)
+	}
+	b.WriteString("</svg>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// flameColor return the warm color decided by frame name, the same function has the same color
+func flameColor(name string) string {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	v := h.Sum32()
+	return fmt.Sprintf("rgb(%d,%d,%d)", 205+v%50, 80+(v>>8)%120, 40+(v>>16)%50)
+}
@@ -0,0 +1,175 @@
+package yakprof
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/pprof/profile"
+
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/yak/antlr4yak/yakvm"
+)
+
+const (
+	FormatPprof  = "pprof"
+	FormatFolded = "folded"
+	FormatSVG    = "svg"
+)
+
+// FormatByPath guess the profile format by file extension, `.svg` is flame graph, `.folded`/`.txt` is
+// folded stacks (input of flamegraph.pl / speedscope), others are pprof protobuf
+func FormatByPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".svg":
+		return FormatSVG
+	case ".folded", ".txt":
+		return FormatFolded
+	default:
+		return FormatPprof
+	}
+}
+
+// Profile is the snapshot of yakvm profiler
+type Profile struct {
+	Samples  []*yakvm.ProfileSample
+	Interval time.Duration
+	Duration time.Duration
+}
+
+// NewProfile take the snapshot of profiler
+func NewProfile(p *yakvm.Profiler) *Profile {
+	if p == nil {
+		return &Profile{}
+	}
+	return &Profile{
+		Samples:  p.Samples(),
+		Interval: p.Interval(),
+		Duration: p.Duration(),
+	}
+}
+
+func (p *Profile) Write(w io.Writer, format string) error {
+	switch format {
+	case FormatPprof, "":
+		return p.WritePprof(w)
+	case FormatFolded:
+		return p.WriteFolded(w)
+	case FormatSVG:
+		return p.WriteSVG(w)
+	default:
+		return utils.Errorf("unsupported profile format: %v", format)
+	}
+}
+
+// Save write the profile to file, empty format means guess by extension
+func (p *Profile) Save(path string, format string) error {
+	if format == "" {
+		format = FormatByPath(path)
+	}
+	fp, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer fp.Close()
+	buf := bufio.NewWriter(fp)
+	if err := p.Write(buf, format); err != nil {
+		return err
+	}
+	return buf.Flush()
+}
+
+// Bytes return the profile in format
+func (p *Profile) Bytes(format string) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := p.Write(&buf, format); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Pprof convert the profile to pprof profile, the sample types are samples/count, cpu/nanoseconds,
+// alloc_space/bytes and alloc_objects/count
+func (p *Profile) Pprof() *profile.Profile {
+	prof := &profile.Profile{
+		SampleType: []*profile.ValueType{
+			{Type: "samples", Unit: "count"},
+			{Type: "cpu", Unit: "nanoseconds"},
+			{Type: "alloc_space", Unit: "bytes"},
+			{Type: "alloc_objects", Unit: "count"},
+		},
+		DefaultSampleType: "cpu",
+		PeriodType:        &profile.ValueType{Type: "cpu", Unit: "nanoseconds"},
+		Period:            int64(p.Interval),
+		DurationNanos:     int64(p.Duration),
+		TimeNanos:         time.Now().Add(-p.Duration).UnixNano(),
+	}
+
+	functions := make(map[[2]string]*profile.Function)
+	locations := make(map[yakvm.ProfileLocation]*profile.Location)
+	for _, s := range p.Samples {
+		sample := &profile.Sample{Value: []int64{s.Count, s.CPU, s.AllocBytes, s.AllocObjects}}
+		for _, loc := range s.Stack {
+			l, ok := locations[loc]
+			if !ok {
+				fn, ok := functions[[2]string{loc.Function, loc.File}]
+				if !ok {
+					fn = &profile.Function{
+						ID:         uint64(len(prof.Function) + 1),
+						Name:       loc.Function,
+						SystemName: loc.Function,
+						Filename:   loc.File,
+					}
+					functions[[2]string{loc.Function, loc.File}] = fn
+					prof.Function = append(prof.Function, fn)
+				}
+				l = &profile.Location{
+					ID:   uint64(len(prof.Location) + 1),
+					Line: []profile.Line{{Function: fn, Line: int64(loc.Line)}},
+				}
+				locations[loc] = l
+				prof.Location = append(prof.Location, l)
+			}
+			sample.Location = append(sample.Location, l)
+		}
+		prof.Sample = append(prof.Sample, sample)
+	}
+	return prof
+}
+
+// WritePprof write the gzipped pprof protobuf, it can be opened by `go tool pprof`
+func (p *Profile) WritePprof(w io.Writer) error {
+	return p.Pprof().Write(w)
+}
+
+// WriteFolded write the folded stacks: `root;caller;callee count` per line
+func (p *Profile) WriteFolded(w io.Writer) error {
+	for _, s := range p.Samples {
+		if _, err := fmt.Fprintf(w, "%s %d\n", strings.Join(foldedFrames(s.Stack, true), ";"), s.Count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// foldedFrames return the frame names from root to leaf, the frame is `function (file:line)`,
+// or `function (file)` without line
+func foldedFrames(stack []yakvm.ProfileLocation, withLine bool) []string {
+	frames := make([]string, len(stack))
+	for i, loc := range stack {
+		name := loc.Function
+		switch {
+		case withLine && loc.Line > 0:
+			name = fmt.Sprintf("%s (%s:%d)", loc.Function, filepath.Base(loc.File), loc.Line)
+		case loc.File != "":
+			name = fmt.Sprintf("%s (%s)", loc.Function, filepath.Base(loc.File))
+		}
+		frames[len(stack)-1-i] = strings.ReplaceAll(name, ";", ":")
+	}
+	return frames
+}
@@ -0,0 +1,92 @@
+package yakprof
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/pprof/profile"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklang/yaklang/common/yak"
+	"github.com/yaklang/yaklang/common/yak/antlr4yak/yakvm"
+)
+
+const code = `busy = func() {
+    start = time.Now().UnixNano()
+    for time.Now().UnixNano() - start < 300000000 {
+        a = str.Repeat("a", 16)
+    }
+}
+
+busy()
+`
+
+func runWithProfiler(t *testing.T) (*Profile, string) {
+	file := filepath.Join(t.TempDir(), "busy.yak")
+	profiler := yakvm.NewProfiler(time.Millisecond)
+	engine := yak.NewScriptEngine(1)
+	engine.SetProfiler(profiler)
+	profiler.Start()
+	require.NoError(t, engine.ExecuteMain(code, file))
+	profiler.Stop()
+	return NewProfile(profiler), file
+}
+
+func TestProfile(t *testing.T) {
+	p, file := runWithProfiler(t)
+	require.NotEmpty(t, p.Samples)
+	require.Equal(t, time.Millisecond, p.Interval)
+	require.GreaterOrEqual(t, p.Duration, 300*time.Millisecond)
+
+	// most samples are in busy function, called by main at line 8
+	var busy int64
+	var total int64
+	for _, s := range p.Samples {
+		total += s.Count
+		require.Equal(t, int64(time.Millisecond)*s.Count, s.CPU)
+		if len(s.Stack) == 2 && s.Stack[0].Function == "busy" {
+			require.Equal(t, file, s.Stack[0].File)
+			require.Contains(t, []int{2, 3, 4}, s.Stack[0].Line)
+			require.Equal(t, yakvm.ProfileLocation{Function: "__yak_main__", File: file, Line: 8}, s.Stack[1])
+			busy += s.Count
+		}
+	}
+	require.Greater(t, busy*2, total)
+
+	// pprof
+	raw, err := p.Bytes(FormatPprof)
+	require.NoError(t, err)
+	prof, err := profile.Parse(bytes.NewReader(raw))
+	require.NoError(t, err)
+	require.NoError(t, prof.CheckValid())
+	require.Len(t, prof.SampleType, 4)
+	require.Equal(t, "cpu", prof.SampleType[1].Type)
+	var names []string
+	for _, fn := range prof.Function {
+		names = append(names, fn.Name)
+	}
+	require.ElementsMatch(t, []string{"busy", "__yak_main__"}, names)
+
+	// folded
+	raw, err = p.Bytes(FormatFolded)
+	require.NoError(t, err)
+	require.Contains(t, string(raw), "__yak_main__ (busy.yak:8);busy (busy.yak:")
+
+	// flame graph
+	raw, err = p.Bytes(FormatSVG)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(raw), "<?xml"))
+	require.Contains(t, string(raw), "busy (busy.yak)")
+
+	_, err = p.Bytes("unknown")
+	require.Error(t, err)
+}
+
+func TestFormatByPath(t *testing.T) {
+	require.Equal(t, FormatPprof, FormatByPath("cpu.pb.gz"))
+	require.Equal(t, FormatSVG, FormatByPath("flame.SVG"))
+	require.Equal(t, FormatFolded, FormatByPath("stacks.folded"))
+}
@@ -0,0 +1,200 @@
+package yakrepl
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/samber/lo"
+
+	"github.com/yaklang/yaklang/common/utils/orderedmap"
+	"github.com/yaklang/yaklang/common/yak/antlr4yak/yakvm"
+	"github.com/yaklang/yaklang/common/yak/yakdoc"
+)
+
+var keywords = []string{
+	"assert", "break", "case", "catch", "chan", "continue", "default", "defer", "elif", "else",
+	"fallthrough", "false", "finally", "fn", "for", "func", "go", "if", "in", "include", "make",
+	"map", "nil", "range", "return", "switch", "true", "try", "undefined", "var",
+}
+
+// Completer 根据 yakdoc 的库索引和当前定义的变量补全标识符与成员
+type Completer struct {
+	helper *yakdoc.DocumentHelper
+	// lookup 查找 REPL 中已经定义的变量
+	lookup func(name string) (any, bool)
+	// names 返回 REPL 中已经定义的变量名
+	names func() []string
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || r == '.' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// Complete 返回光标前的单词的补全候选项（完整的单词，例如 poc.Get），start 为单词在 line 中的起始位置
+func (c *Completer) Complete(line []rune, pos int) ([]string, int) {
+	start := pos
+	for start > 0 && isWordRune(line[start-1]) {
+		start--
+	}
+	word := string(line[start:pos])
+
+	// 行首的 : 开头的是 REPL 命令
+	if start == 1 && line[0] == ':' {
+		return filterPrefix(commandNames(), word), start
+	}
+
+	var (
+		base, prefix string
+		names        []string
+	)
+	if i := strings.LastIndex(word, "."); i >= 0 {
+		base, prefix = word[:i], word[i+1:]
+		names = c.members(base)
+		base += "."
+	} else {
+		prefix = word
+		names = c.globals()
+	}
+
+	var candidates []string
+	for _, name := range filterPrefix(names, prefix) {
+		candidates = append(candidates, base+name)
+	}
+	return candidates, start
+}
+
+func (c *Completer) globals() []string {
+	names := append([]string{}, keywords...)
+	if c.helper != nil {
+		for name := range c.helper.Functions {
+			names = append(names, name)
+		}
+		for name := range c.helper.Libs {
+			// 形如 a.b 的子库只能在成员补全中出现
+			if !strings.Contains(name, ".") {
+				names = append(names, name)
+			}
+		}
+		for name := range c.helper.Instances {
+			names = append(names, name)
+		}
+	}
+	if c.names != nil {
+		names = append(names, c.names()...)
+	}
+	return names
+}
+
+func (c *Completer) members(base string) []string {
+	var names []string
+	// 用户定义的变量会覆盖同名的库
+	if c.helper != nil && !c.isDefined(base) {
+		if lib, ok := c.helper.Libs[base]; ok {
+			for name := range lib.Functions {
+				names = append(names, name)
+			}
+			for name := range lib.Instances {
+				names = append(names, name)
+			}
+			return names
+		}
+	}
+	if c.lookup == nil || strings.Contains(base, ".") {
+		return nil
+	}
+	value, ok := c.lookup(base)
+	if !ok || value == nil {
+		return nil
+	}
+	return valueMembers(value)
+}
+
+func (c *Completer) isDefined(name string) bool {
+	if c.names == nil {
+		return false
+	}
+	for _, defined := range c.names() {
+		if defined == name {
+			return true
+		}
+	}
+	return false
+}
+
+// valueMembers 返回变量可以访问的成员：结构体的方法与字段，map 的键，以及字符串、切片等内置方法
+func valueMembers(value any) []string {
+	var names []string
+	switch ret := value.(type) {
+	case string:
+		return lo.Keys(yakvm.GetStringBuildInMethod())
+	case []byte:
+		return lo.Keys(yakvm.GetBytesBuildInMethod())
+	case *orderedmap.OrderedMap:
+		return ret.Keys()
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Map:
+		for _, key := range rv.MapKeys() {
+			if key.Kind() == reflect.String {
+				names = append(names, key.String())
+			}
+		}
+		return append(names, lo.Keys(yakvm.GetMapBuildInMethod())...)
+	case reflect.Slice, reflect.Array:
+		return lo.Keys(yakvm.GetSliceBuildInMethod())
+	}
+
+	typ := rv.Type()
+	for i := 0; i < typ.NumMethod(); i++ {
+		names = append(names, typ.Method(i).Name)
+	}
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() == reflect.Struct {
+		for i := 0; i < typ.NumField(); i++ {
+			if field := typ.Field(i); field.IsExported() {
+				names = append(names, field.Name)
+			}
+		}
+	}
+	return names
+}
+
+func filterPrefix(names []string, prefix string) []string {
+	seen := make(map[string]struct{}, len(names))
+	var ret []string
+	for _, name := range names {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		ret = append(ret, name)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// commonPrefix 返回候选项的最长公共前缀
+func commonPrefix(candidates []string) string {
+	if len(candidates) == 0 {
+		return ""
+	}
+	prefix := []rune(candidates[0])
+	for _, candidate := range candidates[1:] {
+		runes := []rune(candidate)
+		n := 0
+		for n < len(prefix) && n < len(runes) && prefix[n] == runes[n] {
+			n++
+		}
+		prefix = prefix[:n]
+	}
+	return string(prefix)
+}
@@ -0,0 +1,309 @@
+package yakrepl
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/mattn/go-runewidth"
+
+	"github.com/yaklang/yaklang/common/utils"
+)
+
+// ErrInterrupt 用户在输入时按下了 Ctrl-C
+var ErrInterrupt = utils.Error("interrupt")
+
+const listWidth = 80
+
+const (
+	keyCtrlA     = 1
+	keyCtrlB     = 2
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyCtrlE     = 5
+	keyCtrlF     = 6
+	keyBackspace = 8
+	keyTab       = 9
+	keyLF        = 10
+	keyCtrlK     = 11
+	keyCtrlL     = 12
+	keyCR        = 13
+	keyCtrlN     = 14
+	keyCtrlP     = 16
+	keyCtrlU     = 21
+	keyCtrlW     = 23
+	keyEscape    = 27
+	keyDelete    = 127
+)
+
+// LineEditor 是 REPL 使用的行编辑器，支持光标移动、历史记录与 Tab 补全
+// 在终端的 raw 模式下工作，输入不是终端时按行读取
+type LineEditor struct {
+	in       *bufio.Reader
+	out      io.Writer
+	history  *History
+	complete func(line []rune, pos int) ([]string, int)
+	// makeRaw 让终端进入 raw 模式并返回恢复函数，为 nil 时按行读取
+	makeRaw func() (func(), error)
+}
+
+func NewLineEditor(in io.Reader, out io.Writer, history *History, complete func(line []rune, pos int) ([]string, int)) *LineEditor {
+	if history == nil {
+		history = NewHistory("", 0)
+	}
+	return &LineEditor{
+		in:       bufio.NewReader(in),
+		out:      out,
+		history:  history,
+		complete: complete,
+	}
+}
+
+// ReadLine 读取一行输入，Ctrl-C 返回 ErrInterrupt，空行时 Ctrl-D 返回 io.EOF
+func (e *LineEditor) ReadLine(prompt string) (string, error) {
+	if e.makeRaw == nil {
+		return e.readPlain()
+	}
+	restore, err := e.makeRaw()
+	if err != nil {
+		return e.readPlain()
+	}
+	defer restore()
+	return e.readRaw(prompt)
+}
+
+func (e *LineEditor) readPlain() (string, error) {
+	line, err := e.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+type editState struct {
+	prompt string
+	buf    []rune
+	pos    int
+	// history 当前浏览的历史记录，等于 History.Len() 时为正在编辑的行
+	history int
+	editing []rune
+	lastTab bool
+}
+
+func (e *LineEditor) readRaw(prompt string) (string, error) {
+	s := &editState{prompt: prompt, history: e.history.Len()}
+	e.refresh(s)
+	for {
+		r, _, err := e.in.ReadRune()
+		if err != nil {
+			return "", err
+		}
+		isTab := r == keyTab
+		switch r {
+		case keyCR, keyLF:
+			e.write("\r\n")
+			return string(s.buf), nil
+		case keyCtrlC:
+			e.write("^C\r\n")
+			return "", ErrInterrupt
+		case keyCtrlD:
+			if len(s.buf) == 0 {
+				e.write("\r\n")
+				return "", io.EOF
+			}
+			s.deleteAt(s.pos)
+		case keyCtrlA:
+			s.pos = 0
+		case keyCtrlE:
+			s.pos = len(s.buf)
+		case keyCtrlB:
+			s.move(-1)
+		case keyCtrlF:
+			s.move(1)
+		case keyCtrlK:
+			s.buf = s.buf[:s.pos]
+		case keyCtrlU:
+			s.buf = append([]rune{}, s.buf[s.pos:]...)
+			s.pos = 0
+		case keyCtrlW:
+			s.deleteWord()
+		case keyCtrlL:
+			e.write("\x1b[H\x1b[2J")
+		case keyCtrlP:
+			e.browseHistory(s, -1)
+		case keyCtrlN:
+			e.browseHistory(s, 1)
+		case keyBackspace, keyDelete:
+			if s.pos > 0 {
+				s.pos--
+				s.deleteAt(s.pos)
+			}
+		case keyTab:
+			e.completeWord(s)
+		case keyEscape:
+			e.escape(s)
+		default:
+			if unicode.IsPrint(r) {
+				s.insert(r)
+			}
+		}
+		s.lastTab = isTab
+		e.refresh(s)
+	}
+}
+
+// escape 处理方向键等 ESC [ 开头的控制序列
+func (e *LineEditor) escape(s *editState) {
+	r, _, err := e.in.ReadRune()
+	if err != nil || (r != '[' && r != 'O') {
+		return
+	}
+	var param []rune
+	for {
+		r, _, err = e.in.ReadRune()
+		if err != nil {
+			return
+		}
+		if r >= 0x40 && r <= 0x7e {
+			break
+		}
+		param = append(param, r)
+	}
+	switch r {
+	case 'A':
+		e.browseHistory(s, -1)
+	case 'B':
+		e.browseHistory(s, 1)
+	case 'C':
+		s.move(1)
+	case 'D':
+		s.move(-1)
+	case 'H':
+		s.pos = 0
+	case 'F':
+		s.pos = len(s.buf)
+	case '~':
+		switch string(param) {
+		case "1", "7":
+			s.pos = 0
+		case "4", "8":
+			s.pos = len(s.buf)
+		case "3":
+			s.deleteAt(s.pos)
+		}
+	}
+}
+
+func (e *LineEditor) browseHistory(s *editState, delta int) {
+	next := s.history + delta
+	if next < 0 || next > e.history.Len() {
+		return
+	}
+	if s.history == e.history.Len() {
+		s.editing = append([]rune{}, s.buf...)
+	}
+	s.history = next
+	if next == e.history.Len() {
+		s.buf = append([]rune{}, s.editing...)
+	} else {
+		s.buf = []rune(e.history.Get(next))
+	}
+	s.pos = len(s.buf)
+}
+
+// completeWord 补全光标前的单词，有多个候选项时补全公共前缀，连续按两次 Tab 展示所有候选项
+func (e *LineEditor) completeWord(s *editState) {
+	if e.complete == nil {
+		return
+	}
+	candidates, start := e.complete(s.buf, s.pos)
+	if len(candidates) == 0 {
+		e.write("\a")
+		return
+	}
+	word := string(s.buf[start:s.pos])
+	prefix := commonPrefix(candidates)
+	if len(candidates) == 1 || len(prefix) > len(word) {
+		s.replace(start, []rune(prefix))
+		return
+	}
+	if !s.lastTab {
+		e.write("\a")
+		return
+	}
+
+	width := 0
+	for _, candidate := range candidates {
+		if w := runewidth.StringWidth(candidate); w > width {
+			width = w
+		}
+	}
+	width += 2
+	columns := listWidth / width
+	if columns < 1 {
+		columns = 1
+	}
+	buf := new(bytes.Buffer)
+	buf.WriteString("\r\n")
+	for i, candidate := range candidates {
+		buf.WriteString(runewidth.FillRight(candidate, width))
+		if (i+1)%columns == 0 || i == len(candidates)-1 {
+			buf.WriteString("\r\n")
+		}
+	}
+	e.write(buf.String())
+}
+
+func (e *LineEditor) refresh(s *editState) {
+	line := "\r" + s.prompt + string(s.buf) + "\x1b[K"
+	if back := runewidth.StringWidth(string(s.buf[s.pos:])); back > 0 {
+		line += fmt.Sprintf("\x1b[%dD", back)
+	}
+	e.write(line)
+}
+
+func (e *LineEditor) write(s string) {
+	_, _ = io.WriteString(e.out, s)
+}
+
+func (s *editState) insert(r rune) {
+	s.buf = append(s.buf, 0)
+	copy(s.buf[s.pos+1:], s.buf[s.pos:])
+	s.buf[s.pos] = r
+	s.pos++
+}
+
+func (s *editState) deleteAt(i int) {
+	if i < 0 || i >= len(s.buf) {
+		return
+	}
+	s.buf = append(s.buf[:i], s.buf[i+1:]...)
+}
+
+func (s *editState) deleteWord() {
+	i := s.pos
+	for i > 0 && s.buf[i-1] == ' ' {
+		i--
+	}
+	for i > 0 && s.buf[i-1] != ' ' {
+		i--
+	}
+	s.buf = append(s.buf[:i], s.buf[s.pos:]...)
+	s.pos = i
+}
+
+func (s *editState) move(delta int) {
+	if pos := s.pos + delta; pos >= 0 && pos <= len(s.buf) {
+		s.pos = pos
+	}
+}
+
+// replace 把 [start, pos) 替换为 word
+func (s *editState) replace(start int, word []rune) {
+	rest := append([]rune{}, s.buf[s.pos:]...)
+	s.buf = append(append(s.buf[:start], word...), rest...)
+	s.pos = start + len(word)
+}
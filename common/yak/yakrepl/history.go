@@ -0,0 +1,94 @@
+package yakrepl
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/yaklang/yaklang/common/consts"
+	"github.com/yaklang/yaklang/common/log"
+)
+
+// DefaultHistoryLimit 历史记录文件中最多保存的行数
+const DefaultHistoryLimit = 1000
+
+// DefaultHistoryFile 默认的历史记录文件，位于 yakit-projects 目录下
+func DefaultHistoryFile() string {
+	return filepath.Join(consts.GetDefaultYakitBaseDir(), "yak-repl-history")
+}
+
+// History 保存输入过的每一行，path 不为空时在不同的 REPL 会话之间持久化
+type History struct {
+	path    string
+	limit   int
+	entries []string
+}
+
+// NewHistory 从 path 中加载历史记录，path 为空时只在内存中保存
+func NewHistory(path string, limit int) *History {
+	if limit <= 0 {
+		limit = DefaultHistoryLimit
+	}
+	h := &History{path: path, limit: limit}
+	if path == "" {
+		return h
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return h
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if line := scanner.Text(); strings.TrimSpace(line) != "" {
+			h.entries = append(h.entries, line)
+		}
+	}
+	h.truncate()
+	return h
+}
+
+func (h *History) truncate() {
+	if len(h.entries) > h.limit {
+		h.entries = h.entries[len(h.entries)-h.limit:]
+	}
+}
+
+// Add 添加一行历史记录，空行以及与上一行相同的输入会被忽略
+func (h *History) Add(line string) {
+	if strings.TrimSpace(line) == "" || strings.ContainsAny(line, "\r\n") {
+		return
+	}
+	if n := len(h.entries); n > 0 && h.entries[n-1] == line {
+		return
+	}
+	h.entries = append(h.entries, line)
+	h.truncate()
+	if h.path == "" {
+		return
+	}
+	if err := h.save(); err != nil {
+		log.Warnf("save repl history to %s failed: %v", h.path, err)
+	}
+}
+
+func (h *History) save() error {
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(h.path, []byte(strings.Join(h.entries, "\n")+"\n"), 0o600)
+}
+
+func (h *History) Len() int {
+	return len(h.entries)
+}
+
+// Get 返回第 i 条历史记录，0 为最早的记录
+func (h *History) Get(i int) string {
+	if i < 0 || i >= len(h.entries) {
+		return ""
+	}
+	return h.entries[i]
+}
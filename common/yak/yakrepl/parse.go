@@ -0,0 +1,102 @@
+package yakrepl
+
+import (
+	"github.com/antlr/antlr4/runtime/Go/antlr/v4"
+
+	yak "github.com/yaklang/yaklang/common/yak/antlr4yak/parser"
+)
+
+// silentFunctions 这些函数的返回值（写入的字节数等）没有展示的意义
+var silentFunctions = map[string]struct{}{
+	"print":   {},
+	"println": {},
+	"printf":  {},
+	"dump":    {},
+	"desc":    {},
+}
+
+type errorListener struct {
+	*antlr.DefaultErrorListener
+	errors int
+}
+
+func newErrorListener() *errorListener {
+	return &errorListener{DefaultErrorListener: antlr.NewDefaultErrorListener()}
+}
+
+func (l *errorListener) SyntaxError(antlr.Recognizer, interface{}, int, int, string, antlr.RecognitionException) {
+	l.errors++
+}
+
+// IsComplete 判断输入的代码是否完整，括号、模板字符串没有闭合或者反引号字符串没有结束时需要继续输入下一行
+func IsComplete(code string) bool {
+	lexer := yak.NewYaklangLexer(antlr.NewInputStream(code))
+	lexer.RemoveErrorListeners()
+
+	depth := 0
+	for _, token := range lexer.GetAllTokens() {
+		switch token.GetTokenType() {
+		case yak.YaklangLexerBackTickL, yak.YaklangLexerCommentStart:
+			// 反引号字符串和块注释可以跨行，没有结束时只能匹配到开头的 ` 和 /*
+			return false
+		case yak.YaklangLexerLParen, yak.YaklangLexerLBracket, yak.YaklangLexerLBrace,
+			yak.YaklangLexerTemplateSingleQuoteStringStart, yak.YaklangLexerTemplateDoubleQuoteStringStart, yak.YaklangLexerTemplateBackTickStringStart,
+			yak.YaklangLexerTemplateSingleQuoteStringStartExpression, yak.YaklangLexerTemplateDoubleQuoteStringStartExpression, yak.YaklangLexerTemplateBackTickStringStartExpression:
+			depth++
+		case yak.YaklangLexerRParen, yak.YaklangLexerRBracket, yak.YaklangLexerRBrace, yak.YaklangLexerTemplateCloseBrace,
+			yak.YaklangLexerTemplateSingleQuoteStringCharacterStringEnd, yak.YaklangLexerTemplateDoubleQuoteStringCharacterStringEnd, yak.YaklangLexerTemplateBackTickStringCharacterStringEnd:
+			depth--
+		}
+	}
+	return depth <= 0
+}
+
+// inputKind 描述一次输入的代码，只有单独的表达式才会展示结果
+type inputKind struct {
+	expression bool
+	// call 表达式是函数调用
+	call bool
+	// silent 调用了 println 等函数，不展示返回值
+	silent bool
+}
+
+func classify(code string) inputKind {
+	lexer := yak.NewYaklangLexer(antlr.NewInputStream(code))
+	listener := newErrorListener()
+	lexer.RemoveErrorListeners()
+	lexer.AddErrorListener(listener)
+	parser := yak.NewYaklangParser(antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel))
+	parser.RemoveErrorListeners()
+	parser.AddErrorListener(listener)
+	prog, ok := parser.Program().(*yak.ProgramContext)
+	if !ok || listener.errors > 0 || prog.StatementList() == nil {
+		return inputKind{}
+	}
+
+	var expr *yak.ExpressionContext
+	for _, raw := range prog.StatementList().(*yak.StatementListContext).AllStatement() {
+		stmt := raw.(*yak.StatementContext)
+		if stmt.Empty() != nil || stmt.LineCommentStmt() != nil {
+			continue
+		}
+		if expr != nil || stmt.ExpressionStmt() == nil {
+			return inputKind{}
+		}
+		expr, _ = stmt.ExpressionStmt().(*yak.ExpressionStmtContext).Expression().(*yak.ExpressionContext)
+	}
+	if expr == nil {
+		return inputKind{}
+	}
+
+	// 具名函数的定义作为语句处理
+	if fn, ok := expr.AnonymousFunctionDecl().(*yak.AnonymousFunctionDeclContext); ok && fn.FunctionNameDecl() != nil {
+		return inputKind{}
+	}
+
+	kind := inputKind{expression: true}
+	if expr.FunctionCall() != nil && expr.Expression(0) != nil {
+		kind.call = true
+		_, kind.silent = silentFunctions[expr.Expression(0).GetText()]
+	}
+	return kind
+}
@@ -0,0 +1,185 @@
+package yakrepl
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yaklang/yaklang/common/utils/lowhttp"
+	"github.com/yaklang/yaklang/common/utils/orderedmap"
+	"github.com/yaklang/yaklang/common/yak/antlr4yak/yakvm"
+)
+
+const (
+	// 超过这个长度的 map / slice 会换行展示
+	compactWidth = 80
+	// 响应体最多展示的字节数
+	maxBodySize = 2048
+)
+
+// Pretty 把表达式的结果格式化为便于阅读的文本，lowhttp 的响应会展示为 HTTP 报文，map / orderedmap / slice 会按层级缩进
+func Pretty(v any) string {
+	switch ret := v.(type) {
+	case *lowhttp.LowhttpResponse:
+		if ret != nil {
+			return prettyResponse(ret)
+		}
+	}
+	return pretty(v, 0)
+}
+
+func pretty(v any, indent int) string {
+	compact := compactString(v)
+	if len(compact) <= compactWidth {
+		return compact
+	}
+
+	var (
+		open, close string
+		keys        []string
+		items       []any
+	)
+	switch ret := v.(type) {
+	case *orderedmap.OrderedMap:
+		open, close = "orderedmap{", "}"
+		ret.ForEach(func(key string, value any) {
+			keys = append(keys, fmt.Sprintf("%q", key))
+			items = append(items, value)
+		})
+	case []byte:
+		return compact
+	default:
+		rv := reflect.ValueOf(v)
+		switch rv.Kind() {
+		case reflect.Map:
+			open, close = "{", "}"
+			for _, key := range sortedMapKeys(rv) {
+				keys = append(keys, compactString(key.Interface()))
+				items = append(items, rv.MapIndex(key).Interface())
+			}
+		case reflect.Slice, reflect.Array:
+			open, close = "[", "]"
+			for i := 0; i < rv.Len(); i++ {
+				items = append(items, rv.Index(i).Interface())
+			}
+		default:
+			return compact
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	prefix := strings.Repeat("  ", indent+1)
+	buf.WriteString(open + "\n")
+	for i, item := range items {
+		buf.WriteString(prefix)
+		if keys != nil {
+			buf.WriteString(keys[i] + ": ")
+		}
+		buf.WriteString(pretty(item, indent+1))
+		buf.WriteString(",\n")
+	}
+	buf.WriteString(strings.Repeat("  ", indent) + close)
+	return buf.String()
+}
+
+func compactString(v any) string {
+	switch ret := v.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return fmt.Sprintf("%q", ret)
+	case []byte:
+		if utf8.Valid(ret) {
+			return fmt.Sprintf("b%q", ret)
+		}
+		return fmt.Sprintf("b%q (%d bytes)", ret, len(ret))
+	case error:
+		return "error: " + ret.Error()
+	case *yakvm.Function:
+		return fmt.Sprintf("fn %s(%d params)", ret.GetActualName(), ret.GetNumIn())
+	case *lowhttp.LowhttpResponse:
+		if ret == nil {
+			return "nil"
+		}
+		return fmt.Sprintf("<HTTP %d %s, %d bytes>", ret.GetStatusCode(), ret.Url, len(ret.RawPacket))
+	case *orderedmap.OrderedMap:
+		if ret == nil {
+			return "nil"
+		}
+		var pairs []string
+		ret.ForEach(func(key string, value any) {
+			pairs = append(pairs, fmt.Sprintf("%q: %s", key, compactString(value)))
+		})
+		return "orderedmap{" + strings.Join(pairs, ", ") + "}"
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Func:
+		if rv.IsNil() {
+			return "nil"
+		}
+		return "<function " + rv.Type().String() + ">"
+	case reflect.Map:
+		if rv.IsNil() {
+			return "nil"
+		}
+		pairs := make([]string, 0, rv.Len())
+		for _, key := range sortedMapKeys(rv) {
+			pairs = append(pairs, compactString(key.Interface())+": "+compactString(rv.MapIndex(key).Interface()))
+		}
+		return "{" + strings.Join(pairs, ", ") + "}"
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return "[]"
+		}
+		items := make([]string, rv.Len())
+		for i := range items {
+			items[i] = compactString(rv.Index(i).Interface())
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return "nil"
+		}
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+func sortedMapKeys(rv reflect.Value) []reflect.Value {
+	keys := rv.MapKeys()
+	sort.SliceStable(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+	return keys
+}
+
+func prettyResponse(rsp *lowhttp.LowhttpResponse) string {
+	buf := new(bytes.Buffer)
+	buf.WriteString(fmt.Sprintf("# %s", rsp.Url))
+	if rsp.RemoteAddr != "" {
+		buf.WriteString(fmt.Sprintf(" (%s)", rsp.RemoteAddr))
+	}
+	if rsp.TraceInfo != nil && rsp.TraceInfo.TotalTime > 0 {
+		buf.WriteString(fmt.Sprintf(" %s", rsp.TraceInfo.TotalTime))
+	}
+	buf.WriteString("\n")
+
+	header, body := lowhttp.SplitHTTPPacketFast(rsp.RawPacket)
+	buf.WriteString(strings.TrimRight(header, "\r\n"))
+	buf.WriteString("\n\n")
+	switch {
+	case len(body) == 0:
+	case !utf8.Valid(body) || bytes.IndexByte(body, 0) >= 0:
+		buf.WriteString(fmt.Sprintf("<binary body, %d bytes>", len(body)))
+	case len(body) > maxBodySize:
+		buf.Write(body[:maxBodySize])
+		buf.WriteString(fmt.Sprintf("\n... (%d bytes omitted)", len(body)-maxBodySize))
+	default:
+		buf.Write(body)
+	}
+	return strings.TrimRight(buf.String(), "\r\n")
+}
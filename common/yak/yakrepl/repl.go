@@ -0,0 +1,331 @@
+package yakrepl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/yaklang/yaklang/common/consts"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/yak"
+	"github.com/yaklang/yaklang/common/yak/antlr4yak"
+	"github.com/yaklang/yaklang/common/yak/antlr4yak/yakvm"
+	"github.com/yaklang/yaklang/common/yak/yakdoc"
+	"github.com/yaklang/yaklang/common/yak/yakdoc/doc"
+)
+
+const (
+	prompt         = "yak> "
+	continuePrompt = "...  "
+	// lastValueName 保存上一个表达式结果的变量
+	lastValueName = "_"
+)
+
+var commands = []struct {
+	name, args, usage string
+}{
+	{"help", "", "show this help"},
+	{"doc", "<lib|fn>", "show the document of library or function, e.g. :doc poc.Get"},
+	{"type", "<expr>", "evaluate the expression and show its type"},
+	{"vars", "", "show the variables defined in repl"},
+	{"quit", "", "exit the repl (or Ctrl-D)"},
+}
+
+func commandNames() []string {
+	names := make([]string, 0, len(commands)+1)
+	for _, command := range commands {
+		names = append(names, command.name)
+	}
+	return append(names, "exit")
+}
+
+type Option func(r *REPL)
+
+// WithHistoryFile 设置历史记录文件，为空时不保存历史记录
+func WithHistoryFile(path string) Option {
+	return func(r *REPL) {
+		r.historyFile = path
+	}
+}
+
+func WithInput(in io.Reader) Option {
+	return func(r *REPL) {
+		r.in = in
+	}
+}
+
+func WithOutput(out io.Writer) Option {
+	return func(r *REPL) {
+		r.out = out
+	}
+}
+
+// REPL 交互式执行 yak 代码，变量与函数在多次输入之间保留
+type REPL struct {
+	in          io.Reader
+	out         io.Writer
+	historyFile string
+
+	engine    *antlr4yak.Engine
+	helper    *yakdoc.DocumentHelper
+	completer *Completer
+	editor    *LineEditor
+}
+
+func New(opts ...Option) (*REPL, error) {
+	r := &REPL{
+		in:          os.Stdin,
+		out:         os.Stdout,
+		historyFile: DefaultHistoryFile(),
+		helper:      doc.DefaultDocumentHelper,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	engine, err := yak.NewScriptEngine(1).ExecuteEx("", map[string]any{"YAK_MAIN": true})
+	if err != nil {
+		return nil, utils.Errorf("init yak engine failed: %v", err)
+	}
+	r.engine = engine
+	r.completer = &Completer{
+		helper: r.helper,
+		lookup: engine.GetVar,
+		names:  engine.GetSymNames,
+	}
+	r.editor = NewLineEditor(r.in, r.out, NewHistory(r.historyFile, DefaultHistoryLimit), r.completer.Complete)
+	if f, ok := r.in.(*os.File); ok && isTerminal(int(f.Fd())) {
+		fd := int(f.Fd())
+		r.editor.makeRaw = func() (func(), error) {
+			return makeRaw(fd)
+		}
+	}
+	return r, nil
+}
+
+func (r *REPL) Completer() *Completer {
+	return r.completer
+}
+
+// Run 循环读取输入并执行，直到输入 :quit 或者 Ctrl-D
+func (r *REPL) Run(ctx context.Context) error {
+	r.println("Yak Language %s REPL, type :help for help", consts.GetYakVersion())
+
+	var lines []string
+	for {
+		p := prompt
+		if len(lines) > 0 {
+			p = continuePrompt
+		}
+		line, err := r.editor.ReadLine(p)
+		if errors.Is(err, ErrInterrupt) {
+			lines = nil
+			continue
+		}
+		if err == io.EOF {
+			if len(lines) > 0 {
+				r.evalAndPrint(ctx, strings.Join(lines, "\n"))
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		r.editor.history.Add(line)
+
+		if len(lines) == 0 && strings.HasPrefix(strings.TrimSpace(line), ":") {
+			if quit := r.Command(ctx, strings.TrimSpace(line)); quit {
+				return nil
+			}
+			continue
+		}
+
+		lines = append(lines, line)
+		code := strings.Join(lines, "\n")
+		if strings.TrimSpace(code) == "" {
+			lines = nil
+			continue
+		}
+		if !IsComplete(code) {
+			continue
+		}
+		lines = nil
+		r.evalAndPrint(ctx, code)
+	}
+}
+
+// Eval 在 REPL 的上下文中执行代码，代码是单独的表达式时返回它的值，show 表示结果是否需要展示
+func (r *REPL) Eval(ctx context.Context, code string) (value any, show bool, err error) {
+	kind := classify(code)
+	if err := r.engine.SafeEvalInline(ctx, code); err != nil {
+		return nil, false, err
+	}
+	if !kind.expression || kind.silent {
+		return nil, false, nil
+	}
+	// 值为 nil / undefined 时不在栈上
+	if last, err := r.engine.GetLastStackValue(); err == nil {
+		value = last.Value
+	}
+	// 没有返回值的函数调用
+	if ret, ok := value.([]any); ok && kind.call && len(ret) == 0 {
+		return nil, false, nil
+	}
+	r.engine.SetVar(lastValueName, value)
+	return value, true, nil
+}
+
+func (r *REPL) evalAndPrint(ctx context.Context, code string) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// 执行时 Ctrl-C 只中断当前的代码
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+	go func() {
+		select {
+		case <-interrupt:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	value, show, err := r.Eval(ctx, code)
+	if err != nil {
+		r.println("%v", err)
+		return
+	}
+	if show {
+		r.println("%s", Pretty(value))
+	}
+}
+
+// Command 执行 : 开头的 REPL 命令，返回 true 时退出 REPL
+func (r *REPL) Command(ctx context.Context, line string) (quit bool) {
+	name, arg, _ := strings.Cut(strings.TrimPrefix(line, ":"), " ")
+	arg = strings.TrimSpace(arg)
+	switch name {
+	case "quit", "exit", "q":
+		return true
+	case "help", "h", "?":
+		r.help()
+	case "doc", "d":
+		r.println("%s", r.Doc(arg))
+	case "type", "t":
+		typ, err := r.TypeOf(ctx, arg)
+		if err != nil {
+			r.println("%v", err)
+		} else {
+			r.println("%s", typ)
+		}
+	case "vars":
+		r.vars()
+	default:
+		r.println("unknown command :%s, type :help for help", name)
+	}
+	return false
+}
+
+func (r *REPL) help() {
+	r.println("Yak REPL, the variables and functions are kept between inputs, the value of expression is printed and saved in %s", lastValueName)
+	r.println("unclosed brackets or strings continue on the next line, Tab completes libraries / functions / members\n")
+	for _, command := range commands {
+		r.println("  %-16s %s", strings.TrimSpace(":"+command.name+" "+command.args), command.usage)
+	}
+}
+
+// Doc 返回库或者函数的文档，例如 poc / poc.Get / println
+func (r *REPL) Doc(name string) string {
+	if name == "" {
+		return "usage: :doc <lib|fn>, e.g. :doc poc.Get"
+	}
+	if r.helper != nil {
+		if _, ok := r.helper.Libs[name]; ok {
+			return strings.TrimRight(r.helper.LibHelpInfo(name), "\n")
+		}
+		libName, funcName := "__GLOBAL__", name
+		if i := strings.LastIndex(name, "."); i > 0 {
+			libName, funcName = name[:i], name[i+1:]
+		}
+		if info := r.helper.LibFuncHelpInfo(libName, funcName); info != "" {
+			return info
+		}
+		if libName == "__GLOBAL__" {
+			if instance, ok := r.helper.Instances[name]; ok {
+				return instance.String()
+			}
+		} else if lib, ok := r.helper.Libs[libName]; ok {
+			if instance, ok := lib.Instances[funcName]; ok {
+				return instance.String()
+			}
+		}
+	}
+	return fmt.Sprintf("no document for %s", name)
+}
+
+// TypeOf 返回表达式的类型，库函数返回文档中的函数声明，其他表达式执行后返回值的类型
+func (r *REPL) TypeOf(ctx context.Context, expr string) (string, error) {
+	if expr == "" {
+		return "", utils.Error("usage: :type <expr>")
+	}
+	if r.helper != nil {
+		libName, funcName := "__GLOBAL__", expr
+		if i := strings.LastIndex(expr, "."); i > 0 {
+			libName, funcName = expr[:i], expr[i+1:]
+		}
+		var decl *yakdoc.FuncDecl
+		if libName == "__GLOBAL__" {
+			decl = r.helper.Functions[funcName]
+		} else if lib, ok := r.helper.Libs[libName]; ok {
+			decl = lib.Functions[funcName]
+		}
+		// 被用户定义的同名变量覆盖时以变量为准
+		if decl != nil && !r.completer.isDefined(expr) {
+			return decl.Decl, nil
+		}
+	}
+
+	if !classify(expr).expression {
+		return "", utils.Errorf("%s is not an expression", expr)
+	}
+	value, _, err := r.Eval(ctx, expr)
+	if err != nil {
+		return "", err
+	}
+	return typeName(value), nil
+}
+
+func typeName(value any) string {
+	switch ret := value.(type) {
+	case nil:
+		return "nil"
+	case *yakvm.Function:
+		if ret.IsVariableParameter() {
+			return fmt.Sprintf("fn(%d params, variadic)", ret.GetNumIn())
+		}
+		return fmt.Sprintf("fn(%d params)", ret.GetNumIn())
+	}
+	return reflect.TypeOf(value).String()
+}
+
+func (r *REPL) vars() {
+	names := r.engine.GetSymNames()
+	sort.Strings(names)
+	for _, name := range names {
+		value, ok := r.engine.GetVar(name)
+		if !ok {
+			continue
+		}
+		r.println("%s: %s = %s", name, typeName(value), utils.ShrinkString(compactString(value), 64))
+	}
+}
+
+func (r *REPL) println(format string, args ...any) {
+	_, _ = fmt.Fprintf(r.out, format+"\n", args...)
+}
@@ -0,0 +1,286 @@
+package yakrepl
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklang/yaklang/common/utils/lowhttp"
+	"github.com/yaklang/yaklang/common/utils/orderedmap"
+)
+
+func newTestREPL(t *testing.T, input string) (*REPL, *bytes.Buffer) {
+	t.Helper()
+	out := new(bytes.Buffer)
+	r, err := New(WithInput(strings.NewReader(input)), WithOutput(out), WithHistoryFile(""))
+	require.NoError(t, err)
+	return r, out
+}
+
+func TestIsComplete(t *testing.T) {
+	for code, want := range map[string]bool{
+		`a = 1`:                        true,
+		`func f(a) {`:                  false,
+		"func f(a) {\n  return a\n}":   true,
+		`b = [1, 2,`:                   false,
+		`c = {"a": (1 + 2)}`:           true,
+		"d = `abc":                     false,
+		"d = `abc\ndef`":               true,
+		`e = f"${a + `:                 false,
+		`e = f"${a + 1}"`:              true,
+		`println("{")`:                 true,
+		"/* comment":                   false,
+		"if a {\n} else {\n  b = 1\n}": true,
+	} {
+		require.Equal(t, want, IsComplete(code), code)
+	}
+}
+
+func TestEval(t *testing.T) {
+	r, _ := newTestREPL(t, "")
+	ctx := context.Background()
+
+	eval := func(code string) (any, bool) {
+		value, show, err := r.Eval(ctx, code)
+		require.NoError(t, err, code)
+		return value, show
+	}
+
+	_, show := eval(`a = 1`)
+	require.False(t, show)
+	value, show := eval(`a + 1`)
+	require.True(t, show)
+	require.EqualValues(t, 2, value)
+	value, _ = eval(`_ * 10`)
+	require.EqualValues(t, 20, value)
+
+	_, show = eval("func add(x, y) {\n  return x + y + a\n}")
+	require.False(t, show)
+	value, _ = eval(`add(2, 3)`)
+	require.EqualValues(t, 6, value)
+
+	// println / 没有返回值的函数不展示结果
+	_, show = eval(`println("hello")`)
+	require.False(t, show)
+	_, show = eval(`sleep(0)`)
+	require.False(t, show)
+
+	value, show = eval(`m = {"b": 1}; m`)
+	require.False(t, show, "multiple statements")
+	require.Nil(t, value)
+	value, _ = eval(`m`)
+	require.Equal(t, map[string]int{"b": 1}, value)
+
+	_, _, err := r.Eval(ctx, `undefinedFunction()`)
+	require.Error(t, err)
+	// 出错后状态保留
+	value, _ = eval(`a`)
+	require.EqualValues(t, 1, value)
+}
+
+func TestComplete(t *testing.T) {
+	r, _ := newTestREPL(t, "")
+	_, _, err := r.Eval(context.Background(), "myString = \"abc\"\nmyMap = {\"key1\": 1}")
+	require.NoError(t, err)
+
+	complete := func(line string) []string {
+		candidates, start := r.Completer().Complete([]rune(line), len([]rune(line)))
+		require.LessOrEqual(t, start, len([]rune(line)))
+		return candidates
+	}
+
+	require.Contains(t, complete("po"), "poc")
+	require.Contains(t, complete("x = poc.Ge"), "poc.Get")
+	require.Contains(t, complete("codec.DecodeBa"), "codec.DecodeBase64")
+	require.Contains(t, complete("prin"), "println")
+	require.Contains(t, complete("ret"), "return")
+	require.Equal(t, []string{"myMap", "myString"}, complete("my"))
+	require.Contains(t, complete("myString.HasPre"), "myString.HasPrefix")
+	require.Contains(t, complete("myMap.ke"), "myMap.key1")
+	require.Equal(t, []string{"doc"}, complete(":do"))
+	require.Empty(t, complete("notExistedLib.a"))
+
+	// 变量覆盖同名的库
+	_, _, err = r.Eval(context.Background(), `x = "abc"`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"x.HasPrefix"}, complete("x.HasPre"))
+
+	candidates, start := r.Completer().Complete([]rune("a = str.ToUp + 1"), len("a = str.ToUp"))
+	require.Equal(t, 4, start)
+	require.Contains(t, candidates, "str.ToUpper")
+}
+
+func TestDocAndType(t *testing.T) {
+	r, _ := newTestREPL(t, "")
+	ctx := context.Background()
+
+	require.Contains(t, r.Doc("poc.Get"), "Get(urlStr string")
+	require.Contains(t, r.Doc("println"), "println(")
+	require.Contains(t, r.Doc("poc"), "poc")
+	require.Equal(t, "no document for notExisted", r.Doc("notExisted"))
+
+	typ, err := r.TypeOf(ctx, "codec.DecodeBase64")
+	require.NoError(t, err)
+	require.Equal(t, "DecodeBase64(i string) ([]byte, error)", typ)
+
+	for expr, want := range map[string]string{
+		`1 + 1`:             "int",
+		`"a" + "b"`:         "string",
+		`[1, 2]`:            "[]int",
+		`{"a": "b"}`:        "map[string]string",
+		`(x) => x`:          "fn(1 params)",
+		`codec.EncodeToHex`: "EncodeToHex(i any) string",
+		`println`:           "println(a ...any) (n int, err error)",
+	} {
+		typ, err := r.TypeOf(ctx, expr)
+		require.NoError(t, err, expr)
+		require.Equal(t, want, typ, expr)
+	}
+	// 变量的类型为值的类型
+	_, _, err = r.Eval(ctx, `hex = codec.EncodeToHex`)
+	require.NoError(t, err)
+	typ, err = r.TypeOf(ctx, "hex")
+	require.NoError(t, err)
+	require.Equal(t, "func(interface {}) string", typ)
+
+	_, err = r.TypeOf(ctx, "a = 1")
+	require.Error(t, err)
+}
+
+func TestPretty(t *testing.T) {
+	om := orderedmap.New()
+	om.Set("z", 1)
+	om.Set("a", "b")
+	require.Equal(t, `orderedmap{"z": 1, "a": "b"}`, Pretty(om))
+
+	require.Equal(t, `{"a": 1, "b": [1, 2]}`, Pretty(map[string]any{"b": []int{1, 2}, "a": 1}))
+	require.Equal(t, `b"abc"`, Pretty([]byte("abc")))
+	require.Equal(t, "nil", Pretty(nil))
+
+	long := map[string]any{"key": strings.Repeat("a", 40), "list": []string{strings.Repeat("b", 80), "c"}}
+	require.Equal(t, `{
+  "key": "`+strings.Repeat("a", 40)+`",
+  "list": [
+    "`+strings.Repeat("b", 80)+`",
+    "c",
+  ],
+}`, Pretty(long))
+
+	rsp := &lowhttp.LowhttpResponse{
+		Url:        "http://example.com/",
+		RemoteAddr: "127.0.0.1:80",
+		RawPacket:  []byte("HTTP/1.1 200 OK\r\nContent-Type: text/plain\r\n\r\nhello"),
+	}
+	require.Equal(t, "# http://example.com/ (127.0.0.1:80)\nHTTP/1.1 200 OK\r\nContent-Type: text/plain\n\nhello", Pretty(rsp))
+	require.Equal(t, "[<HTTP 200 http://example.com/, 50 bytes>, nil]", Pretty([]any{rsp, nil}))
+
+	rsp.RawPacket = append([]byte("HTTP/1.1 200 OK\r\n\r\n"), bytes.Repeat([]byte("a"), maxBodySize+10)...)
+	require.True(t, strings.HasSuffix(Pretty(rsp), "\n... (10 bytes omitted)"))
+}
+
+func TestLineEditor(t *testing.T) {
+	readLine := func(input string, history *History, complete func([]rune, int) ([]string, int)) (string, string, error) {
+		out := new(bytes.Buffer)
+		editor := NewLineEditor(strings.NewReader(input), out, history, complete)
+		editor.makeRaw = func() (func(), error) {
+			return func() {}, nil
+		}
+		line, err := editor.ReadLine("> ")
+		return line, out.String(), err
+	}
+
+	// 光标移动与删除
+	line, _, err := readLine("abd\x1b[D\x1b[Dc\x1b[3~\x1b[F!\r", nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, "acd!", line)
+	line, _, _ = readLine("hello world\x17\x01\x0b\x7fyak\r", nil, nil)
+	require.Equal(t, "yak", line)
+	line, _, _ = readLine("中文\x02x\r", nil, nil)
+	require.Equal(t, "中x文", line)
+
+	// 历史记录
+	history := NewHistory("", 0)
+	history.Add("first")
+	history.Add("second")
+	line, _, _ = readLine("\x1b[A\x1b[A\r", history, nil)
+	require.Equal(t, "first", line)
+	line, _, _ = readLine("new\x1b[A\x1b[B\r", history, nil)
+	require.Equal(t, "new", line)
+
+	// 补全
+	complete := func(line []rune, pos int) ([]string, int) {
+		word := string(line[:pos])
+		var ret []string
+		for _, candidate := range []string{"poc.Get", "poc.Post", "poc.HTTP"} {
+			if strings.HasPrefix(candidate, word) {
+				ret = append(ret, candidate)
+			}
+		}
+		return ret, 0
+	}
+	line, _, _ = readLine("poc.G\t(\r", nil, complete)
+	require.Equal(t, "poc.Get(", line)
+	line, out, _ := readLine("p\t\t\r", nil, complete)
+	require.Equal(t, "poc.", line)
+	require.Contains(t, out, "poc.Get")
+	require.Contains(t, out, "poc.HTTP")
+
+	// Ctrl-C / Ctrl-D
+	_, _, err = readLine("abc\x03", nil, nil)
+	require.ErrorIs(t, err, ErrInterrupt)
+	_, _, err = readLine("\x04", nil, nil)
+	require.Equal(t, io.EOF, err)
+}
+
+func TestHistoryFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history")
+	history := NewHistory(path, 3)
+	for _, line := range []string{"a", "b", "b", "", "c", "d"} {
+		history.Add(line)
+	}
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "b\nc\nd\n", string(raw))
+
+	history = NewHistory(path, 0)
+	require.Equal(t, 3, history.Len())
+	require.Equal(t, "b", history.Get(0))
+}
+
+func TestRun(t *testing.T) {
+	input := strings.Join([]string{
+		`a = 1`,
+		`func f(x) {`,
+		`    return x + a`,
+		`}`,
+		`f(2)`,
+		`:type f`,
+		`:doc codec.EncodeBase64`,
+		`:vars`,
+		`b = [`,
+		`"x",`,
+		`]`,
+		`b`,
+		`:unknown`,
+		`:quit`,
+		`a`,
+	}, "\n") + "\n"
+	r, out := newTestREPL(t, input)
+	require.NoError(t, r.Run(context.Background()))
+
+	output := out.String()
+	require.Contains(t, output, "\n3\n")
+	require.Contains(t, output, "fn(1 params)")
+	require.Contains(t, output, "EncodeBase64")
+	require.Contains(t, output, "a: int = 1")
+	require.Contains(t, output, `["x"]`)
+	require.Contains(t, output, "unknown command :unknown")
+	// :quit 之后的输入不会执行
+	require.False(t, strings.HasSuffix(output, "\n1\n"))
+}
@@ -0,0 +1,16 @@
+//go:build !linux && !darwin
+// +build !linux,!darwin
+
+package yakrepl
+
+import "github.com/yaklang/yaklang/common/utils"
+
+// 其他平台（windows）不支持 raw 模式，按行读取输入
+
+func isTerminal(fd int) bool {
+	return false
+}
+
+func makeRaw(fd int) (func(), error) {
+	return nil, utils.Error("raw terminal mode is not supported on this platform")
+}
@@ -0,0 +1,195 @@
+package yakunit
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/yaklang/yaklang/common/utils"
+)
+
+type Status string
+
+const (
+	StatusPass Status = "pass"
+	StatusFail Status = "fail"
+	StatusSkip Status = "skip"
+)
+
+type TestResult struct {
+	Name     string        `json:"name"`
+	Status   Status        `json:"status"`
+	Elapsed  float64       `json:"elapsed"`
+	Message  string        `json:"message,omitempty"`
+	Failures []string      `json:"failures,omitempty"`
+	Logs     []string      `json:"logs,omitempty"`
+	SubTests []*TestResult `json:"subtests,omitempty"`
+}
+
+// flatten return the test and all subtests
+func (r *TestResult) flatten() []*TestResult {
+	ret := []*TestResult{r}
+	for _, sub := range r.SubTests {
+		ret = append(ret, sub.flatten()...)
+	}
+	return ret
+}
+
+type FileResult struct {
+	File    string        `json:"file"`
+	Elapsed float64       `json:"elapsed"`
+	Error   string        `json:"error,omitempty"`
+	Tests   []*TestResult `json:"tests"`
+}
+
+// Failed report whether the file can not be loaded or any test failed
+func (f *FileResult) Failed() bool {
+	if f.Error != "" {
+		return true
+	}
+	for _, test := range f.Tests {
+		if test.Status == StatusFail {
+			return true
+		}
+	}
+	return false
+}
+
+type Report struct {
+	Files   []*FileResult `json:"files"`
+	Elapsed float64       `json:"elapsed"`
+}
+
+// Count return the number of top level tests in each status
+func (r *Report) Count() (pass, fail, skip int) {
+	for _, file := range r.Files {
+		for _, test := range file.Tests {
+			switch test.Status {
+			case StatusPass:
+				pass++
+			case StatusFail:
+				fail++
+			case StatusSkip:
+				skip++
+			}
+		}
+	}
+	return
+}
+
+// Failed report whether any file or test failed
+func (r *Report) Failed() bool {
+	for _, file := range r.Files {
+		if file.Failed() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Report) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Content string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Error     *junitFailure `xml:"error,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitTestSuite struct {
+	Name      string           `xml:"name,attr"`
+	Tests     int              `xml:"tests,attr"`
+	Failures  int              `xml:"failures,attr"`
+	Errors    int              `xml:"errors,attr"`
+	Skipped   int              `xml:"skipped,attr"`
+	Time      string           `xml:"time,attr"`
+	TestCases []*junitTestCase `xml:"testcase"`
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name          `xml:"testsuites"`
+	Tests    int               `xml:"tests,attr"`
+	Failures int               `xml:"failures,attr"`
+	Errors   int               `xml:"errors,attr"`
+	Skipped  int               `xml:"skipped,attr"`
+	Time     string            `xml:"time,attr"`
+	Suites   []*junitTestSuite `xml:"testsuite"`
+}
+
+// failureSummary drop the panic stack of yakvm and shrink the message
+func failureSummary(msg string) string {
+	if _, after, ok := strings.Cut(msg, "YakVM Panic: "); ok {
+		msg = after
+	}
+	return utils.ShrinkString(msg, 256)
+}
+
+func junitTime(seconds float64) string {
+	return fmt.Sprintf("%.3f", seconds)
+}
+
+// JUnit export the report as JUnit XML, every file is a testsuite and subtests are flattened as testcases
+func (r *Report) JUnit() ([]byte, error) {
+	suites := &junitTestSuites{Time: junitTime(r.Elapsed)}
+	for _, file := range r.Files {
+		suite := &junitTestSuite{Name: file.File, Time: junitTime(file.Elapsed)}
+		if file.Error != "" {
+			suite.Errors++
+			suite.TestCases = append(suite.TestCases, &junitTestCase{
+				Name:      file.File,
+				ClassName: file.File,
+				Time:      junitTime(file.Elapsed),
+				Error:     &junitFailure{Message: "load test file failed", Content: file.Error},
+			})
+		}
+		for _, test := range file.Tests {
+			for _, result := range test.flatten() {
+				testCase := &junitTestCase{
+					Name:      result.Name,
+					ClassName: file.File,
+					Time:      junitTime(result.Elapsed),
+					SystemOut: strings.Join(result.Logs, "\n"),
+				}
+				switch result.Status {
+				case StatusFail:
+					suite.Failures++
+					message := "subtest failed"
+					if len(result.Failures) > 0 {
+						message = failureSummary(result.Failures[0])
+					}
+					testCase.Failure = &junitFailure{Message: message, Content: strings.Join(result.Failures, "\n")}
+				case StatusSkip:
+					suite.Skipped++
+					testCase.Skipped = &junitSkipped{Message: result.Message}
+				}
+				suite.Tests++
+				suite.TestCases = append(suite.TestCases, testCase)
+			}
+		}
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Errors += suite.Errors
+		suites.Skipped += suite.Skipped
+		suites.Suites = append(suites.Suites, suite)
+	}
+	raw, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), raw...), nil
+}
@@ -0,0 +1,299 @@
+package yakunit
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/yak"
+	"github.com/yaklang/yaklang/common/yak/antlr4yak/yakvm"
+	"github.com/yaklang/yaklang/common/yak/yaklang"
+)
+
+// TestFileSuffix is the suffix of yak test file
+const TestFileSuffix = "_test.yak"
+
+var testFuncRegexp = regexp.MustCompile(`(?m)^func\s+(test\w*)\s*\(`)
+
+type Option func(*runner)
+
+// WithRunPattern only run the tests (and subtests) whose name match the regexp,
+// like `go test -run`, the pattern is split by `/` and every element matches one level of the name
+func WithRunPattern(pattern string) Option {
+	return func(r *runner) {
+		if pattern == "" {
+			return
+		}
+		for _, elem := range strings.Split(pattern, "/") {
+			re, err := regexp.Compile(elem)
+			if err != nil {
+				r.err = utils.Errorf("invalid run pattern %v: %v", pattern, err)
+				return
+			}
+			r.patterns = append(r.patterns, re)
+		}
+	}
+}
+
+// WithTimeout set the timeout of every test function, zero means no timeout
+func WithTimeout(timeout time.Duration) Option {
+	return func(r *runner) {
+		r.timeout = timeout
+	}
+}
+
+// WithVerbose print every test (=== RUN / --- PASS) instead of only the failed
+func WithVerbose(verbose bool) Option {
+	return func(r *runner) {
+		r.verbose = verbose
+	}
+}
+
+// WithProgressWriter set the writer of progress output, default is io.Discard
+func WithProgressWriter(w io.Writer) Option {
+	return func(r *runner) {
+		r.progress = w
+	}
+}
+
+// WithCoverage collect the coverage of tests into cov
+func WithCoverage(cov *yakvm.Coverage) Option {
+	return func(r *runner) {
+		r.coverage = cov
+	}
+}
+
+type runner struct {
+	patterns []*regexp.Regexp
+	timeout  time.Duration
+	verbose  bool
+	progress io.Writer
+	coverage *yakvm.Coverage
+	err      error
+}
+
+func (r *runner) match(name string) bool {
+	for i, elem := range strings.Split(name, "/") {
+		if i < len(r.patterns) && !r.patterns[i].MatchString(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *runner) start(name string) {
+	if r.verbose {
+		fmt.Fprintf(r.progress, "=== RUN   %s\n", name)
+	}
+}
+
+func (r *runner) finish(result *TestResult) {
+	if !r.verbose && result.Status != StatusFail {
+		return
+	}
+	fmt.Fprintf(r.progress, "--- %s: %s (%.2fs)\n", strings.ToUpper(string(result.Status)), result.Name, result.Elapsed)
+	for _, msg := range result.Logs {
+		fmt.Fprintf(r.progress, "    %s\n", indent(msg))
+	}
+	for _, msg := range result.Failures {
+		fmt.Fprintf(r.progress, "    %s\n", indent(msg))
+	}
+	if result.Status == StatusSkip && result.Message != "" {
+		fmt.Fprintf(r.progress, "    %s\n", indent(result.Message))
+	}
+}
+
+func indent(msg string) string {
+	return strings.ReplaceAll(msg, "\n", "\n    ")
+}
+
+// Discover find the yak test files (*_test.yak) in paths, directory is walked recursively,
+// file given explicitly is always included
+func Discover(paths ...string) ([]string, error) {
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	var files []string
+	for _, path := range paths {
+		path = strings.TrimSuffix(path, "/...")
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if p != path && strings.HasPrefix(d.Name(), ".") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if strings.HasSuffix(d.Name(), TestFileSuffix) {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	files = lo.Uniq(files)
+	sort.Strings(files)
+	return files, nil
+}
+
+// TestFunctions return the top level test function names (`func testXxx(...)`) of code in source order
+func TestFunctions(code string) []string {
+	var ret []string
+	for _, match := range testFuncRegexp.FindAllStringSubmatch(code, -1) {
+		ret = append(ret, match[1])
+	}
+	return ret
+}
+
+// Run discover and run the yak tests in paths, every test function runs in a new engine,
+// the top level code of test file is executed before calling the test function.
+func Run(ctx context.Context, paths []string, opts ...Option) (*Report, error) {
+	r := &runner{progress: io.Discard}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	files, err := Discover(paths...)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{}
+	start := time.Now()
+	for _, file := range files {
+		if ctx.Err() != nil {
+			break
+		}
+		fileResult := r.runFile(ctx, file)
+		report.Files = append(report.Files, fileResult)
+
+		status := "ok  "
+		if fileResult.Failed() {
+			status = "FAIL"
+		}
+		fmt.Fprintf(r.progress, "%s\t%s\t%.3fs\n", status, file, fileResult.Elapsed)
+		if fileResult.Error != "" {
+			fmt.Fprintf(r.progress, "    %s\n", indent(fileResult.Error))
+		}
+	}
+	report.Elapsed = time.Since(start).Seconds()
+	return report, nil
+}
+
+func (r *runner) runFile(ctx context.Context, file string) *FileResult {
+	result := &FileResult{File: file}
+	start := time.Now()
+	defer func() {
+		result.Elapsed = time.Since(start).Seconds()
+	}()
+
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	code := string(raw)
+	if _, err := yaklang.New().Compile(code); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	absFile, err := filepath.Abs(file)
+	if err != nil {
+		absFile = file
+	}
+
+	for _, name := range TestFunctions(code) {
+		if ctx.Err() != nil {
+			break
+		}
+		if !r.match(name) {
+			continue
+		}
+		result.Tests = append(result.Tests, r.runTest(ctx, absFile, code, name))
+	}
+	return result
+}
+
+func (r *runner) runTest(ctx context.Context, file, code, name string) *TestResult {
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	r.start(name)
+	t := newT(ctx, name, r)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		t.call(func() error {
+			scriptEngine := yak.NewScriptEngine(1)
+			if r.coverage != nil {
+				scriptEngine.SetCoverage(r.coverage)
+			}
+			engine, err := scriptEngine.ExecuteExWithContext(ctx, code, map[string]any{
+				"YAK_FILENAME": file,
+			})
+			if err != nil {
+				return err
+			}
+			i, ok := engine.GetVar(name)
+			if !ok {
+				return utils.Errorf("test function %v not found", name)
+			}
+			fn, ok := i.(*yakvm.Function)
+			if !ok {
+				return utils.Errorf("%v is not a function", name)
+			}
+			var params []any
+			if fn.GetNumIn() > 0 {
+				params = append(params, t)
+			}
+			_, err = engine.CallYakFunctionNative(ctx, fn, params...)
+			return err
+		})
+	}()
+
+	select {
+	case <-done:
+		if ctx.Err() == context.DeadlineExceeded {
+			t.timedOut(r.timeout)
+		}
+	case <-ctx.Done():
+		// wait the vm to stop, if it is not stopped in time, report the result and leave it
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.result.Elapsed = r.timeout.Seconds()
+		}
+		if ctx.Err() == context.DeadlineExceeded {
+			t.timedOut(r.timeout)
+		}
+	}
+	r.finish(t.result)
+	return t.result
+}
@@ -0,0 +1,138 @@
+package yakunit
+
+import (
+	"context"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+const testCode = `add = func(a, b) { return a + b }
+
+func testAssert() {
+    asserts.Equal(3, add(1, 2))
+    asserts.Equal("ab", b"ab")
+    asserts.NotEqual(1, 2)
+    asserts.Contains([1, 2, 3], 2)
+    asserts.Contains({"a": 1}, "a")
+    asserts.Contains("hello yak", "yak")
+    asserts.Nil(nil)
+    asserts.Empty("")
+    asserts.Regexp(` + "`^\\d+$`" + `, "123")
+    asserts.Panics(func() { panic("boom") })
+    asserts.PanicsContains(func() { panic("boom") }, "bo")
+    asserts.NotPanics(func() {})
+}
+
+func testAssertFailed(t) {
+    t.Log("before")
+    asserts.Equal(4, add(1, 2), "add should work")
+}
+
+func testSubTests(t) {
+    t.Run("ok", func(t) { asserts.True(true) })
+    t.Run("bad", func(t) { t.Errorf("bad %v", 1) })
+}
+
+func testSkip(t) {
+    t.Skip("not now")
+}
+
+func testFatal(t) {
+    t.Fatal("stop")
+    die("unreachable")
+}
+
+func testHTTPResponse() {
+    rsp = "HTTP/1.1 404 Not Found\r\nContent-Type: text/html\r\n\r\n<title>not found</title>"
+    asserts.HTTPStatusCode(rsp, 404)
+    asserts.HTTPHeader(rsp, "content-type", "html")
+    asserts.HTTPBodyContains(rsp, "not found")
+    asserts.HTTPBodyRegexp(rsp, "<title>.*</title>")
+}
+
+func testTimeout() {
+    for {
+    }
+}
+
+func helper() {}
+`
+
+func runTestCode(t *testing.T, opts ...Option) *Report {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a_test.yak"), []byte(testCode), 0o644))
+	// not test file
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.yak"), []byte(`die("should not run")`), 0o644))
+
+	report, err := Run(context.Background(), []string{dir}, append([]Option{WithTimeout(2 * time.Second)}, opts...)...)
+	require.NoError(t, err)
+	require.Len(t, report.Files, 1)
+	return report
+}
+
+func TestRun(t *testing.T) {
+	report := runTestCode(t)
+	tests := lo.SliceToMap(report.Files[0].Tests, func(r *TestResult) (string, *TestResult) { return r.Name, r })
+	require.Len(t, tests, 7)
+
+	require.Equal(t, StatusPass, tests["testAssert"].Status, tests["testAssert"].Failures)
+	require.Equal(t, StatusPass, tests["testHTTPResponse"].Status, tests["testHTTPResponse"].Failures)
+
+	failed := tests["testAssertFailed"]
+	require.Equal(t, StatusFail, failed.Status)
+	require.Equal(t, []string{"before"}, failed.Logs)
+	require.Contains(t, failed.Failures[0], "add should work: not equal")
+	require.Contains(t, failed.Failures[0], "expected: 4")
+
+	sub := tests["testSubTests"]
+	require.Equal(t, StatusFail, sub.Status)
+	require.Len(t, sub.SubTests, 2)
+	require.Equal(t, "testSubTests/ok", sub.SubTests[0].Name)
+	require.Equal(t, StatusPass, sub.SubTests[0].Status)
+	require.Equal(t, StatusFail, sub.SubTests[1].Status)
+	require.Equal(t, []string{"bad 1"}, sub.SubTests[1].Failures)
+
+	require.Equal(t, StatusSkip, tests["testSkip"].Status)
+	require.Equal(t, "not now", tests["testSkip"].Message)
+
+	require.Equal(t, StatusFail, tests["testFatal"].Status)
+	require.Equal(t, []string{"stop"}, tests["testFatal"].Failures)
+
+	require.Equal(t, StatusFail, tests["testTimeout"].Status)
+	require.Contains(t, tests["testTimeout"].Failures, "test timed out after 2s")
+
+	pass, fail, skip := report.Count()
+	require.Equal(t, []int{2, 4, 1}, []int{pass, fail, skip})
+	require.True(t, report.Failed())
+
+	raw, err := report.JUnit()
+	require.NoError(t, err)
+	var suites junitTestSuites
+	require.NoError(t, xml.Unmarshal(raw, &suites))
+	require.Equal(t, 9, suites.Tests)
+	require.Equal(t, 5, suites.Failures)
+	require.Equal(t, 1, suites.Skipped)
+}
+
+func TestRun_Pattern(t *testing.T) {
+	report := runTestCode(t, WithRunPattern("Assert$|SubTests/^ok$"))
+	names := lo.Map(report.Files[0].Tests, func(r *TestResult, _ int) string { return r.Name })
+	require.Equal(t, []string{"testAssert", "testSubTests"}, names)
+	require.Len(t, report.Files[0].Tests[1].SubTests, 1)
+	require.False(t, report.Failed())
+}
+
+func TestRun_CompileError(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bad_test.yak"), []byte("func testA() {"), 0o644))
+	report, err := Run(context.Background(), []string{dir})
+	require.NoError(t, err)
+	require.NotEmpty(t, report.Files[0].Error)
+	require.True(t, report.Failed())
+}
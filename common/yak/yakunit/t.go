@@ -0,0 +1,205 @@
+package yakunit
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yaklang/yaklang/common/yak/antlr4yak/yakvm"
+	"github.com/yaklang/yaklang/common/yak/yaklib"
+)
+
+// stopSignal is the panic value of Fatal, FailNow and Skip, it stops the test function
+type stopSignal struct{}
+
+// T is passed to the test function which declares a parameter: `func testXxx(t) { ... }`,
+// it records the logs, failures and runs subtests like *testing.T of golang.
+type T struct {
+	ctx    context.Context
+	name   string
+	result *TestResult
+	runner *runner
+
+	mu      sync.Mutex
+	failed  bool
+	skipped bool
+}
+
+func newT(ctx context.Context, name string, r *runner) *T {
+	return &T{
+		ctx:    ctx,
+		name:   name,
+		result: &TestResult{Name: name},
+		runner: r,
+	}
+}
+
+// Name return the full name of test, subtest is named `parent/sub`
+func (t *T) Name() string {
+	return t.name
+}
+
+// Log record the message in test result
+func (t *T) Log(args ...any) {
+	t.log(strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+}
+
+// Logf record the formatted message in test result
+func (t *T) Logf(format string, args ...any) {
+	t.log(fmt.Sprintf(format, args...))
+}
+
+func (t *T) log(msg string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.result.Logs = append(t.result.Logs, msg)
+}
+
+// Fail mark the test failed but continue execution
+func (t *T) Fail() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.failed = true
+}
+
+// Failed report whether the test has failed
+func (t *T) Failed() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.failed
+}
+
+// FailNow mark the test failed and stop it
+func (t *T) FailNow() {
+	t.Fail()
+	panic(stopSignal{})
+}
+
+// Error is equivalent to Log followed by Fail
+func (t *T) Error(args ...any) {
+	t.fail(strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+}
+
+// Errorf is equivalent to Logf followed by Fail
+func (t *T) Errorf(format string, args ...any) {
+	t.fail(fmt.Sprintf(format, args...))
+}
+
+// Fatal is equivalent to Log followed by FailNow
+func (t *T) Fatal(args ...any) {
+	t.Error(args...)
+	panic(stopSignal{})
+}
+
+// Fatalf is equivalent to Logf followed by FailNow
+func (t *T) Fatalf(format string, args ...any) {
+	t.Errorf(format, args...)
+	panic(stopSignal{})
+}
+
+// Skip mark the test skipped and stop it
+func (t *T) Skip(args ...any) {
+	t.mu.Lock()
+	t.skipped = true
+	t.result.Message = strings.TrimSuffix(fmt.Sprintln(args...), "\n")
+	t.mu.Unlock()
+	panic(stopSignal{})
+}
+
+// Skipf mark the test skipped with formatted message and stop it
+func (t *T) Skipf(format string, args ...any) {
+	t.Skip(fmt.Sprintf(format, args...))
+}
+
+// Run run f as a subtest of t, report whether f succeeded
+func (t *T) Run(name string, f func(t *T)) bool {
+	sub := newT(t.ctx, t.name+"/"+name, t.runner)
+	if !t.runner.match(sub.name) {
+		return true
+	}
+	t.runner.start(sub.name)
+	sub.call(func() error {
+		f(sub)
+		return nil
+	})
+	t.runner.finish(sub.result)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.result.SubTests = append(t.result.SubTests, sub.result)
+	if sub.result.Status == StatusFail {
+		t.failed = true
+	}
+	return sub.result.Status != StatusFail
+}
+
+func (t *T) timedOut(timeout time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.failed = true
+	t.result.Status = StatusFail
+	t.result.Failures = append(t.result.Failures, fmt.Sprintf("test timed out after %v", timeout))
+}
+
+func (t *T) fail(msg string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.failed = true
+	t.result.Failures = append(t.result.Failures, msg)
+}
+
+// call run the test body and set the status by failures, skip and panic
+func (t *T) call(f func() error) {
+	start := time.Now()
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				t.recovered(r)
+			}
+		}()
+		if err := f(); err != nil {
+			t.recovered(err)
+		}
+	}()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.result.Elapsed = time.Since(start).Seconds()
+	switch {
+	case t.failed:
+		t.result.Status = StatusFail
+	case t.skipped:
+		t.result.Status = StatusSkip
+	default:
+		t.result.Status = StatusPass
+	}
+}
+
+var ansiColorRegexp = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// recovered handle the panic or error of test body, the panic in yak code is wrapped as *yakvm.VMPanic
+func (t *T) recovered(r any) {
+	if p, ok := r.(*yakvm.VMPanic); ok {
+		if _, ok := p.GetData().(stopSignal); ok {
+			return
+		}
+	}
+	if _, ok := r.(stopSignal); ok {
+		return
+	}
+	t.fail(panicMessage(r))
+}
+
+// panicMessage return the message of failed assertion or panic, the panic stack of yakvm is kept without color
+func panicMessage(r any) string {
+	switch ret := r.(type) {
+	case *yaklib.AssertionError:
+		return ret.Message
+	case error:
+		return strings.TrimSpace(ansiColorRegexp.ReplaceAllString(ret.Error(), ""))
+	}
+	return fmt.Sprint(r)
+}
@@ -156,13 +156,13 @@ func (s *Server) Codec(ctx context.Context, req *ypb.CodecRequest) (*ypb.CodecRe
 			return nil, err
 		}
 
-		engine, err := yak.NewScriptEngine(1000).ExecuteEx(script.Content, map[string]interface{}{
+		engine, err := yak.NewScriptEngine(1000).ExecutePluginEx(script, map[string]interface{}{
 			"YAK_FILENAME": req.GetScriptName(),
 		})
 		if err != nil {
 			return nil, utils.Errorf("execute file %s code failed: %s", req.GetScriptName(), err.Error())
 		}
-		result, err := engine.CallYakFunction(context.Background(), "handle", []interface{}{text})
+		result, err := engine.SafeCallYakFunction(context.Background(), "handle", []interface{}{text})
 		if err != nil {
 			return nil, utils.Errorf("import %v' s handle failed: %s", req.GetScriptName(), err)
 		}
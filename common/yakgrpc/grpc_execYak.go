@@ -13,7 +13,11 @@ import (
 	"github.com/yaklang/yaklang/common/utils"
 	"github.com/yaklang/yaklang/common/yak"
 	"github.com/yaklang/yaklang/common/yak/antlr4yak"
+	"github.com/yaklang/yaklang/common/yak/antlr4yak/yakast"
+	"github.com/yaklang/yaklang/common/yak/antlr4yak/yakvm"
 	"github.com/yaklang/yaklang/common/yak/yaklib"
+	"github.com/yaklang/yaklang/common/yak/yakpolicy"
+	"github.com/yaklang/yaklang/common/yak/yakprof"
 	"github.com/yaklang/yaklang/common/yakgrpc/yakit"
 	"github.com/yaklang/yaklang/common/yakgrpc/ypb"
 	"io"
@@ -136,6 +140,7 @@ func (s *Server) execRequest(req *ypb.ExecRequest, moduleName string, ctx contex
 	log.Info("start to fetch/handling yak code...")
 	var code = req.GetScript()
 	var scriptId = req.GetScriptId()
+	var sandboxPolicy = req.GetSandboxPolicy()
 	if code == "" {
 		if scriptId == "" {
 			return utils.Errorf("fetch yak code failed: %s", "empty code and scriptId")
@@ -145,6 +150,10 @@ func (s *Server) execRequest(req *ypb.ExecRequest, moduleName string, ctx contex
 			return utils.Errorf("cannot find script yak code by scriptId:[%s]", scriptId)
 		}
 		code = script.Content
+		// 直接执行插件代码时使用插件的沙箱策略
+		if sandboxPolicy == "" {
+			sandboxPolicy = yak.PluginSandboxPolicy(script)
+		}
 	}
 	f, err := ioutil.TempFile("", "yaki-code-*.yak")
 	if err != nil {
@@ -165,9 +174,28 @@ func (s *Server) execRequest(req *ypb.ExecRequest, moduleName string, ctx contex
 		}
 		fmt.Println(string(raw))
 	})
+	var engineParams []string
+	var profileFile string
+	if req.GetProfile() {
+		pf, err := os.CreateTemp("", "yak-profile-*")
+		if err != nil {
+			return utils.Errorf("create temp file(for saving profile) failed: %s", err)
+		}
+		pf.Close()
+		profileFile = pf.Name()
+		defer os.RemoveAll(profileFile)
+		// 引擎参数需要在脚本文件之前
+		engineParams = append(engineParams, "--profile", profileFile, "--profile-format", execProfileFormat(req))
+	}
+	if sandboxPolicy != "" {
+		if _, err := yakpolicy.Resolve(sandboxPolicy); err != nil {
+			return err
+		}
+		engineParams = append(engineParams, "--sandbox-policy", sandboxPolicy)
+	}
 	cmd := exec.CommandContext(
 		ctx, yakEnginePath,
-		append([]string{f.Name()}, params...)...)
+		append(append(engineParams, f.Name()), params...)...)
 
 	cmd.Env = append(cmd.Env, os.Environ()...) // 继承主进程环境变量？
 
@@ -216,6 +244,17 @@ func (s *Server) execRequest(req *ypb.ExecRequest, moduleName string, ctx contex
 
 	start := time.Now()
 	err = cmd.Run()
+	if profileFile != "" {
+		if raw, _ := os.ReadFile(profileFile); len(raw) > 0 {
+			if sendErr := handler(&ypb.ExecResult{
+				RuntimeID:     runtimeId,
+				Profile:       raw,
+				ProfileFormat: execProfileFormat(req),
+			}, nil); sendErr != nil {
+				log.Errorf("send profile failed: %v", sendErr)
+			}
+		}
+	}
 	history := &schema.ExecHistory{
 		Script:        code,
 		RuntimeId:     runtimeId,
@@ -262,14 +301,43 @@ func (s *Server) execRequestInCurrentServerEngine(req *ypb.ExecRequest, moduleNa
 	go func() {
 		s.AttachCombinedOutput(nil, vAttach)
 	}()
+	policy, err := yakpolicy.Resolve(req.GetSandboxPolicy())
+	if err != nil {
+		return err
+	}
 	engine := yak.NewYakitVirtualClientScriptEngine(feedbackClient)
-	var fp, err = os.CreateTemp(os.TempDir(), "yakit-plugin-selector-*.txt")
+	engine.SetPolicy(policy)
+	fp, err := os.CreateTemp(os.TempDir(), "yakit-plugin-selector-*.txt")
 	if err != nil {
 		return utils.Errorf("create yakit plugin selector failed: %s", err)
 	}
 	fp.WriteString(req.GetScript())
 	fp.Close()
-	return engine.ExecuteMain(req.GetScript(), fp.Name())
+	if !req.GetProfile() {
+		return engine.ExecuteMain(req.GetScript(), fp.Name())
+	}
+
+	profiler := yakvm.NewProfiler(0)
+	engine.SetProfiler(profiler)
+	profiler.Start()
+	err = engine.ExecuteMain(req.GetScript(), fp.Name())
+	profiler.Stop()
+	raw, profileErr := yakprof.NewProfile(profiler).Bytes(execProfileFormat(req))
+	if profileErr != nil {
+		log.Errorf("generate profile failed: %v", profileErr)
+		return err
+	}
+	if sendErr := handler(&ypb.ExecResult{Profile: raw, ProfileFormat: execProfileFormat(req)}, nil); sendErr != nil {
+		log.Errorf("send profile failed: %v", sendErr)
+	}
+	return err
+}
+
+func execProfileFormat(req *ypb.ExecRequest) string {
+	if req.GetProfileFormat() == "" {
+		return yakprof.FormatPprof
+	}
+	return req.GetProfileFormat()
 }
 func (s *Server) Exec(req *ypb.ExecRequest, stream ypb.Yak_ExecServer) error {
 	return s.ExecWithContext(stream.Context(), req, stream)
@@ -300,3 +368,12 @@ func (s *Server) YaklangCompileAndFormat(cx context.Context, req *ypb.YaklangCom
 	}
 	return &ypb.YaklangCompileAndFormatResponse{Code: newCode}, nil
 }
+
+// FormatYakCode 格式化 yak 代码并保留注释，用于编辑器保存时格式化
+func (s *Server) FormatYakCode(ctx context.Context, req *ypb.FormatYakCodeRequest) (*ypb.FormatYakCodeResponse, error) {
+	code, err := yakast.Format(req.GetCode())
+	if err != nil {
+		return nil, err
+	}
+	return &ypb.FormatYakCodeResponse{Code: code, Changed: code != req.GetCode()}, nil
+}
@@ -320,3 +320,88 @@ func TestGRPCMUSTPASS_LANGUAGE_YakitLog(t *testing.T) {
 	//	}
 	//}
 }
+
+func TestGRPCMUSTPASS_ExecWithProfile(t *testing.T) {
+	client, err := NewLocalClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stream, err := client.Exec(context.Background(), &ypb.ExecRequest{
+		NoDividedEngine: true,
+		ProfileFormat:   "folded",
+		Profile:         true,
+		Script: `hot = func() {
+    start = time.Now().UnixNano()
+    for time.Now().UnixNano() - start < 200000000 {
+        a = str.Repeat("a", 16)
+    }
+}
+hot()
+`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var profile []byte
+	for {
+		rsp, err := stream.Recv()
+		if err != nil {
+			break
+		}
+		if len(rsp.GetProfile()) > 0 {
+			assert.Equal(t, "folded", rsp.GetProfileFormat())
+			profile = rsp.GetProfile()
+		}
+	}
+	assert.Contains(t, string(profile), ";hot (")
+}
+
+func TestGRPCMUSTPASS_ExecWithSandboxPolicy(t *testing.T) {
+	client, err := NewLocalClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stream, err := client.Exec(context.Background(), &ypb.ExecRequest{
+		NoDividedEngine: true,
+		SandboxPolicy:   "isolated",
+		Script:          `exec.System("id")`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for {
+		_, err = stream.Recv()
+		if err != nil {
+			break
+		}
+	}
+	assert.ErrorContains(t, err, "sandbox policy[isolated] violation")
+}
+
+func TestGRPCMUSTPASS_LANGUAGE_FormatYakCode(t *testing.T) {
+	client, err := NewLocalClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rsp, err := client.FormatYakCode(context.Background(), &ypb.FormatYakCodeRequest{
+		Code: "// header\na=1// trailing\nrsp,req=poc.HTTP(x`GET /{{int(1-2)}} HTTP/1.1\nHost: example.com\n\n`)~",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, rsp.GetChanged())
+	assert.Equal(t, "// header\na = 1 // trailing\nrsp, req = poc.HTTP(x`GET /{{int(1-2)}} HTTP/1.1\nHost: example.com\n\n`)~", rsp.GetCode())
+
+	rsp, err = client.FormatYakCode(context.Background(), &ypb.FormatYakCodeRequest{Code: rsp.GetCode()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, rsp.GetChanged())
+
+	_, err = client.FormatYakCode(context.Background(), &ypb.FormatYakCodeRequest{Code: "a = (1"})
+	assert.Error(t, err)
+}
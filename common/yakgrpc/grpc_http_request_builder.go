@@ -59,10 +59,23 @@ func (s *Server) DebugPlugin(req *ypb.DebugPluginRequest, stream ypb.Yak_DebugPl
 		runtimeId = uuid.New().String()
 	}
 
+	var (
+		script *schema.YakScript
+		err    error
+	)
 	if req.GetPluginName() != "" {
-		return s.execScript(input, req.GetPluginType(), req.GetPluginName(), stream, execParams, runtimeId, req.GetHTTPRequestTemplate())
+		script, err = yakit.GetYakScriptByName(s.GetProfileDatabase(), req.GetPluginName())
+	} else {
+		script, err = yakit.NewTemporaryYakScript(req.GetPluginType(), req.GetCode())
+	}
+	if err != nil {
+		return err
+	}
+	// 调试时指定的沙箱策略优先于插件自身的策略
+	if req.GetSandboxPolicy() != "" {
+		script.SandboxPolicy = req.GetSandboxPolicy()
 	}
-	return s.debugScript(input, req.GetPluginType(), req.GetCode(), stream, execParams, runtimeId, req.GetHTTPRequestTemplate())
+	return s.execScriptEx(input, script, stream, execParams, runtimeId, req.GetHTTPRequestTemplate())
 }
 
 func (s *Server) HTTPRequestBuilder(ctx context.Context, req *ypb.HTTPRequestBuilderParams) (*ypb.HTTPRequestBuilderResponse, error) {
@@ -72,7 +72,12 @@ func (s *Server) execScriptWithExecParam(script *schema.YakScript, input string,
 		return stream.Send(result)
 	}, &count) // set risk count
 
+	policy, err := yak.ResolvePluginSandboxPolicy(script)
+	if err != nil {
+		return err
+	}
 	engine := yak.NewYakitVirtualClientScriptEngine(feedbackClient)
+	engine.SetPolicy(policy)
 	log.Infof("engine.ExecuteExWithContext(stream.Context(), debugScript ... \n")
 	engine.RegisterEngineHooks(func(engine *antlr4yak.Engine) error {
 		engine.SetVar("RUNTIME_ID", runtimeId)
@@ -276,22 +281,6 @@ func (s *Server) debugScript(
 	return s.execScriptEx(input, script, stream, execParams, runtimeId, params...)
 }
 
-func (s *Server) execScript(
-	input string, // only "codec" / url: "mitm" "nuclei" "port-scan"
-	scriptType string,
-	name string,
-	stream sender,
-	execParams []*ypb.KVPair, // 脚本执行的参数, only "yak"
-	runtimeId string,
-	params ...*ypb.HTTPRequestBuilderParams, // 用于构建请求, only used in "mitm", "nuclei", "port-scan"
-) error {
-	script, err := yakit.GetYakScriptByName(consts.GetGormProfileDatabase(), name)
-	if err != nil {
-		return err
-	}
-	return s.execScriptEx(input, script, stream, execParams, runtimeId, params...)
-}
-
 func (s *Server) execScriptEx(
 	input string, // only "codec" / url: "mitm" "nuclei" "port-scan"
 	script *schema.YakScript,
@@ -1207,3 +1207,43 @@ aaa`))
 	})
 
 }
+
+func TestGRPCMUSTPASS_HTTP_DebugPlugin_SandboxPolicy(t *testing.T) {
+	client, err := NewLocalClient()
+	require.NoError(t, err)
+
+	debug := func(req *ypb.DebugPluginRequest) error {
+		stream, err := client.DebugPlugin(context.Background(), req)
+		require.NoError(t, err)
+		for {
+			_, err := stream.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	code := `exec.System("id")`
+	err = debug(&ypb.DebugPluginRequest{Code: code, PluginType: "yak", SandboxPolicy: "isolated"})
+	require.ErrorContains(t, err, "sandbox policy[isolated] violation")
+
+	// 保存的插件使用自身的策略，调试时指定的策略优先
+	name := "sandbox-policy-" + uuid.NewString()
+	_, err = client.SaveNewYakScript(context.Background(), &ypb.SaveNewYakScriptRequest{
+		ScriptName: name, Type: "yak", Content: code, SandboxPolicy: "unknown",
+	})
+	require.Error(t, err)
+	script, err := client.SaveNewYakScript(context.Background(), &ypb.SaveNewYakScriptRequest{
+		ScriptName: name, Type: "yak", Content: code, SandboxPolicy: "restricted",
+	})
+	require.NoError(t, err)
+	defer yakit.DeleteYakScriptByName(consts.GetGormProfileDatabase(), name)
+	require.Equal(t, "restricted", script.GetSandboxPolicy())
+
+	err = debug(&ypb.DebugPluginRequest{PluginName: name, PluginType: "yak"})
+	require.ErrorContains(t, err, "sandbox policy[restricted] violation")
+	require.NoError(t, debug(&ypb.DebugPluginRequest{PluginName: name, PluginType: "yak", SandboxPolicy: "unrestricted"}))
+}
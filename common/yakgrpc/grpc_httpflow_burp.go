@@ -0,0 +1,56 @@
+package yakgrpc
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"github.com/yaklang/yaklang/common/log"
+	"github.com/yaklang/yaklang/common/schema"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/yakgrpc/yakit"
+	"github.com/yaklang/yaklang/common/yakgrpc/ypb"
+)
+
+// burpProgressInterval 每处理多少个 item 发送一次进度，burp 导出的 item 包含完整的请求与响应，通常较大
+const burpProgressInterval = 10
+
+func (s *Server) ImportHTTPFlowsFromBurp(req *ypb.ImportHTTPFlowsFromBurpRequest, stream ypb.Yak_ImportHTTPFlowsFromBurpServer) error {
+	path := req.GetBurpXMLPath()
+	fp, err := os.Open(path)
+	if err != nil {
+		return utils.Errorf("open burp xml failed: %s", err)
+	}
+	defer fp.Close()
+	info, err := fp.Stat()
+	if err != nil {
+		return err
+	}
+	reader := utils.NewCountingReader(bufio.NewReader(fp))
+
+	var skipped, processed int64
+	count, err := yakit.ImportHTTPFlowsFromBurp(stream.Context(), s.GetProjectDatabase(), reader, func(flow *schema.HTTPFlow, err error) {
+		processed++
+		if err != nil {
+			skipped++
+			log.Debugf("skip burp item: %v", err)
+		}
+		if processed%burpProgressInterval == 0 && info.Size() > 0 {
+			stream.Send(&ypb.ImportHTTPFlowsFromBurpProgress{
+				Percent: float64(reader.Count()) / float64(info.Size()),
+				Verbose: fmt.Sprintf("imported %d items", processed-skipped),
+				Count:   processed - skipped,
+				Skipped: skipped,
+			})
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return stream.Send(&ypb.ImportHTTPFlowsFromBurpProgress{
+		Percent: 1,
+		Verbose: fmt.Sprintf("imported %d httpflows from %s, %d items skipped", count, path, skipped),
+		Count:   int64(count),
+		Skipped: skipped,
+	})
+}
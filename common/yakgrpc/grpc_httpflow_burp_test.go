@@ -0,0 +1,117 @@
+package yakgrpc
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/yak/yaklib/codec"
+	"github.com/yaklang/yaklang/common/yakgrpc/yakit"
+	"github.com/yaklang/yaklang/common/yakgrpc/ypb"
+)
+
+func TestGRPCMUSTPASS_HTTPFlow_ImportBurp(t *testing.T) {
+	client, err := NewLocalClient()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	token := utils.RandStringBytes(16)
+	host := token + ".example.com"
+	request := codec.EncodeBase64("GET /admin?id=1 HTTP/1.1\r\nHost: " + host + "\r\n\r\n")
+	response := codec.EncodeBase64("HTTP/1.1 200 OK\r\nTransfer-Encoding: chunked\r\n\r\n5\r\nhello\r\n0\r\n\r\n")
+	content := `<?xml version="1.0"?>
+<items burpVersion="2023.10.3.4" exportTime="Mon Jan 01 08:00:00 UTC 2024">
+  <item>
+    <time>Mon Jan 01 08:00:00 UTC 2024</time>
+    <url><![CDATA[https://` + host + `/admin?id=1]]></url>
+    <host ip="127.0.0.1">` + host + `</host>
+    <port>443</port>
+    <protocol>https</protocol>
+    <method><![CDATA[GET]]></method>
+    <path><![CDATA[/admin?id=1]]></path>
+    <extension>null</extension>
+    <request base64="true"><![CDATA[` + request + `]]></request>
+    <status>200</status>
+    <responselength>5</responselength>
+    <mimetype>text</mimetype>
+    <response base64="true"><![CDATA[` + response + `]]></response>
+    <comment>idor|check</comment>
+    <highlight>magenta</highlight>
+  </item>
+  <item>
+    <time>Mon Jan 01 08:00:01 UTC 2024</time>
+    <url><![CDATA[http://` + host + `/]]></url>
+    <host ip="">` + host + `</host>
+    <port>80</port>
+    <protocol>http</protocol>
+    <method><![CDATA[GET]]></method>
+    <path><![CDATA[/]]></path>
+    <extension>null</extension>
+    <request base64="false"><![CDATA[GET / HTTP/1.1
+Host: ` + host + `
+
+]]></request>
+    <status></status>
+    <responselength></responselength>
+    <mimetype></mimetype>
+    <response base64="false"></response>
+    <comment></comment>
+  </item>
+  <item>
+    <time>Mon Jan 01 08:00:02 UTC 2024</time>
+    <url><![CDATA[https://` + host + `/broken]]></url>
+    <request base64="true"><![CDATA[%%%]]></request>
+  </item>
+</items>`
+	xmlPath := filepath.Join(t.TempDir(), "burp.xml")
+	require.NoError(t, os.WriteFile(xmlPath, []byte(content), 0o644))
+
+	importBurp := func() *ypb.ImportHTTPFlowsFromBurpProgress {
+		stream, err := client.ImportHTTPFlowsFromBurp(ctx, &ypb.ImportHTTPFlowsFromBurpRequest{BurpXMLPath: xmlPath})
+		require.NoError(t, err)
+		var last *ypb.ImportHTTPFlowsFromBurpProgress
+		for {
+			rsp, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			last = rsp
+		}
+		require.NotNil(t, last)
+		return last
+	}
+	progress := importBurp()
+	require.Equal(t, float64(1), progress.GetPercent())
+	require.Equal(t, int64(2), progress.GetCount())
+	require.Equal(t, int64(1), progress.GetSkipped())
+	// 重复导入不会产生重复的记录
+	importBurp()
+
+	flows, err := client.QueryHTTPFlows(ctx, &ypb.QueryHTTPFlowRequest{Keyword: token, SourceType: yakit.HTTPFlowSourceBurp})
+	require.NoError(t, err)
+	require.Len(t, flows.GetData(), 2)
+	var ids []int64
+	for _, flow := range flows.GetData() {
+		ids = append(ids, int64(flow.GetId()))
+	}
+	defer client.DeleteHTTPFlows(ctx, &ypb.DeleteHTTPFlowRequest{Id: ids})
+
+	for _, flow := range flows.GetData() {
+		if !flow.GetIsHTTPS() {
+			require.Equal(t, "http://"+host+"/", flow.GetUrl())
+			require.Empty(t, flow.GetTags())
+			continue
+		}
+		require.Equal(t, "https://"+host+"/admin?id=1", flow.GetUrl())
+		require.Equal(t, "127.0.0.1", flow.GetIPAddress())
+		require.Equal(t, int64(200), flow.GetStatusCode())
+		require.Contains(t, string(flow.GetResponse()), "hello")
+		require.Contains(t, flow.GetTags(), "idor check")
+		require.Contains(t, flow.GetTags(), "YAKIT_COLOR_PURPLE")
+	}
+}
@@ -0,0 +1,109 @@
+package yakgrpc
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"github.com/yaklang/yaklang/common/log"
+	"github.com/yaklang/yaklang/common/schema"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/utils/bizhelper"
+	"github.com/yaklang/yaklang/common/yakgrpc/yakit"
+	"github.com/yaklang/yaklang/common/yakgrpc/ypb"
+)
+
+// harProgressInterval 每处理多少条 HTTPFlow 发送一次进度
+const harProgressInterval = 100
+
+func (s *Server) ExportHTTPFlowsToHAR(req *ypb.ExportHTTPFlowsToHARRequest, stream ypb.Yak_ExportHTTPFlowsToHARServer) error {
+	path := req.GetTargetPath()
+	if path == "" {
+		return utils.Error("target path is empty")
+	}
+
+	db := s.GetProjectDatabase()
+	if len(req.GetIds()) > 0 {
+		db = bizhelper.ExactQueryInt64ArrayOr(db.Model(&schema.HTTPFlow{}), "id", req.GetIds())
+	} else {
+		db = yakit.FilterHTTPFlow(db, req.GetFilter())
+	}
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return utils.Errorf("count httpflow failed: %s", err)
+	}
+
+	fp, err := os.Create(path)
+	if err != nil {
+		return utils.Errorf("create har file failed: %s", err)
+	}
+	defer fp.Close()
+	w := bufio.NewWriter(fp)
+
+	count := 0
+	_, err = yakit.ExportHTTPFlowsToHAR(stream.Context(), db, w, func(flow *schema.HTTPFlow) {
+		count++
+		if count%harProgressInterval == 0 && total > 0 {
+			stream.Send(&ypb.HTTPFlowsHARProgress{
+				Percent:    float64(count) / float64(total),
+				Verbose:    fmt.Sprintf("exported %d/%d", count, total),
+				Count:      int64(count),
+				TargetPath: path,
+			})
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return stream.Send(&ypb.HTTPFlowsHARProgress{
+		Percent:    1,
+		Verbose:    fmt.Sprintf("exported %d httpflows to %s", count, path),
+		Count:      int64(count),
+		TargetPath: path,
+	})
+}
+
+func (s *Server) ImportHTTPFlowsFromHAR(req *ypb.ImportHTTPFlowsFromHARRequest, stream ypb.Yak_ImportHTTPFlowsFromHARServer) error {
+	path := req.GetHARPath()
+	fp, err := os.Open(path)
+	if err != nil {
+		return utils.Errorf("open har file failed: %s", err)
+	}
+	defer fp.Close()
+	info, err := fp.Stat()
+	if err != nil {
+		return err
+	}
+	reader := utils.NewCountingReader(bufio.NewReader(fp))
+
+	var skipped, processed int64
+	count, err := yakit.ImportHTTPFlowsFromHAR(stream.Context(), s.GetProjectDatabase(), reader, func(flow *schema.HTTPFlow, err error) {
+		processed++
+		if err != nil {
+			skipped++
+			log.Debugf("skip har entry: %v", err)
+		}
+		if processed%harProgressInterval == 0 && info.Size() > 0 {
+			stream.Send(&ypb.HTTPFlowsHARProgress{
+				Percent:    float64(reader.Count()) / float64(info.Size()),
+				Verbose:    fmt.Sprintf("imported %d entries", processed-skipped),
+				Count:      processed - skipped,
+				Skipped:    skipped,
+				TargetPath: path,
+			})
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return stream.Send(&ypb.HTTPFlowsHARProgress{
+		Percent:    1,
+		Verbose:    fmt.Sprintf("imported %d httpflows from %s, %d entries skipped", count, path, skipped),
+		Count:      int64(count),
+		Skipped:    skipped,
+		TargetPath: path,
+	})
+}
@@ -0,0 +1,145 @@
+package yakgrpc
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yaklang/yaklang/common/har"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/yakgrpc/yakit"
+	"github.com/yaklang/yaklang/common/yakgrpc/ypb"
+)
+
+func recvHARProgress(t *testing.T, stream interface {
+	Recv() (*ypb.HTTPFlowsHARProgress, error)
+}) *ypb.HTTPFlowsHARProgress {
+	var last *ypb.HTTPFlowsHARProgress
+	for {
+		rsp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		last = rsp
+	}
+	require.NotNil(t, last)
+	return last
+}
+
+func TestGRPCMUSTPASS_HTTPFlow_HAR(t *testing.T) {
+	client, err := NewLocalClient()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	token := utils.RandStringBytes(16)
+	harContent := `{"log": {"version": "1.2", "creator": {"name": "WebInspector", "version": "537.36"}, "entries": [
+{
+  "startedDateTime": "2024-01-01T00:00:00.000Z", "time": 42,
+  "request": {"method": "GET", "url": "https://` + token + `.example.com/index?a=1", "httpVersion": "HTTP/1.1",
+    "headers": [{"name": "Host", "value": "` + token + `.example.com"}], "queryString": [], "cookies": [], "headersSize": -1, "bodySize": 0},
+  "response": {"status": 200, "statusText": "OK", "httpVersion": "HTTP/1.1",
+    "headers": [{"name": "Content-Type", "value": "text/html"}, {"name": "Content-Encoding", "value": "br"}],
+    "cookies": [], "content": {"size": 12, "mimeType": "text/html", "text": "hello world!"}, "redirectURL": "", "headersSize": -1, "bodySize": -1},
+  "cache": {}, "timings": {"blocked": -1, "dns": 5, "connect": 10, "ssl": 4, "send": 1, "wait": 20, "receive": 6},
+  "serverIPAddress": "127.0.0.1"
+},
+{
+  "startedDateTime": "2024-01-01T00:00:01.000Z", "time": 1,
+  "request": {"method": "GET", "url": "wss://` + token + `.example.com/ws", "httpVersion": "HTTP/1.1",
+    "headers": [{"name": "Upgrade", "value": "websocket"}], "queryString": [], "cookies": [], "headersSize": -1, "bodySize": 0},
+  "response": {"status": 101, "statusText": "Switching Protocols", "httpVersion": "HTTP/1.1", "headers": [{"name": "Upgrade", "value": "websocket"}],
+    "cookies": [], "content": {"size": 0, "mimeType": "x-unknown"}, "redirectURL": "", "headersSize": -1, "bodySize": 0},
+  "cache": {}, "timings": {"send": 0, "wait": 1, "receive": 0},
+  "_resourceType": "websocket",
+  "_webSocketMessages": [
+    {"type": "send", "time": 1704067201.5, "opcode": 1, "data": "ping"},
+    {"type": "receive", "time": 1704067202, "opcode": 2, "data": "AAE="}
+  ]
+},
+{
+  "startedDateTime": "2024-01-01T00:00:02.000Z", "time": 0,
+  "request": {"method": "GET", "url": "data:text/plain,abc", "httpVersion": "", "headers": [], "queryString": [], "cookies": [], "headersSize": -1, "bodySize": 0},
+  "response": {"status": 200, "statusText": "OK", "httpVersion": "", "headers": [], "cookies": [], "content": {"size": 3, "mimeType": "text/plain", "text": "abc"}, "redirectURL": "", "headersSize": -1, "bodySize": 0},
+  "cache": {}, "timings": {"send": 0, "wait": 0, "receive": 0}
+}
+]}}`
+	dir := t.TempDir()
+	harPath := filepath.Join(dir, "devtools.har")
+	require.NoError(t, os.WriteFile(harPath, []byte(harContent), 0o644))
+
+	importHAR := func() *ypb.HTTPFlowsHARProgress {
+		stream, err := client.ImportHTTPFlowsFromHAR(ctx, &ypb.ImportHTTPFlowsFromHARRequest{HARPath: harPath})
+		require.NoError(t, err)
+		return recvHARProgress(t, stream)
+	}
+	progress := importHAR()
+	require.Equal(t, float64(1), progress.GetPercent())
+	require.Equal(t, int64(2), progress.GetCount())
+	require.Equal(t, int64(1), progress.GetSkipped())
+	// 重复导入不会产生重复的记录
+	importHAR()
+
+	flows, err := client.QueryHTTPFlows(ctx, &ypb.QueryHTTPFlowRequest{Keyword: token, SourceType: yakit.HTTPFlowSourceHAR})
+	require.NoError(t, err)
+	require.Len(t, flows.GetData(), 2)
+	var ids []int64
+	var wsHash string
+	for _, flow := range flows.GetData() {
+		ids = append(ids, int64(flow.GetId()))
+		if flow.GetIsWebsocket() {
+			wsHash = flow.GetWebsocketHash()
+		} else {
+			require.Equal(t, "127.0.0.1", flow.GetIPAddress())
+			require.Contains(t, string(flow.GetResponse()), "hello world!")
+			require.NotContains(t, string(flow.GetResponse()), "Content-Encoding")
+		}
+	}
+	defer client.DeleteHTTPFlows(ctx, &ypb.DeleteHTTPFlowRequest{Id: ids})
+	require.NotEmpty(t, wsHash)
+	frames, err := client.QueryWebsocketFlowByHTTPFlowWebsocketHash(ctx, &ypb.QueryWebsocketFlowByHTTPFlowWebsocketHashRequest{
+		WebsocketRequestHash: wsHash,
+		Pagination:           &ypb.Paging{Page: 1, Limit: 10},
+	})
+	require.NoError(t, err)
+	require.Len(t, frames.GetData(), 2)
+
+	exportPath := filepath.Join(dir, "export.har")
+	stream, err := client.ExportHTTPFlowsToHAR(ctx, &ypb.ExportHTTPFlowsToHARRequest{TargetPath: exportPath, Ids: ids})
+	require.NoError(t, err)
+	progress = recvHARProgress(t, stream)
+	require.Equal(t, int64(2), progress.GetCount())
+
+	fp, err := os.Open(exportPath)
+	require.NoError(t, err)
+	defer fp.Close()
+	entries := make(map[string]*har.Entry)
+	require.NoError(t, har.ReadEntries(fp, func(entry *har.Entry) error {
+		entries[entry.Request.URL] = entry
+		return nil
+	}))
+	require.Len(t, entries, 2)
+
+	entry := entries["https://"+token+".example.com/index?a=1"]
+	require.NotNil(t, entry)
+	require.Equal(t, float64(5), entry.Timings.DNS)
+	require.Equal(t, float64(10), entry.Timings.Connect)
+	require.Equal(t, float64(20), entry.Timings.Wait)
+	require.Equal(t, float64(7), entry.Timings.Receive)
+	require.Equal(t, float64(42), entry.Time)
+	require.Equal(t, token+".example.com", entry.TLS.ServerName)
+	require.Equal(t, "hello world!", entry.Response.Content.Text)
+	require.Equal(t, "127.0.0.1", entry.ServerIPAddress)
+	require.Equal(t, "443", entry.Connection)
+
+	entry = entries["wss://"+token+".example.com/ws"]
+	require.NotNil(t, entry)
+	require.Len(t, entry.WebSocketMessages, 2)
+	require.Equal(t, &har.WebSocketMessage{Type: "send", Time: 1704067201.5, Opcode: 1, Data: "ping"}, entry.WebSocketMessages[0])
+	require.Equal(t, &har.WebSocketMessage{Type: "receive", Time: 1704067202, Opcode: 2, Data: "AAE="}, entry.WebSocketMessages[1])
+	require.True(t, strings.HasPrefix(entry.StartedDateTime, "2024-01-01T00:00:01"))
+}
@@ -24,10 +24,12 @@ import (
 	"github.com/yaklang/yaklang/common/log"
 	"github.com/yaklang/yaklang/common/utils"
 	"github.com/yaklang/yaklang/common/utils/bizhelper"
+	"github.com/yaklang/yaklang/common/yak"
 	"github.com/yaklang/yaklang/common/yak/antlr4yak"
 	"github.com/yaklang/yaklang/common/yak/httptpl"
 	"github.com/yaklang/yaklang/common/yak/yaklib"
 	"github.com/yaklang/yaklang/common/yak/yaklib/tools"
+	"github.com/yaklang/yaklang/common/yak/yakpolicy"
 	"github.com/yaklang/yaklang/common/yakgrpc/yakit"
 	"github.com/yaklang/yaklang/common/yakgrpc/ypb"
 )
@@ -236,6 +238,7 @@ func GRPCYakScriptToYakitScript(script *ypb.YakScript) *schema.YakScript {
 		GeneralModuleVerbose: script.GeneralModuleVerbose,
 		EnablePluginSelector: script.EnablePluginSelector,
 		PluginSelectorTypes:  script.PluginSelectorTypes,
+		SandboxPolicy:        script.SandboxPolicy,
 	}
 }
 
@@ -252,6 +255,9 @@ func (s *Server) SaveYakScript(ctx context.Context, script *ypb.YakScript) (*ypb
 		}
 	}
 
+	if _, err := yakpolicy.Resolve(script.GetSandboxPolicy()); err != nil {
+		return nil, utils.Errorf("save plugin failed! invalid sandbox policy: %s", err)
+	}
 	err := yakit.CreateOrUpdateYakScriptByName(s.GetProfileDatabase(), script.ScriptName, GRPCYakScriptToYakitScript(script))
 	if err != nil {
 		return nil, utils.Errorf("create or update yakscript failed: %s", err.Error())
@@ -260,6 +266,7 @@ func (s *Server) SaveYakScript(ctx context.Context, script *ypb.YakScript) (*ypb
 	_ = yakit.CreateOrUpdateYakScriptByName(s.GetProfileDatabase(), script.ScriptName, map[string]interface{}{
 		"enable_plugin_selector": script.EnablePluginSelector,
 		"plugin_selector_types":  script.PluginSelectorTypes,
+		"sandbox_policy":         script.SandboxPolicy,
 	})
 
 	//if !script.IsGeneralModule {
@@ -390,6 +397,10 @@ func (s *Server) ExecYakScript(req *ypb.ExecRequest, stream ypb.Yak_ExecYakScrip
 	if script == nil {
 		return utils.Errorf("cannot fetch yak script (ExecYakScript) failed: %s", spew.Sdump(req))
 	}
+	// 执行时指定的沙箱策略优先于插件自身的策略
+	if req.GetSandboxPolicy() != "" {
+		script.SandboxPolicy = req.GetSandboxPolicy()
+	}
 
 	switch strings.ToLower(script.Type) {
 	case "packet-hack":
@@ -450,6 +461,7 @@ func (s *Server) ExecYakScript(req *ypb.ExecRequest, stream ypb.Yak_ExecYakScrip
 	default:
 		req.ScriptId = script.ScriptName
 		req.YakScriptId = int64(script.ID)
+		req.SandboxPolicy = yak.PluginSandboxPolicy(script)
 		return s.ExecWithContext(stream.Context(), req, stream)
 	}
 }
@@ -926,6 +938,7 @@ func GRPCYakScriptToYakScript(script *ypb.SaveNewYakScriptRequest) map[string]in
 		"general_module_verbose": script.GeneralModuleVerbose,
 		"enable_plugin_selector": script.EnablePluginSelector,
 		"plugin_selector_types":  script.PluginSelectorTypes,
+		"sandbox_policy":         script.SandboxPolicy,
 	}
 	if len(script.Params) > 0 {
 		raw, _ := json.Marshal(script.Params)
@@ -954,6 +967,9 @@ func (s *Server) SaveNewYakScript(ctx context.Context, script *ypb.SaveNewYakScr
 			return nil, utils.Errorf("save plugin failed! content is invalid(潜在语法错误): %s", err)
 		}
 	}
+	if _, err := yakpolicy.Resolve(script.GetSandboxPolicy()); err != nil {
+		return nil, utils.Errorf("save plugin failed! invalid sandbox policy: %s", err)
+	}
 	script.ScriptName = strings.TrimSpace(script.ScriptName)
 
 	yakScript, _ := yakit.GetYakScriptByName(s.GetProfileDatabase(), script.ScriptName)
@@ -94,8 +94,9 @@ var constClujore = func(i interface{}) func() interface{} {
 }
 
 const (
-	MITMReplacerKeyRecords = "R1oHf8xca6CobwVg2_MITMReplacerKeyRecords"
-	MITMFilterKeyRecords   = "uWokegBnCQdnxezJtMVo_MITMFilterKeyRecords"
+	MITMReplacerKeyRecords   = "R1oHf8xca6CobwVg2_MITMReplacerKeyRecords"
+	MITMFilterKeyRecords     = "uWokegBnCQdnxezJtMVo_MITMFilterKeyRecords"
+	MITMBreakpointKeyRecords = "Qm3vTyd9aKcW2rXhE_MITMBreakpointKeyRecords"
 )
 
 func (s *Server) MITM(stream ypb.Yak_MITMServer) error {
@@ -366,6 +367,16 @@ func (s *Server) MITM(stream ypb.Yak_MITMServer) error {
 		}
 	}()
 
+	/*
+		断点规则，自动放行时只暂停命中规则的请求与响应
+	*/
+	breakpoint := newMITMBreakpointManager(func(flow *ypb.MITMBreakpointFlow) {
+		send(&ypb.MITMResponse{BreakpointFlow: flow})
+	})
+	breakpoint.LoadRules(loadMITMBreakpointRules(s.GetProfileDatabase()))
+	mitmBreakpointManagers.Store(breakpoint, breakpoint)
+	defer mitmBreakpointManagers.Delete(breakpoint)
+
 	autoForward := utils.NewBool(true)
 	autoForwardCh := make(chan struct{}, 1)
 
@@ -612,6 +623,18 @@ func (s *Server) MITM(stream ypb.Yak_MITMServer) error {
 					feedbackToUser(fmt.Sprintf("设置下游代理成功 / set downstream proxy successful: %v", downstreamProxy))
 				}
 			}
+
+			// 放行断点暂停的报文，不进入手动劫持的队列
+			if id := reqInstance.GetBreakpointFlowId(); id != "" {
+				packet := reqInstance.GetResponse()
+				if len(packet) <= 0 {
+					packet = reqInstance.GetRequest()
+				}
+				if !breakpoint.Resume(&ypb.ResumeMITMBreakpointFlowRequest{Id: id, Packet: packet, Drop: reqInstance.GetDrop()}) {
+					log.Warnf("breakpoint flow %v not found or already resumed", id)
+				}
+				continue
+			}
 			messageChan <- reqInstance
 		}
 	}()
@@ -841,7 +864,7 @@ func (s *Server) MITM(stream ypb.Yak_MITMServer) error {
 
 			// 处理响应规则
 			if replacer.haveHijackingRules() {
-				rules, rspHooked, dropped := replacer.hook(false, true, rsp)
+				rules, rspHooked, dropped := replacer.hook(false, true, rsp, httpctx.GetRequestHTTPS(req), httpctx.GetRequestBytes(req))
 				if dropped {
 					httpctx.SetContextValueInfoFromRequest(req, httpctx.RESPONSE_CONTEXT_KEY_IsDropped, true)
 					log.Warn("response should be dropped(VIA replacer.hook)")
@@ -852,13 +875,38 @@ func (s *Server) MITM(stream ypb.Yak_MITMServer) error {
 					httpctx.SetResponseModified(req, "yakit.rule.hook")
 					httpctx.SetHijackedResponseBytes(req, rspHooked)
 				}
-				return rspHooked
+				rsp = rspHooked
 			}
-			return rsp
+
+			// 命中断点规则的响应需要暂停
+			if httpctx.GetContextBoolInfoFromRequest(req, httpctx.REQUEST_CONTEXT_KEY_RequestIsFiltered) || !breakpoint.haveRules() {
+				return rsp
+			}
+			rule := breakpoint.Match(true, plainRequest, rsp)
+			if rule == nil {
+				return rsp
+			}
+			httpctx.SetResponseViewedByUser(req)
+			response, drop := breakpoint.Wait(ctx, rule, true, isHttps, urlStr, plainRequest, rsp)
+			if drop {
+				httpctx.SetContextValueInfoFromRequest(req, httpctx.RESPONSE_CONTEXT_KEY_IsDropped, true)
+				return nil
+			}
+			if !handleResponseModified(response) {
+				return rsp
+			}
+			fixed, _, err := lowhttp.FixHTTPResponse(response)
+			if err != nil {
+				log.Errorf("fix breakpoint response failed: %s", err)
+				return rsp
+			}
+			httpctx.SetResponseModified(req, "yakit.mitm.breakpoint")
+			httpctx.SetHijackedResponseBytes(req, fixed)
+			return fixed
 		}
 
 		// 非自动转发的情况下处理替换器
-		rules, rsp1, shouldBeDropped := replacer.hook(false, true, rsp)
+		rules, rsp1, shouldBeDropped := replacer.hook(false, true, rsp, httpctx.GetRequestHTTPS(req), httpctx.GetRequestBytes(req))
 		if shouldBeDropped {
 			log.Warn("response should be dropped(VIA replacer.hook)")
 			httpctx.SetContextValueInfoFromRequest(req, httpctx.RESPONSE_CONTEXT_KEY_IsDropped, true)
@@ -1276,7 +1324,26 @@ func (s *Server) MITM(stream ypb.Yak_MITMServer) error {
 		// MITM 手动劫持放行
 		if autoForward.IsSet() {
 			httpctx.SetContextValueInfoFromRequest(originReqIns, httpctx.REQUEST_CONTEXT_KEY_AutoFoward, true)
-			return req
+			if !breakpoint.haveRules() {
+				return req
+			}
+			// 命中断点规则的请求需要暂停
+			rule := breakpoint.Match(false, req, nil)
+			if rule == nil {
+				return req
+			}
+			httpctx.SetRequestViewedByUser(originReqIns)
+			request, drop := breakpoint.Wait(ctx, rule, false, isHttps, urlStr, req, nil)
+			if drop {
+				httpctx.SetContextValueInfoFromRequest(originReqIns, httpctx.REQUEST_CONTEXT_KEY_IsDropped, true)
+				return nil
+			}
+			if bytes.Equal(request, req) {
+				return req
+			}
+			request = lowhttp.FixHTTPRequest(request)
+			setModifiedRequest("yakit.mitm.breakpoint", request)
+			return request
 		}
 
 		taskID := utils.CalcSha1(fmt.Sprintf("%p", originReqIns))
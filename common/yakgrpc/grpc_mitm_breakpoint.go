@@ -0,0 +1,342 @@
+package yakgrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gobwas/glob"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	"github.com/yaklang/yaklang/common/log"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/utils/lowhttp"
+	"github.com/yaklang/yaklang/common/yakgrpc/yakit"
+	"github.com/yaklang/yaklang/common/yakgrpc/ypb"
+)
+
+// defaultMITMBreakpointTimeout 断点规则没有设置超时时间时，暂停的报文在这之后自动放行
+const defaultMITMBreakpointTimeout = 5 * time.Minute
+
+// MITMBreakpointFlow.Action
+const (
+	breakpointActionForward  = "forward"
+	breakpointActionModified = "modified"
+	breakpointActionDrop     = "drop"
+	breakpointActionTimeout  = "timeout"
+)
+
+// mitmBreakpointManagers 所有运行中的 MITM 会话的断点，规则更新与放行需要通知每一个会话
+var mitmBreakpointManagers = new(sync.Map)
+
+type mitmBreakpointRule struct {
+	*ypb.MITMBreakpointRule
+	hosts       []glob.Glob
+	path        *regexp.Regexp
+	statusCodes []int
+}
+
+func newMITMBreakpointRule(r *ypb.MITMBreakpointRule) (*mitmBreakpointRule, error) {
+	rule := &mitmBreakpointRule{MITMBreakpointRule: r}
+	for _, pattern := range utils.PrettifyListFromStringSplitEx(r.GetHost(), ",") {
+		g, err := glob.Compile(strings.ToLower(pattern))
+		if err != nil {
+			return nil, utils.Errorf("invalid host glob %v: %v", pattern, err)
+		}
+		rule.hosts = append(rule.hosts, g)
+	}
+	if r.GetPathRegexp() != "" {
+		re, err := regexp.Compile(r.GetPathRegexp())
+		if err != nil {
+			return nil, utils.Errorf("invalid path regexp %v: %v", r.GetPathRegexp(), err)
+		}
+		rule.path = re
+	}
+	if r.GetStatusCode() != "" {
+		rule.statusCodes = utils.ParseStringToInts(r.GetStatusCode())
+		if len(rule.statusCodes) <= 0 {
+			return nil, utils.Errorf("invalid status code: %v", r.GetStatusCode())
+		}
+	}
+	return rule, nil
+}
+
+func (r *mitmBreakpointRule) forRequest() bool {
+	return r.GetForRequest() || !r.GetForResponse()
+}
+
+func (r *mitmBreakpointRule) timeout() time.Duration {
+	if r.GetTimeoutSeconds() > 0 {
+		return time.Duration(r.GetTimeoutSeconds()) * time.Second
+	}
+	return defaultMITMBreakpointTimeout
+}
+
+// match 判断报文是否命中断点，Host / Path / Method 总是从请求中获取
+func (r *mitmBreakpointRule) match(isResponse bool, request, response []byte) bool {
+	if (isResponse && !r.GetForResponse()) || (!isResponse && !r.forRequest()) {
+		return false
+	}
+	if len(r.hosts) > 0 {
+		host := strings.ToLower(utils.ExtractHost(lowhttp.GetHTTPPacketHeader(request, "Host")))
+		matched := false
+		for _, g := range r.hosts {
+			if g.Match(host) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if r.path != nil {
+		path := lowhttp.GetHTTPRequestPath(request)
+		// 代理请求中的 uri 可能是完整的 url
+		if u, err := url.Parse(path); err == nil && u.IsAbs() {
+			path = u.RequestURI()
+		}
+		if !r.path.MatchString(path) {
+			return false
+		}
+	}
+	if methods := utils.StringArrayFilterEmpty(r.GetMethod()); len(methods) > 0 {
+		method := lowhttp.GetHTTPRequestMethod(request)
+		matched := false
+		for _, i := range methods {
+			if strings.EqualFold(strings.TrimSpace(i), method) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	packet := request
+	if isResponse {
+		packet = response
+		if len(r.statusCodes) > 0 {
+			code := lowhttp.GetStatusCodeFromResponse(response)
+			index := sort.SearchInts(r.statusCodes, code)
+			if index >= len(r.statusCodes) || r.statusCodes[index] != code {
+				return false
+			}
+		}
+	}
+	header, body := lowhttp.SplitHTTPPacketFast(packet)
+	if r.GetHeaderContains() != "" && !utils.IContains(header, r.GetHeaderContains()) {
+		return false
+	}
+	if r.GetBodyContains() != "" && !utils.IContains(string(body), r.GetBodyContains()) {
+		return false
+	}
+	return true
+}
+
+type mitmBreakpointFlow struct {
+	*ypb.MITMBreakpointFlow
+	resume chan *ypb.ResumeMITMBreakpointFlowRequest
+}
+
+// mitmBreakpointManager 一个 MITM 会话中的断点规则与暂停中的报文，每个报文独立等待放行
+type mitmBreakpointManager struct {
+	lock  sync.RWMutex
+	rules []*mitmBreakpointRule
+	flows map[string]*mitmBreakpointFlow
+
+	// 暂停与放行报文时调用
+	notify func(flow *ypb.MITMBreakpointFlow)
+}
+
+func newMITMBreakpointManager(notify func(flow *ypb.MITMBreakpointFlow)) *mitmBreakpointManager {
+	return &mitmBreakpointManager{
+		flows:  make(map[string]*mitmBreakpointFlow),
+		notify: notify,
+	}
+}
+
+// LoadRules 加载规则，禁用与无效的规则会被忽略
+func (m *mitmBreakpointManager) LoadRules(rules []*ypb.MITMBreakpointRule) {
+	var enabled []*mitmBreakpointRule
+	for _, i := range rules {
+		if i.GetDisabled() {
+			continue
+		}
+		rule, err := newMITMBreakpointRule(i)
+		if err != nil {
+			log.Warnf("breakpoint rule: %v is disabled: %v", i.GetVerboseName(), err)
+			continue
+		}
+		enabled = append(enabled, rule)
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.rules = enabled
+}
+
+func (m *mitmBreakpointManager) haveRules() bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return len(m.rules) > 0
+}
+
+// Match 返回第一条命中的规则，没有命中时返回 nil
+func (m *mitmBreakpointManager) Match(isResponse bool, request, response []byte) *mitmBreakpointRule {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	for _, rule := range m.rules {
+		if rule.match(isResponse, request, response) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// Wait 暂停报文直到被放行、超时或者 ctx 结束，返回需要发送的报文，drop 为 true 时丢弃报文
+func (m *mitmBreakpointManager) Wait(ctx context.Context, rule *mitmBreakpointRule, isResponse, isHttps bool, urlStr string, request, response []byte) (packet []byte, drop bool) {
+	origin := request
+	if isResponse {
+		origin = response
+	}
+	now := time.Now()
+	timeout := rule.timeout()
+	flow := &mitmBreakpointFlow{
+		MITMBreakpointFlow: &ypb.MITMBreakpointFlow{
+			Id:         uuid.NewString(),
+			IsResponse: isResponse,
+			IsHttps:    isHttps,
+			Url:        urlStr,
+			Request:    request,
+			Response:   response,
+			RuleName:   rule.GetVerboseName(),
+			CreatedAt:  now.Unix(),
+			Deadline:   now.Add(timeout).Unix(),
+		},
+		resume: make(chan *ypb.ResumeMITMBreakpointFlowRequest, 1),
+	}
+
+	m.lock.Lock()
+	m.flows[flow.Id] = flow
+	m.lock.Unlock()
+	defer func() {
+		m.lock.Lock()
+		delete(m.flows, flow.Id)
+		m.lock.Unlock()
+	}()
+	m.notify(flow.MITMBreakpointFlow)
+
+	packet, action := origin, breakpointActionForward
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case req := <-flow.resume:
+		if req.GetDrop() {
+			packet, drop, action = nil, true, breakpointActionDrop
+		} else if len(req.GetPacket()) > 0 && !bytes.Equal(req.GetPacket(), origin) {
+			packet, action = req.GetPacket(), breakpointActionModified
+		}
+	case <-timer.C:
+		action = breakpointActionTimeout
+		log.Infof("breakpoint flow %v timeout, auto forward: %v", flow.Id, urlStr)
+	case <-ctx.Done():
+	}
+
+	finished := proto.Clone(flow.MITMBreakpointFlow).(*ypb.MITMBreakpointFlow)
+	finished.Action = action
+	m.notify(finished)
+	return packet, drop
+}
+
+// Resume 放行暂停中的报文，报文不存在或者已经被放行时返回 false
+func (m *mitmBreakpointManager) Resume(req *ypb.ResumeMITMBreakpointFlowRequest) bool {
+	m.lock.RLock()
+	flow, ok := m.flows[req.GetId()]
+	m.lock.RUnlock()
+	if !ok {
+		return false
+	}
+	select {
+	case flow.resume <- req:
+		return true
+	default:
+		return false
+	}
+}
+
+// Flows 返回暂停中的报文
+func (m *mitmBreakpointManager) Flows() []*ypb.MITMBreakpointFlow {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	flows := make([]*ypb.MITMBreakpointFlow, 0, len(m.flows))
+	for _, flow := range m.flows {
+		flows = append(flows, flow.MITMBreakpointFlow)
+	}
+	return flows
+}
+
+func loadMITMBreakpointRules(db *gorm.DB) []*ypb.MITMBreakpointRule {
+	var rules []*ypb.MITMBreakpointRule
+	if result := yakit.GetKey(db, MITMBreakpointKeyRecords); result != "" {
+		_ = json.Unmarshal([]byte(result), &rules)
+	}
+	return rules
+}
+
+func (s *Server) GetMITMBreakpointRules(ctx context.Context, _ *ypb.Empty) (*ypb.MITMBreakpointRules, error) {
+	return &ypb.MITMBreakpointRules{Rules: loadMITMBreakpointRules(s.GetProfileDatabase())}, nil
+}
+
+// SetMITMBreakpointRules 保存断点规则，运行中的 MITM 会话立即生效
+func (s *Server) SetMITMBreakpointRules(ctx context.Context, req *ypb.MITMBreakpointRules) (*ypb.Empty, error) {
+	for index, rule := range req.GetRules() {
+		if _, err := newMITMBreakpointRule(rule); err != nil {
+			return nil, utils.Errorf("breakpoint rule[%d] %v: %v", index, rule.GetVerboseName(), err)
+		}
+	}
+	raw, err := json.Marshal(req.GetRules())
+	if err != nil {
+		return nil, err
+	}
+	err = yakit.SetKey(s.GetProfileDatabase(), MITMBreakpointKeyRecords, string(raw))
+	if err != nil {
+		return nil, err
+	}
+	mitmBreakpointManagers.Range(func(key, value any) bool {
+		value.(*mitmBreakpointManager).LoadRules(req.GetRules())
+		return true
+	})
+	return &ypb.Empty{}, nil
+}
+
+func (s *Server) QueryMITMBreakpointFlows(ctx context.Context, _ *ypb.Empty) (*ypb.QueryMITMBreakpointFlowsResponse, error) {
+	var flows []*ypb.MITMBreakpointFlow
+	mitmBreakpointManagers.Range(func(key, value any) bool {
+		flows = append(flows, value.(*mitmBreakpointManager).Flows()...)
+		return true
+	})
+	sort.SliceStable(flows, func(i, j int) bool {
+		return flows[i].GetCreatedAt() < flows[j].GetCreatedAt()
+	})
+	return &ypb.QueryMITMBreakpointFlowsResponse{Flows: flows}, nil
+}
+
+func (s *Server) ResumeMITMBreakpointFlow(ctx context.Context, req *ypb.ResumeMITMBreakpointFlowRequest) (*ypb.Empty, error) {
+	resumed := false
+	mitmBreakpointManagers.Range(func(key, value any) bool {
+		resumed = value.(*mitmBreakpointManager).Resume(req)
+		return !resumed
+	})
+	if !resumed {
+		return nil, utils.Errorf("breakpoint flow %v not found or already resumed", req.GetId())
+	}
+	return &ypb.Empty{}, nil
+}
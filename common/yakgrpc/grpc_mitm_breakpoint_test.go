@@ -0,0 +1,218 @@
+package yakgrpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/utils/lowhttp"
+	"github.com/yaklang/yaklang/common/yakgrpc/ypb"
+)
+
+func TestMITMBreakpointRuleMatch(t *testing.T) {
+	request := []byte("POST http://www.example.com/api/login?a=1 HTTP/1.1\r\nHost: www.example.com\r\nX-Token: abc\r\n\r\nusername=admin")
+	response := []byte("HTTP/1.1 302 Found\r\nLocation: /home\r\n\r\nwelcome")
+
+	for _, c := range []struct {
+		rule       *ypb.MITMBreakpointRule
+		isResponse bool
+		expected   bool
+	}{
+		{rule: &ypb.MITMBreakpointRule{Host: "*.example.com", PathRegexp: `^/api/login`}, expected: true},
+		{rule: &ypb.MITMBreakpointRule{Host: "*.example.org, example.com"}, expected: false},
+		{rule: &ypb.MITMBreakpointRule{Method: []string{"get", "post"}, HeaderContains: "x-token"}, expected: true},
+		{rule: &ypb.MITMBreakpointRule{Method: []string{"GET"}}, expected: false},
+		{rule: &ypb.MITMBreakpointRule{BodyContains: "ADMIN"}, expected: true},
+		// 只对响应生效的规则不会暂停请求
+		{rule: &ypb.MITMBreakpointRule{ForResponse: true}, expected: false},
+		{rule: &ypb.MITMBreakpointRule{}, isResponse: true, expected: false},
+		{rule: &ypb.MITMBreakpointRule{ForResponse: true, PathRegexp: "login", StatusCode: "200,300-399"}, isResponse: true, expected: true},
+		{rule: &ypb.MITMBreakpointRule{ForResponse: true, StatusCode: "200"}, isResponse: true, expected: false},
+		{rule: &ypb.MITMBreakpointRule{ForResponse: true, BodyContains: "username"}, isResponse: true, expected: false},
+	} {
+		rule, err := newMITMBreakpointRule(c.rule)
+		require.NoError(t, err)
+		require.Equal(t, c.expected, rule.match(c.isResponse, request, response), "%v", c.rule)
+	}
+
+	for _, r := range []*ypb.MITMBreakpointRule{
+		{PathRegexp: "(login"},
+		{Host: "[a-"},
+		{StatusCode: "abc"},
+	} {
+		_, err := newMITMBreakpointRule(r)
+		require.Error(t, err)
+	}
+}
+
+func TestMITMBreakpointManagerWait(t *testing.T) {
+	notified := make(chan *ypb.MITMBreakpointFlow, 10)
+	m := newMITMBreakpointManager(func(flow *ypb.MITMBreakpointFlow) {
+		notified <- flow
+	})
+	m.LoadRules([]*ypb.MITMBreakpointRule{
+		{VerboseName: "disabled", Disabled: true},
+		{VerboseName: "login", PathRegexp: "/login", TimeoutSeconds: 1},
+	})
+	request := []byte("GET /login HTTP/1.1\r\nHost: www.example.com\r\n\r\n")
+	require.Nil(t, m.Match(false, []byte("GET / HTTP/1.1\r\nHost: www.example.com\r\n\r\n"), nil))
+	rule := m.Match(false, request, nil)
+	require.NotNil(t, rule)
+	require.Equal(t, "login", rule.GetVerboseName())
+
+	// 超时后自动放行原始报文
+	packet, drop := m.Wait(context.Background(), rule, false, false, "", request, nil)
+	require.False(t, drop)
+	require.Equal(t, request, packet)
+	require.Empty(t, (<-notified).GetAction())
+	require.Equal(t, breakpointActionTimeout, (<-notified).GetAction())
+
+	// 多个暂停的报文独立放行
+	rule.TimeoutSeconds = 10
+	var wg sync.WaitGroup
+	results := make([][]byte, 3)
+	drops := make([]bool, 3)
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], drops[i] = m.Wait(context.Background(), rule, false, false, fmt.Sprint(i), request, nil)
+		}(i)
+	}
+	ids := make(map[string]string)
+	for i := 0; i < 3; i++ {
+		flow := <-notified
+		ids[flow.GetUrl()] = flow.GetId()
+	}
+	require.Len(t, m.Flows(), 3)
+	modified := []byte("GET /login?modified=1 HTTP/1.1\r\nHost: www.example.com\r\n\r\n")
+	require.True(t, m.Resume(&ypb.ResumeMITMBreakpointFlowRequest{Id: ids["2"], Packet: modified}))
+	require.Equal(t, breakpointActionModified, (<-notified).GetAction())
+	require.Len(t, m.Flows(), 2)
+	require.True(t, m.Resume(&ypb.ResumeMITMBreakpointFlowRequest{Id: ids["0"], Drop: true}))
+	require.True(t, m.Resume(&ypb.ResumeMITMBreakpointFlowRequest{Id: ids["1"]}))
+	wg.Wait()
+	require.False(t, m.Resume(&ypb.ResumeMITMBreakpointFlowRequest{Id: ids["1"]}))
+	require.Empty(t, m.Flows())
+
+	require.True(t, drops[0])
+	require.Nil(t, results[0])
+	require.False(t, drops[1])
+	require.Equal(t, request, results[1])
+	require.False(t, drops[2])
+	require.Equal(t, modified, results[2])
+}
+
+func TestGRPCMUSTPASS_MITMBreakpoint(t *testing.T) {
+	client, err := NewLocalClient()
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	origin, err := client.GetMITMBreakpointRules(ctx, &ypb.Empty{})
+	require.NoError(t, err)
+	defer client.SetMITMBreakpointRules(context.Background(), origin)
+
+	_, err = client.SetMITMBreakpointRules(ctx, &ypb.MITMBreakpointRules{Rules: []*ypb.MITMBreakpointRule{{PathRegexp: "("}}})
+	require.Error(t, err)
+
+	token := utils.RandStringBytes(16)
+	rules := &ypb.MITMBreakpointRules{Rules: []*ypb.MITMBreakpointRule{
+		{VerboseName: "login request", PathRegexp: `^/login`, Method: []string{"POST"}, TimeoutSeconds: 60},
+		{VerboseName: "login response", ForResponse: true, PathRegexp: `^/login`, BodyContains: token, TimeoutSeconds: 60},
+	}}
+	_, err = client.SetMITMBreakpointRules(ctx, rules)
+	require.NoError(t, err)
+	current, err := client.GetMITMBreakpointRules(ctx, &ypb.Empty{})
+	require.NoError(t, err)
+	require.Len(t, current.GetRules(), 2)
+	require.Equal(t, "login request", current.GetRules()[0].GetVerboseName())
+
+	host, port := utils.DebugMockHTTPEx(func(req []byte) []byte {
+		_, body := lowhttp.SplitHTTPPacketFast(req)
+		return []byte(fmt.Sprintf("HTTP/1.1 200 OK\r\nContent-Length: %d\r\n\r\n%s", len(body)+len(token), string(body)+token))
+	})
+	addr := utils.HostPort(host, port)
+	mitmPort := utils.GetRandomAvailableTCPPort()
+	proxy := fmt.Sprintf("http://127.0.0.1:%d", mitmPort)
+
+	stream, err := client.MITM(ctx)
+	require.NoError(t, err)
+	stream.Send(&ypb.MITMRequest{Host: "127.0.0.1", Port: uint32(mitmPort)})
+
+	sendPacket := func(packet string) []byte {
+		rsp, err := lowhttp.HTTP(
+			lowhttp.WithPacketBytes([]byte(packet)),
+			lowhttp.WithProxy(proxy),
+			lowhttp.WithTimeout(30*time.Second),
+		)
+		if err != nil {
+			return nil
+		}
+		_, body := lowhttp.SplitHTTPPacketFast(rsp.RawPacket)
+		return body
+	}
+
+	var (
+		indexBody, loginBody []byte
+		wg                   sync.WaitGroup
+		actions              []string
+	)
+	for {
+		rsp, err := stream.Recv()
+		if err != nil {
+			break
+		}
+		if strings.Contains(spew.Sdump(rsp), "starting mitm server") {
+			stream.Send(&ypb.MITMRequest{SetResetFilter: true})
+			time.Sleep(time.Second)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				// 未命中断点的请求正常放行
+				indexBody = sendPacket("GET /index HTTP/1.1\r\nHost: " + addr + "\r\n\r\n")
+				loginBody = sendPacket("POST /login HTTP/1.1\r\nHost: " + addr + "\r\nContent-Length: 8\r\n\r\npassword")
+				cancel()
+			}()
+			continue
+		}
+
+		flow := rsp.GetBreakpointFlow()
+		if flow == nil {
+			continue
+		}
+		if flow.GetAction() != "" {
+			actions = append(actions, flow.GetAction())
+			continue
+		}
+		if !flow.GetIsResponse() {
+			require.Equal(t, "login request", flow.GetRuleName())
+			flows, err := client.QueryMITMBreakpointFlows(ctx, &ypb.Empty{})
+			require.NoError(t, err)
+			require.Len(t, flows.GetFlows(), 1)
+			require.Equal(t, flow.GetId(), flows.GetFlows()[0].GetId())
+
+			modified := lowhttp.ReplaceHTTPPacketBody(flow.GetRequest(), []byte("modified"), false)
+			_, err = client.ResumeMITMBreakpointFlow(ctx, &ypb.ResumeMITMBreakpointFlowRequest{Id: flow.GetId(), Packet: modified})
+			require.NoError(t, err)
+			continue
+		}
+		require.Equal(t, "login response", flow.GetRuleName())
+		require.Contains(t, string(flow.GetResponse()), "modified"+token)
+		stream.Send(&ypb.MITMRequest{
+			BreakpointFlowId: flow.GetId(),
+			Response:         lowhttp.ReplaceHTTPPacketBody(flow.GetResponse(), []byte("breakpoint"), false),
+		})
+	}
+	wg.Wait()
+
+	require.Equal(t, token, string(indexBody))
+	require.Equal(t, "breakpoint", string(loginBody))
+	require.Equal(t, []string{breakpointActionModified, breakpointActionModified}, actions)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/yaklang/yaklang/common/schema"
 
 	"github.com/dlclark/regexp2"
+	"github.com/gobwas/glob"
 	"github.com/yaklang/yaklang/common/go-funk"
 	"github.com/yaklang/yaklang/common/log"
 	"github.com/yaklang/yaklang/common/utils"
@@ -77,7 +78,9 @@ func FixPacket(packet []byte, isReq bool) (fixed []byte, use bool) {
 
 type MITMReplaceRule struct {
 	*ypb.MITMContentReplacer
-	cache *regexp2.Regexp
+	cache         *regexp2.Regexp
+	hostGlobOnce  sync.Once
+	hostGlobCache []glob.Glob
 }
 
 func (r *MITMReplaceRule) compile() (*regexp2.Regexp, error) {
@@ -85,10 +88,14 @@ func (r *MITMReplaceRule) compile() (*regexp2.Regexp, error) {
 		return r.cache, nil
 	}
 
-	rule, _, re, err := utils.Regexp2Compile(r.Rule)
+	ruleStr := r.Rule
+	if ruleStr == "" && r.isTargetRule() {
+		ruleStr = replacerWholeValueRule
+	}
+	rule, _, re, err := utils.Regexp2Compile(ruleStr)
 	if err != nil {
 		log.Debugf("regexp2 compile %v failed: %s", rule, err)
-		_, _, re, err = utils.Regexp2Compile(regexp2.Escape(r.Rule))
+		_, _, re, err = utils.Regexp2Compile(regexp2.Escape(ruleStr))
 		if err != nil {
 			return nil, err
 		} else {
@@ -211,7 +218,7 @@ func (m *MITMReplaceRule) splitPacket(packet []byte) (*yakit.PacketInfo, error)
 		ungzip, err := utils.GzipDeCompress(bodyRaw)
 		if err == nil {
 			bodyRaw = ungzip
-			headerRaw = string(lowhttp.DeleteHTTPPacketHeader([]byte(headerRaw), info.ChunkedHeader))
+			headerRaw = string(lowhttp.DeleteHTTPPacketHeader([]byte(headerRaw), info.GzipHeader))
 		}
 	}
 
@@ -224,6 +231,10 @@ func (m *MITMReplaceRule) splitPacket(packet []byte) (*yakit.PacketInfo, error)
 }
 
 func (m *MITMReplaceRule) MatchPacket(packet []byte, isReq bool) (*yakit.PacketInfo, []*yakit.MatchResult, error) {
+	if m.isTargetRule() {
+		packetInfo, matched, _, err := m.matchAndReplaceTarget(packet, isReq)
+		return packetInfo, matched, err
+	}
 	fixed, ok := FixPacket(packet, isReq)
 	if ok {
 		packet = fixed
@@ -240,6 +251,10 @@ func (m *MITMReplaceRule) MatchPacket(packet []byte, isReq bool) (*yakit.PacketI
 
 // MatchAndReplacePacket match and replace package, return matched result and replaced package
 func (m *MITMReplaceRule) MatchAndReplacePacket(packet []byte, isReq bool) ([]*yakit.MatchResult, []byte, error) {
+	if m.isTargetRule() {
+		_, matched, modified, err := m.matchAndReplaceTarget(packet, isReq)
+		return matched, modified, err
+	}
 	originPacket := packet // backup origin packet
 	fixed, ok := FixPacket(packet, isReq)
 	if ok {
@@ -350,7 +365,7 @@ func (m *MITMReplaceRule) MatchAndReplacePacket(packet []byte, isReq bool) ([]*y
 		headerRaw = buf.String()
 	} else {
 		if m.EnableForHeader && m.EnableForBody {
-			raw, err := re.Replace(string(lowhttp.ReplaceHTTPPacketBody([]byte(packetInfo.HeaderRaw), packetInfo.BodyRaw, false)), m.Result, -1, -1)
+			raw, err := m.replaceString(re, string(lowhttp.ReplaceHTTPPacketBody([]byte(packetInfo.HeaderRaw), packetInfo.BodyRaw, false)))
 			if err != nil {
 				return nil, nil, fmt.Errorf("replace packet failed: %v", err)
 			}
@@ -358,20 +373,20 @@ func (m *MITMReplaceRule) MatchAndReplacePacket(packet []byte, isReq bool) ([]*y
 		} else {
 			uri := packetInfo.RequestURI
 			if isReq && m.EnableForURI {
-				uri, err = re.Replace(uri, m.Result, -1, -1)
+				uri, err = m.replaceString(re, uri)
 				if err != nil {
 					return nil, nil, fmt.Errorf("replace uri failed: %v", err)
 				}
 			}
 			headerRaw = strings.Replace(headerRaw, packetInfo.RequestURI, uri, 1)
 			if m.EnableForHeader {
-				headerRaw, err = re.Replace(packetInfo.HeaderRaw, m.Result, -1, -1)
+				headerRaw, err = m.replaceString(re, packetInfo.HeaderRaw)
 				if err != nil {
 					return nil, nil, fmt.Errorf("replace header failed: %v", err)
 				}
 			}
 			if m.EnableForBody {
-				body, err := re.Replace(string(bodyRaw), m.Result, -1, -1)
+				body, err := m.replaceString(re, string(bodyRaw))
 				if err != nil {
 					return nil, nil, fmt.Errorf("replace body failed: %v", err)
 				}
@@ -450,8 +465,7 @@ func (m *mitmReplacer) LoadRules(ruleContents []*ypb.MITMContentReplacer) {
 	var rules []*MITMReplaceRule
 	for _, i := range ruleContents {
 		rules = append(rules, &MITMReplaceRule{
-			i,
-			nil,
+			MITMContentReplacer: i,
 		})
 	}
 	m._ruleRegexpCache = new(sync.Map)
@@ -459,7 +473,8 @@ func (m *mitmReplacer) LoadRules(ruleContents []*ypb.MITMContentReplacer) {
 	m._mirrorRules = nil
 	m.allRules = sortContentReplacer(rules)
 	enabledRules := funk.Filter(rules, func(i *MITMReplaceRule) bool {
-		if i.Rule == "" {
+		// 设置了替换目标时，Rule 为空表示替换目标的整个值
+		if i.Rule == "" && !i.isTargetRule() {
 			return false
 		}
 
@@ -580,7 +595,7 @@ func (m *mitmReplacer) hookColor(request, response []byte, req *http.Request, fl
 			continue
 		}
 
-		if rule.EnableForRequest {
+		if rule.EnableForRequest && rule.matchCondition(request, true, nil) {
 			_, newMatchResults, err = rule.MatchPacket(request, true)
 			if err != nil && !isMatchTimeout(err) {
 				log.Errorf("match package failed: %v", err)
@@ -588,7 +603,7 @@ func (m *mitmReplacer) hookColor(request, response []byte, req *http.Request, fl
 			}
 			matchResults = append(matchResults, newMatchResults...)
 		}
-		if rule.EnableForResponse {
+		if rule.EnableForResponse && rule.matchCondition(response, false, request) {
 			_, newMatchResults, err = rule.MatchPacket(response, false)
 			if err != nil && !isMatchTimeout(err) {
 				log.Errorf("match package failed: %v", err)
@@ -805,6 +820,12 @@ func (m *mitmReplacer) hook(isRequest, isResponse bool, origin []byte, args ...a
 		return matchedRules.MITMContentReplacers(), origin, false
 	}
 
+	// 响应的 args 为 isHttps 与对应的请求，用于判断规则的生效条件
+	var request []byte
+	if isResponse && len(args) > 1 {
+		request = utils.InterfaceToBytes(args[1])
+	}
+
 	originPacket := origin
 	_ = originPacket
 	if isResponse {
@@ -866,6 +887,9 @@ func (m *mitmReplacer) hook(isRequest, isResponse bool, origin []byte, args ...a
 		if !((rule.EnableForRequest && isRequest) || (rule.EnableForResponse && isResponse) || rule.GetEnableForURI()) {
 			continue
 		}
+		if !rule.matchCondition(modifiedPacket, isRequest, request) {
+			continue
+		}
 		matched, packet, err := rule.MatchAndReplacePacket(modifiedPacket, isRequest)
 		if err != nil && !isMatchTimeout(err) {
 			log.Errorf("match package failed: %v", err)
@@ -0,0 +1,90 @@
+package yakgrpc
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/golang/protobuf/proto"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/utils/yakgit/yakdiff"
+	"github.com/yaklang/yaklang/common/yakgrpc/model"
+	"github.com/yaklang/yaklang/common/yakgrpc/yakit"
+	"github.com/yaklang/yaklang/common/yakgrpc/ypb"
+)
+
+// DryRunMITMReplacerRules 使用样例流量试运行替换规则，不会发送请求，也不会保存任何数据
+func (s *Server) DryRunMITMReplacerRules(ctx context.Context, req *ypb.DryRunMITMReplacerRulesRequest) (*ypb.DryRunMITMReplacerRulesResponse, error) {
+	rules := req.GetRules()
+	if len(rules) <= 0 {
+		current, err := s.GetCurrentRules(ctx, &ypb.Empty{})
+		if err != nil {
+			return nil, err
+		}
+		rules = current.GetRules()
+	}
+
+	request, response := req.GetRequest(), req.GetResponse()
+	if id := req.GetHTTPFlowId(); id > 0 {
+		flow, err := yakit.GetHTTPFlow(s.GetProjectDatabase(), id)
+		if err != nil {
+			return nil, utils.Errorf("query httpflow[%d] failed: %v", id, err)
+		}
+		flowIns, err := model.ToHTTPFlowGRPCModelFull(flow)
+		if err != nil {
+			return nil, err
+		}
+		request, response = flowIns.GetRequest(), flowIns.GetResponse()
+	}
+	if len(request) <= 0 && len(response) <= 0 {
+		return nil, utils.Error("empty request and response")
+	}
+
+	// ExtraRepeat 会额外发送一次请求，试运行时需要关闭
+	dryRules := make([]*ypb.MITMContentReplacer, 0, len(rules))
+	for _, rule := range rules {
+		copied := proto.Clone(rule).(*ypb.MITMContentReplacer)
+		copied.ExtraRepeat = false
+		dryRules = append(dryRules, copied)
+	}
+	replacer := NewMITMReplacer(func() []*ypb.MITMContentReplacer {
+		return dryRules
+	})
+
+	rsp := &ypb.DryRunMITMReplacerRulesResponse{Request: request, Response: response}
+	var err error
+	if len(request) > 0 {
+		rsp.RequestMatchedRules, rsp.Request, rsp.RequestDropped = replacer.hook(true, false, request, req.GetIsHttps())
+		rsp.RequestDiff, err = replacerPacketDiff(ctx, request, rsp.Request)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(response) > 0 {
+		// 响应规则的生效条件使用替换后的请求判断，与 MITM 中的行为一致
+		rsp.ResponseMatchedRules, rsp.Response, rsp.ResponseDropped = replacer.hook(false, true, response, req.GetIsHttps(), rsp.Request)
+		rsp.ResponseDiff, err = replacerPacketDiff(ctx, response, rsp.Response)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return rsp, nil
+}
+
+// replacerPacketDiff 返回替换前后报文的 unified diff，没有修改时返回空字符串
+func replacerPacketDiff(ctx context.Context, origin, modified []byte) (string, error) {
+	if bytes.Equal(origin, modified) {
+		return "", nil
+	}
+	var buf bytes.Buffer
+	err := yakdiff.DiffContext(ctx, origin, modified, func(_ *object.Commit, _ *object.Change, patch *object.Patch) error {
+		if patch != nil {
+			buf.WriteString(patch.String())
+		}
+		return nil
+	})
+	if err != nil {
+		return "", utils.Errorf("diff packet failed: %v", err)
+	}
+	return buf.String(), nil
+}
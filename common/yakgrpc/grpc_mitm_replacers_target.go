@@ -0,0 +1,435 @@
+package yakgrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/dlclark/regexp2"
+	"github.com/gobwas/glob"
+	"github.com/yaklang/yaklang/common/jsonpath"
+	"github.com/yaklang/yaklang/common/log"
+	"github.com/yaklang/yaklang/common/mutate"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/utils/lowhttp"
+	"github.com/yaklang/yaklang/common/yakgrpc/yakit"
+)
+
+// MITMContentReplacer.TargetType 支持的替换目标
+const (
+	replacerTargetJSON   = "json"
+	replacerTargetXML    = "xml"
+	replacerTargetForm   = "form"
+	replacerTargetQuery  = "query"
+	replacerTargetCookie = "cookie"
+	replacerTargetHeader = "header"
+)
+
+// replacerWholeValueRule 设置了替换目标但 Rule 为空时，替换目标的整个值
+const replacerWholeValueRule = `^[\s\S]*$`
+
+func (m *MITMReplaceRule) isTargetRule() bool {
+	return m.GetTargetType() != ""
+}
+
+// matchCondition 检查规则的生效条件，Host 与 Method 从请求中获取，响应需要提供对应的请求，Content-Type 从当前报文中获取
+func (m *MITMReplaceRule) matchCondition(packet []byte, isReq bool, request []byte) bool {
+	if isReq {
+		request = packet
+	}
+	if m.GetMatchHost() != "" {
+		if len(request) <= 0 {
+			return false
+		}
+		host := strings.ToLower(utils.ExtractHost(lowhttp.GetHTTPPacketHeader(request, "Host")))
+		matched := false
+		for _, g := range m.hostGlobs() {
+			if g.Match(host) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if methods := utils.StringArrayFilterEmpty(m.GetMatchMethod()); len(methods) > 0 {
+		if len(request) <= 0 {
+			return false
+		}
+		method := lowhttp.GetHTTPRequestMethod(request)
+		matched := false
+		for _, i := range methods {
+			if strings.EqualFold(strings.TrimSpace(i), method) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if contentType := m.GetMatchContentType(); contentType != "" {
+		if !utils.IContains(lowhttp.GetHTTPPacketContentType(packet), contentType) {
+			return false
+		}
+	}
+	return true
+}
+
+// hostGlobs 在第一次使用时编译 MatchHost，规则会被多个连接并发使用
+func (m *MITMReplaceRule) hostGlobs() []glob.Glob {
+	m.hostGlobOnce.Do(func() {
+		for _, pattern := range utils.PrettifyListFromStringSplitEx(m.GetMatchHost(), ",") {
+			g, err := glob.Compile(strings.ToLower(pattern))
+			if err != nil {
+				log.Debugf("rule: %v compile host glob %v failed: %v", m.GetVerboseName(), pattern, err)
+				continue
+			}
+			m.hostGlobCache = append(m.hostGlobCache, g)
+		}
+	})
+	return m.hostGlobCache
+}
+
+// replaceString 使用 Result 替换 input 中所有匹配的内容
+// 开启 EnableFuzztag 时，先展开 Result 中的捕获组，再渲染其中的 fuzztag，多个结果时取第一个
+func (m *MITMReplaceRule) replaceString(re *regexp2.Regexp, input string) (string, error) {
+	if !m.GetEnableFuzztag() {
+		return re.Replace(input, m.GetResult(), -1, -1)
+	}
+	return re.ReplaceFunc(input, func(match regexp2.Match) string {
+		return renderReplacerFuzztag(expandReplacerResult(m.GetResult(), &match))
+	}, -1, -1)
+}
+
+// expandReplacerResult 展开模板中的捕获组，与 regexp2 的替换语法一致：$1 / ${1} / ${name} / $0 / $& / $$
+func expandReplacerResult(template string, match *regexp2.Match) string {
+	group := func(name string) (string, bool) {
+		if name == "&" {
+			name = "0"
+		}
+		if index, err := strconv.Atoi(name); err == nil {
+			if g := match.GroupByNumber(index); g != nil {
+				return g.String(), true
+			}
+			return "", false
+		}
+		if g := match.GroupByName(name); g != nil {
+			return g.String(), true
+		}
+		return "", false
+	}
+
+	var buf strings.Builder
+	for i := 0; i < len(template); i++ {
+		if template[i] != '$' || i+1 >= len(template) {
+			buf.WriteByte(template[i])
+			continue
+		}
+		next := template[i+1]
+		switch {
+		case next == '$':
+			buf.WriteByte('$')
+			i++
+		case next == '&':
+			value, _ := group("&")
+			buf.WriteString(value)
+			i++
+		case next == '{':
+			end := strings.IndexByte(template[i+2:], '}')
+			if end < 0 {
+				buf.WriteByte('$')
+				continue
+			}
+			value, ok := group(template[i+2 : i+2+end])
+			if !ok {
+				buf.WriteByte('$')
+				continue
+			}
+			buf.WriteString(value)
+			i += end + 2
+		case next >= '0' && next <= '9':
+			end := i + 1
+			for end < len(template) && template[end] >= '0' && template[end] <= '9' {
+				end++
+			}
+			// 与 regexp2 一致，优先使用最长的存在的组号
+			for ; end > i+1; end-- {
+				if value, ok := group(template[i+1 : end]); ok {
+					buf.WriteString(value)
+					break
+				}
+			}
+			if end == i+1 {
+				buf.WriteByte('$')
+				continue
+			}
+			i = end - 1
+		default:
+			buf.WriteByte('$')
+		}
+	}
+	return buf.String()
+}
+
+func renderReplacerFuzztag(s string) string {
+	results, err := mutate.FuzzTagExec(s, mutate.Fuzz_WithResultHandler(func(string, []string) bool {
+		return false
+	}))
+	if err != nil || len(results) <= 0 {
+		log.Debugf("render fuzztag in replacer result failed: %v", err)
+		return s
+	}
+	return results[0]
+}
+
+// replaceTargetValue 在目标的值中匹配 Rule 并替换，没有匹配时返回原值
+func (m *MITMReplaceRule) replaceTargetValue(re *regexp2.Regexp, value string, packet []byte, isReq bool) ([]*yakit.MatchResult, string, error) {
+	offset := bytes.Index(packet, []byte(value))
+	if offset < 0 {
+		offset = 0
+	}
+	info := &yakit.MatchInfo{Raw: []byte(value), Offset: offset}
+
+	var results []*yakit.MatchResult
+	match, err := re.FindStringMatch(value)
+	for ; err == nil && match != nil; match, err = re.FindNextMatch(match) {
+		results = append(results, &yakit.MatchResult{
+			Match:          match,
+			IsMatchRequest: isReq,
+			MatchInfo:      info,
+		})
+		if match.Length == 0 {
+			// 匹配到空字符串时不再继续查找，避免重复匹配
+			break
+		}
+	}
+	if err != nil {
+		return nil, value, err
+	}
+	if len(results) <= 0 {
+		return nil, value, nil
+	}
+	replaced, err := m.replaceString(re, value)
+	if err != nil {
+		return nil, value, err
+	}
+	return results, replaced, nil
+}
+
+// matchAndReplaceTarget 按照 TargetType / TargetPath 找到需要替换的值，在值中匹配 Rule 并替换
+func (m *MITMReplaceRule) matchAndReplaceTarget(packet []byte, isReq bool) (*yakit.PacketInfo, []*yakit.MatchResult, []byte, error) {
+	originPacket := packet
+	if fixed, ok := FixPacket(packet, isReq); ok {
+		packet = fixed
+	}
+	packetInfo, err := m.splitPacket(packet)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	packetInfo.IsRequest = isReq
+	if (isReq && !m.GetEnableForRequest()) || (!isReq && !m.GetEnableForResponse()) {
+		return packetInfo, nil, originPacket, nil
+	}
+	re, err := m.compile()
+	if err != nil {
+		return packetInfo, nil, nil, utils.Errorf("compile rule failed: %v", err)
+	}
+
+	plain := lowhttp.ReplaceHTTPPacketBody([]byte(packetInfo.HeaderRaw), packetInfo.BodyRaw, false)
+	name := m.GetTargetPath()
+	var (
+		results  []*yakit.MatchResult
+		modified []byte
+	)
+	replaceValue := func(value string) (string, error) {
+		matched, replaced, err := m.replaceTargetValue(re, value, plain, isReq)
+		if err != nil {
+			return value, err
+		}
+		results = append(results, matched...)
+		return replaced, nil
+	}
+
+	switch strings.ToLower(m.GetTargetType()) {
+	case replacerTargetJSON:
+		body, err := replaceJSONPathValue(packetInfo.BodyRaw, name, replaceValue)
+		if err != nil {
+			return packetInfo, nil, nil, err
+		}
+		if len(results) > 0 {
+			modified = lowhttp.ReplaceHTTPPacketBody([]byte(packetInfo.HeaderRaw), body, false)
+		}
+	case replacerTargetXML:
+		body, err := replaceXPathValue(packetInfo.BodyRaw, name, replaceValue)
+		if err != nil {
+			return packetInfo, nil, nil, err
+		}
+		if len(results) > 0 {
+			modified = lowhttp.ReplaceHTTPPacketBody([]byte(packetInfo.HeaderRaw), body, false)
+		}
+	case replacerTargetForm, replacerTargetQuery:
+		if !isReq {
+			return packetInfo, nil, originPacket, nil
+		}
+		getValues, setValue := lowhttp.GetHTTPRequestPostParamFull, lowhttp.ReplaceHTTPPacketPostParam
+		if strings.EqualFold(m.GetTargetType(), replacerTargetQuery) {
+			getValues, setValue = lowhttp.GetHTTPRequestQueryParamFull, lowhttp.ReplaceHTTPPacketQueryParam
+		}
+		values := getValues(plain, name)
+		if len(values) <= 0 {
+			break
+		}
+		replaced, err := replaceValue(values[0])
+		if err != nil {
+			return packetInfo, nil, nil, err
+		}
+		if len(results) > 0 {
+			modified = setValue(plain, name, replaced)
+		}
+	case replacerTargetCookie:
+		values := lowhttp.GetHTTPPacketCookieValues(plain, name)
+		if len(values) <= 0 {
+			break
+		}
+		replaced, err := replaceValue(values[0])
+		if err != nil {
+			return packetInfo, nil, nil, err
+		}
+		if len(results) > 0 {
+			modified = lowhttp.ReplaceHTTPPacketCookie(plain, name, replaced)
+		}
+	case replacerTargetHeader:
+		for _, header := range packetInfo.Headers {
+			if !strings.EqualFold(header[0], name) {
+				continue
+			}
+			replaced, err := replaceValue(header[1])
+			if err != nil {
+				return packetInfo, nil, nil, err
+			}
+			if len(results) > 0 {
+				modified = lowhttp.ReplaceHTTPPacketHeader(plain, header[0], replaced)
+			}
+			break
+		}
+	default:
+		return packetInfo, nil, nil, utils.Errorf("unsupported replacer target type: %v", m.GetTargetType())
+	}
+
+	if len(results) <= 0 || modified == nil {
+		return packetInfo, results, originPacket, nil
+	}
+	return packetInfo, results, modified, nil
+}
+
+// replaceJSONPathValue 替换 JSONPath 匹配到的值，对象与数组不会被替换，body 不是 JSON 时原样返回
+// 数字、布尔值替换后如果仍然是合法的 JSON 值则保持原本的类型
+func replaceJSONPathValue(body []byte, path string, replace func(string) (string, error)) ([]byte, error) {
+	if len(bytes.TrimSpace(body)) <= 0 {
+		return body, nil
+	}
+	var replaceErr error
+	result, err := jsonpath.ReplaceFunc(string(body), path, func(old interface{}) interface{} {
+		var value string
+		switch ret := old.(type) {
+		case nil, map[string]interface{}, []interface{}:
+			return old
+		case string:
+			value = ret
+		default:
+			value = utils.InterfaceToString(ret)
+		}
+		replaced, err := replace(value)
+		if err != nil {
+			replaceErr = err
+			return old
+		}
+		if replaced == value {
+			return old
+		}
+		if _, ok := old.(string); ok {
+			return replaced
+		}
+		var v interface{}
+		if err := json.Unmarshal([]byte(replaced), &v); err == nil {
+			switch v.(type) {
+			case map[string]interface{}, []interface{}:
+			default:
+				return v
+			}
+		}
+		return replaced
+	})
+	if replaceErr != nil {
+		return nil, replaceErr
+	}
+	if err != nil {
+		log.Debugf("replace jsonpath %v failed: %v", path, err)
+		return body, nil
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(result); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// replaceXPathValue 替换 XPath 匹配到的属性或元素的文本，包含子元素的元素不会被替换，body 不是 XML 时原样返回
+func replaceXPathValue(body []byte, path string, replace func(string) (string, error)) ([]byte, error) {
+	doc, err := xmlquery.Parse(bytes.NewReader(body))
+	if err != nil {
+		log.Debugf("parse body as xml failed: %v", err)
+		return body, nil
+	}
+	nodes, err := xmlquery.QueryAll(doc, path)
+	if err != nil {
+		return nil, utils.Errorf("invalid xpath %v: %v", path, err)
+	}
+	for _, node := range nodes {
+		switch node.Type {
+		case xmlquery.AttributeNode:
+			if node.Parent == nil {
+				continue
+			}
+			for index, attr := range node.Parent.Attr {
+				if attr.Name.Local != node.Data {
+					continue
+				}
+				replaced, err := replace(attr.Value)
+				if err != nil {
+					return nil, err
+				}
+				node.Parent.Attr[index].Value = replaced
+				break
+			}
+		case xmlquery.ElementNode:
+			hasElement := false
+			for child := node.FirstChild; child != nil; child = child.NextSibling {
+				if child.Type == xmlquery.ElementNode {
+					hasElement = true
+					break
+				}
+			}
+			if hasElement {
+				continue
+			}
+			value := node.InnerText()
+			replaced, err := replace(value)
+			if err != nil {
+				return nil, err
+			}
+			if replaced == value {
+				continue
+			}
+			text := &xmlquery.Node{Type: xmlquery.TextNode, Data: replaced, Parent: node}
+			node.FirstChild, node.LastChild = text, text
+		}
+	}
+	return []byte(doc.OutputXML(false)), nil
+}
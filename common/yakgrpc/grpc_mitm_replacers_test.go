@@ -2,10 +2,12 @@ package yakgrpc
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"github.com/yaklang/yaklang/common/schema"
 	"net/http"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -696,3 +698,287 @@ Content-Length: 4`))
 		VerboseName:      "",
 	}, 1, len(reqHeaderRaw))
 }
+
+func TestGRPCMUSTPASS_ReplaceTarget(t *testing.T) {
+	jsonRequest := "POST /api?id=1&name=admin HTTP/1.1\r\nHost: www.example.com\r\nContent-Type: application/json\r\nCookie: session=abc; uid=100\r\nX-Token: tk-123\r\n\r\n" +
+		`{"user":{"name":"admin","age":18},"list":[{"n":"a1"},{"n":"b1"}],"html":"<b>"}`
+	xmlRequest := "POST /xml HTTP/1.1\r\nHost: www.example.com\r\nContent-Type: application/xml\r\n\r\n" +
+		`<?xml version="1.0"?><root><user id="1001"><name>admin</name></user></root>`
+	formRequest := "POST /login?id=1 HTTP/1.1\r\nHost: www.example.com\r\nContent-Type: application/x-www-form-urlencoded\r\n\r\nusername=admin&password=123456"
+
+	for _, c := range []struct {
+		name        string
+		rule        *ypb.MITMContentReplacer
+		packet      string
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:        "jsonpath",
+			rule:        &ypb.MITMContentReplacer{TargetType: "json", TargetPath: "$.user.name", Rule: "admin", Result: "guest"},
+			packet:      jsonRequest,
+			contains:    []string{`"name":"guest"`, `"age":18`, `"html":"<b>"`, "name=admin"},
+			notContains: []string{`"name":"admin"`},
+		},
+		{
+			name:     "jsonpath whole value",
+			rule:     &ypb.MITMContentReplacer{TargetType: "json", TargetPath: "$.user.age", Result: "20"},
+			packet:   jsonRequest,
+			contains: []string{`"age":20`},
+		},
+		{
+			name:     "jsonpath wildcard",
+			rule:     &ypb.MITMContentReplacer{TargetType: "json", TargetPath: "$.list[*].n", Rule: `(\w)1`, Result: "${1}2"},
+			packet:   jsonRequest,
+			contains: []string{`[{"n":"a2"},{"n":"b2"}]`},
+		},
+		{
+			name:        "xpath attribute",
+			rule:        &ypb.MITMContentReplacer{TargetType: "xml", TargetPath: "//user/@id", Result: "1002"},
+			packet:      xmlRequest,
+			contains:    []string{`<user id="1002">`, `<name>admin</name>`},
+			notContains: []string{`1001`},
+		},
+		{
+			name:     "xpath element",
+			rule:     &ypb.MITMContentReplacer{TargetType: "xml", TargetPath: "//user/name", Rule: "adm(in)", Result: "root$1"},
+			packet:   xmlRequest,
+			contains: []string{`<user id="1001">`, `<name>rootin</name>`},
+		},
+		{
+			name:        "form",
+			rule:        &ypb.MITMContentReplacer{TargetType: "form", TargetPath: "password", Result: "admin888"},
+			packet:      formRequest,
+			contains:    []string{"username=admin", "password=admin888", "id=1"},
+			notContains: []string{"123456"},
+		},
+		{
+			name:     "query",
+			rule:     &ypb.MITMContentReplacer{TargetType: "query", TargetPath: "id", Result: "2"},
+			packet:   formRequest,
+			contains: []string{"/login?id=2", "password=123456"},
+		},
+		{
+			name:        "cookie",
+			rule:        &ypb.MITMContentReplacer{TargetType: "cookie", TargetPath: "uid", Rule: `\d+`, Result: "1"},
+			packet:      jsonRequest,
+			contains:    []string{"session=abc", "uid=1"},
+			notContains: []string{"uid=100"},
+		},
+		{
+			name:     "header",
+			rule:     &ypb.MITMContentReplacer{TargetType: "header", TargetPath: "x-token", Rule: `tk-(\d+)`, Result: "tk-$1-$1"},
+			packet:   jsonRequest,
+			contains: []string{"X-Token: tk-123-123"},
+		},
+		{
+			name:        "missing target",
+			rule:        &ypb.MITMContentReplacer{TargetType: "form", TargetPath: "notexisted", Result: "1"},
+			packet:      formRequest,
+			notContains: []string{"notexisted"},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			c.rule.EnableForRequest = true
+			replacer := NewMITMReplacer()
+			replacer.SetRules(c.rule)
+			rules, modified, _ := replacer.hook(true, false, []byte(c.packet), false)
+			for _, s := range c.contains {
+				require.Contains(t, string(modified), s)
+			}
+			for _, s := range c.notContains {
+				require.NotContains(t, string(modified), s)
+			}
+			if len(c.contains) > 0 {
+				require.Len(t, rules, 1)
+			} else {
+				require.Len(t, rules, 0)
+				require.Equal(t, c.packet, string(modified))
+			}
+		})
+	}
+
+	// 响应中的 Set-Cookie 与 JSON
+	replacer := NewMITMReplacer()
+	replacer.SetRules(
+		&ypb.MITMContentReplacer{TargetType: "cookie", TargetPath: "session", Result: "fixed", EnableForResponse: true},
+		&ypb.MITMContentReplacer{TargetType: "json", TargetPath: "$.role", Result: "admin", EnableForResponse: true, Index: 1},
+	)
+	rsp := "HTTP/1.1 200 OK\r\nSet-Cookie: session=abc; Path=/\r\nContent-Type: application/json\r\n\r\n" + `{"role":"user"}`
+	rules, modified, _ := replacer.hook(false, true, []byte(rsp))
+	require.Len(t, rules, 2)
+	require.Contains(t, string(modified), "Set-Cookie: session=fixed; Path=/")
+	require.Contains(t, string(modified), `{"role":"admin"}`)
+}
+
+func TestGRPCMUSTPASS_ReplaceWithFuzztag(t *testing.T) {
+	replacer := NewMITMReplacer()
+	replacer.SetRules(&ypb.MITMContentReplacer{
+		Rule:             `token=(?<token>\w+)`,
+		Result:           `token={{base64(${token})}}&raw=$1&price=$$1`,
+		EnableForRequest: true,
+		EnableForURI:     true,
+		EnableFuzztag:    true,
+	})
+	packet := "GET /?token=abc HTTP/1.1\r\nHost: www.example.com\r\n\r\n"
+	_, modified, _ := replacer.hook(true, false, []byte(packet))
+	require.Contains(t, string(modified), "GET /?token=YWJj&raw=abc&price=$1 HTTP/1.1")
+
+	// 不开启 fuzztag 时保持原样
+	replacer.SetRules(&ypb.MITMContentReplacer{
+		Rule:             `token=(\w+)`,
+		Result:           `token={{base64($1)}}`,
+		EnableForRequest: true,
+		EnableForURI:     true,
+	})
+	_, modified, _ = replacer.hook(true, false, []byte(packet))
+	require.Contains(t, string(modified), "GET /?token={{base64(abc)}} HTTP/1.1")
+}
+
+func TestGRPCMUSTPASS_ReplaceWithCondition(t *testing.T) {
+	newRule := func(f func(rule *ypb.MITMContentReplacer)) *ypb.MITMContentReplacer {
+		rule := &ypb.MITMContentReplacer{
+			Rule:              `secret`,
+			Result:            `******`,
+			EnableForRequest:  true,
+			EnableForResponse: true,
+			EnableForBody:     true,
+		}
+		f(rule)
+		return rule
+	}
+	request := "POST /api HTTP/1.1\r\nHost: api.example.com:8080\r\nContent-Type: application/json\r\n\r\n{\"a\":\"secret\"}"
+	response := "HTTP/1.1 200 OK\r\nContent-Type: text/html\r\n\r\nsecret"
+
+	for _, c := range []struct {
+		name            string
+		rule            *ypb.MITMContentReplacer
+		requestReplaced bool
+		// 响应的条件需要通过对应的请求判断
+		responseReplaced bool
+	}{
+		{"host", newRule(func(r *ypb.MITMContentReplacer) { r.MatchHost = "*.example.com" }), true, true},
+		{"host mismatch", newRule(func(r *ypb.MITMContentReplacer) { r.MatchHost = "*.example.org, example.com" }), false, false},
+		{"method", newRule(func(r *ypb.MITMContentReplacer) { r.MatchMethod = []string{"get", "post"} }), true, true},
+		{"method mismatch", newRule(func(r *ypb.MITMContentReplacer) { r.MatchMethod = []string{"GET"} }), false, false},
+		{"content-type", newRule(func(r *ypb.MITMContentReplacer) { r.MatchContentType = "JSON" }), true, false},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			replacer := NewMITMReplacer()
+			replacer.SetRules(c.rule)
+			_, modified, _ := replacer.hook(true, false, []byte(request))
+			require.Equal(t, c.requestReplaced, !strings.Contains(string(modified), "secret"))
+			_, modified, _ = replacer.hook(false, true, []byte(response), false, []byte(request))
+			require.Equal(t, c.responseReplaced, !strings.Contains(string(modified), "secret"))
+		})
+	}
+
+	// 规则会被多个连接同时使用
+	concurrent := NewMITMReplacer()
+	concurrent.SetRules(newRule(func(r *ypb.MITMContentReplacer) { r.MatchHost = "*.example.com" }))
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, modified, _ := concurrent.hook(true, false, []byte(request))
+			require.NotContains(t, string(modified), "secret")
+		}()
+	}
+	wg.Wait()
+
+	// 没有对应的请求时，Host 条件无法满足
+	replacer := NewMITMReplacer()
+	replacer.SetRules(newRule(func(r *ypb.MITMContentReplacer) { r.MatchHost = "*" }))
+	_, modified, _ := replacer.hook(false, true, []byte(response))
+	require.Contains(t, string(modified), "secret")
+
+	// 镜像规则同样需要满足条件
+	replacer.SetRules(newRule(func(r *ypb.MITMContentReplacer) {
+		r.NoReplace = true
+		r.MatchHost = "*.example.org"
+	}))
+	req, err := lowhttp.ParseBytesToHttpRequest([]byte(request))
+	require.NoError(t, err)
+	require.Empty(t, replacer.hookColor([]byte(request), []byte(response), req, &schema.HTTPFlow{}))
+}
+
+func TestGRPCMUSTPASS_DryRunMITMReplacerRules(t *testing.T) {
+	client, err := NewLocalClient()
+	require.NoError(t, err)
+
+	request := "POST /login HTTP/1.1\r\nHost: www.example.com\r\nContent-Type: application/json\r\n\r\n" + `{"username":"admin","password":"123456"}`
+	response := "HTTP/1.1 200 OK\r\nContent-Type: application/json\r\n\r\n" + `{"ok":false}`
+	rsp, err := client.DryRunMITMReplacerRules(context.Background(), &ypb.DryRunMITMReplacerRulesRequest{
+		Rules: []*ypb.MITMContentReplacer{
+			{
+				VerboseName:      "password",
+				TargetType:       "json",
+				TargetPath:       "$.password",
+				Result:           "{{repeat:str(a|3)}}",
+				EnableFuzztag:    true,
+				EnableForRequest: true,
+				MatchHost:        "*.example.com",
+			},
+			{
+				VerboseName:       "ok",
+				TargetType:        "json",
+				TargetPath:        "$.ok",
+				Result:            "true",
+				EnableForResponse: true,
+				MatchMethod:       []string{"POST"},
+				ExtraRepeat:       true,
+				Index:             1,
+			},
+			{
+				VerboseName:      "unmatched",
+				Rule:             "not-existed",
+				EnableForRequest: true,
+				EnableForBody:    true,
+				Drop:             true,
+				Index:            2,
+			},
+		},
+		Request:  []byte(request),
+		Response: []byte(response),
+	})
+	require.NoError(t, err)
+	require.Contains(t, string(rsp.GetRequest()), `"password":"aaa"`)
+	require.Contains(t, string(rsp.GetResponse()), `{"ok":true}`)
+	require.False(t, rsp.GetRequestDropped())
+	require.Len(t, rsp.GetRequestMatchedRules(), 1)
+	require.Equal(t, "password", rsp.GetRequestMatchedRules()[0].GetVerboseName())
+	require.Len(t, rsp.GetResponseMatchedRules(), 1)
+	require.Contains(t, rsp.GetRequestDiff(), `-{"username":"admin","password":"123456"}`)
+	require.Contains(t, rsp.GetRequestDiff(), `+{"password":"aaa","username":"admin"}`)
+	require.Contains(t, rsp.GetResponseDiff(), `+{"ok":true}`)
+
+	// 没有修改时 diff 为空
+	rsp, err = client.DryRunMITMReplacerRules(context.Background(), &ypb.DryRunMITMReplacerRulesRequest{
+		Rules: []*ypb.MITMContentReplacer{
+			{Rule: "not-existed", EnableForRequest: true, EnableForBody: true},
+		},
+		Request: []byte(request),
+	})
+	require.NoError(t, err)
+	require.Equal(t, request, string(rsp.GetRequest()))
+	require.Empty(t, rsp.GetRequestDiff())
+	require.Empty(t, rsp.GetResponse())
+
+	_, err = client.DryRunMITMReplacerRules(context.Background(), &ypb.DryRunMITMReplacerRulesRequest{})
+	require.Error(t, err)
+}
+
+func TestMITMReplacerSplitGzipPacket(t *testing.T) {
+	body := []byte(`{"a":"secret"}`)
+	gzipped, err := utils.GzipCompress(body)
+	require.NoError(t, err)
+	header := "HTTP/1.1 200 OK\r\nContent-Type: application/json\r\nContent-Encoding: gzip\r\nTransfer-Encoding: chunked\r\n\r\n"
+	rule := &MITMReplaceRule{MITMContentReplacer: &ypb.MITMContentReplacer{}}
+	info, err := rule.splitPacket(lowhttp.ReplaceHTTPPacketBody([]byte(header), gzipped, true))
+	require.NoError(t, err)
+	// 解压后的 body 不应该保留 Content-Encoding 与 Transfer-Encoding
+	require.Equal(t, body, info.BodyRaw)
+	require.Empty(t, lowhttp.GetHTTPPacketHeader([]byte(info.HeaderRaw), "Content-Encoding"))
+	require.Empty(t, lowhttp.GetHTTPPacketHeader([]byte(info.HeaderRaw), "Transfer-Encoding"))
+}
@@ -0,0 +1,93 @@
+package yakgrpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/yakgrpc/yakit"
+	"github.com/yaklang/yaklang/common/yakgrpc/ypb"
+)
+
+func (s *Server) QuerySyntaxFlowRule(ctx context.Context, req *ypb.QuerySyntaxFlowRuleRequest) (*ypb.QuerySyntaxFlowRuleResponse, error) {
+	p, rules, err := yakit.QuerySyntaxFlowRule(s.GetProfileDatabase(), req)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]*ypb.SyntaxFlowRule, 0, len(rules))
+	for _, rule := range rules {
+		data = append(data, rule.ToGRPCModel())
+	}
+	return &ypb.QuerySyntaxFlowRuleResponse{
+		Pagination: req.GetPagination(),
+		Total:      int64(p.TotalRecord),
+		Data:       data,
+	}, nil
+}
+
+func (s *Server) SaveSyntaxFlowRule(ctx context.Context, req *ypb.SaveSyntaxFlowRuleRequest) (*ypb.SyntaxFlowRule, error) {
+	if req.GetContent() == "" {
+		return nil, utils.Error("syntaxflow rule content is empty")
+	}
+	rule, err := yakit.ParseSyntaxFlowRule(req.GetRuleName(), req.GetContent())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetLanguage() != "" {
+		rule.Language = req.GetLanguage()
+	}
+	rule.Groups = strings.Join(req.GetGroups(), ",")
+	rule.Disabled = req.GetDisabled()
+
+	db := s.GetProfileDatabase()
+	if err := yakit.CreateOrUpdateSyntaxFlowRule(db, rule); err != nil {
+		return nil, err
+	}
+	saved, err := yakit.GetSyntaxFlowRuleByName(db, rule.RuleName)
+	if err != nil {
+		return nil, err
+	}
+	return saved.ToGRPCModel(), nil
+}
+
+func (s *Server) DeleteSyntaxFlowRule(ctx context.Context, req *ypb.DeleteSyntaxFlowRuleRequest) (*ypb.Empty, error) {
+	var err error
+	if req.GetDeleteAll() {
+		err = yakit.ClearSyntaxFlowRule(s.GetProfileDatabase())
+	} else {
+		if yakit.IsEmptySyntaxFlowRuleFilter(req.GetFilter()) {
+			return nil, utils.Error("delete syntaxflow rule failed: filter is empty, set DeleteAll to delete all rules")
+		}
+		err = yakit.DeleteSyntaxFlowRule(s.GetProfileDatabase(), req.GetFilter())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ypb.Empty{}, nil
+}
+
+func (s *Server) QuerySyntaxFlowRuleGroup(ctx context.Context, req *ypb.QuerySyntaxFlowRuleGroupRequest) (*ypb.QuerySyntaxFlowRuleGroupResponse, error) {
+	groups, err := yakit.QuerySyntaxFlowRuleGroup(s.GetProfileDatabase(), req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	return &ypb.QuerySyntaxFlowRuleGroupResponse{Group: groups}, nil
+}
+
+func (s *Server) SaveSyntaxFlowRuleGroup(ctx context.Context, req *ypb.SaveSyntaxFlowRuleGroupRequest) (*ypb.Empty, error) {
+	if req.GetFilter() == nil {
+		return nil, utils.Error("save syntaxflow rule group failed: filter is empty")
+	}
+	err := yakit.UpdateSyntaxFlowRuleGroup(s.GetProfileDatabase(), req.GetFilter(), req.GetSaveGroup(), req.GetRemoveGroup())
+	if err != nil {
+		return nil, err
+	}
+	return &ypb.Empty{}, nil
+}
+
+func (s *Server) DeleteSyntaxFlowRuleGroup(ctx context.Context, req *ypb.DeleteSyntaxFlowRuleGroupRequest) (*ypb.Empty, error) {
+	if err := yakit.DeleteSyntaxFlowRuleGroup(s.GetProfileDatabase(), req.GetGroups()); err != nil {
+		return nil, err
+	}
+	return &ypb.Empty{}, nil
+}
@@ -0,0 +1,158 @@
+package yakgrpc
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklang/yaklang/common/consts"
+	"github.com/yaklang/yaklang/common/yakgrpc/yakit"
+	"github.com/yaklang/yaklang/common/yakgrpc/ypb"
+)
+
+func TestGRPCMUSTPASS_SyntaxFlowRule(t *testing.T) {
+	client, err := NewLocalClient()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	ruleName := "java-" + uuid.NewString()
+	group := uuid.NewString()
+	filter := &ypb.SyntaxFlowRuleFilter{RuleNames: []string{ruleName}}
+	defer client.DeleteSyntaxFlowRule(ctx, &ypb.DeleteSyntaxFlowRuleRequest{Filter: filter})
+
+	content := `desc(
+	title: "rce",
+	level: high,
+	cwe: "CWE-78",
+	tags: "rce|command",
+	description: "command injection",
+)
+Runtime.getRuntime().exec(* as $sink);
+alert $sink;
+`
+	rule, err := client.SaveSyntaxFlowRule(ctx, &ypb.SaveSyntaxFlowRuleRequest{
+		RuleName: ruleName + ".sf",
+		Content:  content,
+		Groups:   []string{group},
+	})
+	require.NoError(t, err)
+	require.Equal(t, ruleName, rule.RuleName)
+	require.Equal(t, "rce", rule.Title)
+	require.Equal(t, "java", rule.Language)
+	require.Equal(t, "high", rule.Severity)
+	require.Equal(t, "CWE-78", rule.CWE)
+	require.Equal(t, []string{"rce", "command"}, rule.Tags)
+	require.Equal(t, []string{group}, rule.Groups)
+
+	t.Run("save invalid rule", func(t *testing.T) {
+		_, err := client.SaveSyntaxFlowRule(ctx, &ypb.SaveSyntaxFlowRuleRequest{
+			RuleName: uuid.NewString(),
+			Content:  "desc(",
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("query", func(t *testing.T) {
+		rsp, err := client.QuerySyntaxFlowRule(ctx, &ypb.QuerySyntaxFlowRuleRequest{
+			Pagination: &ypb.Paging{Page: 1, Limit: 10},
+			Filter:     &ypb.SyntaxFlowRuleFilter{Groups: []string{group}, Language: []string{"java"}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), rsp.Total)
+		require.Equal(t, content, rsp.Data[0].Content)
+
+		rsp, err = client.QuerySyntaxFlowRule(ctx, &ypb.QuerySyntaxFlowRuleRequest{
+			Pagination: &ypb.Paging{Page: 1, Limit: 10},
+			Filter:     &ypb.SyntaxFlowRuleFilter{RuleNames: []string{ruleName}, DisabledStatus: 2},
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(0), rsp.Total)
+
+		// group is matched exactly, not by substring
+		rsp, err = client.QuerySyntaxFlowRule(ctx, &ypb.QuerySyntaxFlowRuleRequest{
+			Pagination: &ypb.Paging{Page: 1, Limit: 10},
+			Filter:     &ypb.SyntaxFlowRuleFilter{Groups: []string{group[:8]}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(0), rsp.Total)
+	})
+
+	t.Run("group", func(t *testing.T) {
+		newGroup := uuid.NewString()
+		_, err := client.SaveSyntaxFlowRuleGroup(ctx, &ypb.SaveSyntaxFlowRuleGroupRequest{
+			Filter:      filter,
+			SaveGroup:   []string{newGroup},
+			RemoveGroup: []string{group},
+		})
+		require.NoError(t, err)
+		rsp, err := client.QuerySyntaxFlowRuleGroup(ctx, &ypb.QuerySyntaxFlowRuleGroupRequest{Filter: filter})
+		require.NoError(t, err)
+		require.Len(t, rsp.Group, 1)
+		require.Equal(t, newGroup, rsp.Group[0].Value)
+		require.Equal(t, int32(1), rsp.Group[0].Total)
+
+		_, err = client.DeleteSyntaxFlowRuleGroup(ctx, &ypb.DeleteSyntaxFlowRuleGroupRequest{Groups: []string{newGroup}})
+		require.NoError(t, err)
+		rsp, err = client.QuerySyntaxFlowRuleGroup(ctx, &ypb.QuerySyntaxFlowRuleGroupRequest{Filter: filter})
+		require.NoError(t, err)
+		require.Len(t, rsp.Group, 0)
+	})
+
+	t.Run("export and import", func(t *testing.T) {
+		dir := t.TempDir()
+		count, err := yakit.ExportSyntaxFlowRuleToDir(consts.GetGormProfileDatabase(), filter, dir)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+		raw, err := os.ReadFile(filepath.Join(dir, "java", ruleName+".sf"))
+		require.NoError(t, err)
+		require.Equal(t, content, string(raw))
+
+		_, err = client.DeleteSyntaxFlowRule(ctx, &ypb.DeleteSyntaxFlowRuleRequest{Filter: filter})
+		require.NoError(t, err)
+		count, err = yakit.ImportSyntaxFlowRuleFromDir(consts.GetGormProfileDatabase(), dir)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+		rule, err := yakit.GetSyntaxFlowRuleByName(consts.GetGormProfileDatabase(), ruleName)
+		require.NoError(t, err)
+		require.Equal(t, "java", rule.Language)
+	})
+
+	t.Run("export with path in name", func(t *testing.T) {
+		name := "../" + uuid.NewString()
+		nameFilter := &ypb.SyntaxFlowRuleFilter{RuleNames: []string{name}}
+		defer yakit.DeleteSyntaxFlowRule(consts.GetGormProfileDatabase(), nameFilter)
+		_, err := client.SaveSyntaxFlowRule(ctx, &ypb.SaveSyntaxFlowRuleRequest{
+			RuleName: name,
+			Language: "../..",
+			Content:  content,
+		})
+		require.NoError(t, err)
+
+		parent := t.TempDir()
+		dir := filepath.Join(parent, "export")
+		count, err := yakit.ExportSyntaxFlowRuleToDir(consts.GetGormProfileDatabase(), nameFilter, dir)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+		matches, err := filepath.Glob(filepath.Join(parent, "*", "*", "*.sf"))
+		require.NoError(t, err)
+		require.Len(t, matches, 1)
+		require.True(t, strings.HasPrefix(matches[0], dir+string(filepath.Separator)), matches[0])
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		_, err := client.DeleteSyntaxFlowRule(ctx, &ypb.DeleteSyntaxFlowRuleRequest{})
+		require.Error(t, err)
+		_, err = client.DeleteSyntaxFlowRule(ctx, &ypb.DeleteSyntaxFlowRuleRequest{Filter: &ypb.SyntaxFlowRuleFilter{}})
+		require.Error(t, err)
+		_, err = client.DeleteSyntaxFlowRule(ctx, &ypb.DeleteSyntaxFlowRuleRequest{Filter: &ypb.SyntaxFlowRuleFilter{RuleNames: []string{""}}})
+		require.Error(t, err)
+		_, err = client.DeleteSyntaxFlowRule(ctx, &ypb.DeleteSyntaxFlowRuleRequest{Filter: filter})
+		require.NoError(t, err)
+		_, err = yakit.GetSyntaxFlowRuleByName(consts.GetGormProfileDatabase(), ruleName)
+		require.Error(t, err)
+	})
+}
@@ -92,13 +92,13 @@ func (s *Server) RequireDNSLogDomainByScript(ctx context.Context, req *ypb.Requi
 			return nil, err
 		}
 
-		engine, err := yak.NewScriptEngine(1000).ExecuteEx(script.Content, map[string]interface{}{
+		engine, err := yak.NewScriptEngine(1000).ExecutePluginEx(script, map[string]interface{}{
 			"YAK_FILENAME": req.GetScriptName(),
 		})
 		if err != nil {
 			return nil, utils.Errorf("execute file %s code failed: %s", req.GetScriptName(), err.Error())
 		}
-		result, err := engine.CallYakFunction(context.Background(), "requireDomain", []interface{}{})
+		result, err := engine.SafeCallYakFunction(context.Background(), "requireDomain", []interface{}{})
 		if err != nil {
 			return nil, utils.Errorf("import %v' s handle failed: %s", req.GetScriptName(), err)
 		}
@@ -161,13 +161,13 @@ func (s *Server) QueryDNSLogTokenByScript(ctx context.Context, req *ypb.RequireD
 			return nil, err
 		}
 
-		engine, err := yak.NewScriptEngine(1000).ExecuteEx(script.Content, map[string]interface{}{
+		engine, err := yak.NewScriptEngine(1000).ExecutePluginEx(script, map[string]interface{}{
 			"YAK_FILENAME": req.GetScriptName(),
 		})
 		if err != nil {
 			return nil, utils.Errorf("execute file %s code failed: %s", req.GetScriptName(), err.Error())
 		}
-		result, err := engine.CallYakFunction(context.Background(), "getResults", []interface{}{req.GetToken()})
+		result, err := engine.SafeCallYakFunction(context.Background(), "getResults", []interface{}{req.GetToken()})
 		if err != nil {
 			return nil, utils.Errorf("import %v' s handle failed: %s", req.GetScriptName(), err)
 		}
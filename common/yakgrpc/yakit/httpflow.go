@@ -87,6 +87,12 @@ func RegisterLowHTTPSaveCallback() {
 		flow.RuntimeId = runtimeId
 		flow.HiddenIndex = hiddenIndex
 		flow.Payload = strings.Join(payloads, ",")
+		if trace := r.TraceInfo; trace != nil {
+			flow.DNSDurationMs = trace.DNSTime.Milliseconds()
+			flow.ConnDurationMs = trace.ConnTime.Milliseconds()
+			flow.DurationMs = trace.ServerTime.Milliseconds()
+			flow.TotalDurationMs = trace.TotalTime.Milliseconds()
+		}
 		err = InsertHTTPFlowEx(flow)
 		if err != nil {
 			log.Errorf("insert httpflow failed: %s", err)
@@ -0,0 +1,107 @@
+package yakit
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	"github.com/yaklang/yaklang/common/burp"
+	"github.com/yaklang/yaklang/common/schema"
+	"github.com/yaklang/yaklang/common/utils"
+)
+
+// HTTPFlowSourceBurp 从 Burp Suite 导出的 XML 导入的 HTTPFlow 的 SourceType
+const HTTPFlowSourceBurp = "burp"
+
+// burpHighlightColors Burp 的 highlight 颜色与 HTTPFlow 颜色的对应关系
+var burpHighlightColors = map[string]func(flow *schema.HTTPFlow){
+	"red":     (*schema.HTTPFlow).Red,
+	"orange":  (*schema.HTTPFlow).Orange,
+	"yellow":  (*schema.HTTPFlow).Yellow,
+	"green":   (*schema.HTTPFlow).Green,
+	"cyan":    (*schema.HTTPFlow).Cyan,
+	"blue":    (*schema.HTTPFlow).Blue,
+	"pink":    (*schema.HTTPFlow).Purple,
+	"magenta": (*schema.HTTPFlow).Purple,
+	"gray":    (*schema.HTTPFlow).Grey,
+	"grey":    (*schema.HTTPFlow).Grey,
+}
+
+// BurpItemToHTTPFlow 将 Burp 导出的 item 转换为 HTTPFlow，highlight 转换为颜色，comment 转换为 tag
+// HiddenIndex 由 item 计算，重复导入同一个 XML 不会产生重复的记录
+func BurpItemToHTTPFlow(item *burp.Item) (*schema.HTTPFlow, error) {
+	request, err := item.Request.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	if len(request) == 0 {
+		return nil, utils.Error("empty request")
+	}
+	response, err := item.Response.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	urlStr := strings.TrimSpace(item.URL)
+	if u, err := url.Parse(urlStr); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, utils.Errorf("unsupported url: %v", utils.ShrinkString(urlStr, 64))
+	}
+
+	var remoteAddr string
+	if ip := strings.TrimSpace(item.Host.IP); ip != "" && item.Port > 0 {
+		remoteAddr = utils.HostPort(ip, item.Port)
+	}
+
+	var tags string
+	if comment := strings.TrimSpace(item.Comment); comment != "" {
+		// | 是 tag 的分隔符
+		tags = strings.ReplaceAll(comment, "|", " ")
+	}
+	flow, err := CreateHTTPFlow(
+		CreateHTTPFlowWithHTTPS(item.IsHTTPS()),
+		CreateHTTPFlowWithRequestRaw(request),
+		CreateHTTPFlowWithResponseRaw(response),
+		CreateHTTPFlowWithSource(HTTPFlowSourceBurp),
+		CreateHTTPFlowWithURL(urlStr),
+		CreateHTTPFlowWithRemoteAddr(remoteAddr),
+		CreateHTTPFlowWithTags(tags),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if setColor, ok := burpHighlightColors[strings.ToLower(strings.TrimSpace(item.Highlight))]; ok {
+		setColor(flow)
+	}
+	flow.HiddenIndex = utils.CalcSha1(HTTPFlowSourceBurp, item.Time, urlStr, request)
+	if started := item.StartedTime(); !started.IsZero() {
+		flow.CreatedAt, flow.UpdatedAt = started, started
+	}
+	// 与保存时一致，修正 url 之后计算 hash
+	_ = flow.BeforeSave()
+	return flow, nil
+}
+
+// ImportHTTPFlowsFromBurp 流式读取 Burp Suite "Save items" 导出的 XML 并保存为 HTTPFlow，每处理一个 item 调用一次 handler(可以为 nil)，
+// err 不为空时表示该 item 被跳过，返回成功导入的数量
+func ImportHTTPFlowsFromBurp(ctx context.Context, db *gorm.DB, r io.Reader, handler func(flow *schema.HTTPFlow, err error)) (int, error) {
+	count := 0
+	err := burp.ReadItems(r, func(item *burp.Item) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		flow, err := BurpItemToHTTPFlow(item)
+		if err == nil {
+			err = CreateOrUpdateHTTPFlow(db, flow.Hash, flow)
+		}
+		if err == nil {
+			count++
+		}
+		if handler != nil {
+			handler(flow, err)
+		}
+		return nil
+	})
+	return count, err
+}
@@ -0,0 +1,230 @@
+package yakit
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jinzhu/gorm"
+	"github.com/yaklang/yaklang/common/consts"
+	"github.com/yaklang/yaklang/common/har"
+	"github.com/yaklang/yaklang/common/schema"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/yak/yaklib/codec"
+)
+
+// HTTPFlowSourceHAR 从 HAR 文件导入的 HTTPFlow 的 SourceType
+const HTTPFlowSourceHAR = "har"
+
+func unquoteFlowPacket(raw string) []byte {
+	if raw == "" {
+		return nil
+	}
+	unquoted, err := strconv.Unquote(raw)
+	if err != nil {
+		return []byte(raw)
+	}
+	return []byte(unquoted)
+}
+
+// HTTPFlowToHAREntry 将 HTTPFlow 转换为 HAR entry，websocket 的消息从 db 中读取
+func HTTPFlowToHAREntry(db *gorm.DB, flow *schema.HTTPFlow) *har.Entry {
+	entry := har.NewEntry(flow.Url, unquoteFlowPacket(flow.Request), unquoteFlowPacket(flow.Response), flow.CreatedAt)
+	if flow.TotalDurationMs > 0 {
+		entry.Timings.DNS = float64(flow.DNSDurationMs)
+		entry.Timings.Connect = float64(flow.ConnDurationMs)
+		entry.Timings.Wait = float64(flow.DurationMs)
+		if receive := flow.TotalDurationMs - flow.DNSDurationMs - flow.ConnDurationMs - flow.DurationMs; receive > 0 {
+			entry.Timings.Receive = float64(receive)
+		}
+	}
+	entry.Time = entry.Timings.Total()
+
+	if host, port, err := utils.ParseStringToHostPort(flow.RemoteAddr); err == nil {
+		entry.ServerIPAddress, entry.Connection = host, strconv.Itoa(port)
+	}
+	if entry.ServerIPAddress == "" {
+		entry.ServerIPAddress = flow.IPAddress
+	}
+
+	if flow.IsWebsocket && flow.WebsocketHash != "" && db != nil {
+		var frames []*schema.WebsocketFlow
+		db.Model(&schema.WebsocketFlow{}).Where("websocket_request_hash = ?", flow.WebsocketHash).Order("frame_index asc").Find(&frames)
+		for _, frame := range frames {
+			entry.WebSocketMessages = append(entry.WebSocketMessages, websocketFlowToHARMessage(frame))
+		}
+		entry.ResourceType = "websocket"
+	}
+	return entry
+}
+
+func websocketFlowToHARMessage(frame *schema.WebsocketFlow) *har.WebSocketMessage {
+	data := string(unquoteFlowPacket(frame.QuotedData))
+	message := &har.WebSocketMessage{
+		Type:   har.WebSocketMessageSend,
+		Time:   float64(frame.CreatedAt.UnixNano()) / float64(time.Second),
+		Opcode: har.WebSocketOpcodeText,
+		Data:   data,
+	}
+	if frame.FromServer {
+		message.Type = har.WebSocketMessageReceive
+	}
+	if frame.MessageType == "binary" || !utf8.ValidString(data) {
+		message.Opcode = har.WebSocketOpcodeBinary
+		message.Data = codec.EncodeBase64(data)
+	}
+	return message
+}
+
+// ExportHTTPFlowsToHAR 将 db 查询到的 HTTPFlow 流式写入 HAR，每写入一条调用一次 handler(可以为 nil)，返回写入的数量
+func ExportHTTPFlowsToHAR(ctx context.Context, db *gorm.DB, w io.Writer, handler func(flow *schema.HTTPFlow)) (int, error) {
+	writer, err := har.NewWriter(w, &har.Creator{Name: "yaklang", Version: consts.GetYakVersion()})
+	if err != nil {
+		return 0, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for flow := range YieldHTTPFlows(db.Model(&schema.HTTPFlow{}), ctx) {
+		if err := writer.Write(HTTPFlowToHAREntry(db.New(), flow)); err != nil {
+			return writer.Count(), utils.Errorf("write har entry failed: %v", err)
+		}
+		if handler != nil {
+			handler(flow)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return writer.Count(), err
+	}
+	return writer.Count(), writer.Close()
+}
+
+// HAREntryToHTTPFlow 将 HAR entry 转换为 HTTPFlow，HiddenIndex 由 entry 计算，重复导入同一个 HAR 不会产生重复的记录
+func HAREntryToHTTPFlow(entry *har.Entry) (*schema.HTTPFlow, []*schema.WebsocketFlow, error) {
+	if entry.Request == nil {
+		return nil, nil, utils.Error("empty request")
+	}
+	u, err := url.Parse(entry.Request.URL)
+	if err != nil {
+		return nil, nil, utils.Errorf("parse url %#v failed: %v", entry.Request.URL, err)
+	}
+	switch u.Scheme {
+	case "http", "https", "ws", "wss":
+	default:
+		return nil, nil, utils.Errorf("unsupported url: %v", utils.ShrinkString(entry.Request.URL, 64))
+	}
+
+	request, err := entry.Request.Packet()
+	if err != nil {
+		return nil, nil, err
+	}
+	var response []byte
+	if entry.Response != nil {
+		response, err = entry.Response.Packet()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var remoteAddr string
+	if ip := strings.Trim(entry.ServerIPAddress, "[]"); ip != "" {
+		_, port, _ := utils.ParseStringToHostPort(entry.Request.URL)
+		remoteAddr = utils.HostPort(ip, port)
+	}
+	flow, err := CreateHTTPFlow(
+		CreateHTTPFlowWithHTTPS(entry.IsHTTPS()),
+		CreateHTTPFlowWithRequestRaw(request),
+		CreateHTTPFlowWithFixResponseRaw(response),
+		CreateHTTPFlowWithSource(HTTPFlowSourceHAR),
+		CreateHTTPFlowWithURL(entry.Request.URL),
+		CreateHTTPFlowWithRemoteAddr(remoteAddr),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	flow.HiddenIndex = utils.CalcSha1(HTTPFlowSourceHAR, entry.StartedDateTime, entry.Request.Method, entry.Request.URL)
+	if started := entry.StartedTime(); !started.IsZero() {
+		flow.CreatedAt, flow.UpdatedAt = started, started
+	}
+	if timings := entry.Timings; timings != nil {
+		flow.DNSDurationMs = int64(max0(timings.DNS))
+		flow.ConnDurationMs = int64(max0(timings.Connect))
+		flow.DurationMs = int64(max0(timings.Wait))
+		flow.TotalDurationMs = int64(timings.Total())
+	}
+
+	var frames []*schema.WebsocketFlow
+	if u.Scheme == "ws" || u.Scheme == "wss" || len(entry.WebSocketMessages) > 0 {
+		flow.IsWebsocket = true
+		flow.WebsocketHash = flow.HiddenIndex
+		for index, message := range entry.WebSocketMessages {
+			data := message.Data
+			messageType := "text"
+			if message.Opcode == har.WebSocketOpcodeBinary {
+				if raw, err := codec.DecodeBase64(data); err == nil {
+					data = string(raw)
+				}
+				messageType = "binary"
+			}
+			frame := &schema.WebsocketFlow{
+				WebsocketRequestHash: flow.WebsocketHash,
+				FrameIndex:           index,
+				FromServer:           message.Type == har.WebSocketMessageReceive,
+				QuotedData:           strconv.Quote(data),
+				MessageType:          messageType,
+			}
+			if message.Time > 0 {
+				frame.CreatedAt = time.Unix(0, int64(message.Time*float64(time.Second)))
+			}
+			frame.Hash = frame.CalcHash()
+			frames = append(frames, frame)
+		}
+	}
+	// 与保存时一致，修正 url 之后计算 hash
+	_ = flow.BeforeSave()
+	return flow, frames, nil
+}
+
+func max0(i float64) float64 {
+	if i < 0 {
+		return 0
+	}
+	return i
+}
+
+// ImportHTTPFlowsFromHAR 流式读取 HAR 并保存为 HTTPFlow，每处理一个 entry 调用一次 handler(可以为 nil)，
+// err 不为空时表示该 entry 被跳过，返回成功导入的数量
+func ImportHTTPFlowsFromHAR(ctx context.Context, db *gorm.DB, r io.Reader, handler func(flow *schema.HTTPFlow, err error)) (int, error) {
+	count := 0
+	err := har.ReadEntries(r, func(entry *har.Entry) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		flow, frames, err := HAREntryToHTTPFlow(entry)
+		if err == nil {
+			err = utils.GormTransaction(db, func(tx *gorm.DB) error {
+				if err := CreateOrUpdateHTTPFlow(tx, flow.Hash, flow); err != nil {
+					return err
+				}
+				for _, frame := range frames {
+					if err := CreateOrUpdateWebsocketFlow(tx, frame.Hash, frame); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		}
+		if err == nil {
+			count++
+		}
+		if handler != nil {
+			handler(flow, err)
+		}
+		return nil
+	})
+	return count, err
+}
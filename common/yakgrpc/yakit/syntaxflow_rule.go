@@ -0,0 +1,339 @@
+package yakit
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	"github.com/samber/lo"
+	"github.com/yaklang/yaklang/common/log"
+	"github.com/yaklang/yaklang/common/schema"
+	"github.com/yaklang/yaklang/common/syntaxflow/sfvm"
+	"github.com/yaklang/yaklang/common/utils"
+	"github.com/yaklang/yaklang/common/utils/bizhelper"
+	"github.com/yaklang/yaklang/common/utils/filesys"
+	"github.com/yaklang/yaklang/common/yakgrpc/ypb"
+)
+
+// the languages can be compiled to ssa, same as ssaapi.Language
+var syntaxFlowRuleLanguages = []string{"yak", "js", "php", "java", "go", "python"}
+
+func isSyntaxFlowRuleLanguage(s string) bool {
+	return utils.StringArrayContains(syntaxFlowRuleLanguages, strings.ToLower(s))
+}
+
+// ParseSyntaxFlowRule compile the rule content and extract the meta info from `desc(...)`,
+// if no `lang` in desc, the language is guessed by the prefix of rule name, like `java-xxe`
+func ParseSyntaxFlowRule(ruleName, content string) (*schema.SyntaxFlowRule, error) {
+	ruleName = strings.TrimSuffix(strings.TrimSpace(ruleName), ".sf")
+	if ruleName == "" {
+		return nil, utils.Error("syntaxflow rule name is empty")
+	}
+	frame, err := sfvm.NewSyntaxFlowVirtualMachine().Compile(content)
+	if err != nil {
+		return nil, utils.Wrapf(err, "compile syntaxflow rule %v failed", ruleName)
+	}
+
+	rule := &schema.SyntaxFlowRule{
+		RuleName: ruleName,
+		Content:  content,
+	}
+	frame.GetDescription().ForEach(func(key string, value string) bool {
+		switch strings.ToLower(key) {
+		case "title":
+			rule.Title = value
+		case "lang", "language":
+			rule.Language = value
+		case "type":
+			rule.Type = value
+		case "level", "severity":
+			rule.Severity = value
+		case "cwe":
+			rule.CWE = value
+		case "tag", "tags":
+			rule.Tags = value
+		case "desc", "description":
+			rule.Description = value
+		case "version":
+			rule.Version = value
+		}
+		return true
+	})
+
+	if rule.Title == "" {
+		rule.Title = ruleName
+	}
+	if rule.Language == "" {
+		if prefix, _, ok := strings.Cut(ruleName, "-"); ok && isSyntaxFlowRuleLanguage(prefix) {
+			rule.Language = prefix
+		}
+	}
+	return rule, nil
+}
+
+// CreateOrUpdateSyntaxFlowRule save the rule by rule name, the groups of existed rule are kept if not set
+func CreateOrUpdateSyntaxFlowRule(db *gorm.DB, rule *schema.SyntaxFlowRule) error {
+	db = db.Model(&schema.SyntaxFlowRule{})
+	existed, err := GetSyntaxFlowRuleByName(db, rule.RuleName)
+	if err != nil {
+		if db := db.Create(rule); db.Error != nil {
+			return utils.Errorf("create SyntaxFlow Rule failed: %s", db.Error)
+		}
+		return nil
+	}
+	rule.ID = existed.ID
+	rule.CreatedAt = existed.CreatedAt
+	if rule.Groups == "" {
+		rule.Groups = existed.Groups
+	}
+	if db := db.Save(rule); db.Error != nil {
+		return utils.Errorf("update SyntaxFlow Rule failed: %s", db.Error)
+	}
+	return nil
+}
+
+func GetSyntaxFlowRuleByName(db *gorm.DB, ruleName string) (*schema.SyntaxFlowRule, error) {
+	var rule schema.SyntaxFlowRule
+	if db := db.Model(&schema.SyntaxFlowRule{}).Where("rule_name = ?", ruleName).First(&rule); db.Error != nil {
+		return nil, db.Error
+	}
+	return &rule, nil
+}
+
+func FilterSyntaxFlowRule(db *gorm.DB, filter *ypb.SyntaxFlowRuleFilter) *gorm.DB {
+	db = db.Model(&schema.SyntaxFlowRule{})
+	if filter == nil {
+		return db
+	}
+	db = bizhelper.ExactQueryStringArrayOr(db, "rule_name", filter.GetRuleNames())
+	db = bizhelper.ExactQueryStringArrayOr(db, "language", filter.GetLanguage())
+	db = bizhelper.ExactQueryStringArrayOr(db, "severity", filter.GetSeverity())
+	db = querySyntaxFlowRuleGroups(db, filter.GetGroups())
+	db = bizhelper.FuzzSearchEx(db, []string{"rule_name", "title", "tags", "content"}, filter.GetKeyword(), false)
+	switch filter.GetDisabledStatus() {
+	case 1:
+		db = bizhelper.QueryByBool(db, "disabled", false)
+	case 2:
+		db = bizhelper.QueryByBool(db, "disabled", true)
+	}
+	return db
+}
+
+// querySyntaxFlowRuleGroups match the rule in any of groups exactly, the groups of rule is joined by comma
+func querySyntaxFlowRuleGroups(db *gorm.DB, groups []string) *gorm.DB {
+	groups = utils.StringArrayFilterEmpty(groups)
+	if len(groups) == 0 {
+		return db
+	}
+	var (
+		querys []string
+		items  []interface{}
+	)
+	for _, group := range groups {
+		querys = append(querys, "( instr(',' || `groups` || ',', ?) > 0 )")
+		items = append(items, ","+strings.TrimSpace(group)+",")
+	}
+	return db.Where(strings.Join(querys, " OR "), items...)
+}
+
+// IsEmptySyntaxFlowRuleFilter check whether the filter matches all rules
+func IsEmptySyntaxFlowRuleFilter(filter *ypb.SyntaxFlowRuleFilter) bool {
+	if filter == nil {
+		return true
+	}
+	return len(utils.StringArrayFilterEmpty(filter.GetRuleNames())) == 0 &&
+		len(utils.StringArrayFilterEmpty(filter.GetLanguage())) == 0 &&
+		len(utils.StringArrayFilterEmpty(filter.GetGroups())) == 0 &&
+		len(utils.StringArrayFilterEmpty(filter.GetSeverity())) == 0 &&
+		strings.TrimSpace(filter.GetKeyword()) == "" &&
+		filter.GetDisabledStatus() == 0
+}
+
+func QuerySyntaxFlowRule(db *gorm.DB, params *ypb.QuerySyntaxFlowRuleRequest) (*bizhelper.Paginator, []*schema.SyntaxFlowRule, error) {
+	db = FilterSyntaxFlowRule(db, params.GetFilter())
+	paging := params.GetPagination()
+	db = bizhelper.QueryOrder(db, paging.GetOrderBy(), paging.GetOrder())
+	var ret []*schema.SyntaxFlowRule
+	pag, db := bizhelper.Paging(db, int(paging.GetPage()), int(paging.GetLimit()), &ret)
+	if db.Error != nil {
+		return nil, nil, utils.Errorf("paging failed: %s", db.Error)
+	}
+	return pag, ret, nil
+}
+
+// GetEnabledSyntaxFlowRuleByLanguage return the enabled rules for the language,
+// the rule without language is used for every language
+func GetEnabledSyntaxFlowRuleByLanguage(db *gorm.DB, language string) ([]*schema.SyntaxFlowRule, error) {
+	var rules []*schema.SyntaxFlowRule
+	db = db.Model(&schema.SyntaxFlowRule{}).Where("disabled = ?", false)
+	if language != "" {
+		db = db.Where("language = ? OR language = ?", strings.ToLower(language), "")
+	}
+	if db := db.Order("rule_name asc").Find(&rules); db.Error != nil {
+		return nil, db.Error
+	}
+	return rules, nil
+}
+
+// DeleteSyntaxFlowRule delete the filtered rules, the empty filter is rejected, use ClearSyntaxFlowRule to delete all
+func DeleteSyntaxFlowRule(db *gorm.DB, filter *ypb.SyntaxFlowRuleFilter) error {
+	if IsEmptySyntaxFlowRuleFilter(filter) {
+		return utils.Error("delete SyntaxFlow Rule failed: filter is empty")
+	}
+	db = FilterSyntaxFlowRule(db, filter)
+	if db := db.Unscoped().Delete(&schema.SyntaxFlowRule{}); db.Error != nil {
+		return utils.Errorf("delete SyntaxFlow Rule failed: %s", db.Error)
+	}
+	return nil
+}
+
+func ClearSyntaxFlowRule(db *gorm.DB) error {
+	if db := db.Model(&schema.SyntaxFlowRule{}).Unscoped().Delete(&schema.SyntaxFlowRule{}); db.Error != nil {
+		return utils.Errorf("clear SyntaxFlow Rule failed: %s", db.Error)
+	}
+	return nil
+}
+
+// QuerySyntaxFlowRuleGroup count the rules in every group
+func QuerySyntaxFlowRuleGroup(db *gorm.DB, filter *ypb.SyntaxFlowRuleFilter) ([]*ypb.GroupCount, error) {
+	var rules []*schema.SyntaxFlowRule
+	if db := FilterSyntaxFlowRule(db, filter).Select("`groups`").Find(&rules); db.Error != nil {
+		return nil, db.Error
+	}
+	var groups []string
+	count := make(map[string]int32)
+	for _, rule := range rules {
+		for _, group := range rule.GetGroups() {
+			if _, ok := count[group]; !ok {
+				groups = append(groups, group)
+			}
+			count[group]++
+		}
+	}
+	ret := make([]*ypb.GroupCount, 0, len(groups))
+	for _, group := range groups {
+		ret = append(ret, &ypb.GroupCount{Value: group, Total: count[group]})
+	}
+	return ret, nil
+}
+
+// UpdateSyntaxFlowRuleGroup add and remove groups for the filtered rules
+func UpdateSyntaxFlowRuleGroup(db *gorm.DB, filter *ypb.SyntaxFlowRuleFilter, add, remove []string) error {
+	var rules []*schema.SyntaxFlowRule
+	if db := FilterSyntaxFlowRule(db, filter).Find(&rules); db.Error != nil {
+		return db.Error
+	}
+	for _, rule := range rules {
+		groups := utils.StringArrayFilterEmpty(append(rule.GetGroups(), add...))
+		groups = lo.Without(groups, remove...)
+		if db := db.Model(rule).UpdateColumn("groups", strings.Join(utils.RemoveRepeatStringSlice(groups), ",")); db.Error != nil {
+			return utils.Errorf("update group of SyntaxFlow Rule %v failed: %s", rule.RuleName, db.Error)
+		}
+	}
+	return nil
+}
+
+func DeleteSyntaxFlowRuleGroup(db *gorm.DB, groups []string) error {
+	if len(groups) == 0 {
+		return nil
+	}
+	return UpdateSyntaxFlowRuleGroup(db, &ypb.SyntaxFlowRuleFilter{Groups: groups}, nil, groups)
+}
+
+// ImportSyntaxFlowRuleFromFileSystem save every `.sf` file under dir as rule, the rule name is the file name,
+// if language is not set in rule, the name of parent directory is tried, like `java/xxe.sf`
+func ImportSyntaxFlowRuleFromFileSystem(db *gorm.DB, fileSystem filesys.FileSystem, dir string) (int, error) {
+	var count int
+	var errs []string
+	sep := string(fileSystem.GetSeparators())
+	err := filesys.Recursive(dir, filesys.WithFileSystem(fileSystem), filesys.WithRecursiveDirectory(true), filesys.WithFileStat(func(pathname string, info fs.FileInfo) error {
+		if strings.ToLower(fileSystem.Ext(pathname)) != ".sf" {
+			return nil
+		}
+		raw, err := fileSystem.ReadFile(pathname)
+		if err != nil {
+			errs = append(errs, err.Error())
+			return nil
+		}
+		rule, err := ParseSyntaxFlowRule(info.Name(), string(raw))
+		if err != nil {
+			errs = append(errs, err.Error())
+			return nil
+		}
+		if rule.Language == "" {
+			parent, _ := fileSystem.PathSplit(pathname)
+			parent = parent[strings.LastIndex(parent, sep)+1:]
+			if isSyntaxFlowRuleLanguage(parent) {
+				rule.Language = parent
+			}
+		}
+		if existed, err := GetSyntaxFlowRuleByName(db, rule.RuleName); err == nil {
+			rule.Disabled = existed.Disabled
+		}
+		if err := CreateOrUpdateSyntaxFlowRule(db, rule); err != nil {
+			errs = append(errs, err.Error())
+			return nil
+		}
+		count++
+		return nil
+	}))
+	if err != nil {
+		return count, err
+	}
+	if len(errs) > 0 {
+		return count, utils.Errorf("import %v SyntaxFlow Rule failed: \n%v", len(errs), strings.Join(errs, "\n"))
+	}
+	return count, nil
+}
+
+// ImportSyntaxFlowRuleFromDir import the `.sf` files in local directory, see ImportSyntaxFlowRuleFromFileSystem
+func ImportSyntaxFlowRuleFromDir(db *gorm.DB, dir string) (int, error) {
+	return ImportSyntaxFlowRuleFromFileSystem(db, filesys.NewLocalFs(), dir)
+}
+
+// syntaxFlowRuleFileName replace the path separator in rule name or language, they can't be used as path directly
+func syntaxFlowRuleFileName(s string) string {
+	s = strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', 0:
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(s))
+	if s != "" && strings.Trim(s, ".") == "" {
+		return strings.Repeat("_", len(s))
+	}
+	return s
+}
+
+// ExportSyntaxFlowRuleToDir write the filtered rules to `<dir>/<language>/<rule_name>.sf`,
+// the rule without language is written to dir directly
+func ExportSyntaxFlowRuleToDir(db *gorm.DB, filter *ypb.SyntaxFlowRuleFilter, dir string) (int, error) {
+	var rules []*schema.SyntaxFlowRule
+	if db := FilterSyntaxFlowRule(db, filter).Find(&rules); db.Error != nil {
+		return 0, db.Error
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return 0, err
+	}
+	var count int
+	for _, rule := range rules {
+		target := filepath.Join(dir, syntaxFlowRuleFileName(rule.Language))
+		filename := filepath.Join(target, syntaxFlowRuleFileName(rule.RuleName)+".sf")
+		if rel, err := filepath.Rel(dir, filename); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return count, utils.Errorf("export SyntaxFlow Rule %v failed: path %v is out of %v", rule.RuleName, filename, dir)
+		}
+		if err := os.MkdirAll(target, 0o755); err != nil {
+			return count, err
+		}
+		if err := os.WriteFile(filename, []byte(rule.Content), 0o644); err != nil {
+			return count, utils.Wrapf(err, "write SyntaxFlow Rule %v failed", rule.RuleName)
+		}
+		log.Debugf("export SyntaxFlow Rule %v to %v", rule.RuleName, filename)
+		count++
+	}
+	return count, nil
+}
@@ -96,6 +96,11 @@ type YakClient interface {
 	GetHTTPFlowBare(ctx context.Context, in *HTTPFlowBareRequest, opts ...grpc.CallOption) (*HTTPFlowBareResponse, error)
 	ExportHTTPFlows(ctx context.Context, in *ExportHTTPFlowsRequest, opts ...grpc.CallOption) (*QueryHTTPFlowResponse, error)
 	HTTPFlowsToOnline(ctx context.Context, in *HTTPFlowsToOnlineRequest, opts ...grpc.CallOption) (*Empty, error)
+	// HAR 1.2 导入导出
+	ExportHTTPFlowsToHAR(ctx context.Context, in *ExportHTTPFlowsToHARRequest, opts ...grpc.CallOption) (Yak_ExportHTTPFlowsToHARClient, error)
+	ImportHTTPFlowsFromHAR(ctx context.Context, in *ImportHTTPFlowsFromHARRequest, opts ...grpc.CallOption) (Yak_ImportHTTPFlowsFromHARClient, error)
+	// Burp Suite "Save items" 导出的 XML 导入
+	ImportHTTPFlowsFromBurp(ctx context.Context, in *ImportHTTPFlowsFromBurpRequest, opts ...grpc.CallOption) (Yak_ImportHTTPFlowsFromBurpClient, error)
 	// 从一个 FuzzerRequest 中提取 Url
 	ExtractUrl(ctx context.Context, in *FuzzerRequest, opts ...grpc.CallOption) (*ExtractedUrl, error)
 	// Fuzzer
@@ -186,6 +191,7 @@ type YakClient interface {
 	GetYakVMBuildInMethodCompletion(ctx context.Context, in *GetYakVMBuildInMethodCompletionRequest, opts ...grpc.CallOption) (*GetYakVMBuildInMethodCompletionResponse, error)
 	StaticAnalyzeError(ctx context.Context, in *StaticAnalyzeErrorRequest, opts ...grpc.CallOption) (*StaticAnalyzeErrorResponse, error)
 	YaklangCompileAndFormat(ctx context.Context, in *YaklangCompileAndFormatRequest, opts ...grpc.CallOption) (*YaklangCompileAndFormatResponse, error)
+	FormatYakCode(ctx context.Context, in *FormatYakCodeRequest, opts ...grpc.CallOption) (*FormatYakCodeResponse, error)
 	// LSP
 	YaklangLanguageSuggestion(ctx context.Context, in *YaklangLanguageSuggestionRequest, opts ...grpc.CallOption) (*YaklangLanguageSuggestionResponse, error)
 	YaklangLanguageFind(ctx context.Context, in *YaklangLanguageSuggestionRequest, opts ...grpc.CallOption) (*YaklangLanguageFindResponse, error)
@@ -328,6 +334,12 @@ type YakClient interface {
 	ImportMITMReplacerRules(ctx context.Context, in *ImportMITMReplacerRulesRequest, opts ...grpc.CallOption) (*Empty, error)
 	GetCurrentRules(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MITMContentReplacers, error)
 	SetCurrentRules(ctx context.Context, in *MITMContentReplacers, opts ...grpc.CallOption) (*Empty, error)
+	DryRunMITMReplacerRules(ctx context.Context, in *DryRunMITMReplacerRulesRequest, opts ...grpc.CallOption) (*DryRunMITMReplacerRulesResponse, error)
+	// MITM 断点：自动放行时，仅暂停命中规则的请求或响应
+	GetMITMBreakpointRules(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MITMBreakpointRules, error)
+	SetMITMBreakpointRules(ctx context.Context, in *MITMBreakpointRules, opts ...grpc.CallOption) (*Empty, error)
+	QueryMITMBreakpointFlows(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*QueryMITMBreakpointFlowsResponse, error)
+	ResumeMITMBreakpointFlow(ctx context.Context, in *ResumeMITMBreakpointFlowRequest, opts ...grpc.CallOption) (*Empty, error)
 	GenerateURL(ctx context.Context, in *GenerateURLRequest, opts ...grpc.CallOption) (*GenerateURLResponse, error)
 	ExtractDataToFile(ctx context.Context, opts ...grpc.CallOption) (Yak_ExtractDataToFileClient, error)
 	// 尝试自动解码
@@ -490,6 +502,13 @@ type YakClient interface {
 	GetFingerprint(ctx context.Context, in *GetFingerprintRequest, opts ...grpc.CallOption) (*GetFingerprintResponse, error)
 	AddFingerprint(ctx context.Context, in *AddFingerprintRequest, opts ...grpc.CallOption) (*AddFingerprintResponse, error)
 	ModifyFingerprint(ctx context.Context, in *ModifyFingerprintRequest, opts ...grpc.CallOption) (*ModifyFingerprintResponse, error)
+	// SyntaxFlow 规则库
+	QuerySyntaxFlowRule(ctx context.Context, in *QuerySyntaxFlowRuleRequest, opts ...grpc.CallOption) (*QuerySyntaxFlowRuleResponse, error)
+	SaveSyntaxFlowRule(ctx context.Context, in *SaveSyntaxFlowRuleRequest, opts ...grpc.CallOption) (*SyntaxFlowRule, error)
+	DeleteSyntaxFlowRule(ctx context.Context, in *DeleteSyntaxFlowRuleRequest, opts ...grpc.CallOption) (*Empty, error)
+	QuerySyntaxFlowRuleGroup(ctx context.Context, in *QuerySyntaxFlowRuleGroupRequest, opts ...grpc.CallOption) (*QuerySyntaxFlowRuleGroupResponse, error)
+	SaveSyntaxFlowRuleGroup(ctx context.Context, in *SaveSyntaxFlowRuleGroupRequest, opts ...grpc.CallOption) (*Empty, error)
+	DeleteSyntaxFlowRuleGroup(ctx context.Context, in *DeleteSyntaxFlowRuleGroupRequest, opts ...grpc.CallOption) (*Empty, error)
 }
 
 type yakClient struct {
@@ -1437,6 +1456,102 @@ func (c *yakClient) HTTPFlowsToOnline(ctx context.Context, in *HTTPFlowsToOnline
 	return out, nil
 }
 
+func (c *yakClient) ExportHTTPFlowsToHAR(ctx context.Context, in *ExportHTTPFlowsToHARRequest, opts ...grpc.CallOption) (Yak_ExportHTTPFlowsToHARClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[15], "/ypb.Yak/ExportHTTPFlowsToHAR", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &yakExportHTTPFlowsToHARClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Yak_ExportHTTPFlowsToHARClient interface {
+	Recv() (*HTTPFlowsHARProgress, error)
+	grpc.ClientStream
+}
+
+type yakExportHTTPFlowsToHARClient struct {
+	grpc.ClientStream
+}
+
+func (x *yakExportHTTPFlowsToHARClient) Recv() (*HTTPFlowsHARProgress, error) {
+	m := new(HTTPFlowsHARProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *yakClient) ImportHTTPFlowsFromHAR(ctx context.Context, in *ImportHTTPFlowsFromHARRequest, opts ...grpc.CallOption) (Yak_ImportHTTPFlowsFromHARClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[16], "/ypb.Yak/ImportHTTPFlowsFromHAR", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &yakImportHTTPFlowsFromHARClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Yak_ImportHTTPFlowsFromHARClient interface {
+	Recv() (*HTTPFlowsHARProgress, error)
+	grpc.ClientStream
+}
+
+type yakImportHTTPFlowsFromHARClient struct {
+	grpc.ClientStream
+}
+
+func (x *yakImportHTTPFlowsFromHARClient) Recv() (*HTTPFlowsHARProgress, error) {
+	m := new(HTTPFlowsHARProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *yakClient) ImportHTTPFlowsFromBurp(ctx context.Context, in *ImportHTTPFlowsFromBurpRequest, opts ...grpc.CallOption) (Yak_ImportHTTPFlowsFromBurpClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[17], "/ypb.Yak/ImportHTTPFlowsFromBurp", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &yakImportHTTPFlowsFromBurpClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Yak_ImportHTTPFlowsFromBurpClient interface {
+	Recv() (*ImportHTTPFlowsFromBurpProgress, error)
+	grpc.ClientStream
+}
+
+type yakImportHTTPFlowsFromBurpClient struct {
+	grpc.ClientStream
+}
+
+func (x *yakImportHTTPFlowsFromBurpClient) Recv() (*ImportHTTPFlowsFromBurpProgress, error) {
+	m := new(ImportHTTPFlowsFromBurpProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *yakClient) ExtractUrl(ctx context.Context, in *FuzzerRequest, opts ...grpc.CallOption) (*ExtractedUrl, error) {
 	out := new(ExtractedUrl)
 	err := c.cc.Invoke(ctx, "/ypb.Yak/ExtractUrl", in, out, opts...)
@@ -1483,7 +1598,7 @@ func (c *yakClient) DeleteHistoryHTTPFuzzerTask(ctx context.Context, in *DeleteH
 }
 
 func (c *yakClient) HTTPFuzzer(ctx context.Context, in *FuzzerRequest, opts ...grpc.CallOption) (Yak_HTTPFuzzerClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[15], "/ypb.Yak/HTTPFuzzer", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[18], "/ypb.Yak/HTTPFuzzer", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1515,7 +1630,7 @@ func (x *yakHTTPFuzzerClient) Recv() (*FuzzerResponse, error) {
 }
 
 func (c *yakClient) HTTPFuzzerSequence(ctx context.Context, in *FuzzerRequests, opts ...grpc.CallOption) (Yak_HTTPFuzzerSequenceClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[16], "/ypb.Yak/HTTPFuzzerSequence", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[19], "/ypb.Yak/HTTPFuzzerSequence", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1637,7 +1752,7 @@ func (c *yakClient) GenerateExtractRule(ctx context.Context, in *GenerateExtract
 }
 
 func (c *yakClient) ExtractData(ctx context.Context, opts ...grpc.CallOption) (Yak_ExtractDataClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[17], "/ypb.Yak/ExtractData", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[20], "/ypb.Yak/ExtractData", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1731,7 +1846,7 @@ func (c *yakClient) QueryHTTPFuzzerResponseByTaskId(ctx context.Context, in *Que
 }
 
 func (c *yakClient) CreateWebsocketFuzzer(ctx context.Context, opts ...grpc.CallOption) (Yak_CreateWebsocketFuzzerClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[18], "/ypb.Yak/CreateWebsocketFuzzer", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[21], "/ypb.Yak/CreateWebsocketFuzzer", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1933,7 +2048,7 @@ func (c *yakClient) SavePayload(ctx context.Context, in *SavePayloadRequest, opt
 }
 
 func (c *yakClient) SavePayloadStream(ctx context.Context, in *SavePayloadRequest, opts ...grpc.CallOption) (Yak_SavePayloadStreamClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[19], "/ypb.Yak/SavePayloadStream", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[22], "/ypb.Yak/SavePayloadStream", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1965,7 +2080,7 @@ func (x *yakSavePayloadStreamClient) Recv() (*SavePayloadProgress, error) {
 }
 
 func (c *yakClient) SavePayloadToFileStream(ctx context.Context, in *SavePayloadRequest, opts ...grpc.CallOption) (Yak_SavePayloadToFileStreamClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[20], "/ypb.Yak/SavePayloadToFileStream", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[23], "/ypb.Yak/SavePayloadToFileStream", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2069,7 +2184,7 @@ func (c *yakClient) GetAllPayload(ctx context.Context, in *GetAllPayloadRequest,
 }
 
 func (c *yakClient) GetAllPayloadFromFile(ctx context.Context, in *GetAllPayloadRequest, opts ...grpc.CallOption) (Yak_GetAllPayloadFromFileClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[21], "/ypb.Yak/GetAllPayloadFromFile", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[24], "/ypb.Yak/GetAllPayloadFromFile", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2101,7 +2216,7 @@ func (x *yakGetAllPayloadFromFileClient) Recv() (*GetAllPayloadFromFileResponse,
 }
 
 func (c *yakClient) ExportAllPayload(ctx context.Context, in *GetAllPayloadRequest, opts ...grpc.CallOption) (Yak_ExportAllPayloadClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[22], "/ypb.Yak/ExportAllPayload", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[25], "/ypb.Yak/ExportAllPayload", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2133,7 +2248,7 @@ func (x *yakExportAllPayloadClient) Recv() (*GetAllPayloadResponse, error) {
 }
 
 func (c *yakClient) ExportAllPayloadFromFile(ctx context.Context, in *GetAllPayloadRequest, opts ...grpc.CallOption) (Yak_ExportAllPayloadFromFileClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[23], "/ypb.Yak/ExportAllPayloadFromFile", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[26], "/ypb.Yak/ExportAllPayloadFromFile", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2174,7 +2289,7 @@ func (c *yakClient) CreatePayloadFolder(ctx context.Context, in *NameRequest, op
 }
 
 func (c *yakClient) RemoveDuplicatePayloads(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (Yak_RemoveDuplicatePayloadsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[24], "/ypb.Yak/RemoveDuplicatePayloads", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[27], "/ypb.Yak/RemoveDuplicatePayloads", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2206,7 +2321,7 @@ func (x *yakRemoveDuplicatePayloadsClient) Recv() (*SavePayloadProgress, error)
 }
 
 func (c *yakClient) CoverPayloadGroupToDatabase(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (Yak_CoverPayloadGroupToDatabaseClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[25], "/ypb.Yak/CoverPayloadGroupToDatabase", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[28], "/ypb.Yak/CoverPayloadGroupToDatabase", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2238,7 +2353,7 @@ func (x *yakCoverPayloadGroupToDatabaseClient) Recv() (*SavePayloadProgress, err
 }
 
 func (c *yakClient) ConvertPayloadGroupToDatabase(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (Yak_ConvertPayloadGroupToDatabaseClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[26], "/ypb.Yak/ConvertPayloadGroupToDatabase", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[29], "/ypb.Yak/ConvertPayloadGroupToDatabase", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2270,7 +2385,7 @@ func (x *yakConvertPayloadGroupToDatabaseClient) Recv() (*SavePayloadProgress, e
 }
 
 func (c *yakClient) MigratePayloads(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Yak_MigratePayloadsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[27], "/ypb.Yak/MigratePayloads", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[30], "/ypb.Yak/MigratePayloads", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2337,6 +2452,15 @@ func (c *yakClient) YaklangCompileAndFormat(ctx context.Context, in *YaklangComp
 	return out, nil
 }
 
+func (c *yakClient) FormatYakCode(ctx context.Context, in *FormatYakCodeRequest, opts ...grpc.CallOption) (*FormatYakCodeResponse, error) {
+	out := new(FormatYakCodeResponse)
+	err := c.cc.Invoke(ctx, "/ypb.Yak/FormatYakCode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *yakClient) YaklangLanguageSuggestion(ctx context.Context, in *YaklangLanguageSuggestionRequest, opts ...grpc.CallOption) (*YaklangLanguageSuggestionResponse, error) {
 	out := new(YaklangLanguageSuggestionResponse)
 	err := c.cc.Invoke(ctx, "/ypb.Yak/YaklangLanguageSuggestion", in, out, opts...)
@@ -2374,7 +2498,7 @@ func (c *yakClient) YaklangGetCliCodeFromDatabase(ctx context.Context, in *Yakla
 }
 
 func (c *yakClient) PortScan(ctx context.Context, in *PortScanRequest, opts ...grpc.CallOption) (Yak_PortScanClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[28], "/ypb.Yak/PortScan", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[31], "/ypb.Yak/PortScan", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2415,7 +2539,7 @@ func (c *yakClient) ViewPortScanCode(ctx context.Context, in *Empty, opts ...grp
 }
 
 func (c *yakClient) SimpleDetect(ctx context.Context, in *RecordPortScanRequest, opts ...grpc.CallOption) (Yak_SimpleDetectClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[29], "/ypb.Yak/SimpleDetect", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[32], "/ypb.Yak/SimpleDetect", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2456,7 +2580,7 @@ func (c *yakClient) SaveCancelSimpleDetect(ctx context.Context, in *RecordPortSc
 }
 
 func (c *yakClient) SimpleDetectCreatReport(ctx context.Context, in *CreatReportRequest, opts ...grpc.CallOption) (Yak_SimpleDetectCreatReportClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[30], "/ypb.Yak/SimpleDetectCreatReport", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[33], "/ypb.Yak/SimpleDetectCreatReport", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2515,7 +2639,7 @@ func (c *yakClient) DeleteSimpleDetectUnfinishedTask(ctx context.Context, in *De
 }
 
 func (c *yakClient) RecoverSimpleDetectTask(ctx context.Context, in *RecoverUnfinishedTaskRequest, opts ...grpc.CallOption) (Yak_RecoverSimpleDetectTaskClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[31], "/ypb.Yak/RecoverSimpleDetectTask", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[34], "/ypb.Yak/RecoverSimpleDetectTask", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2574,7 +2698,7 @@ func (c *yakClient) PopSimpleDetectUnfinishedTaskByUid(ctx context.Context, in *
 }
 
 func (c *yakClient) RecoverSimpleDetectUnfinishedTask(ctx context.Context, in *RecoverExecBatchYakScriptUnfinishedTaskRequest, opts ...grpc.CallOption) (Yak_RecoverSimpleDetectUnfinishedTaskClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[32], "/ypb.Yak/RecoverSimpleDetectUnfinishedTask", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[35], "/ypb.Yak/RecoverSimpleDetectUnfinishedTask", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2930,7 +3054,7 @@ func (c *yakClient) DeleteYakScriptExec(ctx context.Context, in *Empty, opts ...
 }
 
 func (c *yakClient) StartBrute(ctx context.Context, in *StartBruteParams, opts ...grpc.CallOption) (Yak_StartBruteClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[33], "/ypb.Yak/StartBrute", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[36], "/ypb.Yak/StartBrute", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2989,7 +3113,7 @@ func (c *yakClient) VerifyTunnelServerDomain(ctx context.Context, in *VerifyTunn
 }
 
 func (c *yakClient) StartFacades(ctx context.Context, in *StartFacadesParams, opts ...grpc.CallOption) (Yak_StartFacadesClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[34], "/ypb.Yak/StartFacades", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[37], "/ypb.Yak/StartFacades", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -3021,7 +3145,7 @@ func (x *yakStartFacadesClient) Recv() (*ExecResult, error) {
 }
 
 func (c *yakClient) StartFacadesWithYsoObject(ctx context.Context, in *StartFacadesWithYsoParams, opts ...grpc.CallOption) (Yak_StartFacadesWithYsoObjectClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[35], "/ypb.Yak/StartFacadesWithYsoObject", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[38], "/ypb.Yak/StartFacadesWithYsoObject", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -3071,7 +3195,7 @@ func (c *yakClient) BytesToBase64(ctx context.Context, in *BytesToBase64Request,
 }
 
 func (c *yakClient) ConfigGlobalReverse(ctx context.Context, in *ConfigGlobalReverseParams, opts ...grpc.CallOption) (Yak_ConfigGlobalReverseClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[36], "/ypb.Yak/ConfigGlobalReverse", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[39], "/ypb.Yak/ConfigGlobalReverse", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -3526,7 +3650,7 @@ func (c *yakClient) ForceUpdateAvailableYakScriptTags(ctx context.Context, in *E
 }
 
 func (c *yakClient) ExecYakitPluginsByYakScriptFilter(ctx context.Context, in *ExecYakitPluginsByYakScriptFilterRequest, opts ...grpc.CallOption) (Yak_ExecYakitPluginsByYakScriptFilterClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[37], "/ypb.Yak/ExecYakitPluginsByYakScriptFilter", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[40], "/ypb.Yak/ExecYakitPluginsByYakScriptFilter", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -3611,6 +3735,51 @@ func (c *yakClient) SetCurrentRules(ctx context.Context, in *MITMContentReplacer
 	return out, nil
 }
 
+func (c *yakClient) DryRunMITMReplacerRules(ctx context.Context, in *DryRunMITMReplacerRulesRequest, opts ...grpc.CallOption) (*DryRunMITMReplacerRulesResponse, error) {
+	out := new(DryRunMITMReplacerRulesResponse)
+	err := c.cc.Invoke(ctx, "/ypb.Yak/DryRunMITMReplacerRules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *yakClient) GetMITMBreakpointRules(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MITMBreakpointRules, error) {
+	out := new(MITMBreakpointRules)
+	err := c.cc.Invoke(ctx, "/ypb.Yak/GetMITMBreakpointRules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *yakClient) SetMITMBreakpointRules(ctx context.Context, in *MITMBreakpointRules, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/ypb.Yak/SetMITMBreakpointRules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *yakClient) QueryMITMBreakpointFlows(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*QueryMITMBreakpointFlowsResponse, error) {
+	out := new(QueryMITMBreakpointFlowsResponse)
+	err := c.cc.Invoke(ctx, "/ypb.Yak/QueryMITMBreakpointFlows", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *yakClient) ResumeMITMBreakpointFlow(ctx context.Context, in *ResumeMITMBreakpointFlowRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/ypb.Yak/ResumeMITMBreakpointFlow", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *yakClient) GenerateURL(ctx context.Context, in *GenerateURLRequest, opts ...grpc.CallOption) (*GenerateURLResponse, error) {
 	out := new(GenerateURLResponse)
 	err := c.cc.Invoke(ctx, "/ypb.Yak/GenerateURL", in, out, opts...)
@@ -3621,7 +3790,7 @@ func (c *yakClient) GenerateURL(ctx context.Context, in *GenerateURLRequest, opt
 }
 
 func (c *yakClient) ExtractDataToFile(ctx context.Context, opts ...grpc.CallOption) (Yak_ExtractDataToFileClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[38], "/ypb.Yak/ExtractDataToFile", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[41], "/ypb.Yak/ExtractDataToFile", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -3778,7 +3947,7 @@ func (c *yakClient) DownloadOnlinePluginByIds(ctx context.Context, in *DownloadO
 }
 
 func (c *yakClient) DownloadOnlinePluginAll(ctx context.Context, in *DownloadOnlinePluginByTokenRequest, opts ...grpc.CallOption) (Yak_DownloadOnlinePluginAllClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[39], "/ypb.Yak/DownloadOnlinePluginAll", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[42], "/ypb.Yak/DownloadOnlinePluginAll", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -3855,7 +4024,7 @@ func (c *yakClient) DownloadOnlinePluginByScriptNames(ctx context.Context, in *D
 }
 
 func (c *yakClient) DownloadOnlinePlugins(ctx context.Context, in *DownloadOnlinePluginsRequest, opts ...grpc.CallOption) (Yak_DownloadOnlinePluginsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[40], "/ypb.Yak/DownloadOnlinePlugins", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[43], "/ypb.Yak/DownloadOnlinePlugins", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -3914,7 +4083,7 @@ func (c *yakClient) DownloadOnlinePluginByUUID(ctx context.Context, in *Download
 }
 
 func (c *yakClient) ExecPacketScan(ctx context.Context, in *ExecPacketScanRequest, opts ...grpc.CallOption) (Yak_ExecPacketScanClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[41], "/ypb.Yak/ExecPacketScan", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[44], "/ypb.Yak/ExecPacketScan", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -4036,7 +4205,7 @@ func (c *yakClient) ResetAndInvalidUserData(ctx context.Context, in *ResetAndInv
 }
 
 func (c *yakClient) CreateYaklangShell(ctx context.Context, opts ...grpc.CallOption) (Yak_CreateYaklangShellClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[42], "/ypb.Yak/CreateYaklangShell", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[45], "/ypb.Yak/CreateYaklangShell", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -4067,7 +4236,7 @@ func (x *yakCreateYaklangShellClient) Recv() (*YaklangShellResponse, error) {
 }
 
 func (c *yakClient) AttachCombinedOutput(ctx context.Context, in *AttachCombinedOutputRequest, opts ...grpc.CallOption) (Yak_AttachCombinedOutputClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[43], "/ypb.Yak/AttachCombinedOutput", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[46], "/ypb.Yak/AttachCombinedOutput", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -4207,7 +4376,7 @@ func (c *yakClient) GetTemporaryProject(ctx context.Context, in *Empty, opts ...
 }
 
 func (c *yakClient) ExportProject(ctx context.Context, in *ExportProjectRequest, opts ...grpc.CallOption) (Yak_ExportProjectClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[44], "/ypb.Yak/ExportProject", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[47], "/ypb.Yak/ExportProject", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -4239,7 +4408,7 @@ func (x *yakExportProjectClient) Recv() (*ProjectIOProgress, error) {
 }
 
 func (c *yakClient) ImportProject(ctx context.Context, in *ImportProjectRequest, opts ...grpc.CallOption) (Yak_ImportProjectClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[45], "/ypb.Yak/ImportProject", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[48], "/ypb.Yak/ImportProject", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -4316,7 +4485,7 @@ func (c *yakClient) DeleteChaosMakerRuleByID(ctx context.Context, in *DeleteChao
 }
 
 func (c *yakClient) ExecuteChaosMakerRule(ctx context.Context, in *ExecuteChaosMakerRuleRequest, opts ...grpc.CallOption) (Yak_ExecuteChaosMakerRuleClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[46], "/ypb.Yak/ExecuteChaosMakerRule", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[49], "/ypb.Yak/ExecuteChaosMakerRule", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -4393,7 +4562,7 @@ func (c *yakClient) IsCVEDatabaseReady(ctx context.Context, in *IsCVEDatabaseRea
 }
 
 func (c *yakClient) UpdateCVEDatabase(ctx context.Context, in *UpdateCVEDatabaseRequest, opts ...grpc.CallOption) (Yak_UpdateCVEDatabaseClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[47], "/ypb.Yak/UpdateCVEDatabase", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[50], "/ypb.Yak/UpdateCVEDatabase", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -4425,7 +4594,7 @@ func (x *yakUpdateCVEDatabaseClient) Recv() (*ExecResult, error) {
 }
 
 func (c *yakClient) ExportsProfileDatabase(ctx context.Context, in *ExportsProfileDatabaseRequest, opts ...grpc.CallOption) (Yak_ExportsProfileDatabaseClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[48], "/ypb.Yak/ExportsProfileDatabase", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[51], "/ypb.Yak/ExportsProfileDatabase", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -4457,7 +4626,7 @@ func (x *yakExportsProfileDatabaseClient) Recv() (*ExecResult, error) {
 }
 
 func (c *yakClient) ImportsProfileDatabase(ctx context.Context, in *ImportsProfileDatabaseRequest, opts ...grpc.CallOption) (Yak_ImportsProfileDatabaseClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[49], "/ypb.Yak/ImportsProfileDatabase", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[52], "/ypb.Yak/ImportsProfileDatabase", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -4525,7 +4694,7 @@ func (c *yakClient) IsScrecorderReady(ctx context.Context, in *IsScrecorderReady
 }
 
 func (c *yakClient) InstallScrecorder(ctx context.Context, in *InstallScrecorderRequest, opts ...grpc.CallOption) (Yak_InstallScrecorderClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[50], "/ypb.Yak/InstallScrecorder", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[53], "/ypb.Yak/InstallScrecorder", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -4557,7 +4726,7 @@ func (x *yakInstallScrecorderClient) Recv() (*ExecResult, error) {
 }
 
 func (c *yakClient) StartScrecorder(ctx context.Context, in *StartScrecorderRequest, opts ...grpc.CallOption) (Yak_StartScrecorderClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[51], "/ypb.Yak/StartScrecorder", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[54], "/ypb.Yak/StartScrecorder", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -4643,7 +4812,7 @@ func (c *yakClient) IsVulinboxReady(ctx context.Context, in *IsVulinboxReadyRequ
 }
 
 func (c *yakClient) InstallVulinbox(ctx context.Context, in *InstallVulinboxRequest, opts ...grpc.CallOption) (Yak_InstallVulinboxClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[52], "/ypb.Yak/InstallVulinbox", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[55], "/ypb.Yak/InstallVulinbox", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -4675,7 +4844,7 @@ func (x *yakInstallVulinboxClient) Recv() (*ExecResult, error) {
 }
 
 func (c *yakClient) StartVulinbox(ctx context.Context, in *StartVulinboxRequest, opts ...grpc.CallOption) (Yak_StartVulinboxClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[53], "/ypb.Yak/StartVulinbox", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[56], "/ypb.Yak/StartVulinbox", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -4707,7 +4876,7 @@ func (x *yakStartVulinboxClient) Recv() (*ExecResult, error) {
 }
 
 func (c *yakClient) GenQualityInspectionReport(ctx context.Context, in *GenQualityInspectionReportRequest, opts ...grpc.CallOption) (Yak_GenQualityInspectionReportClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[54], "/ypb.Yak/GenQualityInspectionReport", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[57], "/ypb.Yak/GenQualityInspectionReport", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -4748,7 +4917,7 @@ func (c *yakClient) HTTPRequestBuilder(ctx context.Context, in *HTTPRequestBuild
 }
 
 func (c *yakClient) DebugPlugin(ctx context.Context, in *DebugPluginRequest, opts ...grpc.CallOption) (Yak_DebugPluginClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[55], "/ypb.Yak/DebugPlugin", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[58], "/ypb.Yak/DebugPlugin", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -4789,7 +4958,7 @@ func (c *yakClient) SmokingEvaluatePlugin(ctx context.Context, in *SmokingEvalua
 }
 
 func (c *yakClient) SmokingEvaluatePluginBatch(ctx context.Context, in *SmokingEvaluatePluginBatchRequest, opts ...grpc.CallOption) (Yak_SmokingEvaluatePluginBatchClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[56], "/ypb.Yak/SmokingEvaluatePluginBatch", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[59], "/ypb.Yak/SmokingEvaluatePluginBatch", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -4830,7 +4999,7 @@ func (c *yakClient) GetSystemDefaultDnsServers(ctx context.Context, in *Empty, o
 }
 
 func (c *yakClient) DiagnoseNetwork(ctx context.Context, in *DiagnoseNetworkRequest, opts ...grpc.CallOption) (Yak_DiagnoseNetworkClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[57], "/ypb.Yak/DiagnoseNetwork", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[60], "/ypb.Yak/DiagnoseNetwork", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -4862,7 +5031,7 @@ func (x *yakDiagnoseNetworkClient) Recv() (*DiagnoseNetworkResponse, error) {
 }
 
 func (c *yakClient) DiagnoseNetworkDNS(ctx context.Context, in *DiagnoseNetworkDNSRequest, opts ...grpc.CallOption) (Yak_DiagnoseNetworkDNSClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[58], "/ypb.Yak/DiagnoseNetworkDNS", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[61], "/ypb.Yak/DiagnoseNetworkDNS", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -4894,7 +5063,7 @@ func (x *yakDiagnoseNetworkDNSClient) Recv() (*DiagnoseNetworkResponse, error) {
 }
 
 func (c *yakClient) TraceRoute(ctx context.Context, in *TraceRouteRequest, opts ...grpc.CallOption) (Yak_TraceRouteClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[59], "/ypb.Yak/TraceRoute", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[62], "/ypb.Yak/TraceRoute", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -4980,7 +5149,7 @@ func (c *yakClient) GetPcapMetadata(ctx context.Context, in *PcapMetadataRequest
 }
 
 func (c *yakClient) PcapX(ctx context.Context, opts ...grpc.CallOption) (Yak_PcapXClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[60], "/ypb.Yak/PcapX", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[63], "/ypb.Yak/PcapX", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -5047,7 +5216,7 @@ func (c *yakClient) ParseTraffic(ctx context.Context, in *ParseTrafficRequest, o
 }
 
 func (c *yakClient) DuplexConnection(ctx context.Context, opts ...grpc.CallOption) (Yak_DuplexConnectionClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[61], "/ypb.Yak/DuplexConnection", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[64], "/ypb.Yak/DuplexConnection", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -5078,7 +5247,7 @@ func (x *yakDuplexConnectionClient) Recv() (*DuplexConnectionResponse, error) {
 }
 
 func (c *yakClient) HybridScan(ctx context.Context, opts ...grpc.CallOption) (Yak_HybridScanClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[62], "/ypb.Yak/HybridScan", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[65], "/ypb.Yak/HybridScan", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -5154,7 +5323,7 @@ func (c *yakClient) GetSpaceEngineAccountStatusV2(ctx context.Context, in *Third
 }
 
 func (c *yakClient) FetchPortAssetFromSpaceEngine(ctx context.Context, in *FetchPortAssetFromSpaceEngineRequest, opts ...grpc.CallOption) (Yak_FetchPortAssetFromSpaceEngineClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[63], "/ypb.Yak/FetchPortAssetFromSpaceEngine", opts...)
+	stream, err := c.cc.NewStream(ctx, &Yak_ServiceDesc.Streams[66], "/ypb.Yak/FetchPortAssetFromSpaceEngine", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -5248,6 +5417,60 @@ func (c *yakClient) ModifyFingerprint(ctx context.Context, in *ModifyFingerprint
 	return out, nil
 }
 
+func (c *yakClient) QuerySyntaxFlowRule(ctx context.Context, in *QuerySyntaxFlowRuleRequest, opts ...grpc.CallOption) (*QuerySyntaxFlowRuleResponse, error) {
+	out := new(QuerySyntaxFlowRuleResponse)
+	err := c.cc.Invoke(ctx, "/ypb.Yak/QuerySyntaxFlowRule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *yakClient) SaveSyntaxFlowRule(ctx context.Context, in *SaveSyntaxFlowRuleRequest, opts ...grpc.CallOption) (*SyntaxFlowRule, error) {
+	out := new(SyntaxFlowRule)
+	err := c.cc.Invoke(ctx, "/ypb.Yak/SaveSyntaxFlowRule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *yakClient) DeleteSyntaxFlowRule(ctx context.Context, in *DeleteSyntaxFlowRuleRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/ypb.Yak/DeleteSyntaxFlowRule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *yakClient) QuerySyntaxFlowRuleGroup(ctx context.Context, in *QuerySyntaxFlowRuleGroupRequest, opts ...grpc.CallOption) (*QuerySyntaxFlowRuleGroupResponse, error) {
+	out := new(QuerySyntaxFlowRuleGroupResponse)
+	err := c.cc.Invoke(ctx, "/ypb.Yak/QuerySyntaxFlowRuleGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *yakClient) SaveSyntaxFlowRuleGroup(ctx context.Context, in *SaveSyntaxFlowRuleGroupRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/ypb.Yak/SaveSyntaxFlowRuleGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *yakClient) DeleteSyntaxFlowRuleGroup(ctx context.Context, in *DeleteSyntaxFlowRuleGroupRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/ypb.Yak/DeleteSyntaxFlowRuleGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // YakServer is the server API for Yak service.
 // All implementations must embed UnimplementedYakServer
 // for forward compatibility
@@ -5330,6 +5553,11 @@ type YakServer interface {
 	GetHTTPFlowBare(context.Context, *HTTPFlowBareRequest) (*HTTPFlowBareResponse, error)
 	ExportHTTPFlows(context.Context, *ExportHTTPFlowsRequest) (*QueryHTTPFlowResponse, error)
 	HTTPFlowsToOnline(context.Context, *HTTPFlowsToOnlineRequest) (*Empty, error)
+	// HAR 1.2 导入导出
+	ExportHTTPFlowsToHAR(*ExportHTTPFlowsToHARRequest, Yak_ExportHTTPFlowsToHARServer) error
+	ImportHTTPFlowsFromHAR(*ImportHTTPFlowsFromHARRequest, Yak_ImportHTTPFlowsFromHARServer) error
+	// Burp Suite "Save items" 导出的 XML 导入
+	ImportHTTPFlowsFromBurp(*ImportHTTPFlowsFromBurpRequest, Yak_ImportHTTPFlowsFromBurpServer) error
 	// 从一个 FuzzerRequest 中提取 Url
 	ExtractUrl(context.Context, *FuzzerRequest) (*ExtractedUrl, error)
 	// Fuzzer
@@ -5420,6 +5648,7 @@ type YakServer interface {
 	GetYakVMBuildInMethodCompletion(context.Context, *GetYakVMBuildInMethodCompletionRequest) (*GetYakVMBuildInMethodCompletionResponse, error)
 	StaticAnalyzeError(context.Context, *StaticAnalyzeErrorRequest) (*StaticAnalyzeErrorResponse, error)
 	YaklangCompileAndFormat(context.Context, *YaklangCompileAndFormatRequest) (*YaklangCompileAndFormatResponse, error)
+	FormatYakCode(context.Context, *FormatYakCodeRequest) (*FormatYakCodeResponse, error)
 	// LSP
 	YaklangLanguageSuggestion(context.Context, *YaklangLanguageSuggestionRequest) (*YaklangLanguageSuggestionResponse, error)
 	YaklangLanguageFind(context.Context, *YaklangLanguageSuggestionRequest) (*YaklangLanguageFindResponse, error)
@@ -5562,6 +5791,12 @@ type YakServer interface {
 	ImportMITMReplacerRules(context.Context, *ImportMITMReplacerRulesRequest) (*Empty, error)
 	GetCurrentRules(context.Context, *Empty) (*MITMContentReplacers, error)
 	SetCurrentRules(context.Context, *MITMContentReplacers) (*Empty, error)
+	DryRunMITMReplacerRules(context.Context, *DryRunMITMReplacerRulesRequest) (*DryRunMITMReplacerRulesResponse, error)
+	// MITM 断点：自动放行时，仅暂停命中规则的请求或响应
+	GetMITMBreakpointRules(context.Context, *Empty) (*MITMBreakpointRules, error)
+	SetMITMBreakpointRules(context.Context, *MITMBreakpointRules) (*Empty, error)
+	QueryMITMBreakpointFlows(context.Context, *Empty) (*QueryMITMBreakpointFlowsResponse, error)
+	ResumeMITMBreakpointFlow(context.Context, *ResumeMITMBreakpointFlowRequest) (*Empty, error)
 	GenerateURL(context.Context, *GenerateURLRequest) (*GenerateURLResponse, error)
 	ExtractDataToFile(Yak_ExtractDataToFileServer) error
 	// 尝试自动解码
@@ -5724,6 +5959,13 @@ type YakServer interface {
 	GetFingerprint(context.Context, *GetFingerprintRequest) (*GetFingerprintResponse, error)
 	AddFingerprint(context.Context, *AddFingerprintRequest) (*AddFingerprintResponse, error)
 	ModifyFingerprint(context.Context, *ModifyFingerprintRequest) (*ModifyFingerprintResponse, error)
+	// SyntaxFlow 规则库
+	QuerySyntaxFlowRule(context.Context, *QuerySyntaxFlowRuleRequest) (*QuerySyntaxFlowRuleResponse, error)
+	SaveSyntaxFlowRule(context.Context, *SaveSyntaxFlowRuleRequest) (*SyntaxFlowRule, error)
+	DeleteSyntaxFlowRule(context.Context, *DeleteSyntaxFlowRuleRequest) (*Empty, error)
+	QuerySyntaxFlowRuleGroup(context.Context, *QuerySyntaxFlowRuleGroupRequest) (*QuerySyntaxFlowRuleGroupResponse, error)
+	SaveSyntaxFlowRuleGroup(context.Context, *SaveSyntaxFlowRuleGroupRequest) (*Empty, error)
+	DeleteSyntaxFlowRuleGroup(context.Context, *DeleteSyntaxFlowRuleGroupRequest) (*Empty, error)
 	mustEmbedUnimplementedYakServer()
 }
 
@@ -5929,6 +6171,15 @@ func (UnimplementedYakServer) ExportHTTPFlows(context.Context, *ExportHTTPFlowsR
 func (UnimplementedYakServer) HTTPFlowsToOnline(context.Context, *HTTPFlowsToOnlineRequest) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HTTPFlowsToOnline not implemented")
 }
+func (UnimplementedYakServer) ExportHTTPFlowsToHAR(*ExportHTTPFlowsToHARRequest, Yak_ExportHTTPFlowsToHARServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportHTTPFlowsToHAR not implemented")
+}
+func (UnimplementedYakServer) ImportHTTPFlowsFromHAR(*ImportHTTPFlowsFromHARRequest, Yak_ImportHTTPFlowsFromHARServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportHTTPFlowsFromHAR not implemented")
+}
+func (UnimplementedYakServer) ImportHTTPFlowsFromBurp(*ImportHTTPFlowsFromBurpRequest, Yak_ImportHTTPFlowsFromBurpServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportHTTPFlowsFromBurp not implemented")
+}
 func (UnimplementedYakServer) ExtractUrl(context.Context, *FuzzerRequest) (*ExtractedUrl, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ExtractUrl not implemented")
 }
@@ -6130,6 +6381,9 @@ func (UnimplementedYakServer) StaticAnalyzeError(context.Context, *StaticAnalyze
 func (UnimplementedYakServer) YaklangCompileAndFormat(context.Context, *YaklangCompileAndFormatRequest) (*YaklangCompileAndFormatResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method YaklangCompileAndFormat not implemented")
 }
+func (UnimplementedYakServer) FormatYakCode(context.Context, *FormatYakCodeRequest) (*FormatYakCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FormatYakCode not implemented")
+}
 func (UnimplementedYakServer) YaklangLanguageSuggestion(context.Context, *YaklangLanguageSuggestionRequest) (*YaklangLanguageSuggestionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method YaklangLanguageSuggestion not implemented")
 }
@@ -6478,6 +6732,21 @@ func (UnimplementedYakServer) GetCurrentRules(context.Context, *Empty) (*MITMCon
 func (UnimplementedYakServer) SetCurrentRules(context.Context, *MITMContentReplacers) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetCurrentRules not implemented")
 }
+func (UnimplementedYakServer) DryRunMITMReplacerRules(context.Context, *DryRunMITMReplacerRulesRequest) (*DryRunMITMReplacerRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DryRunMITMReplacerRules not implemented")
+}
+func (UnimplementedYakServer) GetMITMBreakpointRules(context.Context, *Empty) (*MITMBreakpointRules, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMITMBreakpointRules not implemented")
+}
+func (UnimplementedYakServer) SetMITMBreakpointRules(context.Context, *MITMBreakpointRules) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMITMBreakpointRules not implemented")
+}
+func (UnimplementedYakServer) QueryMITMBreakpointFlows(context.Context, *Empty) (*QueryMITMBreakpointFlowsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryMITMBreakpointFlows not implemented")
+}
+func (UnimplementedYakServer) ResumeMITMBreakpointFlow(context.Context, *ResumeMITMBreakpointFlowRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeMITMBreakpointFlow not implemented")
+}
 func (UnimplementedYakServer) GenerateURL(context.Context, *GenerateURLRequest) (*GenerateURLResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GenerateURL not implemented")
 }
@@ -6826,6 +7095,24 @@ func (UnimplementedYakServer) AddFingerprint(context.Context, *AddFingerprintReq
 func (UnimplementedYakServer) ModifyFingerprint(context.Context, *ModifyFingerprintRequest) (*ModifyFingerprintResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ModifyFingerprint not implemented")
 }
+func (UnimplementedYakServer) QuerySyntaxFlowRule(context.Context, *QuerySyntaxFlowRuleRequest) (*QuerySyntaxFlowRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QuerySyntaxFlowRule not implemented")
+}
+func (UnimplementedYakServer) SaveSyntaxFlowRule(context.Context, *SaveSyntaxFlowRuleRequest) (*SyntaxFlowRule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveSyntaxFlowRule not implemented")
+}
+func (UnimplementedYakServer) DeleteSyntaxFlowRule(context.Context, *DeleteSyntaxFlowRuleRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSyntaxFlowRule not implemented")
+}
+func (UnimplementedYakServer) QuerySyntaxFlowRuleGroup(context.Context, *QuerySyntaxFlowRuleGroupRequest) (*QuerySyntaxFlowRuleGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QuerySyntaxFlowRuleGroup not implemented")
+}
+func (UnimplementedYakServer) SaveSyntaxFlowRuleGroup(context.Context, *SaveSyntaxFlowRuleGroupRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveSyntaxFlowRuleGroup not implemented")
+}
+func (UnimplementedYakServer) DeleteSyntaxFlowRuleGroup(context.Context, *DeleteSyntaxFlowRuleGroupRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSyntaxFlowRuleGroup not implemented")
+}
 func (UnimplementedYakServer) mustEmbedUnimplementedYakServer() {}
 
 // UnsafeYakServer may be embedded to opt out of forward compatibility for this service.
@@ -8082,6 +8369,69 @@ func _Yak_HTTPFlowsToOnline_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Yak_ExportHTTPFlowsToHAR_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportHTTPFlowsToHARRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(YakServer).ExportHTTPFlowsToHAR(m, &yakExportHTTPFlowsToHARServer{stream})
+}
+
+type Yak_ExportHTTPFlowsToHARServer interface {
+	Send(*HTTPFlowsHARProgress) error
+	grpc.ServerStream
+}
+
+type yakExportHTTPFlowsToHARServer struct {
+	grpc.ServerStream
+}
+
+func (x *yakExportHTTPFlowsToHARServer) Send(m *HTTPFlowsHARProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Yak_ImportHTTPFlowsFromHAR_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ImportHTTPFlowsFromHARRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(YakServer).ImportHTTPFlowsFromHAR(m, &yakImportHTTPFlowsFromHARServer{stream})
+}
+
+type Yak_ImportHTTPFlowsFromHARServer interface {
+	Send(*HTTPFlowsHARProgress) error
+	grpc.ServerStream
+}
+
+type yakImportHTTPFlowsFromHARServer struct {
+	grpc.ServerStream
+}
+
+func (x *yakImportHTTPFlowsFromHARServer) Send(m *HTTPFlowsHARProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Yak_ImportHTTPFlowsFromBurp_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ImportHTTPFlowsFromBurpRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(YakServer).ImportHTTPFlowsFromBurp(m, &yakImportHTTPFlowsFromBurpServer{stream})
+}
+
+type Yak_ImportHTTPFlowsFromBurpServer interface {
+	Send(*ImportHTTPFlowsFromBurpProgress) error
+	grpc.ServerStream
+}
+
+type yakImportHTTPFlowsFromBurpServer struct {
+	grpc.ServerStream
+}
+
+func (x *yakImportHTTPFlowsFromBurpServer) Send(m *ImportHTTPFlowsFromBurpProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Yak_ExtractUrl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FuzzerRequest)
 	if err := dec(in); err != nil {
@@ -9337,6 +9687,24 @@ func _Yak_YaklangCompileAndFormat_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Yak_FormatYakCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FormatYakCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(YakServer).FormatYakCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ypb.Yak/FormatYakCode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(YakServer).FormatYakCode(ctx, req.(*FormatYakCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Yak_YaklangLanguageSuggestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(YaklangLanguageSuggestionRequest)
 	if err := dec(in); err != nil {
@@ -11455,6 +11823,96 @@ func _Yak_SetCurrentRules_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Yak_DryRunMITMReplacerRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DryRunMITMReplacerRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(YakServer).DryRunMITMReplacerRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ypb.Yak/DryRunMITMReplacerRules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(YakServer).DryRunMITMReplacerRules(ctx, req.(*DryRunMITMReplacerRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Yak_GetMITMBreakpointRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(YakServer).GetMITMBreakpointRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ypb.Yak/GetMITMBreakpointRules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(YakServer).GetMITMBreakpointRules(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Yak_SetMITMBreakpointRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MITMBreakpointRules)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(YakServer).SetMITMBreakpointRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ypb.Yak/SetMITMBreakpointRules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(YakServer).SetMITMBreakpointRules(ctx, req.(*MITMBreakpointRules))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Yak_QueryMITMBreakpointFlows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(YakServer).QueryMITMBreakpointFlows(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ypb.Yak/QueryMITMBreakpointFlows",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(YakServer).QueryMITMBreakpointFlows(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Yak_ResumeMITMBreakpointFlow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeMITMBreakpointFlowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(YakServer).ResumeMITMBreakpointFlow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ypb.Yak/ResumeMITMBreakpointFlow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(YakServer).ResumeMITMBreakpointFlow(ctx, req.(*ResumeMITMBreakpointFlowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Yak_GenerateURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GenerateURLRequest)
 	if err := dec(in); err != nil {
@@ -13646,6 +14104,114 @@ func _Yak_ModifyFingerprint_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Yak_QuerySyntaxFlowRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuerySyntaxFlowRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(YakServer).QuerySyntaxFlowRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ypb.Yak/QuerySyntaxFlowRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(YakServer).QuerySyntaxFlowRule(ctx, req.(*QuerySyntaxFlowRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Yak_SaveSyntaxFlowRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveSyntaxFlowRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(YakServer).SaveSyntaxFlowRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ypb.Yak/SaveSyntaxFlowRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(YakServer).SaveSyntaxFlowRule(ctx, req.(*SaveSyntaxFlowRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Yak_DeleteSyntaxFlowRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSyntaxFlowRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(YakServer).DeleteSyntaxFlowRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ypb.Yak/DeleteSyntaxFlowRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(YakServer).DeleteSyntaxFlowRule(ctx, req.(*DeleteSyntaxFlowRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Yak_QuerySyntaxFlowRuleGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuerySyntaxFlowRuleGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(YakServer).QuerySyntaxFlowRuleGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ypb.Yak/QuerySyntaxFlowRuleGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(YakServer).QuerySyntaxFlowRuleGroup(ctx, req.(*QuerySyntaxFlowRuleGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Yak_SaveSyntaxFlowRuleGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveSyntaxFlowRuleGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(YakServer).SaveSyntaxFlowRuleGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ypb.Yak/SaveSyntaxFlowRuleGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(YakServer).SaveSyntaxFlowRuleGroup(ctx, req.(*SaveSyntaxFlowRuleGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Yak_DeleteSyntaxFlowRuleGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSyntaxFlowRuleGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(YakServer).DeleteSyntaxFlowRuleGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ypb.Yak/DeleteSyntaxFlowRuleGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(YakServer).DeleteSyntaxFlowRuleGroup(ctx, req.(*DeleteSyntaxFlowRuleGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Yak_ServiceDesc is the grpc.ServiceDesc for Yak service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -14073,6 +14639,10 @@ var Yak_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "YaklangCompileAndFormat",
 			Handler:    _Yak_YaklangCompileAndFormat_Handler,
 		},
+		{
+			MethodName: "FormatYakCode",
+			Handler:    _Yak_FormatYakCode_Handler,
+		},
 		{
 			MethodName: "YaklangLanguageSuggestion",
 			Handler:    _Yak_YaklangLanguageSuggestion_Handler,
@@ -14497,6 +15067,26 @@ var Yak_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetCurrentRules",
 			Handler:    _Yak_SetCurrentRules_Handler,
 		},
+		{
+			MethodName: "DryRunMITMReplacerRules",
+			Handler:    _Yak_DryRunMITMReplacerRules_Handler,
+		},
+		{
+			MethodName: "GetMITMBreakpointRules",
+			Handler:    _Yak_GetMITMBreakpointRules_Handler,
+		},
+		{
+			MethodName: "SetMITMBreakpointRules",
+			Handler:    _Yak_SetMITMBreakpointRules_Handler,
+		},
+		{
+			MethodName: "QueryMITMBreakpointFlows",
+			Handler:    _Yak_QueryMITMBreakpointFlows_Handler,
+		},
+		{
+			MethodName: "ResumeMITMBreakpointFlow",
+			Handler:    _Yak_ResumeMITMBreakpointFlow_Handler,
+		},
 		{
 			MethodName: "GenerateURL",
 			Handler:    _Yak_GenerateURL_Handler,
@@ -14857,6 +15447,30 @@ var Yak_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ModifyFingerprint",
 			Handler:    _Yak_ModifyFingerprint_Handler,
 		},
+		{
+			MethodName: "QuerySyntaxFlowRule",
+			Handler:    _Yak_QuerySyntaxFlowRule_Handler,
+		},
+		{
+			MethodName: "SaveSyntaxFlowRule",
+			Handler:    _Yak_SaveSyntaxFlowRule_Handler,
+		},
+		{
+			MethodName: "DeleteSyntaxFlowRule",
+			Handler:    _Yak_DeleteSyntaxFlowRule_Handler,
+		},
+		{
+			MethodName: "QuerySyntaxFlowRuleGroup",
+			Handler:    _Yak_QuerySyntaxFlowRuleGroup_Handler,
+		},
+		{
+			MethodName: "SaveSyntaxFlowRuleGroup",
+			Handler:    _Yak_SaveSyntaxFlowRuleGroup_Handler,
+		},
+		{
+			MethodName: "DeleteSyntaxFlowRuleGroup",
+			Handler:    _Yak_DeleteSyntaxFlowRuleGroup_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -14936,6 +15550,21 @@ var Yak_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Yak_ImportYakScript_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ExportHTTPFlowsToHAR",
+			Handler:       _Yak_ExportHTTPFlowsToHAR_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportHTTPFlowsFromHAR",
+			Handler:       _Yak_ImportHTTPFlowsFromHAR_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportHTTPFlowsFromBurp",
+			Handler:       _Yak_ImportHTTPFlowsFromBurp_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "HTTPFuzzer",
 			Handler:       _Yak_HTTPFuzzer_Handler,